@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/urfave/cli"
+)
+
+// DkgCommand contains the definition of the dkg command-line subcommand and
+// its own subcommands.
+var DkgCommand cli.Command
+
+const memberFlag = "member"
+
+const dkgDescription = `The dkg command gives insight into distributed key
+   generation processes the client took part in. For every DKG, the client
+   stores in the data directory an encrypted and signed transcript of all the
+   messages each of its members sent and received. The "replay" subcommand
+   replays stored transcripts offline and reports which group members
+   misbehaved in which protocol phase and why. Transcripts can be narrowed
+   down to the given DKG seed and member index.`
+
+func init() {
+	DkgCommand = cli.Command{
+		Name:        "dkg",
+		Usage:       `Provides insight into distributed key generation.`,
+		Description: dkgDescription,
+		Subcommands: []cli.Command{
+			{
+				Name:   "replay",
+				Usage:  "Replays recorded distributed key generation transcripts.",
+				Action: replayDkg,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  seedFlag,
+						Usage: "hexadecimal seed of the DKG to replay",
+					},
+					&cli.IntFlag{
+						Name:  memberFlag,
+						Usage: "index of the member whose transcript to replay",
+					},
+				},
+			},
+		},
+	}
+}
+
+// replayDkg replays transcripts stored in data directories of all operators
+// hosted by the client and prints reports.
+func replayDkg(c *cli.Context) error {
+	cfg, err := config.ReadConfig(c.GlobalString("config"))
+	if err != nil {
+		return fmt.Errorf("error reading config file: [%v]", err)
+	}
+
+	seed := strings.TrimPrefix(strings.ToLower(c.String(seedFlag)), "0x")
+	memberIndex := c.Int(memberFlag)
+
+	operatorAccounts, err := readOperatorAccounts(cfg)
+	if err != nil {
+		return err
+	}
+
+	replayed := 0
+	for i, account := range operatorAccounts {
+		dataDir := operatorDataDir(cfg, account.address, i == 0)
+		if _, err := os.Stat(dataDir); os.IsNotExist(err) {
+			continue
+		}
+
+		handle, err := openTranscriptPersistence(dataDir, account.password)
+		if err != nil {
+			return err
+		}
+
+		transcripts := dkg.ReadTranscripts(handle)
+
+		names := make([]string, 0, len(transcripts))
+		for name := range transcripts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			signing, err := replaySigning(account, transcripts[name])
+			if err != nil {
+				return fmt.Errorf(
+					"could not verify DKG transcript [%v]: [%v]",
+					name,
+					err,
+				)
+			}
+
+			transcript, err := transcripts[name].Open(signing)
+			if err != nil {
+				return fmt.Errorf(
+					"could not open DKG transcript [%v]: [%v]",
+					name,
+					err,
+				)
+			}
+
+			if seed != "" && transcript.Seed.Text(16) != seed {
+				continue
+			}
+			if memberIndex != 0 &&
+				transcript.MemberIndex != group.MemberIndex(memberIndex) {
+				continue
+			}
+
+			report, err := gjkr.Replay(transcript, signing)
+			if err != nil {
+				return fmt.Errorf(
+					"could not replay DKG transcript [%v]: [%v]",
+					name,
+					err,
+				)
+			}
+
+			if err := printReplayReport(os.Stdout, transcript, report); err != nil {
+				return err
+			}
+			replayed++
+		}
+	}
+
+	if replayed == 0 {
+		fmt.Println("No DKG transcripts found.")
+	}
+
+	return nil
+}
+
+// replaySigning returns the signing used to verify the given transcript
+// recorded by the operator with the given account. Transcripts are only
+// verified during the replay so if the operator key is held by the external
+// signer, the public key the transcript is signed with is enough.
+func replaySigning(
+	account *operatorAccount,
+	signedTranscript *gjkr.SignedTranscript,
+) (chain.Signing, error) {
+	if account.key != nil {
+		return ethereum.NewSigning(account.key.PrivateKey), nil
+	}
+
+	publicKey, err := operator.Unmarshal(signedTranscript.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not unmarshal transcript public key: [%v]",
+			err,
+		)
+	}
+
+	return ethereum.NewVerifyingSigning(publicKey), nil
+}
+
+func printReplayReport(
+	output io.Writer,
+	transcript *gjkr.Transcript,
+	report *gjkr.ReplayReport,
+) error {
+	fmt.Fprintf(
+		output,
+		"DKG with seed [0x%v] started at block [%v]; member [%v] of [%v] "+
+			"reached phase [%v]\n",
+		transcript.Seed.Text(16),
+		transcript.StartBlockHeight,
+		report.MemberIndex,
+		transcript.GroupSize,
+		report.LastPhase,
+	)
+	if report.Error != "" {
+		fmt.Fprintf(output, "Execution failed: [%v]\n", report.Error)
+	}
+
+	if len(report.Misbehaviors) == 0 {
+		fmt.Fprintln(output, "No misbehaving members.")
+	} else {
+		writer := tabwriter.NewWriter(output, 0, 0, 2, ' ', 0)
+
+		fmt.Fprintln(writer, "PHASE\tSTATE\tMEMBER\tSTATUS\tREASON")
+		for _, misbehavior := range report.Misbehaviors {
+			status := "disqualified"
+			if misbehavior.Inactive {
+				status = "inactive"
+			}
+
+			fmt.Fprintf(
+				writer,
+				"%v\t%v\t%v\t%v\t%v\n",
+				misbehavior.Phase,
+				misbehavior.State,
+				misbehavior.MemberIndex,
+				status,
+				misbehavior.Reason,
+			)
+		}
+
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if report.GroupPublicKey != nil {
+		fmt.Fprintf(output, "Group public key: [0x%x]\n", report.GroupPublicKey)
+	}
+	fmt.Fprintln(output)
+
+	return nil
+}
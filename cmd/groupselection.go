@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/config"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/groupselection"
+	"github.com/urfave/cli"
+)
+
+// GroupSelectionCommand contains the definition of the groupselection
+// command-line subcommand and its own subcommands.
+var GroupSelectionCommand cli.Command
+
+const (
+	seedFlag                    = "seed"
+	stakeFlag                   = "stake"
+	ticketSubmissionTimeoutFlag = "ticket-submission-timeout"
+	ticketSubmissionGasFlag     = "ticket-submission-gas"
+	gasPriceFlag                = "gas-price"
+	seatRewardFlag              = "seat-reward"
+	runsFlag                    = "runs"
+)
+
+const groupSelectionDescription = `The groupselection command gives insight into
+   the group selection protocol. The "simulate" subcommand simulates ticket
+   generation, ticket submission rounds and the final group selection for the
+   given seed and stake distribution, without connecting to any chain. Stakes
+   are given as address=amount pairs, one --stake flag per staker. With more
+   than one run, each run uses the Keccak-256 hash of the previous seed as its
+   seed and the report shows averages over all runs. If the seat reward is set,
+   all stakers submit only tickets worth their gas cost.`
+
+func init() {
+	GroupSelectionCommand = cli.Command{
+		Name:        "groupselection",
+		Usage:       `Provides insight into the group selection protocol.`,
+		Description: groupSelectionDescription,
+		Subcommands: []cli.Command{
+			{
+				Name:   "simulate",
+				Usage:  "Simulates group selection for the given stakes.",
+				Action: simulateGroupSelection,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  seedFlag,
+						Value: "0x01",
+						Usage: "group selection seed, decimal or hexadecimal",
+					},
+					&cli.StringSliceFlag{
+						Name:  stakeFlag,
+						Usage: "stake of a staker as address=amount",
+					},
+					&cli.IntFlag{
+						Name:  groupSizeFlag,
+						Value: 64,
+					},
+					&cli.StringFlag{
+						Name:  minimumStakeFlag,
+						Value: "100000000000000000000000",
+					},
+					&cli.Uint64Flag{
+						Name:  ticketSubmissionTimeoutFlag,
+						Value: 6*11 + 12,
+						Usage: "ticket submission timeout in blocks",
+					},
+					&cli.Uint64Flag{
+						Name:  ticketSubmissionGasFlag,
+						Value: 250000,
+						Usage: "gas used by a single ticket submission",
+					},
+					&cli.StringFlag{
+						Name:  gasPriceFlag,
+						Value: "20000000000",
+						Usage: "gas price in wei",
+					},
+					&cli.StringFlag{
+						Name:  seatRewardFlag,
+						Usage: "reward in wei expected for a seat in a group",
+					},
+					&cli.IntFlag{
+						Name:  runsFlag,
+						Value: 1,
+					},
+				},
+			},
+		},
+	}
+}
+
+// simulateGroupSelection simulates group selection for the stakes given with
+// flags and prints the report.
+func simulateGroupSelection(c *cli.Context) error {
+	seed, err := parseBigInt(c.String(seedFlag))
+	if err != nil {
+		return fmt.Errorf("invalid seed: [%v]", err)
+	}
+
+	stakers, err := parseStakes(c.StringSlice(stakeFlag))
+	if err != nil {
+		return err
+	}
+
+	minimumStake, err := parseBigInt(c.String(minimumStakeFlag))
+	if err != nil {
+		return fmt.Errorf("invalid minimum stake: [%v]", err)
+	}
+	if minimumStake.Sign() <= 0 {
+		return fmt.Errorf("minimum stake must be positive")
+	}
+
+	gasPrice, err := parseBigInt(c.String(gasPriceFlag))
+	if err != nil {
+		return fmt.Errorf("invalid gas price: [%v]", err)
+	}
+
+	parameters := &groupselection.SimulationParameters{
+		ChainConfig: &config.Chain{
+			GroupSize:               c.Int(groupSizeFlag),
+			TicketSubmissionTimeout: c.Uint64(ticketSubmissionTimeoutFlag),
+			MinimumStake:            minimumStake,
+		},
+		TicketSubmissionGas: c.Uint64(ticketSubmissionGasFlag),
+		GasPrice:            gasPrice,
+	}
+
+	if seatReward := c.String(seatRewardFlag); seatReward != "" {
+		reward, err := parseBigInt(seatReward)
+		if err != nil {
+			return fmt.Errorf("invalid seat reward: [%v]", err)
+		}
+		parameters.Policy = &groupselection.ProfitabilityPolicy{
+			SeatReward: reward,
+		}
+	}
+
+	runs := c.Int(runsFlag)
+	if runs < 1 {
+		return fmt.Errorf("at least one run is required")
+	}
+
+	simulations := make([]*groupselection.Simulation, runs)
+	for run := 0; run < runs; run++ {
+		simulations[run], err = groupselection.Simulate(seed, stakers, parameters)
+		if err != nil {
+			return fmt.Errorf("could not simulate group selection: [%v]", err)
+		}
+
+		seed = new(big.Int).SetBytes(crypto.Keccak256(seed.Bytes()))
+	}
+
+	return printSimulationReport(os.Stdout, parameters, simulations)
+}
+
+// parseStakes parses stakes given as address=amount pairs.
+func parseStakes(stakes []string) ([]*groupselection.SimulatedStaker, error) {
+	if len(stakes) == 0 {
+		return nil, fmt.Errorf("at least one stake is required")
+	}
+
+	stakers := make([]*groupselection.SimulatedStaker, len(stakes))
+	for i, stake := range stakes {
+		parts := strings.SplitN(stake, "=", 2)
+		if len(parts) != 2 || !common.IsHexAddress(parts[0]) {
+			return nil, fmt.Errorf(
+				"stake [%v] is not in the address=amount format",
+				stake,
+			)
+		}
+
+		amount, err := parseBigInt(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount of stake [%v]: [%v]", stake, err)
+		}
+
+		stakers[i] = &groupselection.SimulatedStaker{
+			Address: relaychain.StakerAddress(
+				common.HexToAddress(parts[0]).Bytes(),
+			),
+			Stake: amount,
+		}
+	}
+
+	return stakers, nil
+}
+
+func parseBigInt(value string) (*big.Int, error) {
+	number, ok := new(big.Int).SetString(value, 0)
+	if !ok {
+		return nil, fmt.Errorf("[%v] is not a number", value)
+	}
+
+	return number, nil
+}
+
+// printSimulationReport prints, for every staker, the number of tickets,
+// submitted tickets, seats in the group and gas used averaged over all the
+// simulation runs. Expected seats are proportional to the stake.
+func printSimulationReport(
+	output io.Writer,
+	parameters *groupselection.SimulationParameters,
+	simulations []*groupselection.Simulation,
+) error {
+	runs := float64(len(simulations))
+	stakers := simulations[0].Stakers
+
+	totalStake := new(big.Int)
+	for _, staker := range stakers {
+		totalStake.Add(totalStake, staker.Stake)
+	}
+
+	writer := tabwriter.NewWriter(output, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	fmt.Fprintln(
+		writer,
+		"STAKER\tSTAKE\tTICKETS\tSUBMITTED\tSEATS\tEXPECTED SEATS\t"+
+			"GAS USED\tFEES\t",
+	)
+
+	totalSubmitted := 0
+	totalGasUsed := uint64(0)
+	for i, staker := range stakers {
+		submitted := 0
+		seats := 0
+		gasUsed := uint64(0)
+		for _, simulation := range simulations {
+			submitted += simulation.Stakers[i].SubmittedTickets
+			seats += simulation.Stakers[i].Seats
+			gasUsed += simulation.Stakers[i].GasUsed
+		}
+		totalSubmitted += submitted
+		totalGasUsed += gasUsed
+
+		stakeShare, _ := new(big.Rat).SetFrac(staker.Stake, totalStake).Float64()
+		fees := new(big.Int).Mul(
+			new(big.Int).SetUint64(gasUsed),
+			parameters.GasPrice,
+		)
+
+		fmt.Fprintf(
+			writer,
+			"0x%x\t%v\t%v\t%.2f\t%.2f\t%.2f\t%.0f\t%v\t\n",
+			staker.Address,
+			staker.Stake,
+			staker.Tickets,
+			float64(submitted)/runs,
+			float64(seats)/runs,
+			stakeShare*float64(parameters.ChainConfig.GroupSize),
+			float64(gasUsed)/runs,
+			new(big.Int).Div(fees, big.NewInt(int64(len(simulations)))),
+		)
+	}
+
+	fmt.Fprintf(
+		writer,
+		"total\t%v\t\t%.2f\t%v\t\t%.0f\t\t\n",
+		totalStake,
+		float64(totalSubmitted)/runs,
+		parameters.ChainConfig.GroupSize,
+		float64(totalGasUsed)/runs,
+	)
+
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(output, "\nAverages over [%v] runs.\n", len(simulations))
+
+	return nil
+}
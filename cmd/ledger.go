@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"text/tabwriter"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/ledger"
+	"github.com/urfave/cli"
+)
+
+// LedgerCommand contains the definition of the ledger command-line subcommand
+// and its own subcommands.
+var LedgerCommand cli.Command
+
+const ledgerDescription = `The ledger command gives access to the operator fee
+	ledger kept by the client in the data directory. The ledger records gas
+	used, gas price and fee of every ticket, DKG result, relay entry and relay
+	entry timeout report submitted by operators along with DKG submitter
+	reimbursements and withdrawn group member rewards. The "report" subcommand
+	prints totals per operator and kind of entry together with the profit of
+	each operator.`
+
+func init() {
+	LedgerCommand = cli.Command{
+		Name:        "ledger",
+		Usage:       `Provides access to the operator fee ledger.`,
+		Description: ledgerDescription,
+		Subcommands: []cli.Command{
+			{
+				Name:   "report",
+				Usage:  "Prints fees, reimbursements and rewards of operators.",
+				Action: ledgerReport,
+			},
+		},
+	}
+}
+
+// ledgerReport prints totals of the operator fee ledger entries per operator
+// and kind of entry. All amounts are in wei.
+func ledgerReport(c *cli.Context) error {
+	cfg, err := config.ReadConfig(c.GlobalString("config"))
+	if err != nil {
+		return fmt.Errorf("error reading config file: [%v]", err)
+	}
+
+	feeLedger, err := ledger.Open(cfg.Storage.DataDir)
+	if err != nil {
+		return fmt.Errorf("error opening operator fee ledger: [%v]", err)
+	}
+	defer feeLedger.Close()
+
+	entries, err := feeLedger.Entries()
+	if err != nil {
+		return fmt.Errorf("error reading operator fee ledger: [%v]", err)
+	}
+
+	return printLedgerReport(os.Stdout, ledger.Report(entries))
+}
+
+func printLedgerReport(output io.Writer, rows []*ledger.ReportRow) error {
+	writer := tabwriter.NewWriter(output, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	fmt.Fprintln(
+		writer,
+		"OPERATOR\tKIND\tCOUNT\tGAS USED\tFEES\tREIMBURSEMENTS\tREWARDS\t",
+	)
+
+	printTotal := func(operator string, profit *big.Int) {
+		fmt.Fprintf(writer, "%v\tprofit\t\t\t\t\t%v\t\n", operator, profit)
+	}
+
+	operatorProfit := new(big.Int)
+	for i, row := range rows {
+		fmt.Fprintf(
+			writer,
+			"%v\t%v\t%v\t%v\t%v\t%v\t%v\t\n",
+			row.Operator,
+			row.Kind,
+			row.Count,
+			row.Totals.GasUsed,
+			row.Totals.Fees,
+			row.Totals.Reimbursements,
+			row.Totals.Rewards,
+		)
+
+		operatorProfit.Add(operatorProfit, row.Totals.Profit())
+
+		if i == len(rows)-1 || rows[i+1].Operator != row.Operator {
+			printTotal(row.Operator, operatorProfit)
+			operatorProfit = new(big.Int)
+		}
+	}
+
+	return writer.Flush()
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/chain/localchain"
+	"github.com/urfave/cli"
+)
+
+// LocalChainCommand contains the definition of the local-chain command-line
+// subcommand and its own subcommands.
+var LocalChainCommand cli.Command
+
+const (
+	dataDirFlag         = "data-dir"
+	blockTimeFlag       = "block-time"
+	groupSizeFlag       = "group-size"
+	honestThresholdFlag = "honest-threshold"
+	minimumStakeFlag    = "minimum-stake"
+	initialStakeFlag    = "initial-stake"
+	requestIntervalFlag = "request-interval"
+)
+
+const localChainDescription = `The local-chain command allows running a local chain
+   server used instead of Ethereum in development networks. Keep clients connect
+   to the server when its address is set in the LocalChain section of their
+   configuration. The "start" subcommand starts the server in the foreground.
+   The chain state is stored in the data directory and chain parameters given
+   with flags are used only when a new chain is created there. The "request"
+   subcommand requests a new relay entry from the server configured in the
+   LocalChain section of the configuration file.`
+
+func init() {
+	defaults := localchain.DefaultParameters()
+
+	LocalChainCommand = cli.Command{
+		Name:        "local-chain",
+		Usage:       `Runs a local chain for development networks.`,
+		Description: localChainDescription,
+		Subcommands: []cli.Command{
+			{
+				Name:   "start",
+				Usage:  "Starts the local chain server in the foreground.",
+				Action: startLocalChain,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  dataDirFlag,
+						Value: "local-chain",
+					},
+					&cli.IntFlag{
+						Name:  portFlag + "," + portShort,
+						Value: 3918,
+					},
+					&cli.DurationFlag{
+						Name:  blockTimeFlag,
+						Value: time.Second,
+					},
+					&cli.IntFlag{
+						Name:  groupSizeFlag,
+						Value: defaults.GroupSize,
+					},
+					&cli.IntFlag{
+						Name:  honestThresholdFlag,
+						Value: defaults.HonestThreshold,
+					},
+					&cli.Int64Flag{
+						Name:  minimumStakeFlag,
+						Value: defaults.MinimumStake.Int64(),
+					},
+					&cli.Int64Flag{
+						Name:  initialStakeFlag,
+						Value: defaults.InitialStake.Int64(),
+					},
+					&cli.Uint64Flag{
+						Name:  requestIntervalFlag,
+						Usage: "blocks between automatic relay entry requests",
+					},
+				},
+			},
+			{
+				Name:   "request",
+				Usage:  "Requests a new entry from the local chain relay.",
+				Action: requestLocalChainEntry,
+			},
+		},
+	}
+}
+
+// startLocalChain starts the local chain server. The server runs until it
+// receives SIGINT or SIGTERM.
+func startLocalChain(c *cli.Context) error {
+	parameters := localchain.DefaultParameters()
+	parameters.GroupSize = c.Int(groupSizeFlag)
+	parameters.HonestThreshold = c.Int(honestThresholdFlag)
+	parameters.MinimumStake = big.NewInt(c.Int64(minimumStakeFlag))
+	parameters.InitialStake = big.NewInt(c.Int64(initialStakeFlag))
+
+	if parameters.HonestThreshold > parameters.GroupSize {
+		return fmt.Errorf(
+			"honest threshold [%v] must not be greater than group size [%v]",
+			parameters.HonestThreshold,
+			parameters.GroupSize,
+		)
+	}
+
+	server, err := localchain.NewServer(
+		localchain.ServerConfig{
+			DataDir:         c.String(dataDirFlag),
+			BlockTime:       c.Duration(blockTimeFlag),
+			RequestInterval: c.Uint64(requestIntervalFlag),
+		},
+		parameters,
+	)
+	if err != nil {
+		return fmt.Errorf("error creating local chain server: [%v]", err)
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", c.Int(portFlag)))
+	if err != nil {
+		return fmt.Errorf("error listening on port [%v]: [%v]", c.Int(portFlag), err)
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	signalChannel := make(chan os.Signal, 1)
+	signal.Notify(signalChannel, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signalChannel)
+
+	go func() {
+		receivedSignal := <-signalChannel
+		logger.Infof("received [%v] signal; stopping local chain", receivedSignal)
+		cancelCtx()
+	}()
+
+	logger.Infof(
+		"local chain listening on [%v] with group size [%v], honest "+
+			"threshold [%v] and minimum stake [%v]",
+		listener.Addr(),
+		server.Parameters().GroupSize,
+		server.Parameters().HonestThreshold,
+		server.Parameters().MinimumStake,
+	)
+
+	return server.Serve(ctx, listener)
+}
+
+// requestLocalChainEntry requests a new relay entry from the local chain
+// server and prints the block at which the entry has been requested.
+func requestLocalChainEntry(c *cli.Context) error {
+	cfg, err := config.ReadConfig(c.GlobalString("config"))
+	if err != nil {
+		return fmt.Errorf("error reading config file: [%v]", err)
+	}
+
+	if cfg.LocalChain.Address == "" {
+		return fmt.Errorf("local chain server address is not configured")
+	}
+
+	block, err := localchain.RequestRelayEntry(cfg.LocalChain)
+	if err != nil {
+		return fmt.Errorf("error requesting relay entry: [%v]", err)
+	}
+
+	fmt.Printf("Relay entry requested at block [%v]\n", block)
+
+	return nil
+}
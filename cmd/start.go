@@ -3,8 +3,19 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"math/big"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/keep-network/keep-core/pkg/admin"
 	"github.com/keep-network/keep-core/pkg/metrics"
 	"github.com/keep-network/keep-core/pkg/net"
 
@@ -13,8 +24,14 @@ import (
 	"github.com/keep-network/keep-common/pkg/persistence"
 	"github.com/keep-network/keep-core/config"
 	"github.com/keep-network/keep-core/pkg/beacon"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/audit"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/groupselection"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/rewards"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/ledger"
+	"github.com/keep-network/keep-core/pkg/chain/localchain"
 	"github.com/keep-network/keep-core/pkg/firewall"
 	"github.com/keep-network/keep-core/pkg/net/key"
 	"github.com/keep-network/keep-core/pkg/net/libp2p"
@@ -35,8 +52,23 @@ const (
 	portShort         = "p"
 	waitForStakeFlag  = "wait-for-stake"
 	waitForStakeShort = "w"
+	drainTimeoutFlag  = "drain-timeout"
 )
 
+// operatorsDataDir is the name of the data directory subdirectory in which
+// data of operators other than the primary one are stored.
+const operatorsDataDir = "operators"
+
+// transcriptsDataDir is the name of the data directory subdirectory in which
+// transcripts of distributed key generations are stored. Transcripts are
+// kept apart from the other persisted data so that they are not read on
+// every client start.
+const transcriptsDataDir = "dkg_transcripts"
+
+// defaultDrainTimeout is the default time the client waits for the running
+// signing sessions to complete after receiving a shutdown signal.
+const defaultDrainTimeout = 2 * time.Minute
+
 const startDescription = `Starts the Keep client in the foreground. Currently this only consists of the
    threshold relay client for the Keep random beacon.`
 
@@ -54,12 +86,19 @@ func init() {
 				&cli.IntFlag{
 					Name: waitForStakeFlag + "," + waitForStakeShort,
 				},
+				&cli.DurationFlag{
+					Name:  drainTimeoutFlag,
+					Value: defaultDrainTimeout,
+				},
 			},
 		}
 }
 
 // Start starts a node; if it's not a bootstrap node it will get the Node.URLs
-// from the config file
+// from the config file. The node runs until it receives SIGINT or SIGTERM.
+// Then, it stops accepting new work and waits for the running signing
+// sessions to complete before exiting. The second signal received while
+// waiting forces an immediate exit.
 func Start(c *cli.Context) error {
 	config, err := config.ReadConfig(c.GlobalString("config"))
 	if err != nil {
@@ -70,23 +109,25 @@ func Start(c *cli.Context) error {
 		config.LibP2P.Port = c.Int(portFlag)
 	}
 
-	ethereumKey, err := ethutil.DecryptKeyFile(
-		config.Ethereum.Account.KeyFile,
-		config.Ethereum.Account.KeyFilePassword,
-	)
+	operatorAccounts, err := readOperatorAccounts(config)
 	if err != nil {
-		return fmt.Errorf(
-			"failed to read key file [%s]: [%v]",
-			config.Ethereum.Account.KeyFile,
-			err,
-		)
+		return err
+	}
+
+	networkPrivateKey, err := readNetworkKey(config, operatorAccounts[0])
+	if err != nil {
+		return err
 	}
 
-	chainProvider, err := ethereum.Connect(config.Ethereum)
+	chainProviders, err := connectChain(config, operatorAccounts)
 	if err != nil {
-		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+		return err
 	}
 
+	// All chain handles share the same connection so any of them can be used
+	// for operations not specific to an operator.
+	chainProvider := chainProviders[0]
+
 	blockCounter, err := chainProvider.BlockCounter()
 	if err != nil {
 		return err
@@ -96,32 +137,24 @@ func Start(c *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("error obtaining stake monitor handle [%v]", err)
 	}
-	if c.Int(waitForStakeFlag) != 0 {
-		err = waitForStake(stakeMonitor, ethereumKey.Address.Hex(), c.Int(waitForStakeFlag))
+	for _, operatorAccount := range operatorAccounts {
+		err := checkStake(
+			stakeMonitor,
+			operatorAccount.address.Hex(),
+			c.Int(waitForStakeFlag),
+		)
 		if err != nil {
 			return err
 		}
 	}
-	hasMinimumStake, err := stakeMonitor.HasMinimumStake(
-		ethereumKey.Address.Hex(),
-	)
-	if err != nil {
-		return fmt.Errorf("could not check the stake [%v]", err)
-	}
-	if !hasMinimumStake {
-		return fmt.Errorf(
-			"no minimum KEEP stake or operator is not authorized to use it; " +
-				"please make sure the operator address in the configuration " +
-				"is correct and it has KEEP tokens delegated and the operator " +
-				"contract has been authorized to operate on the stake",
-		)
-	}
 
-	ctx := context.Background()
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	signalChannel := make(chan os.Signal, 1)
+	signal.Notify(signalChannel, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signalChannel)
 
-	networkPrivateKey, _ := key.OperatorKeyToNetworkKey(
-		operator.EthereumKeyToOperatorKey(ethereumKey),
-	)
 	netProvider, err := libp2p.Connect(
 		ctx,
 		config.LibP2P,
@@ -136,36 +169,439 @@ func Start(c *cli.Context) error {
 
 	nodeHeader(netProvider.ConnectionManager().AddrStrings(), config.LibP2P.Port)
 
-	handle, err := persistence.NewDiskHandle(config.Storage.DataDir)
+	beaconHandles := make([]*beacon.Handle, len(operatorAccounts))
+	for i, operatorAccount := range operatorAccounts {
+		beaconHandles[i], err = initializeBeacon(
+			ctx,
+			config,
+			operatorAccount,
+			i == 0,
+			chainProviders[i],
+			netProvider,
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"error initializing beacon for operator [%v]: [%v]",
+				operatorAccount.address.Hex(),
+				err,
+			)
+		}
+	}
+
+	entryAuditor, err := initializeAudit(ctx, config, chainProvider)
+	if err != nil {
+		return fmt.Errorf("error initializing relay entry audit: [%v]", err)
+	}
+
+	feeLedger, err := initializeLedger(ctx, config, chainProviders)
+	if err != nil {
+		return fmt.Errorf("error initializing operator fee ledger: [%v]", err)
+	}
+
+	initializeMetrics(
+		ctx,
+		config,
+		netProvider,
+		stakeMonitor,
+		operatorAccounts[0].address.Hex(),
+		entryAuditor,
+		feeLedger,
+		chainProviders,
+	)
+	initializeAdmin(ctx, config, operatorAccounts, beaconHandles, entryAuditor)
+
+	receivedSignal := <-signalChannel
+
+	drainTimeout := c.Duration(drainTimeoutFlag)
+	logger.Infof(
+		"received [%v] signal; waiting up to [%v] for running signing "+
+			"sessions to complete",
+		receivedSignal,
+		drainTimeout,
+	)
+
+	drainResult := make(chan error, 1)
+	go func() {
+		drainResult <- drainBeacons(
+			operatorAccounts,
+			beaconHandles,
+			drainTimeout,
+		)
+	}()
+
+	select {
+	case err := <-drainResult:
+		if err != nil {
+			logger.Warningf("could not drain the beacon: [%v]", err)
+		} else {
+			logger.Infof("all running signing sessions completed")
+		}
+	case receivedSignal := <-signalChannel:
+		logger.Warningf(
+			"received [%v] signal while draining; shutting down immediately",
+			receivedSignal,
+		)
+	}
+
+	cancelCtx()
+	logger.Infof("client shut down")
+
+	return nil
+}
+
+// operatorAccount is an account of an operator hosted by the client. The key
+// of the account is nil when the key is held by the external signer.
+type operatorAccount struct {
+	address  common.Address
+	key      *keystore.Key
+	password string
+}
+
+// readOperatorAccounts returns accounts of all operators hosted by the client,
+// the primary operator first. If the external signer is configured, the
+// operator address is taken from the configuration, key files are not read and
+// the data of the operator is encrypted with the Ethereum account password.
+// Only one operator can be hosted with the external signer since peers accept
+// network messages of an operator only if they are signed with the key of the
+// operator and the client holds the network key of the primary operator only.
+// Otherwise, the key files of the operators are decrypted.
+func readOperatorAccounts(config *config.Config) ([]*operatorAccount, error) {
+	if config.Ethereum.ExternalSigner != "" {
+		addresses, err := config.Ethereum.ExternalSignerAddresses()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(addresses) > 1 {
+			return nil, fmt.Errorf(
+				"only one operator account can be managed by the " +
+					"external signer",
+			)
+		}
+
+		operatorAccounts := make([]*operatorAccount, len(addresses))
+		for i, address := range addresses {
+			operatorAccounts[i] = &operatorAccount{
+				address:  address,
+				password: config.Ethereum.Account.KeyFilePassword,
+			}
+		}
+
+		return operatorAccounts, nil
+	}
+
+	accounts := config.OperatorAccounts()
+
+	operatorAccounts := make([]*operatorAccount, len(accounts))
+	for i, account := range accounts {
+		operatorKey, err := ethutil.DecryptKeyFile(
+			account.KeyFile,
+			account.KeyFilePassword,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to read key file [%s]: [%v]",
+				account.KeyFile,
+				err,
+			)
+		}
+
+		operatorAccounts[i] = &operatorAccount{
+			address:  operatorKey.Address,
+			key:      operatorKey,
+			password: account.KeyFilePassword,
+		}
+	}
+
+	return operatorAccounts, nil
+}
+
+// readNetworkKey returns the key identifying the client in the network. It is
+// the key of the primary operator or, if the key is held by the external
+// signer, the key from the key file configured in the LibP2P section,
+// decrypted with the Ethereum account password. Peers validate messages of the
+// operator against the address of the network key so the key from the file
+// has to belong to the primary operator.
+func readNetworkKey(
+	config *config.Config,
+	primaryOperator *operatorAccount,
+) (*key.NetworkPrivate, error) {
+	if primaryOperator.key != nil {
+		networkPrivateKey, _ := key.OperatorKeyToNetworkKey(
+			operator.EthereumKeyToOperatorKey(primaryOperator.key),
+		)
+		return networkPrivateKey, nil
+	}
+
+	if config.LibP2P.KeyFile == "" {
+		return nil, fmt.Errorf(
+			"network key file is required when operator keys are held " +
+				"by the external signer",
+		)
+	}
+
+	networkKey, err := ethutil.DecryptKeyFile(
+		config.LibP2P.KeyFile,
+		config.Ethereum.Account.KeyFilePassword,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to read network key file [%s]: [%v]",
+			config.LibP2P.KeyFile,
+			err,
+		)
+	}
+
+	if networkKey.Address != primaryOperator.address {
+		return nil, fmt.Errorf(
+			"network key file [%s] does not belong to operator [%s]",
+			config.LibP2P.KeyFile,
+			primaryOperator.address.Hex(),
+		)
+	}
+
+	networkPrivateKey, _ := key.OperatorKeyToNetworkKey(
+		operator.EthereumKeyToOperatorKey(networkKey),
+	)
+	return networkPrivateKey, nil
+}
+
+// connectChain connects all operators hosted by the client to the local chain
+// server, if its address is configured, or to the Ethereum node otherwise.
+func connectChain(
+	config *config.Config,
+	operatorAccounts []*operatorAccount,
+) ([]chain.Handle, error) {
+	operatorAddresses := make([]common.Address, len(operatorAccounts))
+	operatorKeys := make([]*keystore.Key, len(operatorAccounts))
+	for i, operatorAccount := range operatorAccounts {
+		operatorAddresses[i] = operatorAccount.address
+		operatorKeys[i] = operatorAccount.key
+	}
+
+	if config.LocalChain.Address != "" {
+		if config.Ethereum.ExternalSigner != "" {
+			return nil, fmt.Errorf(
+				"external signer can not be used with local chain server",
+			)
+		}
+
+		logger.Warningf(
+			"connecting to local chain server at [%v]; local chain must "+
+				"be used only in development networks",
+			config.LocalChain.Address,
+		)
+
+		chainProviders, err := localchain.ConnectOperators(
+			config.LocalChain,
+			operatorKeys,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error connecting to local chain server: [%v]",
+				err,
+			)
+		}
+
+		return chainProviders, nil
+	}
+
+	chainProviders, err := ethereum.ConnectOperators(
+		config.Ethereum,
+		operatorAddresses,
+		operatorKeys,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+	}
+
+	return chainProviders, nil
+}
+
+// checkStake ensures the operator has the minimum stake required to join the
+// network. If the wait time in minutes is set, it waits up to that time for
+// the stake to become available.
+func checkStake(
+	stakeMonitor chain.StakeMonitor,
+	address string,
+	waitMinutes int,
+) error {
+	if waitMinutes != 0 {
+		err := waitForStake(stakeMonitor, address, waitMinutes)
+		if err != nil {
+			return err
+		}
+	}
+
+	hasMinimumStake, err := stakeMonitor.HasMinimumStake(address)
+	if err != nil {
+		return fmt.Errorf("could not check the stake [%v]", err)
+	}
+	if !hasMinimumStake {
+		return fmt.Errorf(
+			"no minimum KEEP stake for operator [%v] or operator is not "+
+				"authorized to use it; please make sure the operator address "+
+				"in the configuration is correct and it has KEEP tokens "+
+				"delegated and the operator contract has been authorized to "+
+				"operate on the stake",
+			address,
+		)
+	}
+
+	return nil
+}
+
+// initializeBeacon initializes the random beacon for one of the operators
+// hosted by the client. The primary operator keeps its data directly in the
+// data directory while other operators keep it in their own subdirectories.
+// Network messages of an operator whose key is held by the external signer
+// are sent on behalf of the client, whose network key belongs to the operator.
+func initializeBeacon(
+	ctx context.Context,
+	config *config.Config,
+	account *operatorAccount,
+	isPrimary bool,
+	chainProvider chain.Handle,
+	netProvider net.Provider,
+) (*beacon.Handle, error) {
+	operatorNetProvider := netProvider
+	if account.key != nil {
+		networkPrivateKey, _ := key.OperatorKeyToNetworkKey(
+			operator.EthereumKeyToOperatorKey(account.key),
+		)
+
+		var err error
+		operatorNetProvider, err = netProvider.ForOperator(networkPrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not get network provider: [%v]",
+				err,
+			)
+		}
+	}
+
+	dataDir := operatorDataDir(config, account.address, isPrimary)
+	if !isPrimary {
+		if err := os.MkdirAll(dataDir, 0700); err != nil {
+			return nil, fmt.Errorf(
+				"could not create operator storage directory: [%v]",
+				err,
+			)
+		}
+	}
+
+	handle, err := persistence.NewDiskHandle(dataDir)
 	if err != nil {
-		return fmt.Errorf("failed while creating a storage disk handler: [%v]", err)
+		return nil, fmt.Errorf(
+			"failed while creating a storage disk handler: [%v]",
+			err,
+		)
 	}
 	persistence := persistence.NewEncryptedPersistence(
 		handle,
-		config.Ethereum.Account.KeyFilePassword,
+		account.password,
 	)
 
-	err = beacon.Initialize(
+	transcriptPersistence, err := openTranscriptPersistence(
+		dataDir,
+		account.password,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return beacon.Initialize(
 		ctx,
-		ethereumKey.Address.Hex(),
+		account.address.Hex(),
 		chainProvider,
-		netProvider,
+		eventConfirmationDepth(config),
+		operatorNetProvider,
 		persistence,
+		transcriptPersistence,
+		rewardsConfig(config),
+		ticketProfitabilityPolicy(config),
+	)
+}
+
+// openTranscriptPersistence returns the encrypted persistence handle storing
+// transcripts of distributed key generations in the given operator data
+// directory.
+func openTranscriptPersistence(
+	dataDir string,
+	password string,
+) (persistence.Handle, error) {
+	handle, err := persistence.NewDiskHandle(
+		filepath.Join(dataDir, transcriptsDataDir),
 	)
 	if err != nil {
-		return fmt.Errorf("error initializing beacon: [%v]", err)
+		return nil, fmt.Errorf(
+			"failed while creating a transcript storage disk handler: [%v]",
+			err,
+		)
 	}
 
-	initializeMetrics(ctx, config, netProvider, stakeMonitor, ethereumKey.Address.Hex())
+	return persistence.NewEncryptedPersistence(handle, password), nil
+}
 
-	select {
-	case <-ctx.Done():
+// operatorDataDir returns the data directory of the given operator. The primary
+// operator keeps its data directly in the data directory while other operators
+// keep it in their own subdirectories.
+func operatorDataDir(
+	config *config.Config,
+	operatorAddress common.Address,
+	isPrimary bool,
+) string {
+	if isPrimary {
+		return config.Storage.DataDir
+	}
+
+	return filepath.Join(
+		config.Storage.DataDir,
+		operatorsDataDir,
+		operatorAddress.Hex(),
+	)
+}
+
+// drainBeacons drains beacons of all operators hosted by the client at the
+// same time and waits until all of them complete.
+func drainBeacons(
+	operatorAccounts []*operatorAccount,
+	beaconHandles []*beacon.Handle,
+	timeout time.Duration,
+) error {
+	drainErrors := make([]error, len(beaconHandles))
+
+	var wg sync.WaitGroup
+	wg.Add(len(beaconHandles))
+	for i, beaconHandle := range beaconHandles {
+		go func(i int, beaconHandle *beacon.Handle) {
+			defer wg.Done()
+			drainErrors[i] = beaconHandle.Drain(timeout)
+		}(i, beaconHandle)
+	}
+	wg.Wait()
+
+	var failures []string
+	for i, err := range drainErrors {
 		if err != nil {
-			return err
+			failures = append(
+				failures,
+				fmt.Sprintf(
+					"operator [%v]: [%v]",
+					operatorAccounts[i].address.Hex(),
+					err,
+				),
+			)
 		}
-
-		return fmt.Errorf("uh-oh, we went boom boom for no reason")
 	}
+	if len(failures) > 0 {
+		return fmt.Errorf(
+			"could not drain all beacons: [%v]",
+			strings.Join(failures, "; "),
+		)
+	}
+
+	return nil
 }
 
 func waitForStake(stakeMonitor chain.StakeMonitor, address string, timeout int) error {
@@ -185,12 +621,139 @@ func waitForStake(stakeMonitor chain.StakeMonitor, address string, timeout int)
 	return fmt.Errorf("timed out waiting for %s to have required minimum stake", address)
 }
 
+// eventConfirmationDepth returns the number of blocks chain events are
+// delivered after. Events of the local chain are delivered immediately.
+func eventConfirmationDepth(config *config.Config) uint64 {
+	if config.LocalChain.Address != "" {
+		return 0
+	}
+
+	return config.Ethereum.ConfirmationDepth
+}
+
+// rewardsConfig returns the configuration of automatic rewards withdrawal or
+// nil if automatic rewards withdrawal is not enabled.
+func rewardsConfig(config *config.Config) *rewards.Config {
+	if !config.Rewards.AutoWithdraw {
+		logger.Infof("automatic rewards withdrawal is not enabled")
+		return nil
+	}
+
+	rewardsConfig := &rewards.Config{
+		WithdrawalInterval: time.Duration(
+			config.Rewards.WithdrawalInterval,
+		) * time.Second,
+	}
+	if config.Rewards.MaxGasPrice != 0 {
+		rewardsConfig.MaxGasPrice = new(big.Int).SetUint64(
+			config.Rewards.MaxGasPrice,
+		)
+	}
+
+	logger.Infof(
+		"enabled automatic rewards withdrawal with [%v] interval "+
+			"and [%v] wei max gas price",
+		rewardsConfig.WithdrawalInterval,
+		rewardsConfig.MaxGasPrice,
+	)
+
+	return rewardsConfig
+}
+
+// ticketProfitabilityPolicy returns the profitability policy of group
+// selection tickets or nil if all qualifying tickets should be submitted.
+func ticketProfitabilityPolicy(
+	config *config.Config,
+) *groupselection.ProfitabilityPolicy {
+	if !config.GroupSelection.ProfitableTicketsOnly {
+		return nil
+	}
+
+	policy := &groupselection.ProfitabilityPolicy{
+		SeatReward: new(big.Int).SetUint64(config.GroupSelection.SeatReward),
+	}
+
+	logger.Infof(
+		"submitting only profitable tickets with [%v] wei expected seat reward",
+		policy.SeatReward,
+	)
+
+	return policy
+}
+
+// initializeAudit starts independent verification of all relay entries
+// submitted on-chain. Verification results are written to the audit log in
+// the data directory.
+func initializeAudit(
+	ctx context.Context,
+	config *config.Config,
+	chainProvider chain.Handle,
+) (*audit.Auditor, error) {
+	auditLog, err := audit.OpenLog(config.Storage.DataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	auditor, err := audit.NewAuditor(auditLog)
+	if err != nil {
+		auditLog.Close()
+		return nil, err
+	}
+
+	err = auditor.Start(ctx, chainProvider.ThresholdRelay())
+	if err != nil {
+		auditLog.Close()
+		return nil, err
+	}
+
+	go func() {
+		<-ctx.Done()
+		if err := auditLog.Close(); err != nil {
+			logger.Errorf("could not close relay entry audit log: [%v]", err)
+		}
+	}()
+
+	return auditor, nil
+}
+
+// initializeLedger starts recording fees paid, DKG reimbursements and
+// rewards received by all the operators in the ledger in the data directory.
+func initializeLedger(
+	ctx context.Context,
+	config *config.Config,
+	chainHandles []chain.Handle,
+) (*ledger.Ledger, error) {
+	feeLedger, err := ledger.Open(config.Storage.DataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, chainHandle := range chainHandles {
+		if err := ethereum.UseLedger(chainHandle, feeLedger); err != nil {
+			feeLedger.Close()
+			return nil, err
+		}
+	}
+
+	go func() {
+		<-ctx.Done()
+		if err := feeLedger.Close(); err != nil {
+			logger.Errorf("could not close operator fee ledger: [%v]", err)
+		}
+	}()
+
+	return feeLedger, nil
+}
+
 func initializeMetrics(
 	ctx context.Context,
 	config *config.Config,
 	netProvider net.Provider,
 	stakeMonitor chain.StakeMonitor,
 	ethereumAddress string,
+	entryAuditor *audit.Auditor,
+	feeLedger *ledger.Ledger,
+	chainHandles []chain.Handle,
 ) {
 	registry, isConfigured := metrics.Initialize(
 		config.Metrics.Port,
@@ -228,8 +791,100 @@ func initializeMetrics(
 		time.Duration(config.Metrics.EthereumMetricsTick)*time.Second,
 	)
 
+	metrics.ObserveRelayEntryAudit(
+		ctx,
+		registry,
+		entryAuditor,
+		time.Duration(config.Metrics.EthereumMetricsTick)*time.Second,
+	)
+
+	metrics.ObserveProtocolTransactions(
+		ctx,
+		registry,
+		chainHandles,
+		time.Duration(config.Metrics.EthereumMetricsTick)*time.Second,
+	)
+
+	metrics.ObserveOperatorLedger(
+		ctx,
+		registry,
+		feeLedger,
+		time.Duration(config.Metrics.EthereumMetricsTick)*time.Second,
+	)
+
+	// All the chain handles share the connection with Ethereum endpoints.
+	metrics.ObserveEthereumEndpoints(
+		ctx,
+		registry,
+		chainHandles[0],
+		time.Duration(config.Metrics.EthereumMetricsTick)*time.Second,
+	)
+
 	metrics.ExposeLibP2PInfo(
 		registry,
 		netProvider,
 	)
 }
+
+func initializeAdmin(
+	ctx context.Context,
+	config *config.Config,
+	operatorAccounts []*operatorAccount,
+	beaconHandles []*beacon.Handle,
+	entryAuditor *audit.Auditor,
+) {
+	server, isConfigured := admin.Initialize(
+		ctx,
+		config.Admin.Host,
+		config.Admin.Port,
+	)
+	if !isConfigured {
+		logger.Infof("admin API is not configured")
+		return
+	}
+
+	logger.Infof("enabled admin API on port [%v]", config.Admin.Port)
+
+	// Sources of the primary operator are available without a prefix, as
+	// well as under the operator address, as the other operators' sources.
+	registerBeaconSources(server, "", beaconHandles[0])
+	for i, beaconHandle := range beaconHandles {
+		registerBeaconSources(
+			server,
+			operatorAccounts[i].address.Hex()+"/",
+			beaconHandle,
+		)
+	}
+
+	// State machines are not attributed to operators so they are available
+	// once for all the operators hosted by the client.
+	server.RegisterSource("state-machines", func() interface{} {
+		return state.RunningMachines()
+	})
+
+	server.RegisterSource("relay-entry-audit", func() interface{} {
+		return entryAuditor.Status()
+	})
+}
+
+func registerBeaconSources(
+	server *admin.Server,
+	prefix string,
+	beaconHandle *beacon.Handle,
+) {
+	server.RegisterSource(prefix+"groups", func() interface{} {
+		return beaconHandle.Groups()
+	})
+	server.RegisterSource(prefix+"relay-requests", func() interface{} {
+		return beaconHandle.RelayRequests()
+	})
+	server.RegisterSource(prefix+"group-selections", func() interface{} {
+		return beaconHandle.GroupSelections()
+	})
+	server.RegisterSource(prefix+"key-generations", func() interface{} {
+		return beaconHandle.KeyGenerations()
+	})
+	server.RegisterSource(prefix+"rewards", func() interface{} {
+		return beaconHandle.Rewards()
+	})
+}
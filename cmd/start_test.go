@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/keep-network/keep-core/config"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/net/key"
+)
+
+const testKeyFilePassword = "password"
+
+func TestExternalSignerNetworkKeyPassesMembershipValidation(t *testing.T) {
+	keyDir, err := ioutil.TempDir("", "network-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(keyDir)
+
+	keyStore := keystore.NewKeyStore(
+		keyDir,
+		keystore.LightScryptN,
+		keystore.LightScryptP,
+	)
+	operatorAccount, err := keyStore.NewAccount(testKeyFilePassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := externalSignerConfig(
+		operatorAccount.Address.Hex(),
+		operatorAccount.URL.Path,
+	)
+
+	operatorAccounts, err := readOperatorAccounts(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	networkPrivateKey, err := readNetworkKey(config, operatorAccounts[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherOperatorKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Signing is used only to map public keys to addresses.
+	signing := ethereum.NewSigning(otherOperatorKey)
+
+	validator := group.NewStakersMembershipValidator(
+		[]relaychain.StakerAddress{
+			operatorAccount.Address.Bytes(),
+			crypto.PubkeyToAddress(otherOperatorKey.PublicKey).Bytes(),
+		},
+		signing,
+	)
+
+	// Messages sent on behalf of the operator are signed with the network
+	// key so peers validate the membership against its public key.
+	senderPublicKey := key.Marshal(
+		key.Libp2pKeyToNetworkKey(networkPrivateKey.GetPublic()),
+	)
+
+	if !validator.IsValidMembership(group.MemberIndex(1), senderPublicKey) {
+		t.Errorf("expected valid membership of the operator")
+	}
+	if validator.IsValidMembership(group.MemberIndex(2), senderPublicKey) {
+		t.Errorf("expected invalid membership at the other position")
+	}
+}
+
+func TestExternalSignerNetworkKeyOfOtherAccount(t *testing.T) {
+	keyDir, err := ioutil.TempDir("", "network-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(keyDir)
+
+	keyStore := keystore.NewKeyStore(
+		keyDir,
+		keystore.LightScryptN,
+		keystore.LightScryptP,
+	)
+	networkAccount, err := keyStore.NewAccount(testKeyFilePassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := externalSignerConfig(
+		"0xAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA8AAAAAAAAA",
+		networkAccount.URL.Path,
+	)
+
+	operatorAccounts, err := readOperatorAccounts(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = readNetworkKey(config, operatorAccounts[0])
+	if err == nil || !strings.Contains(err.Error(), "does not belong") {
+		t.Errorf("expected network key ownership error, has: [%v]", err)
+	}
+}
+
+func TestExternalSignerMultipleAccounts(t *testing.T) {
+	config := externalSignerConfig(
+		"0xAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA8AAAAAAAAA",
+		"",
+	)
+	config.Ethereum.ExternalSignerAccounts = append(
+		config.Ethereum.ExternalSignerAccounts,
+		"0xDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDD",
+	)
+
+	_, err := readOperatorAccounts(config)
+	if err == nil {
+		t.Errorf("expected error for multiple external signer accounts")
+	}
+}
+
+func externalSignerConfig(address string, networkKeyFile string) *config.Config {
+	config := &config.Config{}
+	config.Ethereum.ExternalSigner = "http://127.0.0.1:8550"
+	config.Ethereum.ExternalSignerAccounts = []string{address}
+	config.Ethereum.Account.KeyFilePassword = testKeyFilePassword
+	config.LibP2P.KeyFile = networkKeyFile
+	return config
+}
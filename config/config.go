@@ -8,6 +8,8 @@ import (
 
 	"github.com/BurntSushi/toml"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum"
+	ethereumchain "github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/chain/localchain"
 	"github.com/keep-network/keep-core/pkg/net/libp2p"
 	"golang.org/x/crypto/ssh/terminal"
 )
@@ -16,10 +18,27 @@ const passwordEnvVariable = "KEEP_ETHEREUM_PASSWORD"
 
 // Config is the top level config structure.
 type Config struct {
-	Ethereum ethereum.Config
+	Ethereum ethereumchain.Config
 	LibP2P   libp2p.Config
 	Storage  Storage
 	Metrics  Metrics
+	Admin    Admin
+	Rewards  Rewards
+
+	GroupSelection GroupSelection
+
+	// LocalChain configures the connection to the local chain server used
+	// instead of Ethereum in development networks. Ethereum is used if the
+	// local chain server address is not set. The Ethereum section account
+	// and operators are still used as operator identities.
+	LocalChain localchain.Config
+
+	// Operators lists accounts of additional operators hosted by the client
+	// next to the operator whose account is configured in the Ethereum
+	// section. All operators share the same Ethereum connection and network
+	// provider. If the key file password of an additional operator is not
+	// set, the password of the Ethereum section account is used.
+	Operators []ethereum.Account
 }
 
 // Storage stores meta-info about keeping data on disk
@@ -34,6 +53,44 @@ type Metrics struct {
 	EthereumMetricsTick int
 }
 
+// Admin stores meta-info about the local operator admin API.
+type Admin struct {
+	// Port the admin API listens on. Admin API is disabled if not set.
+	Port int
+	// Host the admin API is bound to. Defaults to the local loopback
+	// interface so that the API is not exposed outside of the machine.
+	Host string
+}
+
+// Rewards stores meta-info about automatic withdrawal of group member rewards.
+type Rewards struct {
+	// AutoWithdraw enables automatic withdrawal of rewards from stale groups
+	// the operator is a member of. Rewards are transferred to the operator's
+	// beneficiary but the withdrawal transaction is paid by the operator.
+	AutoWithdraw bool
+	// WithdrawalInterval in seconds in which stale groups are checked for
+	// rewards to withdraw. If not set, stale groups are checked only when
+	// a new group is registered on-chain.
+	WithdrawalInterval int
+	// MaxGasPrice in wei the operator is willing to pay for the withdrawal
+	// transaction. Withdrawals are postponed for as long as the current gas
+	// price is higher. If not set, there is no limit.
+	MaxGasPrice uint64
+}
+
+// GroupSelection stores meta-info about ticket submission in group selection.
+type GroupSelection struct {
+	// ProfitableTicketsOnly enables submitting only the tickets for which
+	// the reward expected for a seat in a group, weighted by the estimated
+	// probability of the ticket being selected, covers the cost of the ticket
+	// submission transaction. If not enabled, all qualifying tickets are
+	// submitted.
+	ProfitableTicketsOnly bool
+	// SeatReward in wei the operator expects to earn for a single seat in
+	// a group over the lifetime of the group.
+	SeatReward uint64
+}
+
 var (
 	// KeepOpts contains global application settings
 	KeepOpts Config
@@ -71,6 +128,13 @@ func ReadConfig(filePath string) (*Config, error) {
 		)
 	}
 
+	for i := range config.Operators {
+		if config.Operators[i].KeyFilePassword == "" {
+			config.Operators[i].KeyFilePassword =
+				config.Ethereum.Account.KeyFilePassword
+		}
+	}
+
 	if config.LibP2P.Port == 0 {
 		return nil, fmt.Errorf("missing value for port; see node section in config file or use --port flag")
 	}
@@ -82,6 +146,12 @@ func ReadConfig(filePath string) (*Config, error) {
 	return config, nil
 }
 
+// OperatorAccounts returns accounts of all operators hosted by the client.
+// The account configured in the Ethereum section is always the first one.
+func (c *Config) OperatorAccounts() []ethereum.Account {
+	return append([]ethereum.Account{c.Ethereum.Account}, c.Operators...)
+}
+
 // ReadEthereumConfig reads in the configuration file at `filePath` and returns
 // its contained Ethereum config, or an error if something fails while reading
 // the file.
@@ -96,7 +166,7 @@ func ReadEthereumConfig(filePath string) (ethereum.Config, error) {
 		return ethereum.Config{}, err
 	}
 
-	return config.Ethereum, nil
+	return config.Ethereum.Config, nil
 }
 
 // ReadPassword prompts a user to enter a password.   The read password uses
@@ -4,6 +4,8 @@ import (
 	"os"
 	"reflect"
 	"testing"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethereum"
 )
 
 func TestReadConfig(t *testing.T) {
@@ -34,16 +36,76 @@ func TestReadConfig(t *testing.T) {
 			readValueFunc: func(c *Config) interface{} { return c.Ethereum.URLRPC },
 			expectedValue: "http://192.168.0.158:8545",
 		},
+		"Ethereum.ConfirmationDepth": {
+			readValueFunc: func(c *Config) interface{} { return c.Ethereum.ConfirmationDepth },
+			expectedValue: uint64(12),
+		},
+		"Ethereum.Endpoints": {
+			readValueFunc: func(c *Config) interface{} { return c.Ethereum.Endpoints },
+			expectedValue: []string{
+				"ws://192.168.0.159:8546",
+				"ws://192.168.0.160:8546",
+			},
+		},
+		"Ethereum.ExternalSigner": {
+			readValueFunc: func(c *Config) interface{} { return c.Ethereum.ExternalSigner },
+			expectedValue: "http://127.0.0.1:8550",
+		},
+		"Ethereum.ExternalSignerAccounts": {
+			readValueFunc: func(c *Config) interface{} { return c.Ethereum.ExternalSignerAccounts },
+			expectedValue: []string{"0xc2a56884538778bacd91aa5bf343bf882c5fb18b"},
+		},
 		"Ethereum.ContractAddresses": {
 			readValueFunc: func(c *Config) interface{} { return c.Ethereum.ContractAddresses },
 			expectedValue: map[string]string{
 				"KeepRandomBeaconOperator": "0xcf64c2a367341170cb4e09cf8c0ed137d8473ceb",
 			},
 		},
+		"LibP2P.KeyFile": {
+			readValueFunc: func(c *Config) interface{} { return c.LibP2P.KeyFile },
+			expectedValue: "/tmp/UTC--2018-03-11T01-37-36.202765887Z--f2a56884538778bacd91aa5bf343bf882c5fb18b",
+		},
 		"Storage.DataDir": {
 			readValueFunc: func(c *Config) interface{} { return c.Storage.DataDir },
 			expectedValue: "/my/secure/location",
 		},
+		"Admin.Port": {
+			readValueFunc: func(c *Config) interface{} { return c.Admin.Port },
+			expectedValue: 9601,
+		},
+		"Rewards.AutoWithdraw": {
+			readValueFunc: func(c *Config) interface{} { return c.Rewards.AutoWithdraw },
+			expectedValue: true,
+		},
+		"Rewards.WithdrawalInterval": {
+			readValueFunc: func(c *Config) interface{} { return c.Rewards.WithdrawalInterval },
+			expectedValue: 3600,
+		},
+		"Rewards.MaxGasPrice": {
+			readValueFunc: func(c *Config) interface{} { return c.Rewards.MaxGasPrice },
+			expectedValue: uint64(50000000000),
+		},
+		"GroupSelection.ProfitableTicketsOnly": {
+			readValueFunc: func(c *Config) interface{} { return c.GroupSelection.ProfitableTicketsOnly },
+			expectedValue: true,
+		},
+		"GroupSelection.SeatReward": {
+			readValueFunc: func(c *Config) interface{} { return c.GroupSelection.SeatReward },
+			expectedValue: uint64(2000000000000000000),
+		},
+		"Operators": {
+			readValueFunc: func(c *Config) interface{} { return c.Operators },
+			expectedValue: []ethereum.Account{
+				{
+					KeyFile:         "/tmp/UTC--2018-03-11T01-37-34.202765887Z--d2a56884538778bacd91aa5bf343bf882c5fb18b",
+					KeyFilePassword: "not-my-password",
+				},
+				{
+					KeyFile:         "/tmp/UTC--2018-03-11T01-37-35.202765887Z--e2a56884538778bacd91aa5bf343bf882c5fb18b",
+					KeyFilePassword: "my-other-password",
+				},
+			},
+		},
 	}
 
 	for testName, test := range configReadTests {
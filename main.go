@@ -62,6 +62,10 @@ func main() {
 		cmd.RelayCommand,
 		cmd.PingCommand,
 		cmd.EthereumCommand,
+		cmd.LocalChainCommand,
+		cmd.LedgerCommand,
+		cmd.GroupSelectionCommand,
+		cmd.DkgCommand,
 	}
 
 	cli.AppHelpTemplate = fmt.Sprintf(`%s
@@ -0,0 +1,170 @@
+// Package admin implements a local, read-only HTTP/JSON API allowing
+// operators to inspect the state of a running client.
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-log"
+)
+
+var logger = log.Logger("keep-admin")
+
+// DefaultHost is the default host the admin API is bound to. By default,
+// the API is available only from the local machine.
+const DefaultHost = "127.0.0.1"
+
+// shutdownTimeout is the time given to the server to complete requests being
+// handled before it is shut down.
+const shutdownTimeout = 5 * time.Second
+
+// Source provides a read-only snapshot of a part of the client state.
+// The returned value is serialized to JSON.
+type Source func() interface{}
+
+// Server serves snapshots of registered sources over HTTP as JSON documents.
+// Each source is available under the path equal to its name. The root path
+// lists names of all registered sources.
+type Server struct {
+	mutex   sync.RWMutex
+	sources map[string]Source
+}
+
+// NewServer creates a new admin server with no sources registered.
+func NewServer() *Server {
+	return &Server{
+		sources: make(map[string]Source),
+	}
+}
+
+// Initialize sets up the admin server and starts listening on the given host
+// and port. If the port is zero, the admin API is considered as not configured
+// and false is returned. If the host is empty, DefaultHost is used. The server
+// is shut down when the provided context is done.
+func Initialize(ctx context.Context, host string, port int) (*Server, bool) {
+	if port == 0 {
+		return nil, false
+	}
+
+	if host == "" {
+		host = DefaultHost
+	}
+
+	server := NewServer()
+
+	httpServer := &http.Server{
+		Addr:    net.JoinHostPort(host, strconv.Itoa(port)),
+		Handler: server,
+	}
+
+	go func() {
+		err := httpServer.ListenAndServe()
+		if err != nil && err != http.ErrServerClosed {
+			logger.Errorf("admin server failed: [%v]", err)
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancelShutdownCtx := context.WithTimeout(
+			context.Background(),
+			shutdownTimeout,
+		)
+		defer cancelShutdownCtx()
+
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			logger.Warningf("could not shut down admin server: [%v]", err)
+		}
+	}()
+
+	return server, true
+}
+
+// RegisterSource registers a new source under the given name. If a source
+// with the same name has been already registered, it is replaced.
+func (s *Server) RegisterSource(name string, source Source) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.sources[name] = source
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		writeJSON(
+			writer,
+			http.StatusMethodNotAllowed,
+			errorResponse{fmt.Sprintf("method [%v] not allowed", request.Method)},
+		)
+		return
+	}
+
+	name := strings.Trim(request.URL.Path, "/")
+	if name == "" {
+		writeJSON(writer, http.StatusOK, sourcesResponse{s.sourceNames()})
+		return
+	}
+
+	s.mutex.RLock()
+	source, ok := s.sources[name]
+	s.mutex.RUnlock()
+
+	if !ok {
+		writeJSON(
+			writer,
+			http.StatusNotFound,
+			errorResponse{fmt.Sprintf("unknown source [%v]", name)},
+		)
+		return
+	}
+
+	writeJSON(writer, http.StatusOK, source())
+}
+
+func (s *Server) sourceNames() []string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	names := make([]string, 0, len(s.sources))
+	for name := range s.sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+type sourcesResponse struct {
+	Sources []string `json:"sources"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(writer http.ResponseWriter, status int, value interface{}) {
+	body, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		logger.Errorf("could not marshal admin response: [%v]", err)
+		status = http.StatusInternalServerError
+		body = []byte(`{"error": "could not marshal response"}`)
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+
+	if _, err := writer.Write(body); err != nil {
+		logger.Warningf("could not write admin response: [%v]", err)
+	}
+}
@@ -0,0 +1,94 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestServeSourceList(t *testing.T) {
+	server := NewServer()
+	server.RegisterSource("groups", func() interface{} { return nil })
+	server.RegisterSource("dkg", func() interface{} { return nil })
+
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("unexpected status code [%v]", recorder.Code)
+	}
+
+	response := &sourcesResponse{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), response); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedSources := []string{"dkg", "groups"}
+	if !reflect.DeepEqual(expectedSources, response.Sources) {
+		t.Errorf(
+			"unexpected sources\nexpected: [%v]\nactual:   [%v]",
+			expectedSources,
+			response.Sources,
+		)
+	}
+}
+
+func TestServeSource(t *testing.T) {
+	server := NewServer()
+	server.RegisterSource("relay-requests", func() interface{} {
+		return []string{"0x01", "0x02"}
+	})
+
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(
+		recorder,
+		httptest.NewRequest(http.MethodGet, "/relay-requests", nil),
+	)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("unexpected status code [%v]", recorder.Code)
+	}
+
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("unexpected content type [%v]", contentType)
+	}
+
+	var response []string
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"0x01", "0x02"}
+	if !reflect.DeepEqual(expected, response) {
+		t.Errorf(
+			"unexpected response\nexpected: [%v]\nactual:   [%v]",
+			expected,
+			response,
+		)
+	}
+}
+
+func TestServeUnknownSource(t *testing.T) {
+	server := NewServer()
+
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("unexpected status code [%v]", recorder.Code)
+	}
+}
+
+func TestRejectNonGetRequests(t *testing.T) {
+	server := NewServer()
+	server.RegisterSource("groups", func() interface{} { return nil })
+
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/groups", nil))
+
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("unexpected status code [%v]", recorder.Code)
+	}
+}
@@ -0,0 +1,267 @@
+package beacon
+
+import (
+	"fmt"
+	"sort"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+// backfillBatchSize is the maximum number of blocks for which past events
+// are requested from the chain at once.
+const backfillBatchSize = uint64(5000)
+
+// eventHandlers are handlers of chain events replayed by the backfill.
+// Events of a type with no handler set are not requested from the chain.
+type eventHandlers struct {
+	onRelayEntrySubmitted   func(*event.EntrySubmitted)
+	onGroupSelectionStarted func(*event.GroupSelectionStart)
+	onDKGResultSubmitted    func(*event.DKGResultSubmission)
+	onGroupRegistered       func(*event.GroupRegistration)
+	onRelayEntryRequested   func(*event.Request)
+}
+
+// replayMissedEvents replays events emitted after the last processed block
+// stored in the checkpoint up to the current block, updating the checkpoint
+// as the events are replayed. If the checkpoint has not been set yet, no
+// events are replayed and the checkpoint is set to the current block.
+//
+// Returns true if all missed events have been replayed and the checkpoint
+// can be advanced further. Otherwise, the checkpoint is left at the last
+// replayed block so that the remaining events are replayed on the next start.
+func replayMissedEvents(
+	relayChain relaychain.PastEventsInterface,
+	blockCounter chain.BlockCounter,
+	checkpoint *blockCheckpoint,
+	handlers *eventHandlers,
+) bool {
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		logger.Errorf(
+			"could not get the current block; missed chain events "+
+				"will not be replayed: [%v]",
+			err,
+		)
+		return false
+	}
+
+	lastProcessedBlock, isSet := checkpoint.lastBlock()
+	if !isSet {
+		logger.Infof(
+			"no processed block checkpoint found; chain events will be "+
+				"processed starting from block [%v]",
+			currentBlock,
+		)
+
+		if err := checkpoint.update(currentBlock); err != nil {
+			logger.Errorf(
+				"could not update checkpoint to block [%v]: [%v]",
+				currentBlock,
+				err,
+			)
+			return false
+		}
+
+		return true
+	}
+
+	if lastProcessedBlock >= currentBlock {
+		return true
+	}
+
+	logger.Infof(
+		"replaying chain events missed in blocks [%v-%v]",
+		lastProcessedBlock+1,
+		currentBlock,
+	)
+
+	err = backfillEvents(
+		relayChain,
+		lastProcessedBlock+1,
+		currentBlock,
+		backfillBatchSize,
+		handlers,
+		func(batchEndBlock uint64) {
+			if err := checkpoint.update(batchEndBlock); err != nil {
+				logger.Errorf(
+					"could not update checkpoint to block [%v]: [%v]",
+					batchEndBlock,
+					err,
+				)
+			}
+		},
+	)
+	if err != nil {
+		logger.Errorf(
+			"could not replay missed chain events: [%v]; remaining events "+
+				"will be replayed on the next start",
+			err,
+		)
+		return false
+	}
+
+	return true
+}
+
+type pastEvent struct {
+	blockNumber uint64
+	handle      func()
+}
+
+// backfillEvents replays all events emitted between the start and the end
+// block, both inclusive, by passing them to the handlers. Events are requested
+// from the chain in batches of at most batchSize blocks.
+//
+// Events are replayed deterministically: ordered by the block number and,
+// within the same block, by the event type in the order in which they are
+// emitted by the chain when a relay entry is submitted: relay entry
+// submitted, group selection started, DKG result submitted, group registered
+// and relay entry requested. Handlers are called sequentially.
+//
+// Once all events of a batch have been replayed, onBatchReplayed is called
+// with the last block of the batch.
+func backfillEvents(
+	chain relaychain.PastEventsInterface,
+	startBlock uint64,
+	endBlock uint64,
+	batchSize uint64,
+	handlers *eventHandlers,
+	onBatchReplayed func(batchEndBlock uint64),
+) error {
+	for batchStartBlock := startBlock; batchStartBlock <= endBlock; {
+		batchEndBlock := batchStartBlock + batchSize - 1
+		if batchEndBlock > endBlock {
+			batchEndBlock = endBlock
+		}
+
+		events, err := fetchPastEvents(
+			chain,
+			batchStartBlock,
+			batchEndBlock,
+			handlers,
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"could not fetch events from blocks [%v-%v]: [%v]",
+				batchStartBlock,
+				batchEndBlock,
+				err,
+			)
+		}
+
+		sort.SliceStable(events, func(i, j int) bool {
+			return events[i].blockNumber < events[j].blockNumber
+		})
+
+		for _, event := range events {
+			event.handle()
+		}
+
+		onBatchReplayed(batchEndBlock)
+
+		batchStartBlock = batchEndBlock + 1
+	}
+
+	return nil
+}
+
+// fetchPastEvents returns events emitted in the given range of blocks for all
+// event types with a handler set. Events are grouped by type, in the order
+// of replay.
+func fetchPastEvents(
+	chain relaychain.PastEventsInterface,
+	startBlock uint64,
+	endBlock uint64,
+	handlers *eventHandlers,
+) ([]*pastEvent, error) {
+	events := make([]*pastEvent, 0)
+
+	if handlers.onRelayEntrySubmitted != nil {
+		submissions, err := chain.PastRelayEntrySubmittedEvents(
+			startBlock,
+			endBlock,
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, submission := range submissions {
+			submission := submission
+			events = append(events, &pastEvent{
+				blockNumber: submission.BlockNumber,
+				handle:      func() { handlers.onRelayEntrySubmitted(submission) },
+			})
+		}
+	}
+
+	if handlers.onGroupSelectionStarted != nil {
+		selections, err := chain.PastGroupSelectionStartedEvents(
+			startBlock,
+			endBlock,
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, selection := range selections {
+			selection := selection
+			events = append(events, &pastEvent{
+				blockNumber: selection.BlockNumber,
+				handle:      func() { handlers.onGroupSelectionStarted(selection) },
+			})
+		}
+	}
+
+	if handlers.onDKGResultSubmitted != nil {
+		submissions, err := chain.PastDKGResultSubmittedEvents(
+			startBlock,
+			endBlock,
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, submission := range submissions {
+			submission := submission
+			events = append(events, &pastEvent{
+				blockNumber: submission.BlockNumber,
+				handle:      func() { handlers.onDKGResultSubmitted(submission) },
+			})
+		}
+	}
+
+	if handlers.onGroupRegistered != nil {
+		registrations, err := chain.PastGroupRegisteredEvents(
+			startBlock,
+			endBlock,
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, registration := range registrations {
+			registration := registration
+			events = append(events, &pastEvent{
+				blockNumber: registration.BlockNumber,
+				handle:      func() { handlers.onGroupRegistered(registration) },
+			})
+		}
+	}
+
+	if handlers.onRelayEntryRequested != nil {
+		requests, err := chain.PastRelayEntryRequestedEvents(
+			startBlock,
+			endBlock,
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, request := range requests {
+			request := request
+			events = append(events, &pastEvent{
+				blockNumber: request.BlockNumber,
+				handle:      func() { handlers.onRelayEntryRequested(request) },
+			})
+		}
+	}
+
+	return events, nil
+}
@@ -0,0 +1,262 @@
+package beacon
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+)
+
+func TestBackfillEventsReplaysEventsInOrder(t *testing.T) {
+	chain := &mockPastEventsChain{
+		groupSelections: []*event.GroupSelectionStart{
+			{NewEntry: big.NewInt(1), BlockNumber: 12},
+		},
+		dkgResults: []*event.DKGResultSubmission{
+			{GroupPublicKey: []byte{0x01}, BlockNumber: 20},
+		},
+		groupRegistrations: []*event.GroupRegistration{
+			{GroupPublicKey: []byte{0x01}, BlockNumber: 20},
+		},
+		relayRequests: []*event.Request{
+			{PreviousEntry: []byte{0x02}, BlockNumber: 12},
+			{PreviousEntry: []byte{0x03}, BlockNumber: 25},
+			// Outside of the backfilled range.
+			{PreviousEntry: []byte{0x04}, BlockNumber: 31},
+		},
+		entrySubmissions: []*event.EntrySubmitted{
+			{BlockNumber: 12},
+			{BlockNumber: 8},
+		},
+	}
+
+	replayed := make([]string, 0)
+	handlers := &eventHandlers{
+		onRelayEntrySubmitted: func(submission *event.EntrySubmitted) {
+			replayed = append(
+				replayed,
+				fmt.Sprintf("%v:entry-submitted", submission.BlockNumber),
+			)
+		},
+		onGroupSelectionStarted: func(selection *event.GroupSelectionStart) {
+			replayed = append(
+				replayed,
+				fmt.Sprintf("%v:group-selection", selection.BlockNumber),
+			)
+		},
+		onDKGResultSubmitted: func(submission *event.DKGResultSubmission) {
+			replayed = append(
+				replayed,
+				fmt.Sprintf("%v:dkg-result", submission.BlockNumber),
+			)
+		},
+		onGroupRegistered: func(registration *event.GroupRegistration) {
+			replayed = append(
+				replayed,
+				fmt.Sprintf("%v:group-registered", registration.BlockNumber),
+			)
+		},
+		onRelayEntryRequested: func(request *event.Request) {
+			replayed = append(
+				replayed,
+				fmt.Sprintf("%v:entry-requested", request.BlockNumber),
+			)
+		},
+	}
+
+	replayedBatches := make([]uint64, 0)
+	err := backfillEvents(
+		chain,
+		5,
+		30,
+		10,
+		handlers,
+		func(batchEndBlock uint64) {
+			replayedBatches = append(replayedBatches, batchEndBlock)
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedReplayed := []string{
+		"8:entry-submitted",
+		"12:entry-submitted",
+		"12:group-selection",
+		"12:entry-requested",
+		"20:dkg-result",
+		"20:group-registered",
+		"25:entry-requested",
+	}
+	if !reflect.DeepEqual(expectedReplayed, replayed) {
+		t.Errorf(
+			"unexpected replayed events\nexpected: [%v]\nactual:   [%v]",
+			expectedReplayed,
+			replayed,
+		)
+	}
+
+	expectedBatches := []uint64{14, 24, 30}
+	if !reflect.DeepEqual(expectedBatches, replayedBatches) {
+		t.Errorf(
+			"unexpected replayed batches\nexpected: [%v]\nactual:   [%v]",
+			expectedBatches,
+			replayedBatches,
+		)
+	}
+}
+
+func TestBackfillEventsStopsOnError(t *testing.T) {
+	chain := &mockPastEventsChain{
+		relayRequests: []*event.Request{
+			{PreviousEntry: []byte{0x01}, BlockNumber: 5},
+			{PreviousEntry: []byte{0x02}, BlockNumber: 15},
+		},
+		failFromBlock: 10,
+	}
+
+	replayedRequests := 0
+	handlers := &eventHandlers{
+		onRelayEntryRequested: func(request *event.Request) {
+			replayedRequests++
+		},
+	}
+
+	replayedBatches := make([]uint64, 0)
+	err := backfillEvents(
+		chain,
+		0,
+		19,
+		10,
+		handlers,
+		func(batchEndBlock uint64) {
+			replayedBatches = append(replayedBatches, batchEndBlock)
+		},
+	)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if replayedRequests != 1 {
+		t.Errorf(
+			"unexpected number of replayed requests\nexpected: [%v]\nactual:   [%v]",
+			1,
+			replayedRequests,
+		)
+	}
+
+	expectedBatches := []uint64{9}
+	if !reflect.DeepEqual(expectedBatches, replayedBatches) {
+		t.Errorf(
+			"unexpected replayed batches\nexpected: [%v]\nactual:   [%v]",
+			expectedBatches,
+			replayedBatches,
+		)
+	}
+}
+
+type mockPastEventsChain struct {
+	groupSelections    []*event.GroupSelectionStart
+	dkgResults         []*event.DKGResultSubmission
+	groupRegistrations []*event.GroupRegistration
+	relayRequests      []*event.Request
+	entrySubmissions   []*event.EntrySubmitted
+
+	// failFromBlock makes the chain fail all requests for ranges of blocks
+	// starting at or after the given block, if set.
+	failFromBlock uint64
+}
+
+func (mpec *mockPastEventsChain) checkRange(startBlock uint64) error {
+	if mpec.failFromBlock != 0 && startBlock >= mpec.failFromBlock {
+		return fmt.Errorf("chain not available")
+	}
+	return nil
+}
+
+func (mpec *mockPastEventsChain) PastGroupSelectionStartedEvents(
+	startBlock uint64,
+	endBlock uint64,
+) ([]*event.GroupSelectionStart, error) {
+	if err := mpec.checkRange(startBlock); err != nil {
+		return nil, err
+	}
+
+	events := make([]*event.GroupSelectionStart, 0)
+	for _, event := range mpec.groupSelections {
+		if event.BlockNumber >= startBlock && event.BlockNumber <= endBlock {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (mpec *mockPastEventsChain) PastDKGResultSubmittedEvents(
+	startBlock uint64,
+	endBlock uint64,
+) ([]*event.DKGResultSubmission, error) {
+	if err := mpec.checkRange(startBlock); err != nil {
+		return nil, err
+	}
+
+	events := make([]*event.DKGResultSubmission, 0)
+	for _, event := range mpec.dkgResults {
+		if event.BlockNumber >= startBlock && event.BlockNumber <= endBlock {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (mpec *mockPastEventsChain) PastGroupRegisteredEvents(
+	startBlock uint64,
+	endBlock uint64,
+) ([]*event.GroupRegistration, error) {
+	if err := mpec.checkRange(startBlock); err != nil {
+		return nil, err
+	}
+
+	events := make([]*event.GroupRegistration, 0)
+	for _, event := range mpec.groupRegistrations {
+		if event.BlockNumber >= startBlock && event.BlockNumber <= endBlock {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (mpec *mockPastEventsChain) PastRelayEntryRequestedEvents(
+	startBlock uint64,
+	endBlock uint64,
+) ([]*event.Request, error) {
+	if err := mpec.checkRange(startBlock); err != nil {
+		return nil, err
+	}
+
+	events := make([]*event.Request, 0)
+	for _, event := range mpec.relayRequests {
+		if event.BlockNumber >= startBlock && event.BlockNumber <= endBlock {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (mpec *mockPastEventsChain) PastRelayEntrySubmittedEvents(
+	startBlock uint64,
+	endBlock uint64,
+) ([]*event.EntrySubmitted, error) {
+	if err := mpec.checkRange(startBlock); err != nil {
+		return nil, err
+	}
+
+	events := make([]*event.EntrySubmitted, 0)
+	for _, event := range mpec.entrySubmissions {
+		if event.BlockNumber >= startBlock && event.BlockNumber <= endBlock {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
@@ -3,6 +3,7 @@ package beacon
 import (
 	"context"
 	"encoding/hex"
+	"fmt"
 	"sync"
 	"time"
 
@@ -14,42 +15,93 @@ import (
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/groupselection"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/rewards"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/watchdog"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/subscription"
 )
 
 var logger = log.Logger("keep-beacon")
 
+// Handle is a handle to the initialized random beacon allowing to control
+// its lifecycle.
+type Handle struct {
+	node          *relay.Node
+	groupRegistry *registry.Groups
+
+	pendingGroupSelections *event.GroupSelectionTrack
+	pendingRelayRequests   *event.RelayRequestTrack
+
+	rewardsWithdrawer *rewards.Withdrawer
+}
+
+// Drain makes the beacon refuse any new work, that is joining new groups and
+// starting new signing sessions, and waits for the already running signing
+// sessions to complete. If they do not complete within the given timeout,
+// an error is returned. Drain does not cancel any running work; this is done
+// by cancelling the context passed to Initialize.
+func (h *Handle) Drain(timeout time.Duration) error {
+	return h.node.Drain(timeout)
+}
+
 // Initialize kicks off the random beacon by initializing internal state,
 // ensuring preconditions like staking are met, and then kicking off the
 // internal random beacon implementation. Returns an error if this failed,
-// otherwise enters a blocked loop.
+// otherwise returns a handle to the running beacon.
+//
+// When the provided context is done, the beacon unsubscribes from all chain
+// events and aborts all key generation and signing processes in progress.
+//
+// If the rewards config is provided, rewards accumulated by the operator as
+// a member of stale groups are automatically withdrawn.
+//
+// If the ticket profitability policy is provided, group selection tickets are
+// submitted only if the reward expected for them covers the submission cost.
+//
+// The last block for which chain events have been processed is stored with
+// the provided persistence handle. On start, events emitted after that block
+// while the client was not running are replayed. Chain events are expected
+// to be delivered once the given number of blocks is mined on top of them,
+// so the stored block never follows blocks with events not delivered yet.
+//
+// Transcripts of distributed key generations are stored with the separate
+// transcript persistence handle, as they are only read when replayed offline.
+//
+// Distributed key generations are checkpointed with the provided persistence
+// handle as well. On start, key generations interrupted by a crash or restart
+// are resumed if they can still complete. The same applies to relay entry
+// signing sessions.
 func Initialize(
 	ctx context.Context,
 	stakingID string,
 	chainHandle chain.Handle,
+	eventConfirmationDepth uint64,
 	netProvider net.Provider,
 	persistence persistence.Handle,
-) error {
+	transcriptPersistence persistence.Handle,
+	rewardsConfig *rewards.Config,
+	ticketPolicy *groupselection.ProfitabilityPolicy,
+) (*Handle, error) {
 	relayChain := chainHandle.ThresholdRelay()
 	chainConfig, err := relayChain.GetConfig()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	stakeMonitor, err := chainHandle.StakeMonitor()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	staker, err := stakeMonitor.StakerFor(stakingID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	blockCounter, err := chainHandle.BlockCounter()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	signing := chainHandle.Signing()
@@ -63,6 +115,8 @@ func Initialize(
 		blockCounter,
 		chainConfig,
 		groupRegistry,
+		persistence,
+		transcriptPersistence,
 	)
 
 	pendingGroupSelections := &event.GroupSelectionTrack{
@@ -75,9 +129,42 @@ func Initialize(
 		Mutex: &sync.Mutex{},
 	}
 
-	node.ResumeSigningIfEligible(relayChain, signing)
+	var rewardsWithdrawer *rewards.Withdrawer
+	if rewardsConfig != nil {
+		rewardsWithdrawer = rewards.NewWithdrawer(
+			staker.Address(),
+			relayChain,
+			groupRegistry,
+			rewardsConfig.MaxGasPrice,
+		)
+
+		if rewardsConfig.WithdrawalInterval > 0 {
+			go rewardsWithdrawer.WithdrawPeriodically(
+				ctx,
+				rewardsConfig.WithdrawalInterval,
+			)
+		}
+	}
+
+	unauthorizedSigningWatchdog := watchdog.NewWatchdog(
+		staker.Address(),
+		relayChain,
+		groupRegistry,
+		netProvider,
+	)
+	unauthorizedSigningWatchdog.WatchGroupChannels(ctx)
+	groupRegistry.OnGroupRegistered(func(membership *registry.Membership) {
+		unauthorizedSigningWatchdog.WatchGroupChannels(ctx)
+	})
+
+	relayRequestCancellers := newRelayRequestCancellers()
+
+	checkpoint, err := loadBlockCheckpoint(persistence)
+	if err != nil {
+		return nil, err
+	}
 
-	relayChain.OnRelayEntryRequested(func(request *event.Request) {
+	onRelayEntryRequested := func(request *event.Request) {
 		onConfirmed := func() {
 			if node.IsInGroup(request.GroupPublicKey) {
 				go func() {
@@ -94,6 +181,12 @@ func Initialize(
 
 					defer pendingRelayRequests.Remove(previousEntry)
 
+					signingCtx, signingDone := relayRequestCancellers.add(
+						ctx,
+						request,
+					)
+					defer signingDone()
+
 					logger.Infof(
 						"new relay entry requested at block [%v] from group "+
 							"[0x%x] using previous entry [0x%x]",
@@ -103,6 +196,7 @@ func Initialize(
 					)
 
 					node.GenerateRelayEntry(
+						signingCtx,
 						request.PreviousEntry,
 						relayChain,
 						signing,
@@ -115,6 +209,7 @@ func Initialize(
 			}
 
 			go node.MonitorRelayEntry(
+				ctx,
 				relayChain,
 				request.BlockNumber,
 				chainConfig,
@@ -131,9 +226,9 @@ func Initialize(
 			currentRelayRequestConfirmationRetries,
 			currentRelayRequestConfirmationDelay,
 		)
-	})
+	}
 
-	relayChain.OnGroupSelectionStarted(func(event *event.GroupSelectionStart) {
+	onGroupSelectionStarted := func(event *event.GroupSelectionStart) {
 		onGroupSelected := func(group *groupselection.Result) {
 			for index, staker := range group.SelectedStakers {
 				logger.Infof(
@@ -143,6 +238,7 @@ func Initialize(
 				)
 			}
 			node.JoinGroupIfEligible(
+				ctx,
 				relayChain,
 				signing,
 				group,
@@ -150,6 +246,14 @@ func Initialize(
 			)
 		}
 
+		if node.IsDraining() {
+			logger.Warningf(
+				"node is draining; ignoring group selection with seed [0x%x]",
+				event.NewEntry,
+			)
+			return
+		}
+
 		newEntry := event.NewEntry.Text(16)
 		go func() {
 			if ok := pendingGroupSelections.Add(newEntry); !ok {
@@ -175,24 +279,174 @@ func Initialize(
 				staker,
 				event.NewEntry,
 				event.BlockNumber,
+				ticketPolicy,
 				onGroupSelected,
 			)
 			if err != nil {
 				logger.Errorf("Tickets submission failed: [%v]", err)
 			}
 		}()
-	})
+	}
 
-	relayChain.OnGroupRegistered(func(registration *event.GroupRegistration) {
+	onGroupRegistered := func(registration *event.GroupRegistration) {
 		logger.Infof(
 			"new group with public key [0x%x] registered on-chain at block [%v]",
 			registration.GroupPublicKey,
 			registration.BlockNumber,
 		)
-		go groupRegistry.UnregisterStaleGroups()
-	})
+		go func() {
+			// Rewards have to be looked up before stale groups are
+			// removed from the registry.
+			if rewardsWithdrawer != nil {
+				rewardsWithdrawer.WithdrawStaleGroupRewards()
+			}
+			groupRegistry.UnregisterStaleGroups()
+			unauthorizedSigningWatchdog.WatchGroupChannels(ctx)
+		}()
+	}
+
+	relayEntryRequestedSubscription, err := relayChain.OnRelayEntryRequested(
+		onRelayEntryRequested,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not subscribe for relay entry requests: [%v]",
+			err,
+		)
+	}
+
+	groupSelectionStartedSubscription, err := relayChain.OnGroupSelectionStarted(
+		onGroupSelectionStarted,
+	)
+	if err != nil {
+		relayEntryRequestedSubscription.Unsubscribe()
+		return nil, fmt.Errorf(
+			"could not subscribe for group selection start: [%v]",
+			err,
+		)
+	}
+
+	groupRegisteredSubscription, err := relayChain.OnGroupRegistered(
+		onGroupRegistered,
+	)
+	if err != nil {
+		relayEntryRequestedSubscription.Unsubscribe()
+		groupSelectionStartedSubscription.Unsubscribe()
+		return nil, fmt.Errorf(
+			"could not subscribe for group registrations: [%v]",
+			err,
+		)
+	}
+
+	eventRemovedSubscription, err := relayChain.OnEventRemoved(
+		func(removal *event.Removed) {
+			handleEventRemoval(removal, relayRequestCancellers)
+		},
+	)
+	if err != nil {
+		relayEntryRequestedSubscription.Unsubscribe()
+		groupSelectionStartedSubscription.Unsubscribe()
+		groupRegisteredSubscription.Unsubscribe()
+		return nil, fmt.Errorf(
+			"could not subscribe for removed events: [%v]",
+			err,
+		)
+	}
+
+	go unsubscribeOnDone(
+		ctx,
+		relayEntryRequestedSubscription,
+		groupSelectionStartedSubscription,
+		groupRegisteredSubscription,
+		eventRemovedSubscription,
+	)
+
+	// Key generations interrupted by a restart are resumed before missed
+	// events are replayed. If a group selection for the same seed is
+	// replayed, the node does not start the key generation again.
+	node.ResumeKeyGenerationsIfEligible(ctx, relayChain, signing)
+
+	// Signing sessions interrupted by a restart are restored before missed
+	// events are replayed so that signing for the current relay request is
+	// resumed no matter if it is started by the replay or by
+	// ResumeSigningIfEligible.
+	node.RestoreSigningSessions(relayChain)
+
+	// Events are replayed only after subscribing to new events so that no
+	// event is missed between the last replayed block and the subscription.
+	// Events seen both by the replay and the subscriptions are deduplicated
+	// by the pending group selections and relay requests tracks.
+	replayedRelayRequests := false
+	allEventsReplayed := replayMissedEvents(
+		relayChain,
+		blockCounter,
+		checkpoint,
+		&eventHandlers{
+			onGroupSelectionStarted: func(event *event.GroupSelectionStart) {
+				ticketSubmissionEndBlock := event.BlockNumber +
+					chainConfig.TicketSubmissionTimeout
+				currentBlock, err := blockCounter.CurrentBlock()
+				if err == nil && currentBlock > ticketSubmissionEndBlock {
+					logger.Warningf(
+						"missed group selection with seed [0x%x] started "+
+							"at block [%v]; ticket submission has already ended",
+						event.NewEntry,
+						event.BlockNumber,
+					)
+					return
+				}
+
+				onGroupSelectionStarted(event)
+			},
+			onDKGResultSubmitted: func(submission *event.DKGResultSubmission) {
+				logger.Infof(
+					"missed DKG result submission for group [0x%x] "+
+						"at block [%v]",
+					submission.GroupPublicKey,
+					submission.BlockNumber,
+				)
+			},
+			onGroupRegistered: onGroupRegistered,
+			onRelayEntryRequested: func(request *event.Request) {
+				replayedRelayRequests = true
+				onRelayEntryRequested(request)
+			},
+		},
+	)
+
+	// The most recent relay entry request, if any, is the one currently in
+	// progress. If all missed requests have been replayed, the signing is
+	// already resumed.
+	if !allEventsReplayed || !replayedRelayRequests {
+		node.ResumeSigningIfEligible(ctx, relayChain, signing)
+	}
+
+	if allEventsReplayed {
+		go checkpoint.track(ctx, blockCounter, eventConfirmationDepth)
+	}
+
+	return &Handle{
+		node:                   &node,
+		groupRegistry:          groupRegistry,
+		pendingGroupSelections: pendingGroupSelections,
+		pendingRelayRequests:   pendingRelayRequests,
+		rewardsWithdrawer:      rewardsWithdrawer,
+	}, nil
+}
+
+// unsubscribeOnDone waits until the provided context is done and then
+// unsubscribes from all the provided event subscriptions.
+func unsubscribeOnDone(
+	ctx context.Context,
+	subscriptions ...subscription.EventSubscription,
+) {
+	<-ctx.Done()
+
+	for _, subscription := range subscriptions {
+		subscription.Unsubscribe()
+	}
 
-	return nil
+	logger.Infof("unsubscribed from all beacon chain events")
 }
 
 // Before we start relay entry signing process we need to confirm the current
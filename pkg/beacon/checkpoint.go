@@ -0,0 +1,165 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+const (
+	checkpointDirectory = "checkpoint"
+	checkpointFileName  = "last_processed_block"
+
+	// checkpointInterval is the number of blocks between subsequent updates
+	// of the stored checkpoint. The stored block lags behind the most recent
+	// block with delivered events by the same number of blocks so that events
+	// from those blocks, possibly not yet delivered by event subscriptions,
+	// are replayed after a restart.
+	checkpointInterval = uint64(20)
+)
+
+// blockCheckpoint keeps track of the last block for which chain events have
+// been processed by the beacon. The checkpoint is stored with the persistence
+// handle so that events emitted while the client was not running can be
+// replayed on the next start.
+type blockCheckpoint struct {
+	persistence persistence.Handle
+
+	mutex              sync.Mutex
+	lastProcessedBlock uint64
+	isSet              bool
+}
+
+// loadBlockCheckpoint reads the checkpoint stored with the given persistence
+// handle. If no checkpoint has been stored yet, the returned checkpoint is
+// not set.
+func loadBlockCheckpoint(handle persistence.Handle) (*blockCheckpoint, error) {
+	checkpoint := &blockCheckpoint{persistence: handle}
+
+	dataChannel, errorChannel := handle.ReadAll()
+
+	// Both channels have to be drained till they are closed, even if the
+	// checkpoint has been already found, so that the persistence producer
+	// is not blocked.
+	var checkpointErr error
+	for dataChannel != nil || errorChannel != nil {
+		select {
+		case descriptor, ok := <-dataChannel:
+			if !ok {
+				dataChannel = nil
+				continue
+			}
+
+			if descriptor.Directory() != checkpointDirectory ||
+				descriptor.Name() != checkpointFileName {
+				continue
+			}
+
+			content, err := descriptor.Content()
+			if err != nil {
+				checkpointErr = fmt.Errorf(
+					"could not read checkpoint: [%v]",
+					err,
+				)
+				continue
+			}
+
+			block, err := strconv.ParseUint(string(content), 10, 64)
+			if err != nil {
+				checkpointErr = fmt.Errorf(
+					"could not parse checkpoint: [%v]",
+					err,
+				)
+				continue
+			}
+
+			checkpoint.lastProcessedBlock = block
+			checkpoint.isSet = true
+		case err, ok := <-errorChannel:
+			if !ok {
+				errorChannel = nil
+				continue
+			}
+
+			logger.Debugf("could not read persisted data: [%v]", err)
+		}
+	}
+
+	if checkpointErr != nil {
+		return nil, checkpointErr
+	}
+
+	return checkpoint, nil
+}
+
+// lastBlock returns the last processed block and a flag indicating whether
+// the checkpoint has been set.
+func (bc *blockCheckpoint) lastBlock() (uint64, bool) {
+	bc.mutex.Lock()
+	defer bc.mutex.Unlock()
+
+	return bc.lastProcessedBlock, bc.isSet
+}
+
+// update stores the given block as the last processed one. The checkpoint
+// never moves backwards; blocks lower than the current checkpoint are
+// ignored.
+func (bc *blockCheckpoint) update(block uint64) error {
+	bc.mutex.Lock()
+	defer bc.mutex.Unlock()
+
+	if bc.isSet && block <= bc.lastProcessedBlock {
+		return nil
+	}
+
+	err := bc.persistence.Save(
+		[]byte(strconv.FormatUint(block, 10)),
+		checkpointDirectory,
+		checkpointFileName,
+	)
+	if err != nil {
+		return fmt.Errorf("could not save checkpoint: [%v]", err)
+	}
+
+	bc.lastProcessedBlock = block
+	bc.isSet = true
+
+	return nil
+}
+
+// track advances the checkpoint as new blocks are mined until the provided
+// context is done. Events are expected to be delivered once the given
+// confirmation depth of blocks is mined on top of them, so the checkpoint
+// lags behind the current block by the confirmation depth in addition to the
+// checkpoint interval.
+func (bc *blockCheckpoint) track(
+	ctx context.Context,
+	blockCounter chain.BlockCounter,
+	confirmationDepth uint64,
+) {
+	lag := confirmationDepth + checkpointInterval
+
+	for block := range blockCounter.WatchBlocks(ctx) {
+		if block < lag {
+			continue
+		}
+
+		checkpointBlock := block - lag
+		lastProcessedBlock, _ := bc.lastBlock()
+		if checkpointBlock < lastProcessedBlock+checkpointInterval {
+			continue
+		}
+
+		if err := bc.update(checkpointBlock); err != nil {
+			logger.Errorf(
+				"could not update checkpoint to block [%v]: [%v]",
+				checkpointBlock,
+				err,
+			)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package beacon
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+func TestBlockCheckpoint(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "checkpoint_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	handle, err := persistence.NewDiskHandle(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Data other than the checkpoint stored with the same handle.
+	if err := handle.Save([]byte{0x01}, "group", "membership_1"); err != nil {
+		t.Fatal(err)
+	}
+
+	checkpoint, err := loadBlockCheckpoint(handle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, isSet := checkpoint.lastBlock(); isSet {
+		t.Fatal("checkpoint should not be set")
+	}
+
+	if err := checkpoint.update(120); err != nil {
+		t.Fatal(err)
+	}
+	// The checkpoint never moves backwards.
+	if err := checkpoint.update(100); err != nil {
+		t.Fatal(err)
+	}
+
+	reloadedCheckpoint, err := loadBlockCheckpoint(handle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lastBlock, isSet := reloadedCheckpoint.lastBlock()
+	if !isSet {
+		t.Fatal("checkpoint should be set")
+	}
+	if lastBlock != 120 {
+		t.Errorf(
+			"unexpected last processed block\nexpected: [%v]\nactual:   [%v]",
+			120,
+			lastBlock,
+		)
+	}
+}
+
+// testWatchedBlockCounter emits the given blocks to block watchers.
+type testWatchedBlockCounter struct {
+	chain.BlockCounter
+
+	blocks []uint64
+}
+
+func (twbc *testWatchedBlockCounter) WatchBlocks(
+	ctx context.Context,
+) <-chan uint64 {
+	blocks := make(chan uint64, len(twbc.blocks))
+	for _, block := range twbc.blocks {
+		blocks <- block
+	}
+	close(blocks)
+
+	return blocks
+}
+
+func TestTrackCheckpointBehindConfirmationDepth(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "checkpoint_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	handle, err := persistence.NewDiskHandle(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkpoint, err := loadBlockCheckpoint(handle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	confirmationDepth := uint64(50)
+	checkpoint.track(
+		context.Background(),
+		&testWatchedBlockCounter{blocks: []uint64{60, 80, 100}},
+		confirmationDepth,
+	)
+
+	// Events are delivered up to block 50 at block 100 so the checkpoint
+	// lags behind that block by the checkpoint interval.
+	expectedBlock := 100 - confirmationDepth - checkpointInterval
+	lastBlock, isSet := checkpoint.lastBlock()
+	if !isSet {
+		t.Fatal("checkpoint should be set")
+	}
+	if lastBlock != expectedBlock {
+		t.Errorf(
+			"unexpected last processed block\nexpected: [%v]\nactual:   [%v]",
+			expectedBlock,
+			lastBlock,
+		)
+	}
+}
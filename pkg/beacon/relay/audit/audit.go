@@ -0,0 +1,304 @@
+// Package audit contains the implementation of independent verification of
+// relay entries submitted on-chain by all groups, not only the ones the
+// operator is a member of. Results of the verification are stored in
+// a persistent, append-only audit log so that the history of the beacon
+// output can be proven valid by the operator's own client.
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-log"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/altbn128"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/bls"
+)
+
+var logger = log.Logger("keep-audit")
+
+const (
+	g1CompressedLength   = 32
+	g1UncompressedLength = 64
+	g2CompressedLength   = 64
+	g2UncompressedLength = 128
+)
+
+// Auditor verifies every relay entry submitted on-chain as a BLS signature
+// over the previous entry made with the private key of the group responsible
+// for the request.
+//
+// Each entry is verified as soon as its submission is observed. If the chain
+// could not determine the submitted entry, the entry is verified once the
+// next relay request is observed, since the previous entry of every relay
+// request is the most recent entry submitted on-chain. A relay request with
+// the same previous entry as the pending one means the pending request timed
+// out and has been assigned to another group.
+type Auditor struct {
+	log *Log
+
+	mutex               sync.Mutex
+	pendingRequest      *event.Request
+	lastSubmissionBlock uint64
+	submittedEntries    uint64
+	validEntries        uint64
+	invalidEntries      uint64
+	lastRecord          *Record
+}
+
+// Status describes the current state of the relay entry audit.
+type Status struct {
+	// SubmittedEntries is the number of relay entry submissions observed
+	// since the client started.
+	SubmittedEntries uint64 `json:"submittedEntries"`
+	// ValidEntries is the number of all valid entries in the audit log.
+	ValidEntries uint64 `json:"validEntries"`
+	// InvalidEntries is the number of all invalid entries in the audit log.
+	InvalidEntries uint64 `json:"invalidEntries"`
+	// LastRecord is the most recent record in the audit log, if any.
+	LastRecord *Record `json:"lastRecord,omitempty"`
+}
+
+// NewAuditor creates a new relay entry auditor writing to the given log.
+// Records already present in the log are taken into account in the audit
+// status.
+func NewAuditor(auditLog *Log) (*Auditor, error) {
+	records, err := auditLog.Records()
+	if err != nil {
+		return nil, fmt.Errorf("could not read audit log: [%v]", err)
+	}
+
+	auditor := &Auditor{log: auditLog}
+	for _, record := range records {
+		auditor.count(record)
+	}
+
+	return auditor, nil
+}
+
+// Start subscribes the auditor to relay entry requests and submissions
+// observed on-chain. The auditor is unsubscribed when the provided context
+// is done.
+func (a *Auditor) Start(
+	ctx context.Context,
+	relayChain relaychain.RelayEntryInterface,
+) error {
+	requestedSubscription, err := relayChain.OnRelayEntryRequested(
+		a.onRelayEntryRequested,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"could not subscribe for relay entry requests: [%v]",
+			err,
+		)
+	}
+
+	submittedSubscription, err := relayChain.OnRelayEntrySubmitted(
+		a.onRelayEntrySubmitted,
+	)
+	if err != nil {
+		requestedSubscription.Unsubscribe()
+		return fmt.Errorf(
+			"could not subscribe for relay entry submissions: [%v]",
+			err,
+		)
+	}
+
+	go func() {
+		<-ctx.Done()
+		requestedSubscription.Unsubscribe()
+		submittedSubscription.Unsubscribe()
+	}()
+
+	return nil
+}
+
+// Status returns the current status of the relay entry audit.
+func (a *Auditor) Status() *Status {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return &Status{
+		SubmittedEntries: a.submittedEntries,
+		ValidEntries:     a.validEntries,
+		InvalidEntries:   a.invalidEntries,
+		LastRecord:       a.lastRecord,
+	}
+}
+
+func (a *Auditor) onRelayEntrySubmitted(submission *event.EntrySubmitted) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.submittedEntries++
+	if submission.BlockNumber > a.lastSubmissionBlock {
+		a.lastSubmissionBlock = submission.BlockNumber
+	}
+
+	if a.pendingRequest == nil {
+		logger.Warningf(
+			"relay entry submitted at block [%v] for a request not "+
+				"observed by this client; the entry cannot be verified",
+			submission.BlockNumber,
+		)
+		return
+	}
+
+	if submission.Entry == nil {
+		// The entry will be verified once the next relay request is
+		// observed.
+		return
+	}
+
+	a.verify(a.pendingRequest, submission.Entry, submission.BlockNumber)
+	a.pendingRequest = nil
+}
+
+func (a *Auditor) onRelayEntryRequested(request *event.Request) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	pendingRequest := a.pendingRequest
+	a.pendingRequest = request
+
+	if pendingRequest == nil {
+		return
+	}
+
+	if bytes.Equal(pendingRequest.PreviousEntry, request.PreviousEntry) {
+		logger.Infof(
+			"relay request from block [%v] has been assigned to group "+
+				"[0x%x] at block [%v]",
+			pendingRequest.BlockNumber,
+			request.GroupPublicKey,
+			request.BlockNumber,
+		)
+		return
+	}
+
+	var submissionBlock uint64
+	if a.lastSubmissionBlock >= pendingRequest.BlockNumber {
+		submissionBlock = a.lastSubmissionBlock
+	}
+
+	a.verify(pendingRequest, request.PreviousEntry, submissionBlock)
+}
+
+// verify checks if the entry is valid for the given request and appends the
+// result to the audit log. Submission block is zero if it is not known.
+func (a *Auditor) verify(
+	request *event.Request,
+	entry []byte,
+	submissionBlock uint64,
+) {
+	record := &Record{
+		RequestBlock:    request.BlockNumber,
+		SubmissionBlock: submissionBlock,
+		GroupPublicKey:  hex.EncodeToString(request.GroupPublicKey),
+		PreviousEntry:   hex.EncodeToString(request.PreviousEntry),
+		Entry:           hex.EncodeToString(entry),
+		Valid:           true,
+		VerifiedAt:      time.Now(),
+	}
+
+	err := VerifyEntry(request.GroupPublicKey, request.PreviousEntry, entry)
+	if err != nil {
+		record.Valid = false
+		record.Error = err.Error()
+
+		logger.Errorf(
+			"relay entry [0x%s] for request from block [%v] is invalid: [%v]",
+			record.Entry,
+			record.RequestBlock,
+			err,
+		)
+	} else {
+		logger.Infof(
+			"verified relay entry [0x%s] for request from block [%v]",
+			record.Entry,
+			record.RequestBlock,
+		)
+	}
+
+	if err := a.log.Append(record); err != nil {
+		logger.Errorf("could not append record to the audit log: [%v]", err)
+	}
+
+	a.count(record)
+}
+
+func (a *Auditor) count(record *Record) {
+	if record.Valid {
+		a.validEntries++
+	} else {
+		a.invalidEntries++
+	}
+
+	a.lastRecord = record
+}
+
+// VerifyEntry checks if the given relay entry is a valid BLS signature over
+// the previous entry made with the private key of the group with the given
+// public key. All points may be passed either in a compressed or uncompressed
+// form.
+func VerifyEntry(groupPublicKey, previousEntry, entry []byte) error {
+	groupPublicKeyPoint, err := unmarshalG2(groupPublicKey)
+	if err != nil {
+		return fmt.Errorf("could not unmarshal group public key: [%v]", err)
+	}
+
+	previousEntryPoint, err := unmarshalG1(previousEntry)
+	if err != nil {
+		return fmt.Errorf("could not unmarshal previous entry: [%v]", err)
+	}
+
+	entryPoint, err := unmarshalG1(entry)
+	if err != nil {
+		return fmt.Errorf("could not unmarshal entry: [%v]", err)
+	}
+
+	if !bls.VerifyG1(groupPublicKeyPoint, previousEntryPoint, entryPoint) {
+		return fmt.Errorf(
+			"entry is not a valid signature over the previous entry",
+		)
+	}
+
+	return nil
+}
+
+func unmarshalG1(bytes []byte) (*bn256.G1, error) {
+	switch len(bytes) {
+	case g1CompressedLength:
+		return altbn128.DecompressToG1(bytes)
+	case g1UncompressedLength:
+		point := new(bn256.G1)
+		if _, err := point.Unmarshal(bytes); err != nil {
+			return nil, err
+		}
+		return point, nil
+	default:
+		return nil, fmt.Errorf("unexpected G1 point length [%v]", len(bytes))
+	}
+}
+
+func unmarshalG2(bytes []byte) (*bn256.G2, error) {
+	switch len(bytes) {
+	case g2CompressedLength:
+		return altbn128.DecompressToG2(bytes)
+	case g2UncompressedLength:
+		point := new(bn256.G2)
+		if _, err := point.Unmarshal(bytes); err != nil {
+			return nil, err
+		}
+		return point, nil
+	default:
+		return nil, fmt.Errorf("unexpected G2 point length [%v]", len(bytes))
+	}
+}
@@ -0,0 +1,243 @@
+package audit
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/altbn128"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/bls"
+)
+
+var groupSecretKey = big.NewInt(123)
+
+func TestVerifyEntry(t *testing.T) {
+	groupPublicKey := new(bn256.G2).ScalarBaseMult(groupSecretKey)
+	previousEntry := new(bn256.G1).ScalarBaseMult(big.NewInt(456))
+	entry := bls.SignG1(groupSecretKey, previousEntry)
+	otherEntry := bls.SignG1(big.NewInt(789), previousEntry)
+
+	var tests = map[string]struct {
+		groupPublicKey []byte
+		previousEntry  []byte
+		entry          []byte
+		expectedError  bool
+	}{
+		"uncompressed points": {
+			groupPublicKey: groupPublicKey.Marshal(),
+			previousEntry:  previousEntry.Marshal(),
+			entry:          entry.Marshal(),
+		},
+		"compressed points": {
+			groupPublicKey: altbn128.G2Point{G2: groupPublicKey}.Compress(),
+			previousEntry:  altbn128.G1Point{G1: previousEntry}.Compress(),
+			entry:          altbn128.G1Point{G1: entry}.Compress(),
+		},
+		"entry signed with another key": {
+			groupPublicKey: groupPublicKey.Marshal(),
+			previousEntry:  previousEntry.Marshal(),
+			entry:          otherEntry.Marshal(),
+			expectedError:  true,
+		},
+		"malformed entry": {
+			groupPublicKey: groupPublicKey.Marshal(),
+			previousEntry:  previousEntry.Marshal(),
+			entry:          []byte{0x01, 0x02},
+			expectedError:  true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := VerifyEntry(
+				test.groupPublicKey,
+				test.previousEntry,
+				test.entry,
+			)
+
+			if test.expectedError && err == nil {
+				t.Errorf("expected an error")
+			}
+			if !test.expectedError && err != nil {
+				t.Errorf("unexpected error: [%v]", err)
+			}
+		})
+	}
+}
+
+func TestAuditorVerifiesSubmittedEntries(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "audit_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	auditLog, err := OpenLog(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	auditor, err := NewAuditor(auditLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	groupPublicKey := new(bn256.G2).ScalarBaseMult(groupSecretKey).Marshal()
+	entry0 := new(bn256.G1).ScalarBaseMult(big.NewInt(456))
+	entry1 := bls.SignG1(groupSecretKey, entry0)
+	invalidEntry2 := bls.SignG1(big.NewInt(789), entry1)
+
+	auditor.onRelayEntryRequested(&event.Request{
+		PreviousEntry:  entry0.Marshal(),
+		GroupPublicKey: groupPublicKey,
+		BlockNumber:    10,
+	})
+	auditor.onRelayEntrySubmitted(&event.EntrySubmitted{BlockNumber: 12})
+	auditor.onRelayEntryRequested(&event.Request{
+		PreviousEntry:  entry1.Marshal(),
+		GroupPublicKey: groupPublicKey,
+		BlockNumber:    20,
+	})
+	// The request timed out and has been assigned to the same group again.
+	auditor.onRelayEntryRequested(&event.Request{
+		PreviousEntry:  entry1.Marshal(),
+		GroupPublicKey: groupPublicKey,
+		BlockNumber:    30,
+	})
+	auditor.onRelayEntrySubmitted(&event.EntrySubmitted{BlockNumber: 32})
+	auditor.onRelayEntryRequested(&event.Request{
+		PreviousEntry:  invalidEntry2.Marshal(),
+		GroupPublicKey: groupPublicKey,
+		BlockNumber:    40,
+	})
+
+	records, err := auditLog.Records()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("expected [2] audit records; has: [%v]", len(records))
+	}
+
+	validRecord := records[0]
+	if !validRecord.Valid {
+		t.Errorf("expected valid record; has: [%+v]", validRecord)
+	}
+	if validRecord.RequestBlock != 10 || validRecord.SubmissionBlock != 12 {
+		t.Errorf("unexpected record blocks: [%+v]", validRecord)
+	}
+	if validRecord.Entry != hex.EncodeToString(entry1.Marshal()) {
+		t.Errorf("unexpected record entry: [%v]", validRecord.Entry)
+	}
+
+	invalidRecord := records[1]
+	if invalidRecord.Valid || invalidRecord.Error == "" {
+		t.Errorf("expected invalid record; has: [%+v]", invalidRecord)
+	}
+	if invalidRecord.RequestBlock != 30 || invalidRecord.SubmissionBlock != 32 {
+		t.Errorf("unexpected record blocks: [%+v]", invalidRecord)
+	}
+
+	status := auditor.Status()
+	if status.SubmittedEntries != 2 ||
+		status.ValidEntries != 1 ||
+		status.InvalidEntries != 1 {
+		t.Errorf("unexpected audit status: [%+v]", status)
+	}
+
+	if err := auditLog.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Records from the existing log are taken into account after restart.
+	reopenedLog, err := OpenLog(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopenedLog.Close()
+
+	restartedAuditor, err := NewAuditor(reopenedLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status = restartedAuditor.Status()
+	if status.SubmittedEntries != 0 ||
+		status.ValidEntries != 1 ||
+		status.InvalidEntries != 1 {
+		t.Errorf("unexpected audit status after restart: [%+v]", status)
+	}
+}
+
+func TestAuditorVerifiesEntryOnSubmission(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "audit_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	auditLog, err := OpenLog(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer auditLog.Close()
+
+	auditor, err := NewAuditor(auditLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	groupPublicKey := new(bn256.G2).ScalarBaseMult(groupSecretKey).Marshal()
+	entry0 := new(bn256.G1).ScalarBaseMult(big.NewInt(456))
+	entry1 := bls.SignG1(groupSecretKey, entry0)
+
+	auditor.onRelayEntryRequested(&event.Request{
+		PreviousEntry:  entry0.Marshal(),
+		GroupPublicKey: groupPublicKey,
+		BlockNumber:    10,
+	})
+	auditor.onRelayEntrySubmitted(&event.EntrySubmitted{
+		Entry:       entry1.Marshal(),
+		BlockNumber: 12,
+	})
+
+	// The entry is verified without waiting for the next relay request.
+	records, err := auditLog.Records()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected [1] audit record; has: [%v]", len(records))
+	}
+
+	record := records[0]
+	if !record.Valid {
+		t.Errorf("expected valid record; has: [%+v]", record)
+	}
+	if record.RequestBlock != 10 || record.SubmissionBlock != 12 {
+		t.Errorf("unexpected record blocks: [%+v]", record)
+	}
+	if record.Entry != hex.EncodeToString(entry1.Marshal()) {
+		t.Errorf("unexpected record entry: [%v]", record.Entry)
+	}
+
+	// The next relay request does not verify the entry again.
+	auditor.onRelayEntryRequested(&event.Request{
+		PreviousEntry:  entry1.Marshal(),
+		GroupPublicKey: groupPublicKey,
+		BlockNumber:    20,
+	})
+
+	records, err = auditLog.Records()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected [1] audit record; has: [%v]", len(records))
+	}
+}
@@ -0,0 +1,113 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// LogFileName is the name of the relay entry audit log file created in the
+// data directory.
+const LogFileName = "relay_entry_audit.log"
+
+// Record is a result of an independent verification of a single relay entry
+// submitted on-chain.
+type Record struct {
+	RequestBlock    uint64    `json:"requestBlock"`
+	SubmissionBlock uint64    `json:"submissionBlock,omitempty"`
+	GroupPublicKey  string    `json:"groupPublicKey"`
+	PreviousEntry   string    `json:"previousEntry"`
+	Entry           string    `json:"entry"`
+	Valid           bool      `json:"valid"`
+	Error           string    `json:"error,omitempty"`
+	VerifiedAt      time.Time `json:"verifiedAt"`
+}
+
+// Log is a persistent, append-only log of relay entry verification records.
+// Each record is stored as a single line of JSON. Records are never modified
+// or removed once written.
+type Log struct {
+	mutex sync.Mutex
+	path  string
+	file  *os.File
+}
+
+// OpenLog opens the relay entry audit log in the given data directory,
+// creating the log file if it does not exist yet.
+func OpenLog(dataDir string) (*Log, error) {
+	path := filepath.Join(dataDir, LogFileName)
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("could not open audit log [%v]: [%v]", path, err)
+	}
+
+	return &Log{
+		path: path,
+		file: file,
+	}, nil
+}
+
+// Append writes the given record at the end of the log and flushes it to
+// the disk.
+func (l *Log) Append(record *Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("could not marshal audit record: [%v]", err)
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if _, err := l.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("could not write audit record: [%v]", err)
+	}
+
+	return l.file.Sync()
+}
+
+// Records reads all records from the log, ordered from the oldest to the
+// newest one.
+func (l *Log) Records() ([]*Record, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	file, err := os.Open(l.path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open audit log [%v]: [%v]", l.path, err)
+	}
+	defer file.Close()
+
+	records := make([]*Record, 0)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		record := &Record{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			return nil, fmt.Errorf("could not unmarshal audit record: [%v]", err)
+		}
+
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read audit log [%v]: [%v]", l.path, err)
+	}
+
+	return records, nil
+}
+
+// Close closes the log file. No records can be appended to a closed log.
+func (l *Log) Close() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.file.Close()
+}
@@ -63,6 +63,9 @@ type GroupSelectionInterface interface {
 	// is fulfilled with the entry as seen on-chain, or failed if there is an
 	// error submitting the entry.
 	SubmitTicket(ticket *Ticket) *async.EventGroupTicketSubmissionPromise
+	// SubmitTicketGasEstimate returns the estimated amount of gas needed to
+	// submit the given ticket to the chain.
+	SubmitTicketGasEstimate(ticket *Ticket) (uint64, error)
 	// GetSubmittedTickets gets the submitted group candidate tickets so far.
 	GetSubmittedTickets() ([]uint64, error)
 	// GetSelectedParticipants returns `GroupSize` slice of addresses of
@@ -89,11 +92,27 @@ type GroupRegistrationInterface interface {
 	GetGroupMembers(groupPublicKey []byte) ([]StakerAddress, error)
 }
 
+// UnauthorizedSigningInterface defines the subset of the relay chain interface
+// that pertains to reporting unauthorized use of group private keys.
+type UnauthorizedSigningInterface interface {
+	// ReportUnauthorizedSigning notifies the chain that the private key of
+	// the group with the given public key has been used without group
+	// authorization. The proof is a signature over the address of the
+	// operator submitting the report, made with the group private key.
+	// The chain terminates the reported group and seizes stakes of its
+	// members.
+	ReportUnauthorizedSigning(
+		groupPublicKey []byte,
+		signedOperatorAddress []byte,
+	) error
+}
+
 // GroupInterface defines the subset of the relay chain interface that pertains
 // specifically to relay group management.
 type GroupInterface interface {
 	GroupSelectionInterface
 	GroupRegistrationInterface
+	UnauthorizedSigningInterface
 }
 
 // DistributedKeyGenerationInterface defines the subset of the relay chain
@@ -122,6 +141,89 @@ type DistributedKeyGenerationInterface interface {
 	CalculateDKGResultHash(dkgResult *DKGResult) (DKGResultHash, error)
 }
 
+// RewardsInterface defines the subset of the relay chain interface that
+// pertains to rewards earned by group members.
+type RewardsInterface interface {
+	// GetGroupMemberRewards returns the amount of rewards accumulated by
+	// a single member of the group with the given public key.
+	GetGroupMemberRewards(groupPublicKey []byte) (*big.Int, error)
+	// HasWithdrawnRewards checks if the given operator has already withdrawn
+	// rewards accumulated as a member of the group with the given public key.
+	HasWithdrawnRewards(
+		operator StakerAddress,
+		groupPublicKey []byte,
+	) (bool, error)
+	// WithdrawGroupMemberRewards withdraws rewards accumulated by the given
+	// operator as a member of the group with the given public key and returns
+	// a promise to track the withdrawal. Rewards are transferred to the
+	// operator's beneficiary and can be withdrawn only from stale groups.
+	// The promise is fulfilled when the withdrawal is seen on-chain, or failed
+	// if the withdrawal could not be submitted.
+	WithdrawGroupMemberRewards(
+		operator StakerAddress,
+		groupPublicKey []byte,
+	) *async.EventGroupMemberRewardsWithdrawnPromise
+	// OnGroupMemberRewardsWithdrawn is a callback that is invoked when an
+	// on-chain notification of group member rewards being withdrawn is seen.
+	OnGroupMemberRewardsWithdrawn(
+		func(withdrawal *event.GroupMemberRewardsWithdrawn),
+	) (subscription.EventSubscription, error)
+	// CurrentGasPrice returns the gas price the chain currently expects
+	// transactions to be submitted with.
+	CurrentGasPrice() (*big.Int, error)
+}
+
+// PastEventsInterface defines the subset of the relay chain interface that
+// pertains to retrieval of events emitted on-chain in the given range of
+// blocks. It allows the client to catch up with events it missed when it was
+// not running or was disconnected from the chain. All functions return events
+// emitted between the start and the end block, both inclusive, ordered by the
+// block number.
+type PastEventsInterface interface {
+	// PastGroupSelectionStartedEvents returns group selection started events
+	// emitted in the given range of blocks.
+	PastGroupSelectionStartedEvents(
+		startBlock uint64,
+		endBlock uint64,
+	) ([]*event.GroupSelectionStart, error)
+	// PastDKGResultSubmittedEvents returns DKG result submitted events
+	// emitted in the given range of blocks.
+	PastDKGResultSubmittedEvents(
+		startBlock uint64,
+		endBlock uint64,
+	) ([]*event.DKGResultSubmission, error)
+	// PastGroupRegisteredEvents returns group registered events emitted in
+	// the given range of blocks.
+	PastGroupRegisteredEvents(
+		startBlock uint64,
+		endBlock uint64,
+	) ([]*event.GroupRegistration, error)
+	// PastRelayEntryRequestedEvents returns relay entry requested events
+	// emitted in the given range of blocks.
+	PastRelayEntryRequestedEvents(
+		startBlock uint64,
+		endBlock uint64,
+	) ([]*event.Request, error)
+	// PastRelayEntrySubmittedEvents returns relay entry submitted events
+	// emitted in the given range of blocks.
+	PastRelayEntrySubmittedEvents(
+		startBlock uint64,
+		endBlock uint64,
+	) ([]*event.EntrySubmitted, error)
+}
+
+// ReorganizationInterface defines the subset of the relay chain interface
+// that pertains to chain reorganizations.
+type ReorganizationInterface interface {
+	// OnEventRemoved is a callback that is invoked when an event already
+	// delivered to handlers registered with other functions of the interface
+	// has been removed from the chain by a chain reorganization. Handlers are
+	// notified once per removed event and type of the delivered event.
+	OnEventRemoved(
+		func(removal *event.Removed),
+	) (subscription.EventSubscription, error)
+}
+
 // Interface represents the interface that the relay expects to interact with
 // the anchoring blockchain on.
 type Interface interface {
@@ -134,4 +236,7 @@ type Interface interface {
 	GroupInterface
 	RelayEntryInterface
 	DistributedKeyGenerationInterface
+	RewardsInterface
+	PastEventsInterface
+	ReorganizationInterface
 }
@@ -0,0 +1,82 @@
+package chain
+
+import (
+	"fmt"
+)
+
+// SubmissionFailure describes why the chain rejected a relay entry or DKG
+// result submission.
+type SubmissionFailure int
+
+const (
+	// UnknownFailure means the submission failed for a reason the chain
+	// implementation could not recognize.
+	UnknownFailure SubmissionFailure = iota
+	// AlreadySubmitted means another member has already submitted the same
+	// relay entry or DKG result.
+	AlreadySubmitted
+	// NotEligibleYet means the submitter is not yet eligible to submit
+	// according to the chain. Submission may succeed in one of the following
+	// blocks.
+	NotEligibleYet
+	// TimedOut means the time for the submission has passed.
+	TimedOut
+	// Reverted means the submission was rejected by the chain for another
+	// reason, usually available in the revert reason.
+	Reverted
+	// OutOfGas means the submission would run or ran out of gas.
+	OutOfGas
+)
+
+func (sf SubmissionFailure) String() string {
+	switch sf {
+	case AlreadySubmitted:
+		return "already submitted"
+	case NotEligibleYet:
+		return "not eligible yet"
+	case TimedOut:
+		return "timed out"
+	case Reverted:
+		return "reverted"
+	case OutOfGas:
+		return "out of gas"
+	default:
+		return "unknown failure"
+	}
+}
+
+// SubmissionError is returned by the chain when a relay entry or DKG result
+// submission fails for a recognized reason. Submitters can switch on the
+// Failure to decide whether to give up, wait or retry.
+type SubmissionError struct {
+	// Failure is the category of the failure.
+	Failure SubmissionFailure
+	// RevertReason is the reason returned by the reverted contract call, if
+	// any.
+	RevertReason string
+	// Cause is the original error returned by the chain.
+	Cause error
+}
+
+func (se *SubmissionError) Error() string {
+	if se.RevertReason != "" {
+		return fmt.Sprintf(
+			"submission failed [%v] with revert reason [%v]: [%v]",
+			se.Failure,
+			se.RevertReason,
+			se.Cause,
+		)
+	}
+
+	return fmt.Sprintf("submission failed [%v]: [%v]", se.Failure, se.Cause)
+}
+
+// SubmissionFailureOf returns the failure category of the given submission
+// error. UnknownFailure is returned for errors other than SubmissionError.
+func SubmissionFailureOf(err error) SubmissionFailure {
+	if submissionError, ok := err.(*SubmissionError); ok {
+		return submissionError.Failure
+	}
+
+	return UnknownFailure
+}
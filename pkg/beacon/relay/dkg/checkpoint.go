@@ -0,0 +1,93 @@
+package dkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/internal/persistenceutils"
+)
+
+// checkpointDirectoryPrefix is the prefix of names of persistence
+// directories in which GJKR protocol checkpoints are stored. Checkpoints of
+// each protocol execution are stored in a separate directory so that they
+// can be archived once the execution completes.
+const checkpointDirectoryPrefix = "dkg_checkpoint_"
+
+// SaveCheckpoint stores the given GJKR protocol checkpoint with the
+// persistence handle. Checkpoints contain secrets of the member so the handle
+// should encrypt the stored data.
+//
+// Each checkpoint is stored in a separate file named after the number of
+// checkpointed phases. A checkpoint with the same number of phases taken
+// later overwrites the previous one.
+func SaveCheckpoint(
+	handle persistence.Handle,
+	checkpoint *gjkr.Transcript,
+) error {
+	checkpointBytes, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("could not marshal checkpoint: [%v]", err)
+	}
+
+	return handle.Save(
+		checkpointBytes,
+		checkpointDirectory(checkpoint.Seed, checkpoint.MemberIndex),
+		fmt.Sprintf("phase_%02d", len(checkpoint.Phases)),
+	)
+}
+
+// ArchiveCheckpoints archives all checkpoints of the GJKR protocol executed
+// by the given member for the given seed so that they are no longer read.
+func ArchiveCheckpoints(
+	handle persistence.Handle,
+	seed *big.Int,
+	memberIndex group.MemberIndex,
+) error {
+	return handle.Archive(checkpointDirectory(seed, memberIndex))
+}
+
+// ReadCheckpoints reads the latest checkpoint of each GJKR protocol execution
+// stored with the persistence handle. Checkpoints which could not be read,
+// for example because the client crashed while saving them, are skipped.
+// Returned checkpoints are ordered by the seed and member index.
+func ReadCheckpoints(handle persistence.Handle) []*gjkr.Transcript {
+	latestCheckpoints := persistenceutils.ReadLatest(
+		handle,
+		checkpointDirectoryPrefix,
+		func(content []byte) (interface{}, error) {
+			checkpoint := &gjkr.Transcript{}
+			if err := json.Unmarshal(content, checkpoint); err != nil {
+				return nil, err
+			}
+			return checkpoint, nil
+		},
+	)
+
+	checkpoints := make([]*gjkr.Transcript, 0, len(latestCheckpoints))
+	for _, checkpoint := range latestCheckpoints {
+		checkpoints = append(checkpoints, checkpoint.(*gjkr.Transcript))
+	}
+
+	sort.Slice(checkpoints, func(i, j int) bool {
+		if seeds := checkpoints[i].Seed.Cmp(checkpoints[j].Seed); seeds != 0 {
+			return seeds < 0
+		}
+		return checkpoints[i].MemberIndex < checkpoints[j].MemberIndex
+	})
+
+	return checkpoints
+}
+
+func checkpointDirectory(seed *big.Int, memberIndex group.MemberIndex) string {
+	return fmt.Sprintf(
+		"%v%v_%v",
+		checkpointDirectoryPrefix,
+		seed.Text(16),
+		memberIndex,
+	)
+}
@@ -0,0 +1,101 @@
+package dkg
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+)
+
+func TestSaveReadAndArchiveCheckpoints(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "checkpoint_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	diskHandle, err := persistence.NewDiskHandle(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handle := persistence.NewEncryptedPersistence(diskHandle, "password")
+
+	// Data other than checkpoints stored with the same handle.
+	if err := handle.Save([]byte{0x01}, "group", "membership_1"); err != nil {
+		t.Fatal(err)
+	}
+
+	newCheckpoint := func(seed int64, memberIndex int, phases int) *gjkr.Transcript {
+		checkpoint := &gjkr.Transcript{
+			Seed:        big.NewInt(seed),
+			MemberIndex: group.MemberIndex(memberIndex),
+			Phases:      make([]*gjkr.TranscriptPhase, phases),
+			Secrets:     &gjkr.TranscriptSecrets{},
+		}
+		for i := range checkpoint.Phases {
+			checkpoint.Phases[i] = &gjkr.TranscriptPhase{
+				Phase:            i + 1,
+				StartBlockHeight: uint64(i),
+				Sent:             []*gjkr.TranscriptMessage{},
+				Received:         []*gjkr.TranscriptMessage{},
+			}
+		}
+		return checkpoint
+	}
+
+	checkpoint1 := newCheckpoint(0xff, 2, 3)
+	checkpoint2 := newCheckpoint(0xff, 1, 1)
+	checkpoint3 := newCheckpoint(0xaa, 5, 2)
+
+	for _, checkpoint := range []*gjkr.Transcript{
+		newCheckpoint(0xff, 2, 1),
+		newCheckpoint(0xff, 2, 2),
+		checkpoint1,
+		checkpoint2,
+		checkpoint3,
+	} {
+		if err := SaveCheckpoint(handle, checkpoint); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Checkpoint which has not been completely saved.
+	if err := diskHandle.Save(
+		[]byte{0x01, 0x02},
+		checkpointDirectory(big.NewInt(0xaa), group.MemberIndex(5)),
+		"phase_03",
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*gjkr.Transcript{checkpoint3, checkpoint2, checkpoint1}
+	checkpoints := ReadCheckpoints(handle)
+	if !reflect.DeepEqual(expected, checkpoints) {
+		t.Fatalf(
+			"unexpected checkpoints\nexpected: [%+v]\nactual:   [%+v]",
+			expected,
+			checkpoints,
+		)
+	}
+
+	err = ArchiveCheckpoints(handle, big.NewInt(0xff), group.MemberIndex(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected = []*gjkr.Transcript{checkpoint3, checkpoint2}
+	checkpoints = ReadCheckpoints(handle)
+	if !reflect.DeepEqual(expected, checkpoints) {
+		t.Fatalf(
+			"unexpected checkpoints after archiving\n"+
+				"expected: [%+v]\nactual:   [%+v]",
+			expected,
+			checkpoints,
+		)
+	}
+}
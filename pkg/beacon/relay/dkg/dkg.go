@@ -2,6 +2,7 @@ package dkg
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"math/big"
 
@@ -18,8 +19,13 @@ import (
 
 var logger = log.Logger("keep-dkg")
 
-// ExecuteDKG runs the full distributed key generation lifecycle.
+// ExecuteDKG runs the full distributed key generation lifecycle. The execution
+// is aborted when the provided context is done. If the transcript recorder is
+// not nil, all GJKR protocol messages sent and received by the member are
+// recorded with it. If the seats are not nil, the member executes the GJKR
+// protocol along with other seats of the same operator, see gjkr.Seats.
 func ExecuteDKG(
+	ctx context.Context,
 	seed *big.Int,
 	index uint8, // starts with 0
 	groupSize int,
@@ -30,6 +36,8 @@ func ExecuteDKG(
 	relayChain relayChain.Interface,
 	signing chain.Signing,
 	channel net.BroadcastChannel,
+	transcriptRecorder *gjkr.TranscriptRecorder,
+	seats *gjkr.Seats,
 ) (*ThresholdSigner, error) {
 	// The staker index should begin with 1
 	playerIndex := group.MemberIndex(index + 1)
@@ -38,6 +46,7 @@ func ExecuteDKG(
 	dkgResult.RegisterUnmarshallers(channel)
 
 	gjkrResult, gjkrEndBlockHeight, err := gjkr.Execute(
+		ctx,
 		playerIndex,
 		groupSize,
 		blockCounter,
@@ -46,6 +55,8 @@ func ExecuteDKG(
 		seed,
 		membershipValidator,
 		startBlockHeight,
+		transcriptRecorder,
+		seats,
 	)
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -55,6 +66,81 @@ func ExecuteDKG(
 		)
 	}
 
+	return publishResult(
+		ctx,
+		playerIndex,
+		gjkrResult,
+		gjkrEndBlockHeight,
+		membershipValidator,
+		blockCounter,
+		relayChain,
+		signing,
+		channel,
+	)
+}
+
+// ResumeDKG resumes the distributed key generation interrupted after the
+// given GJKR protocol checkpoint has been taken and then runs the rest of
+// the key generation lifecycle. The execution is aborted when the provided
+// context is done. If the transcript recorder is not nil, recording of GJKR
+// protocol messages continues from the checkpoint.
+func ResumeDKG(
+	ctx context.Context,
+	checkpoint *gjkr.Transcript,
+	membershipValidator group.MembershipValidator,
+	blockCounter chain.BlockCounter,
+	relayChain relayChain.Interface,
+	signing chain.Signing,
+	channel net.BroadcastChannel,
+	transcriptRecorder *gjkr.TranscriptRecorder,
+) (*ThresholdSigner, error) {
+	playerIndex := checkpoint.MemberIndex
+
+	gjkr.RegisterUnmarshallers(channel)
+	dkgResult.RegisterUnmarshallers(channel)
+
+	gjkrResult, gjkrEndBlockHeight, err := gjkr.Resume(
+		ctx,
+		checkpoint,
+		blockCounter,
+		channel,
+		membershipValidator,
+		transcriptRecorder,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"[member:%v] resumed GJKR execution failed [%v]",
+			playerIndex,
+			err,
+		)
+	}
+
+	return publishResult(
+		ctx,
+		playerIndex,
+		gjkrResult,
+		gjkrEndBlockHeight,
+		membershipValidator,
+		blockCounter,
+		relayChain,
+		signing,
+		channel,
+	)
+}
+
+// publishResult publishes the result of the GJKR protocol and returns
+// a threshold signer if the member stays in the group.
+func publishResult(
+	ctx context.Context,
+	playerIndex group.MemberIndex,
+	gjkrResult *gjkr.Result,
+	gjkrEndBlockHeight uint64,
+	membershipValidator group.MembershipValidator,
+	blockCounter chain.BlockCounter,
+	relayChain relayChain.Interface,
+	signing chain.Signing,
+	channel net.BroadcastChannel,
+) (*ThresholdSigner, error) {
 	startPublicationBlockHeight := gjkrEndBlockHeight
 
 	dkgResultChannel := make(chan *event.DKGResultSubmission)
@@ -73,6 +159,7 @@ func ExecuteDKG(
 	defer dkgResultSubscription.Unsubscribe()
 
 	err = dkgResult.Publish(
+		ctx,
 		playerIndex,
 		gjkrResult.Group,
 		membershipValidator,
@@ -97,6 +184,7 @@ func ExecuteDKG(
 		)
 
 		if err := decideMemberFate(
+			ctx,
 			playerIndex,
 			gjkrResult,
 			dkgResultChannel,
@@ -121,6 +209,7 @@ func ExecuteDKG(
 // supports the same group public key as the one registered on-chain and
 // the member is not considered as misbehaving by the group.
 func decideMemberFate(
+	ctx context.Context,
 	playerIndex group.MemberIndex,
 	gjkrResult *gjkr.Result,
 	dkgResultChannel chan *event.DKGResultSubmission,
@@ -129,6 +218,7 @@ func decideMemberFate(
 	blockCounter chain.BlockCounter,
 ) error {
 	dkgResultEvent, err := waitForDkgResultEvent(
+		ctx,
 		dkgResultChannel,
 		startPublicationBlockHeight,
 		relayChain,
@@ -168,6 +258,7 @@ func decideMemberFate(
 }
 
 func waitForDkgResultEvent(
+	ctx context.Context,
 	dkgResultChannel chan *event.DKGResultSubmission,
 	startPublicationBlockHeight uint64,
 	relayChain relayChain.Interface,
@@ -192,5 +283,10 @@ func waitForDkgResultEvent(
 		return dkgResultEvent, nil
 	case <-timeoutBlockChannel:
 		return nil, fmt.Errorf("DKG result publication timed out")
+	case <-ctx.Done():
+		return nil, fmt.Errorf(
+			"waiting for DKG result publication cancelled: [%v]",
+			ctx.Err(),
+		)
 	}
 }
@@ -1,6 +1,7 @@
 package dkg
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"reflect"
@@ -43,6 +44,7 @@ func TestDecideMemberFate_HappyPath(t *testing.T) {
 	}
 
 	err := decideMemberFate(
+		context.Background(),
 		playerIndex,
 		gjkrResult,
 		dkgResultChannel,
@@ -70,6 +72,7 @@ func TestDecideMemberFate_NotSameGroupPublicKey(t *testing.T) {
 	}
 
 	err := decideMemberFate(
+		context.Background(),
 		playerIndex,
 		gjkrResult,
 		dkgResultChannel,
@@ -101,6 +104,7 @@ func TestDecideMemberFate_MemberIsMisbehaved(t *testing.T) {
 	}
 
 	err := decideMemberFate(
+		context.Background(),
 		playerIndex,
 		gjkrResult,
 		dkgResultChannel,
@@ -127,6 +131,7 @@ func TestDecideMemberFate_Timeout(t *testing.T) {
 	setup()
 
 	err := decideMemberFate(
+		context.Background(),
 		playerIndex,
 		gjkrResult,
 		dkgResultChannel,
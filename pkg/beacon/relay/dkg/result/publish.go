@@ -1,6 +1,7 @@
 package result
 
 import (
+	"context"
 	"fmt"
 
 	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
@@ -25,8 +26,10 @@ func RegisterUnmarshallers(channel net.BroadcastChannel) {
 // chosen result is hashed, signed, and sent over a broadcast channel. Then, all
 // other signatures and results are received and accounted for. Those that match
 // our own result and added to the list of votes. Finally, we submit the result
-// along with everyone's votes.
+// along with everyone's votes. Publication is aborted when the provided context
+// is done.
 func Publish(
+	ctx context.Context,
 	memberIndex group.MemberIndex,
 	dkgGroup *group.Group,
 	membershipValidator group.MembershipValidator,
@@ -50,7 +53,7 @@ func Publish(
 
 	stateMachine := state.NewMachine(channel, blockCounter, initialState)
 
-	lastState, _, err := stateMachine.Execute(startBlockHeight)
+	lastState, _, err := stateMachine.Execute(ctx, startBlockHeight)
 	if err != nil {
 		return err
 	}
@@ -39,7 +39,8 @@ func NewSubmittingMember(
 //
 // If a result is submitted by another member and it's accepted by the chain,
 // the current member finishes the phase immediately, without submitting
-// their own result.
+// their own result. The same happens when the chain rejects the member's
+// submission because the result has been already submitted.
 //
 // It returns the on-chain block height of the moment when the result was
 // successfully submitted on chain by the member. In case of failure or result
@@ -125,32 +126,80 @@ func (sm *SubmittingMember) SubmitDKGResult(
 		select {
 		case blockNumber := <-eligibleToSubmitWaiter:
 			// Member becomes eligible to submit the result.
-			errorChannel := make(chan error)
-			defer close(errorChannel)
-
 			subscription.Unsubscribe()
 			close(onSubmittedResultChan)
 
-			logger.Infof(
-				"[member:%v] submitting DKG result with public key [0x%x] and "+
-					"[%v] supporting member signatures at block [%v]",
-				sm.index,
-				result.GroupPublicKey,
-				len(signatures),
-				blockNumber,
-			)
-			chainRelay.SubmitDKGResult(
-				sm.index,
-				result,
-				signatures,
-			).
-				OnComplete(func(
-					dkgResultPublishedEvent *event.DKGResultSubmission,
-					err error,
-				) {
-					errorChannel <- err
-				})
-			return <-errorChannel
+			// The chain state the submission is checked against may be
+			// a block behind so the result is submitted again in the next
+			// block if the chain does not consider the member eligible yet.
+			// It is retried no longer than for the block step since the next
+			// member is eligible to submit by then, and no longer than the
+			// result is not published by other member.
+			for retry := uint64(0); ; retry++ {
+				logger.Infof(
+					"[member:%v] submitting DKG result with public key [0x%x] "+
+						"and [%v] supporting member signatures at block [%v]",
+					sm.index,
+					result.GroupPublicKey,
+					len(signatures),
+					blockNumber,
+				)
+
+				err := sm.submit(result, signatures, chainRelay)
+				if err == nil {
+					return nil
+				}
+
+				switch relayChain.SubmissionFailureOf(err) {
+				case relayChain.AlreadySubmitted:
+					logger.Infof(
+						"[member:%v] leaving; DKG result already submitted "+
+							"by other member",
+						sm.index,
+					)
+					return nil
+				case relayChain.NotEligibleYet:
+					if retry < config.ResultPublicationBlockStep {
+						logger.Warningf(
+							"[member:%v] not eligible to submit DKG result "+
+								"at block [%v]; retrying in the next block",
+							sm.index,
+							blockNumber,
+						)
+						blockNumber++
+						if err := blockCounter.WaitForBlockHeight(
+							blockNumber,
+						); err != nil {
+							return fmt.Errorf(
+								"wait for next block failure: [%v]",
+								err,
+							)
+						}
+
+						published, err := chainRelay.IsGroupRegistered(
+							result.GroupPublicKey,
+						)
+						if err != nil {
+							return fmt.Errorf(
+								"could not check if the result is already "+
+									"submitted: [%v]",
+								err,
+							)
+						}
+						if published {
+							logger.Infof(
+								"[member:%v] leaving; DKG result submitted "+
+									"by other member",
+								sm.index,
+							)
+							return nil
+						}
+						continue
+					}
+				}
+
+				return err
+			}
 		case blockNumber := <-onSubmittedResultChan:
 			logger.Infof(
 				"[member:%v] leaving; DKG result submitted by other member at block [%v]",
@@ -164,6 +213,30 @@ func (sm *SubmittingMember) SubmitDKGResult(
 	}
 }
 
+// submit submits the result to the chain and waits until the submission
+// completes.
+func (sm *SubmittingMember) submit(
+	result *relayChain.DKGResult,
+	signatures map[group.MemberIndex][]byte,
+	chainRelay relayChain.Interface,
+) error {
+	errorChannel := make(chan error, 1)
+
+	chainRelay.SubmitDKGResult(
+		sm.index,
+		result,
+		signatures,
+	).
+		OnComplete(func(
+			dkgResultPublishedEvent *event.DKGResultSubmission,
+			err error,
+		) {
+			errorChannel <- err
+		})
+
+	return <-errorChannel
+}
+
 // waitForSubmissionEligibility waits until the current member is eligible to
 // submit a result to the blockchain. First member is eligible to submit straight
 // away, each following member is eligible after pre-defined block step.
@@ -0,0 +1,83 @@
+package dkg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/internal/persistenceutils"
+)
+
+// transcriptDirectoryPrefix is the prefix of names of persistence
+// directories in which GJKR protocol transcripts are stored. Each transcript
+// is stored in a separate directory named after the hexadecimal DKG seed and
+// the index of the member who recorded the transcript.
+const transcriptDirectoryPrefix = "dkg_transcript_"
+
+// transcriptFileName is the name of the file in which the transcript is
+// stored in its directory.
+const transcriptFileName = "transcript"
+
+// SaveTranscript signs the given transcript with the operator signing and
+// stores it with the persistence handle. Transcripts contain secrets of the
+// member so the handle should encrypt the stored data.
+func SaveTranscript(
+	handle persistence.Handle,
+	transcript *gjkr.Transcript,
+	signing chain.Signing,
+) error {
+	signedTranscript, err := gjkr.SignTranscript(transcript, signing)
+	if err != nil {
+		return err
+	}
+
+	transcriptBytes, err := signedTranscript.Marshal()
+	if err != nil {
+		return fmt.Errorf("could not marshal signed transcript: [%v]", err)
+	}
+
+	return handle.Save(
+		transcriptBytes,
+		transcriptDirectory(transcript),
+		transcriptFileName,
+	)
+}
+
+// ReadTranscripts reads all signed transcripts stored with the persistence
+// handle. Transcripts which could not be read are skipped. Transcripts are
+// returned by their names consisting of the hexadecimal DKG seed and the index
+// of the member who recorded the transcript.
+func ReadTranscripts(
+	handle persistence.Handle,
+) map[string]*gjkr.SignedTranscript {
+	latestTranscripts := persistenceutils.ReadLatest(
+		handle,
+		transcriptDirectoryPrefix,
+		func(content []byte) (interface{}, error) {
+			signedTranscript := &gjkr.SignedTranscript{}
+			if err := signedTranscript.Unmarshal(content); err != nil {
+				return nil, err
+			}
+			return signedTranscript, nil
+		},
+	)
+
+	transcripts := make(map[string]*gjkr.SignedTranscript)
+	for directory, transcript := range latestTranscripts {
+		name := strings.TrimPrefix(directory, transcriptDirectoryPrefix)
+		transcripts[name] = transcript.(*gjkr.SignedTranscript)
+	}
+
+	return transcripts
+}
+
+func transcriptDirectory(transcript *gjkr.Transcript) string {
+	return fmt.Sprintf(
+		"%v%v_%v",
+		transcriptDirectoryPrefix,
+		transcript.Seed.Text(16),
+		transcript.MemberIndex,
+	)
+}
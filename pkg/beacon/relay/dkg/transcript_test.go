@@ -0,0 +1,83 @@
+package dkg
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/chain/local"
+)
+
+func TestSaveAndReadTranscripts(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "transcript_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	diskHandle, err := persistence.NewDiskHandle(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handle := persistence.NewEncryptedPersistence(diskHandle, "password")
+
+	// Data other than transcripts stored with the same handle.
+	if err := handle.Save([]byte{0x01}, "group", "membership_1"); err != nil {
+		t.Fatal(err)
+	}
+
+	signing := local.Connect(3, 2, big.NewInt(10)).Signing()
+
+	transcript := &gjkr.Transcript{
+		Seed:               big.NewInt(0xabcd),
+		MemberIndex:        group.MemberIndex(1),
+		GroupSize:          3,
+		DishonestThreshold: 1,
+		GroupMembers: []relaychain.StakerAddress{
+			signing.PublicKey(),
+			signing.PublicKey(),
+			signing.PublicKey(),
+		},
+		Phases:  []*gjkr.TranscriptPhase{},
+		Secrets: &gjkr.TranscriptSecrets{},
+		Error:   "execution cancelled",
+	}
+
+	if err := SaveTranscript(handle, transcript, signing); err != nil {
+		t.Fatal(err)
+	}
+
+	transcripts := ReadTranscripts(handle)
+
+	if len(transcripts) != 1 {
+		t.Fatalf(
+			"unexpected number of transcripts\nexpected: [%v]\nactual:   [%v]",
+			1,
+			len(transcripts),
+		)
+	}
+
+	signedTranscript, ok := transcripts["abcd_1"]
+	if !ok {
+		t.Fatalf("transcript [abcd_1] not found")
+	}
+
+	readTranscript, err := signedTranscript.Open(signing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(transcript, readTranscript) {
+		t.Fatalf(
+			"unexpected transcript\nexpected: [%+v]\nactual:   [%+v]",
+			transcript,
+			readTranscript,
+		)
+	}
+}
@@ -3,6 +3,7 @@ package entry
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 
@@ -25,21 +26,52 @@ var logger = log.Logger("keep-entry")
 func RegisterUnmarshallers(channel net.BroadcastChannel) {
 	channel.RegisterUnmarshaler(
 		func() net.TaggedUnmarshaler { return &SignatureShareMessage{} })
+	channel.RegisterUnmarshaler(
+		func() net.TaggedUnmarshaler { return &SignatureSharesMessage{} })
 }
 
 // SignAndSubmit triggers the threshold signature process for the
 // previous relay entry and publishes the signature to the chain as
-// a new relay entry.
+// a new relay entry. The process is aborted when the provided context is done.
+//
+// The provided signers are all the seats this node has in the group. Shares
+// of all the seats are broadcast in one message and a single message loop
+// collects shares of other members for all of them, so the signature is
+// restored and submitted once per node. Each received share must come from
+// the operator selected to the group at the position of the share's member,
+// as confirmed by the membership validator. Only the first share received
+// from each member is validated.
+//
+// The session state is passed to the provided function whenever new valid
+// shares are collected and once the signature is restored, so that it can be
+// persisted. If a persisted session is provided, it is resumed: the valid
+// shares collected so far are not received again and, if the signature has
+// already been restored, the session only waits for its turn to submit the
+// relay entry. Both the session and the function are optional.
 func SignAndSubmit(
+	parentCtx context.Context,
 	blockCounter chain.BlockCounter,
 	channel net.BroadcastChannel,
 	relayChain relayChain.Interface,
 	previousEntryBytes []byte,
 	honestThreshold int,
-	signer *dkg.ThresholdSigner,
+	signers []*dkg.ThresholdSigner,
+	membershipValidator group.MembershipValidator,
 	startBlockHeight uint64,
+	resumedSession *Session,
+	onSessionUpdate func(*Session),
 ) error {
-	ctx, cancelCtx := context.WithCancel(context.Background())
+	if len(signers) == 0 {
+		return fmt.Errorf("no signers provided")
+	}
+
+	signers = sortSigners(signers)
+	// Signer with the lowest member index represents all the seats in logs
+	// and submits the relay entry, as it is the first of them eligible to
+	// submit.
+	signer := signers[0]
+
+	ctx, cancelCtx := context.WithCancel(parentCtx)
 	defer cancelCtx()
 
 	relayEntrySubmittedChannel := make(chan uint64)
@@ -71,54 +103,158 @@ func SignAndSubmit(
 		return err
 	}
 
-	selfShare := signer.CalculateSignatureShare(previousEntry)
+	receivedValidShares := make(map[group.MemberIndex]*bn256.G1)
+	var signature *bn256.G1
+	if resumedSession != nil {
+		receivedValidShares = resumedSession.restoreShares()
+		signature = resumedSession.restoreSignature()
 
-	go broadcastShare(ctx, signer.MemberID(), selfShare, channel)
+		logger.Infof(
+			"[member:%v] resuming signing session with [%v] valid "+
+				"signature shares; signature restored: [%v]",
+			signer.MemberID(),
+			len(receivedValidShares),
+			signature != nil,
+		)
+	}
+
+	selfShares := make(map[group.MemberIndex]*bn256.G1)
+	for _, seat := range signers {
+		share := seat.CalculateSignatureShare(previousEntry)
+		selfShares[seat.MemberID()] = share
+		receivedValidShares[seat.MemberID()] = share
+	}
+
+	saveSession := func() {
+		if onSessionUpdate == nil {
+			return
+		}
+
+		onSessionUpdate(newSession(
+			signer.GroupPublicKeyBytes(),
+			previousEntryBytes,
+			startBlockHeight,
+			receivedValidShares,
+			signature,
+		))
+	}
+
+	// Once the signature is restored, the node only waits for its turn to
+	// submit the relay entry and shares of its seats are not broadcast again.
+	if signature == nil {
+		saveSession()
+		go broadcastShares(
+			ctx,
+			signer.MemberID(),
+			selfShares,
+			channel,
+			groupsSharesSupport.requiresSeparateShares(channel.Name()),
+		)
+	}
 
 	receiveChannel := make(chan net.Message, 64)
 	channel.Recv(ctx, func(netMessage net.Message) {
 		receiveChannel <- netMessage
 	})
 
-	receivedValidShares := map[group.MemberIndex]*bn256.G1{
-		signer.MemberID(): selfShare,
-	}
+	// Signature shares received but not yet validated. Shares are validated
+	// in batches, once there are enough of them to reach the honest
+	// threshold if all of them are valid.
+	receivedShares := make(map[group.MemberIndex]*bn256.G1)
+
+	// Members whose share has been already received, whether it turned out
+	// to be valid or not. The same share may be received more than once,
+	// for example in both SignatureShareMessage and SignatureSharesMessage
+	// or when the message is retransmitted.
+	handledMembers := make(map[group.MemberIndex]bool)
 
 	// Run the message loop until the number of received and valid signature
 	// shares is equal to the honest threshold. Message loop will be also
 	// terminated if an other member submits the result or the relay entry
 	// timeout block is reached.
-	for len(receivedValidShares) < honestThreshold {
+	for signature == nil && len(receivedValidShares) < honestThreshold {
 		select {
 		case netMessage := <-receiveChannel:
-			message, ok := netMessage.Payload().(*SignatureShareMessage)
-			if !ok || group.IsMessageFromSelf(signer.MemberID(), message) {
+			var messages []*SignatureShareMessage
+			isSharesMessage := false
+			switch message := netMessage.Payload().(type) {
+			case *SignatureShareMessage:
+				messages = []*SignatureShareMessage{message}
+			case *SignatureSharesMessage:
+				messages = message.shares
+				isSharesMessage = true
+			default:
 				continue
 			}
 
-			share, err := extractAndValidateShare(
-				message,
-				signer.GroupPublicKeyShares(),
-				previousEntry,
-			)
-			if err != nil {
-				logger.Warningf(
-					"[member:%v] rejecting signature share from "+
-						"member [%v]: [%v]",
-					signer.MemberID(),
+			for _, message := range messages {
+				if _, ok := selfShares[message.senderID]; ok {
+					continue
+				}
+
+				if !membershipValidator.IsValidMembership(
 					message.senderID,
-					err,
+					netMessage.SenderPublicKey(),
+				) {
+					logger.Warningf(
+						"[member:%v] rejecting signature share from "+
+							"member [%v]: [sender is not a group member "+
+							"at the given position]",
+						signer.MemberID(),
+						message.senderID,
+					)
+					continue
+				}
+
+				groupsSharesSupport.record(
+					channel.Name(),
+					message.senderID,
+					isSharesMessage,
+				)
+
+				if _, ok := receivedValidShares[message.senderID]; ok {
+					continue
+				}
+				if handledMembers[message.senderID] {
+					continue
+				}
+				handledMembers[message.senderID] = true
+
+				share, err := extractShare(
+					message,
+					signer.GroupPublicKeyShares(),
 				)
+				if err != nil {
+					logger.Warningf(
+						"[member:%v] rejecting signature share from "+
+							"member [%v]: [%v]",
+						signer.MemberID(),
+						message.senderID,
+						err,
+					)
+					continue
+				}
+
+				receivedShares[message.senderID] = share
+			}
+
+			if len(receivedShares) == 0 ||
+				len(receivedValidShares)+len(receivedShares) < honestThreshold {
 				continue
 			}
 
-			logger.Debugf(
-				"[member:%v] accepting signature share from member [%v]",
-				signer.MemberID(),
-				message.senderID,
+			validSharesCount := len(receivedValidShares)
+			validateShares(
+				signer,
+				receivedShares,
+				previousEntry,
+				receivedValidShares,
 			)
+			receivedShares = make(map[group.MemberIndex]*bn256.G1)
 
-			receivedValidShares[message.senderID] = share
+			if len(receivedValidShares) > validSharesCount {
+				saveSession()
+			}
 		case blockNumber := <-relayEntrySubmittedChannel:
 			logger.Infof(
 				"[member:%v] leaving message loop; "+
@@ -133,12 +269,26 @@ func SignAndSubmit(
 				blockNumber,
 				len(receivedValidShares),
 			)
+		case <-ctx.Done():
+			return fmt.Errorf(
+				"relay entry signing cancelled; received [%v] valid signature shares: [%v]",
+				len(receivedValidShares),
+				ctx.Err(),
+			)
 		}
 	}
 
-	signature, err := completeSignature(signer, receivedValidShares, honestThreshold)
-	if err != nil {
-		return err
+	if signature == nil {
+		signature, err = completeSignature(
+			signer,
+			receivedValidShares,
+			honestThreshold,
+		)
+		if err != nil {
+			return err
+		}
+
+		saveSession()
 	}
 
 	submitter := &relayEntrySubmitter{
@@ -153,6 +303,7 @@ func SignAndSubmit(
 	// still a possibility those signals appear in the future so the submitter
 	// must be aware of them and break the execution if they occur.
 	return submitter.submitRelayEntry(
+		ctx,
 		signature.Marshal(),
 		signer.GroupPublicKeyBytes(),
 		startBlockHeight,
@@ -161,30 +312,67 @@ func SignAndSubmit(
 	)
 }
 
-func broadcastShare(
+// sortSigners returns a copy of the given signers ordered by member index.
+func sortSigners(signers []*dkg.ThresholdSigner) []*dkg.ThresholdSigner {
+	sorted := make([]*dkg.ThresholdSigner, len(signers))
+	copy(sorted, signers)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].MemberID() < sorted[j].MemberID()
+	})
+
+	return sorted
+}
+
+// broadcastShares sends signature shares of all the seats together in
+// a SignatureSharesMessage. If separate shares are required, each share is
+// additionally sent in a separate SignatureShareMessage, so that clients not
+// supporting SignatureSharesMessage yet receive shares of all the seats.
+func broadcastShares(
 	ctx context.Context,
 	memberID group.MemberIndex,
-	share *bn256.G1,
+	shares map[group.MemberIndex]*bn256.G1,
 	channel net.BroadcastChannel,
+	separateShares bool,
 ) {
-	message := &SignatureShareMessage{
-		memberID,
-		share.Marshal(),
+	messages := make([]*SignatureShareMessage, 0, len(shares))
+	for seatID, share := range shares {
+		messages = append(messages, &SignatureShareMessage{
+			seatID,
+			share.Marshal(),
+		})
 	}
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].senderID < messages[j].senderID
+	})
 
-	if err := channel.Send(ctx, message); err != nil {
+	err := channel.Send(ctx, &SignatureSharesMessage{messages})
+	if err != nil {
 		logger.Errorf(
-			"[member:%v] could not send signature share: [%v]",
+			"[member:%v] could not send signature shares: [%v]",
 			memberID,
 			err,
 		)
 	}
+
+	if !separateShares {
+		return
+	}
+
+	for _, message := range messages {
+		if err := channel.Send(ctx, message); err != nil {
+			logger.Errorf(
+				"[member:%v] could not send signature share of seat [%v]: [%v]",
+				memberID,
+				message.senderID,
+				err,
+			)
+		}
+	}
 }
 
-func extractAndValidateShare(
+func extractShare(
 	message *SignatureShareMessage,
 	groupPublicKeyShares map[group.MemberIndex]*bn256.G2,
-	previousEntry *bn256.G1,
 ) (*bn256.G1, error) {
 	share := new(bn256.G1)
 	_, err := share.Unmarshal(message.shareBytes)
@@ -195,19 +383,80 @@ func extractAndValidateShare(
 		)
 	}
 
-	publicKeyShare, ok := groupPublicKeyShares[message.senderID]
-	if !ok {
+	if _, ok := groupPublicKeyShares[message.senderID]; !ok {
 		return nil, fmt.Errorf(
 			"could not validate signature share; " +
 				"group public key share for sender not found",
 		)
 	}
 
-	if !bls.VerifyG1(publicKeyShare, previousEntry, share) {
-		return nil, fmt.Errorf("invalid signature share")
+	return share, nil
+}
+
+// validateShares validates the received signature shares against group
+// public key shares of their senders in a single batch, see
+// bls.BatchVerifyG1, and adds the valid ones to validShares.
+func validateShares(
+	signer *dkg.ThresholdSigner,
+	shares map[group.MemberIndex]*bn256.G1,
+	previousEntry *bn256.G1,
+	validShares map[group.MemberIndex]*bn256.G1,
+) {
+	groupPublicKeyShares := signer.GroupPublicKeyShares()
+
+	senders := make([]group.MemberIndex, 0, len(shares))
+	publicKeyShares := make([]*bn256.G2, 0, len(shares))
+	signatureShares := make([]*bn256.G1, 0, len(shares))
+	for senderID, share := range shares {
+		senders = append(senders, senderID)
+		publicKeyShares = append(publicKeyShares, groupPublicKeyShares[senderID])
+		signatureShares = append(signatureShares, share)
 	}
 
-	return share, nil
+	invalidShares := make(map[group.MemberIndex]bool)
+	invalidPositions, err := bls.BatchVerifyG1(
+		publicKeyShares,
+		previousEntry,
+		signatureShares,
+	)
+	if err != nil {
+		logger.Warningf(
+			"[member:%v] could not validate signature shares in batch; "+
+				"validating each share separately: [%v]",
+			signer.MemberID(),
+			err,
+		)
+
+		for i, senderID := range senders {
+			if !bls.VerifyG1(publicKeyShares[i], previousEntry, signatureShares[i]) {
+				invalidShares[senderID] = true
+			}
+		}
+	} else {
+		for _, position := range invalidPositions {
+			invalidShares[senders[position]] = true
+		}
+	}
+
+	for _, senderID := range senders {
+		if invalidShares[senderID] {
+			logger.Warningf(
+				"[member:%v] rejecting signature share from "+
+					"member [%v]: [invalid signature share]",
+				signer.MemberID(),
+				senderID,
+			)
+			continue
+		}
+
+		logger.Debugf(
+			"[member:%v] accepting signature share from member [%v]",
+			signer.MemberID(),
+			senderID,
+		)
+
+		validShares[senderID] = shares[senderID]
+	}
 }
 
 func completeSignature(
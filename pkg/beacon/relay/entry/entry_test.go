@@ -0,0 +1,177 @@
+package entry
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/net"
+	netLocal "github.com/keep-network/keep-core/pkg/net/local"
+)
+
+func TestBroadcastShares(t *testing.T) {
+	var tests = map[string]struct {
+		seats          []group.MemberIndex
+		separateShares bool
+	}{
+		"single seat": {
+			seats: []group.MemberIndex{2},
+		},
+		"multiple seats": {
+			seats: []group.MemberIndex{1, 3, 4},
+		},
+		"multiple seats with separate shares": {
+			seats:          []group.MemberIndex{1, 3, 4},
+			separateShares: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			channel, err := netLocal.Connect().BroadcastChannelFor(
+				fmt.Sprintf("broadcast-shares-test-%v", testName),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			RegisterUnmarshallers(channel)
+
+			ctx, cancelCtx := context.WithTimeout(
+				context.Background(),
+				5*time.Second,
+			)
+			defer cancelCtx()
+
+			received := make(chan net.Message, 16)
+			channel.Recv(ctx, func(message net.Message) {
+				received <- message
+			})
+
+			shares := make(map[group.MemberIndex]*bn256.G1)
+			for _, seat := range test.seats {
+				shares[seat] = new(bn256.G1).ScalarBaseMult(
+					big.NewInt(int64(seat)),
+				)
+			}
+
+			broadcastShares(
+				ctx,
+				test.seats[0],
+				shares,
+				channel,
+				test.separateShares,
+			)
+
+			expectedSeparateSenders := 0
+			if test.separateShares {
+				expectedSeparateSenders = len(test.seats)
+			}
+
+			// Shares of all the seats are sent together and, if required,
+			// in separate messages for clients not supporting
+			// SignatureSharesMessage.
+			separateSenders := make(map[group.MemberIndex]bool)
+			var combinedSenders []group.MemberIndex
+			for len(separateSenders) < expectedSeparateSenders ||
+				len(combinedSenders) < len(test.seats) {
+				select {
+				case message := <-received:
+					switch payload := message.Payload().(type) {
+					case *SignatureShareMessage:
+						separateSenders[payload.SenderID()] = true
+					case *SignatureSharesMessage:
+						combinedSenders = nil
+						for _, share := range payload.Shares() {
+							combinedSenders = append(
+								combinedSenders,
+								share.SenderID(),
+							)
+						}
+					}
+				case <-ctx.Done():
+					t.Fatalf(
+						"not all messages received\n"+
+							"separate share senders: [%v]\n"+
+							"combined share senders: [%v]",
+						separateSenders,
+						combinedSenders,
+					)
+				}
+			}
+
+			if !reflect.DeepEqual(test.seats, combinedSenders) {
+				t.Errorf(
+					"unexpected combined share senders\n"+
+						"expected: [%v]\nactual:   [%v]",
+					test.seats,
+					combinedSenders,
+				)
+			}
+
+			// Give separate messages time to arrive if they were sent.
+			time.Sleep(50 * time.Millisecond)
+		drain:
+			for {
+				select {
+				case message := <-received:
+					if payload, ok :=
+						message.Payload().(*SignatureShareMessage); ok {
+						separateSenders[payload.SenderID()] = true
+					}
+				default:
+					break drain
+				}
+			}
+
+			if len(separateSenders) != expectedSeparateSenders {
+				t.Errorf(
+					"unexpected separate share senders\n"+
+						"expected: [%v]\nactual:   [%v]",
+					expectedSeparateSenders,
+					separateSenders,
+				)
+			}
+		})
+	}
+}
+
+func TestSharesSupport(t *testing.T) {
+	registry := &sharesSupportRegistry{
+		groups: make(map[string]*sharesSupport),
+	}
+
+	if !registry.requiresSeparateShares("group") {
+		t.Errorf("separate shares should be required in an unknown group")
+	}
+
+	// Member 2 sends its share in both messages, as clients supporting
+	// SignatureSharesMessage do when separate shares are required.
+	registry.record("group", 2, false)
+	registry.record("group", 2, true)
+	registry.record("group", 3, true)
+	registry.record("group", 2, false)
+
+	if registry.requiresSeparateShares("group") {
+		t.Errorf(
+			"separate shares should not be required if all members " +
+				"support SignatureSharesMessage",
+		)
+	}
+
+	registry.record("group", 4, false)
+
+	if !registry.requiresSeparateShares("group") {
+		t.Errorf(
+			"separate shares should be required if any member does " +
+				"not support SignatureSharesMessage",
+		)
+	}
+	if !registry.requiresSeparateShares("another group") {
+		t.Errorf("separate shares should be required in an unknown group")
+	}
+}
@@ -76,25 +76,71 @@ func (m *SignatureShare) GetShare() []byte {
 	return nil
 }
 
+type SignatureShares struct {
+	Shares []*SignatureShare `protobuf:"bytes,1,rep,name=shares,proto3" json:"shares,omitempty"`
+}
+
+func (m *SignatureShares) Reset()      { *m = SignatureShares{} }
+func (*SignatureShares) ProtoMessage() {}
+func (*SignatureShares) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8447775385e7eb85, []int{1}
+}
+func (m *SignatureShares) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SignatureShares) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SignatureShares.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SignatureShares) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignatureShares.Merge(m, src)
+}
+func (m *SignatureShares) XXX_Size() int {
+	return m.Size()
+}
+func (m *SignatureShares) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignatureShares.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignatureShares proto.InternalMessageInfo
+
+func (m *SignatureShares) GetShares() []*SignatureShare {
+	if m != nil {
+		return m.Shares
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*SignatureShare)(nil), "entry.SignatureShare")
+	proto.RegisterType((*SignatureShares)(nil), "entry.SignatureShares")
 }
 
 func init() { proto.RegisterFile("pb/message.proto", fileDescriptor_8447775385e7eb85) }
 
 var fileDescriptor_8447775385e7eb85 = []byte{
-	// 170 bytes of a gzipped FileDescriptorProto
+	// 198 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x28, 0x48, 0xd2, 0xcf,
 	0x4d, 0x2d, 0x2e, 0x4e, 0x4c, 0x4f, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x4d, 0xcd,
 	0x2b, 0x29, 0xaa, 0x54, 0x72, 0xe2, 0xe2, 0x0b, 0xce, 0x4c, 0xcf, 0x4b, 0x2c, 0x29, 0x2d, 0x4a,
 	0x0d, 0xce, 0x48, 0x2c, 0x4a, 0x15, 0x92, 0xe2, 0xe2, 0x28, 0x4e, 0xcd, 0x4b, 0x49, 0x2d, 0xf2,
 	0x74, 0x91, 0x60, 0x54, 0x60, 0xd4, 0xe0, 0x0d, 0x82, 0xf3, 0x85, 0x44, 0xb8, 0x58, 0x8b, 0x41,
-	0x8a, 0x24, 0x98, 0x14, 0x18, 0x35, 0x78, 0x82, 0x20, 0x1c, 0x27, 0x8b, 0x0b, 0x0f, 0xe5, 0x18,
-	0x6e, 0x3c, 0x94, 0x63, 0xf8, 0xf0, 0x50, 0x8e, 0xb1, 0xe1, 0x91, 0x1c, 0xe3, 0x8a, 0x47, 0x72,
-	0x8c, 0x27, 0x1e, 0xc9, 0x31, 0x5e, 0x78, 0x24, 0xc7, 0xf8, 0xe0, 0x91, 0x1c, 0xe3, 0x8b, 0x47,
-	0x72, 0x0c, 0x1f, 0x1e, 0xc9, 0x31, 0x4e, 0x78, 0x2c, 0xc7, 0x70, 0xe1, 0xb1, 0x1c, 0xc3, 0x8d,
-	0xc7, 0x72, 0x0c, 0x51, 0x4c, 0x05, 0x49, 0x49, 0x6c, 0x60, 0xb7, 0x18, 0x03, 0x02, 0x00, 0x00,
-	0xff, 0xff, 0xf7, 0xfa, 0x98, 0xd4, 0x9f, 0x00, 0x00, 0x00,
+	0x8a, 0x24, 0x98, 0x14, 0x18, 0x35, 0x78, 0x82, 0x20, 0x1c, 0x25, 0x07, 0x2e, 0x7e, 0x54, 0x33,
+	0x8a, 0x85, 0x74, 0xb9, 0xd8, 0xc0, 0x72, 0xc5, 0x12, 0x8c, 0x0a, 0xcc, 0x1a, 0xdc, 0x46, 0xa2,
+	0x7a, 0x60, 0xeb, 0xf4, 0x50, 0xd5, 0x05, 0x41, 0x15, 0x39, 0x59, 0x5c, 0x78, 0x28, 0xc7, 0x70,
+	0xe3, 0xa1, 0x1c, 0xc3, 0x87, 0x87, 0x72, 0x8c, 0x0d, 0x8f, 0xe4, 0x18, 0x57, 0x3c, 0x92, 0x63,
+	0x3c, 0xf1, 0x48, 0x8e, 0xf1, 0xc2, 0x23, 0x39, 0xc6, 0x07, 0x8f, 0xe4, 0x18, 0x5f, 0x3c, 0x92,
+	0x63, 0xf8, 0xf0, 0x48, 0x8e, 0x71, 0xc2, 0x63, 0x39, 0x86, 0x0b, 0x8f, 0xe5, 0x18, 0x6e, 0x3c,
+	0x96, 0x63, 0x88, 0x62, 0x2a, 0x48, 0x4a, 0x62, 0x03, 0xfb, 0xc6, 0x18, 0x30, 0x00, 0x36, 0xff,
+	0xf6, 0xd4, 0xe1, 0x00, 0x00, 0x00,
 }
 
 func (this *SignatureShare) Equal(that interface{}) bool {
@@ -124,6 +170,35 @@ func (this *SignatureShare) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *SignatureShares) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*SignatureShares)
+	if !ok {
+		that2, ok := that.(SignatureShares)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Shares) != len(that1.Shares) {
+		return false
+	}
+	for i := range this.Shares {
+		if !this.Shares[i].Equal(that1.Shares[i]) {
+			return false
+		}
+	}
+	return true
+}
 func (this *SignatureShare) GoString() string {
 	if this == nil {
 		return "nil"
@@ -135,6 +210,18 @@ func (this *SignatureShare) GoString() string {
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
+func (this *SignatureShares) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.SignatureShares{")
+	if this.Shares != nil {
+		s = append(s, "Shares: "+fmt.Sprintf("%#v", this.Shares)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
 func valueToGoStringMessage(v interface{}, typ string) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -178,6 +265,43 @@ func (m *SignatureShare) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *SignatureShares) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SignatureShares) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SignatureShares) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Shares) > 0 {
+		for iNdEx := len(m.Shares) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Shares[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintMessage(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintMessage(dAtA []byte, offset int, v uint64) int {
 	offset -= sovMessage(v)
 	base := offset
@@ -205,6 +329,21 @@ func (m *SignatureShare) Size() (n int) {
 	return n
 }
 
+func (m *SignatureShares) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Shares) > 0 {
+		for _, e := range m.Shares {
+			l = e.Size()
+			n += 1 + l + sovMessage(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovMessage(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -222,6 +361,21 @@ func (this *SignatureShare) String() string {
 	}, "")
 	return s
 }
+func (this *SignatureShares) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForShares := "[]*SignatureShare{"
+	for _, f := range this.Shares {
+		repeatedStringForShares += strings.Replace(f.String(), "SignatureShare", "SignatureShare", 1) + ","
+	}
+	repeatedStringForShares += "}"
+	s := strings.Join([]string{`&SignatureShares{`,
+		`Shares:` + repeatedStringForShares + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func valueToStringMessage(v interface{}) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -336,6 +490,93 @@ func (m *SignatureShare) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *SignatureShares) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SignatureShares: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SignatureShares: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shares", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Shares = append(m.Shares, &SignatureShare{})
+			if err := m.Shares[len(m.Shares)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipMessage(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -52,3 +52,61 @@ func (ssm *SignatureShareMessage) Unmarshal(bytes []byte) error {
 
 	return nil
 }
+
+// Type returns a string describing a SignatureSharesMessage's type.
+func (*SignatureSharesMessage) Type() string {
+	return "relay/signature/shares"
+}
+
+// Marshal converts this SignatureSharesMessage to a byte array suitable for
+// network communication.
+func (ssm *SignatureSharesMessage) Marshal() ([]byte, error) {
+	pbShares := make([]*pb.SignatureShare, len(ssm.shares))
+	for i, share := range ssm.shares {
+		pbShares[i] = &pb.SignatureShare{
+			SenderID: uint32(share.senderID),
+			Share:    share.shareBytes,
+		}
+	}
+
+	pbSignatureShares := pb.SignatureShares{
+		Shares: pbShares,
+	}
+
+	return pbSignatureShares.Marshal()
+}
+
+// Unmarshal converts a byte array produced by Marshal to a
+// SignatureSharesMessage. Messages carrying more than one share for the same
+// member are rejected.
+func (ssm *SignatureSharesMessage) Unmarshal(bytes []byte) error {
+	pbSignatureShares := pb.SignatureShares{}
+	err := pbSignatureShares.Unmarshal(bytes)
+	if err != nil {
+		return err
+	}
+
+	senders := make(map[uint32]bool)
+	shares := make([]*SignatureShareMessage, len(pbSignatureShares.Shares))
+	for i, pbShare := range pbSignatureShares.Shares {
+		if err := validateMemberIndex(pbShare.SenderID); err != nil {
+			return err
+		}
+
+		if senders[pbShare.SenderID] {
+			return fmt.Errorf(
+				"duplicate signature share for member [%v]",
+				pbShare.SenderID,
+			)
+		}
+		senders[pbShare.SenderID] = true
+
+		shares[i] = &SignatureShareMessage{
+			senderID:   group.MemberIndex(pbShare.SenderID),
+			shareBytes: pbShare.Share,
+		}
+	}
+	ssm.shares = shares
+
+	return nil
+}
@@ -54,3 +54,89 @@ func TestFuzzSignatureShareMessageRoundtrip(t *testing.T) {
 func TestFuzzSignatureShareMessageUnmarshaler(t *testing.T) {
 	pbutils.FuzzUnmarshaler(&SignatureShareMessage{})
 }
+
+func TestSignatureSharesMessageRoundTrip(t *testing.T) {
+	msg := &SignatureSharesMessage{
+		[]*SignatureShareMessage{
+			{3, []byte{1, 2, 3}},
+			{7, []byte{4, 5, 6}},
+		},
+	}
+	unmarshaled := &SignatureSharesMessage{}
+
+	err := pbutils.RoundTrip(msg, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(msg.shares) != len(unmarshaled.shares) {
+		t.Fatalf(
+			"unexpected number of shares\nexpected: [%v]\nactual:   [%v]",
+			len(msg.shares),
+			len(unmarshaled.shares),
+		)
+	}
+
+	for i, share := range msg.shares {
+		if share.senderID != unmarshaled.shares[i].senderID {
+			t.Errorf(
+				"unexpected sender ID\nexpected: [%v]\nactual:   [%v]",
+				share.senderID,
+				unmarshaled.shares[i].senderID,
+			)
+		}
+
+		testutils.AssertBytesEqual(
+			t,
+			share.shareBytes,
+			unmarshaled.shares[i].shareBytes,
+		)
+	}
+}
+
+func TestSignatureSharesMessageDuplicateSender(t *testing.T) {
+	msg := &SignatureSharesMessage{
+		[]*SignatureShareMessage{
+			{3, []byte{1, 2, 3}},
+			{3, []byte{4, 5, 6}},
+		},
+	}
+
+	err := pbutils.RoundTrip(msg, &SignatureSharesMessage{})
+
+	expectedError := "duplicate signature share for member [3]"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedError,
+			err,
+		)
+	}
+}
+
+func TestFuzzSignatureSharesMessageRoundtrip(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var (
+			senderIDs  []group.MemberIndex
+			shareBytes []byte
+		)
+
+		f := fuzz.New().NilChance(0.1).NumElements(0, 512)
+
+		f.Fuzz(&senderIDs)
+		f.Fuzz(&shareBytes)
+
+		shares := make([]*SignatureShareMessage, len(senderIDs))
+		for j, senderID := range senderIDs {
+			shares[j] = &SignatureShareMessage{senderID, shareBytes}
+		}
+
+		message := &SignatureSharesMessage{shares}
+
+		_ = pbutils.RoundTrip(message, &SignatureSharesMessage{})
+	}
+}
+
+func TestFuzzSignatureSharesMessageUnmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&SignatureSharesMessage{})
+}
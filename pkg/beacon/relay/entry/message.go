@@ -22,3 +22,22 @@ func NewSignatureShareMessage(
 func (ssm *SignatureShareMessage) SenderID() group.MemberIndex {
 	return ssm.senderID
 }
+
+// SignatureSharesMessage is a message payload that carries signature shares
+// of all the sender's seats in the group for the given message. It is sent
+// instead of a separate SignatureShareMessage for each seat, also when the
+// sender controls a single member of the group.
+type SignatureSharesMessage struct {
+	shares []*SignatureShareMessage
+}
+
+func NewSignatureSharesMessage(
+	shares []*SignatureShareMessage,
+) *SignatureSharesMessage {
+	return &SignatureSharesMessage{shares}
+}
+
+// Shares returns signature shares of all members carried by the message.
+func (ssm *SignatureSharesMessage) Shares() []*SignatureShareMessage {
+	return ssm.shares
+}
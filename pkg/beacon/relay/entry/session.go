@@ -0,0 +1,166 @@
+package entry
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/internal/persistenceutils"
+)
+
+// sessionDirectoryPrefix is the prefix of names of persistence directories
+// in which relay entry signing sessions are stored. Each session is stored
+// in a separate directory so that it can be archived once the session
+// completes.
+const sessionDirectoryPrefix = "entry_session_"
+
+// Session is the state of a relay entry signing session executed by the node
+// for all its seats in the signing group. The session is persisted whenever
+// new valid signature shares are collected so that it can be resumed if the
+// client is restarted before the relay entry is submitted.
+type Session struct {
+	GroupPublicKey   []byte `json:"groupPublicKey"`
+	PreviousEntry    []byte `json:"previousEntry"`
+	StartBlockHeight uint64 `json:"startBlockHeight"`
+
+	// ValidShares are marshalled signature shares validated against group
+	// public key shares of their senders, including shares of the seats.
+	ValidShares map[group.MemberIndex][]byte `json:"validShares"`
+
+	// Signature is the marshalled signature restored from the valid shares.
+	// It is set once the honest threshold of valid shares is collected and
+	// the session only waits for its turn to submit the relay entry.
+	Signature []byte `json:"signature,omitempty"`
+}
+
+func newSession(
+	groupPublicKey []byte,
+	previousEntry []byte,
+	startBlockHeight uint64,
+	validShares map[group.MemberIndex]*bn256.G1,
+	signature *bn256.G1,
+) *Session {
+	session := &Session{
+		GroupPublicKey:   groupPublicKey,
+		PreviousEntry:    previousEntry,
+		StartBlockHeight: startBlockHeight,
+		ValidShares:      make(map[group.MemberIndex][]byte),
+	}
+
+	for memberIndex, share := range validShares {
+		session.ValidShares[memberIndex] = share.Marshal()
+	}
+
+	if signature != nil {
+		session.Signature = signature.Marshal()
+	}
+
+	return session
+}
+
+// restoreShares unmarshals valid signature shares of the session. Shares
+// which could not be unmarshalled are skipped.
+func (s *Session) restoreShares() map[group.MemberIndex]*bn256.G1 {
+	shares := make(map[group.MemberIndex]*bn256.G1)
+	for memberIndex, shareBytes := range s.ValidShares {
+		share := new(bn256.G1)
+		if _, err := share.Unmarshal(shareBytes); err != nil {
+			logger.Warningf(
+				"could not restore signature share of member [%v]: [%v]",
+				memberIndex,
+				err,
+			)
+			continue
+		}
+
+		shares[memberIndex] = share
+	}
+
+	return shares
+}
+
+// restoreSignature unmarshals the signature of the session. It returns nil
+// if the signature has not been restored before the session was persisted
+// or could not be unmarshalled.
+func (s *Session) restoreSignature() *bn256.G1 {
+	if len(s.Signature) == 0 {
+		return nil
+	}
+
+	signature := new(bn256.G1)
+	if _, err := signature.Unmarshal(s.Signature); err != nil {
+		logger.Warningf("could not restore signature: [%v]", err)
+		return nil
+	}
+
+	return signature
+}
+
+// SaveSession stores the given relay entry signing session with the
+// persistence handle.
+//
+// Each state of the session is stored in a separate file named after the
+// number of valid signature shares, so that a state which could not be
+// completely saved does not replace the previous one. The file with the
+// restored signature is named so that it follows the file with the same
+// number of shares.
+func SaveSession(handle persistence.Handle, session *Session) error {
+	sessionBytes, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("could not marshal signing session: [%v]", err)
+	}
+
+	name := fmt.Sprintf("shares_%03d", len(session.ValidShares))
+	if len(session.Signature) > 0 {
+		name += "_signed"
+	}
+
+	return handle.Save(
+		sessionBytes,
+		sessionDirectory(session.PreviousEntry),
+		name,
+	)
+}
+
+// ArchiveSession archives the relay entry signing session for the given
+// previous entry so that it is no longer read.
+func ArchiveSession(handle persistence.Handle, previousEntry []byte) error {
+	return handle.Archive(sessionDirectory(previousEntry))
+}
+
+// ReadSessions reads the latest state of each relay entry signing session
+// stored with the persistence handle. States which could not be read, for
+// example because the client crashed while saving them, are skipped.
+// Returned sessions are ordered by the start block height.
+func ReadSessions(handle persistence.Handle) []*Session {
+	latestSessions := persistenceutils.ReadLatest(
+		handle,
+		sessionDirectoryPrefix,
+		func(content []byte) (interface{}, error) {
+			session := &Session{}
+			if err := json.Unmarshal(content, session); err != nil {
+				return nil, err
+			}
+			return session, nil
+		},
+	)
+
+	sessions := make([]*Session, 0, len(latestSessions))
+	for _, session := range latestSessions {
+		sessions = append(sessions, session.(*Session))
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].StartBlockHeight < sessions[j].StartBlockHeight
+	})
+
+	return sessions
+}
+
+func sessionDirectory(previousEntry []byte) string {
+	return sessionDirectoryPrefix + hex.EncodeToString(previousEntry)
+}
@@ -0,0 +1,290 @@
+package entry
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/bls"
+	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
+	netLocal "github.com/keep-network/keep-core/pkg/net/local"
+)
+
+func TestSaveReadAndArchiveSessions(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "session_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	diskHandle, err := persistence.NewDiskHandle(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handle := persistence.NewEncryptedPersistence(diskHandle, "password")
+
+	// Data other than sessions stored with the same handle.
+	if err := handle.Save([]byte{0x01}, "group", "membership_1"); err != nil {
+		t.Fatal(err)
+	}
+
+	newTestSession := func(
+		previousEntry byte,
+		startBlockHeight uint64,
+		sharesCount int,
+		signed bool,
+	) *Session {
+		session := &Session{
+			GroupPublicKey:   []byte{0xaa},
+			PreviousEntry:    []byte{previousEntry},
+			StartBlockHeight: startBlockHeight,
+			ValidShares:      make(map[group.MemberIndex][]byte),
+		}
+		for i := 1; i <= sharesCount; i++ {
+			session.ValidShares[group.MemberIndex(i)] = []byte{byte(i)}
+		}
+		if signed {
+			session.Signature = []byte{0xff}
+		}
+		return session
+	}
+
+	session1 := newTestSession(0x01, 20, 3, true)
+	session2 := newTestSession(0x02, 10, 2, false)
+
+	for _, session := range []*Session{
+		newTestSession(0x01, 20, 1, false),
+		newTestSession(0x01, 20, 3, false),
+		session1,
+		session2,
+	} {
+		if err := SaveSession(handle, session); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Session state which has not been completely saved.
+	if err := diskHandle.Save(
+		[]byte{0x01, 0x02},
+		sessionDirectory([]byte{0x02}),
+		"shares_003",
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*Session{session2, session1}
+	sessions := ReadSessions(handle)
+	if !reflect.DeepEqual(expected, sessions) {
+		t.Fatalf(
+			"unexpected sessions\nexpected: [%+v]\nactual:   [%+v]",
+			expected,
+			sessions,
+		)
+	}
+
+	if err := ArchiveSession(handle, []byte{0x01}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected = []*Session{session2}
+	sessions = ReadSessions(handle)
+	if !reflect.DeepEqual(expected, sessions) {
+		t.Fatalf(
+			"unexpected sessions after archiving\n"+
+				"expected: [%+v]\nactual:   [%+v]",
+			expected,
+			sessions,
+		)
+	}
+}
+
+func TestSignAndSubmitResumedSession(t *testing.T) {
+	honestThreshold := 2
+	previousEntry := new(bn256.G1).ScalarBaseMult(big.NewInt(1337))
+
+	signers, groupPublicKey, err := generateThresholdSigners(3, honestThreshold)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	groupSignature := func() *bn256.G1 {
+		shares := make([]*bls.SignatureShare, 0)
+		for _, signer := range signers[:honestThreshold] {
+			shares = append(shares, &bls.SignatureShare{
+				I: int(signer.MemberID()),
+				V: signer.CalculateSignatureShare(previousEntry),
+			})
+		}
+		signature, err := bls.RecoverSignature(shares, honestThreshold)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signature
+	}()
+
+	var tests = map[string]struct {
+		// Valid shares of the session in addition to the share of member 1
+		// executing the session.
+		sessionShares    []group.MemberIndex
+		sessionSignature *bn256.G1
+	}{
+		"signature restored": {
+			sessionShares:    []group.MemberIndex{2},
+			sessionSignature: groupSignature,
+		},
+		"honest threshold of shares collected": {
+			sessionShares: []group.MemberIndex{2},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			chain := chainLocal.Connect(3, honestThreshold, big.NewInt(20))
+			blockCounter, err := chain.BlockCounter()
+			if err != nil {
+				t.Fatal(err)
+			}
+			startBlockHeight, err := blockCounter.CurrentBlock()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// No other members send their shares to the channel so the
+			// signature could be restored only from the session.
+			channel, err := netLocal.Connect().BroadcastChannelFor(
+				fmt.Sprintf("session-test-%v", testName),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			RegisterUnmarshallers(channel)
+
+			validShares := make(map[group.MemberIndex]*bn256.G1)
+			for _, memberIndex := range test.sessionShares {
+				validShares[memberIndex] =
+					signers[memberIndex-1].CalculateSignatureShare(previousEntry)
+			}
+			session := newSession(
+				signers[0].GroupPublicKeyBytes(),
+				previousEntry.Marshal(),
+				startBlockHeight,
+				validShares,
+				test.sessionSignature,
+			)
+
+			var updatedSessions []*Session
+			ctx, cancelCtx := context.WithTimeout(
+				context.Background(),
+				10*time.Second,
+			)
+			defer cancelCtx()
+
+			err = SignAndSubmit(
+				ctx,
+				blockCounter,
+				channel,
+				chain.ThresholdRelay(),
+				previousEntry.Marshal(),
+				honestThreshold,
+				signers[:1],
+				nil,
+				startBlockHeight,
+				session,
+				func(session *Session) {
+					updatedSessions = append(updatedSessions, session)
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			entry := new(bn256.G1)
+			if _, err := entry.Unmarshal(chain.GetLastRelayEntry()); err != nil {
+				t.Fatal(err)
+			}
+			if !bls.VerifyG1(groupPublicKey, previousEntry, entry) {
+				t.Errorf("submitted relay entry is not a valid signature")
+			}
+
+			if test.sessionSignature != nil {
+				if len(updatedSessions) != 0 {
+					t.Errorf(
+						"resumed session with signature should not be updated",
+					)
+				}
+				return
+			}
+
+			if len(updatedSessions) == 0 {
+				t.Fatal("session with restored signature should be saved")
+			}
+			lastSession := updatedSessions[len(updatedSessions)-1]
+			if !bytes.Equal(lastSession.Signature, entry.Marshal()) {
+				t.Errorf(
+					"unexpected signature of the saved session\n"+
+						"expected: [%x]\nactual:   [%x]",
+					entry.Marshal(),
+					lastSession.Signature,
+				)
+			}
+		})
+	}
+}
+
+// generateThresholdSigners creates signers of a group with the given size and
+// honest threshold using Shamir's secret sharing of a random group private
+// key.
+func generateThresholdSigners(groupSize, honestThreshold int) (
+	[]*dkg.ThresholdSigner,
+	*bn256.G2,
+	error,
+) {
+	coefficients := make([]*big.Int, honestThreshold)
+	for i := range coefficients {
+		coefficient, err := rand.Int(rand.Reader, bn256.Order)
+		if err != nil {
+			return nil, nil, err
+		}
+		coefficients[i] = coefficient
+	}
+
+	privateKeyShares := make(map[group.MemberIndex]*big.Int)
+	publicKeyShares := make(map[group.MemberIndex]*bn256.G2)
+	for i := 1; i <= groupSize; i++ {
+		share := big.NewInt(0)
+		for k := len(coefficients) - 1; k >= 0; k-- {
+			share.Mul(share, big.NewInt(int64(i)))
+			share.Add(share, coefficients[k])
+			share.Mod(share, bn256.Order)
+		}
+
+		privateKeyShares[group.MemberIndex(i)] = share
+		publicKeyShares[group.MemberIndex(i)] = new(bn256.G2).ScalarBaseMult(share)
+	}
+
+	groupPublicKey := new(bn256.G2).ScalarBaseMult(coefficients[0])
+
+	signers := make([]*dkg.ThresholdSigner, groupSize)
+	for i := range signers {
+		memberIndex := group.MemberIndex(i + 1)
+		signers[i] = dkg.NewThresholdSigner(
+			memberIndex,
+			groupPublicKey,
+			privateKeyShares[memberIndex],
+			publicKeyShares,
+		)
+	}
+
+	return signers, groupPublicKey, nil
+}
@@ -1,6 +1,7 @@
 package entry
 
 import (
+	"context"
 	"fmt"
 
 	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
@@ -21,8 +22,9 @@ type relayEntrySubmitter struct {
 // Group member with index 1 tries to submit as the first one, group member 2
 // tries to submit after a few blocks if member 1 did not submit and so on.
 // Relay entry submit process starts at block height defined by startBlockheight
-// parameter.
+// parameter. Submission is abandoned when the provided context is done.
 func (res *relayEntrySubmitter) submitRelayEntry(
+	ctx context.Context,
 	newEntry []byte,
 	groupPublicKey []byte,
 	startBlockHeight uint64,
@@ -49,83 +51,99 @@ func (res *relayEntrySubmitter) submitRelayEntry(
 		return fmt.Errorf("wait for eligibility failure: [%v]", err)
 	}
 
-	for {
-		select {
-		case blockNumber := <-eligibleToSubmitWaiter:
-			// Member becomes eligible to submit the result.
-			errorChannel := make(chan error)
-			defer close(errorChannel)
-
-			logger.Infof(
-				"[member:%v] submitting relay entry [0x%x] on behalf of group "+
-					"[0x%x] at block [%v]",
-				res.index,
-				newEntry,
-				groupPublicKey,
-				blockNumber,
-			)
-
-			res.chain.SubmitRelayEntry(newEntry).OnComplete(
-				func(entry *event.EntrySubmitted, err error) {
-					if err == nil {
-						logger.Infof(
-							"[member:%v] successfully submitted "+
-								"relay entry at block: [%v]",
-							res.index,
-							entry.BlockNumber,
-						)
-					}
-					errorChannel <- err
-				})
-
-			entryErr := <-errorChannel
-
-			if entryErr != nil {
-				isEntryInProgress, err := res.chain.IsEntryInProgress()
-				if err != nil {
-					logger.Errorf(
-						"[member:%v] could not check entry status after "+
-							"relay entry submission error: [%v]; "+
-							"original error will be returned",
-						res.index,
-						err,
-					)
-					return entryErr
-				}
+	select {
+	case blockNumber := <-eligibleToSubmitWaiter:
+		// Member becomes eligible to submit the result.
+		errorChannel := make(chan error, 1)
+
+		logger.Infof(
+			"[member:%v] submitting relay entry [0x%x] on behalf of group "+
+				"[0x%x] at block [%v]",
+			res.index,
+			newEntry,
+			groupPublicKey,
+			blockNumber,
+		)
 
-				// Check if we failed because someone else submitted in the
-				// meantime or because something wrong happened with
-				// our transaction.
-				if !isEntryInProgress {
+		res.chain.SubmitRelayEntry(newEntry).OnComplete(
+			func(entry *event.EntrySubmitted, err error) {
+				if err == nil {
 					logger.Infof(
-						"[member:%v] relay entry already submitted",
+						"[member:%v] successfully submitted "+
+							"relay entry at block: [%v]",
 						res.index,
+						entry.BlockNumber,
 					)
-					return nil
 				}
-			}
+				errorChannel <- err
+			})
 
-			return entryErr
-		case blockNumber := <-relayEntrySubmittedChannel:
+		entryErr := <-errorChannel
+		if entryErr == nil {
+			return nil
+		}
+
+		switch relayChain.SubmissionFailureOf(entryErr) {
+		case relayChain.AlreadySubmitted:
 			logger.Infof(
-				"[member:%v] leaving submitter; "+
-					"relay entry submitted by other member at block [%v]",
+				"[member:%v] relay entry already submitted",
 				res.index,
-				blockNumber,
 			)
 			return nil
-		case blockNumber := <-relayEntryTimeoutChannel:
-			return fmt.Errorf(
-				"relay entry timed out at block [%v]",
-				blockNumber,
-			)
+		case relayChain.TimedOut:
+			return fmt.Errorf("relay entry timed out: [%v]", entryErr)
+		case relayChain.UnknownFailure:
+			isEntryInProgress, err := res.chain.IsEntryInProgress()
+			if err != nil {
+				logger.Errorf(
+					"[member:%v] could not check entry status after "+
+						"relay entry submission error: [%v]; "+
+						"original error will be returned",
+					res.index,
+					err,
+				)
+				return entryErr
+			}
+
+			// Check if we failed because someone else submitted in the
+			// meantime or because something wrong happened with
+			// our transaction.
+			if !isEntryInProgress {
+				logger.Infof(
+					"[member:%v] relay entry already submitted",
+					res.index,
+				)
+				return nil
+			}
 		}
+
+		return entryErr
+	case blockNumber := <-relayEntrySubmittedChannel:
+		logger.Infof(
+			"[member:%v] leaving submitter; "+
+				"relay entry submitted by other member at block [%v]",
+			res.index,
+			blockNumber,
+		)
+		return nil
+	case blockNumber := <-relayEntryTimeoutChannel:
+		return fmt.Errorf(
+			"relay entry timed out at block [%v]",
+			blockNumber,
+		)
+	case <-ctx.Done():
+		return fmt.Errorf(
+			"relay entry submission cancelled: [%v]",
+			ctx.Err(),
+		)
 	}
 }
 
 // waitForSubmissionEligibility waits until the current member is eligible to
 // submit entry to the blockchain. First member is eligible to submit straight
 // away, each following member is eligible after pre-defined block step.
+// Eligibility is computed from the start block of the relay entry request, so
+// a session resumed after the eligibility block is eligible immediately.
 func (res *relayEntrySubmitter) waitForSubmissionEligibility(
 	startBlockHeight uint64,
 	blockStep uint64,
@@ -0,0 +1,76 @@
+package entry
+
+import (
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+)
+
+// groupsSharesSupport keeps track of members of each group, identified by
+// the name of the group broadcast channel, known to support
+// SignatureSharesMessage. Clients supporting SignatureSharesMessage always
+// send shares of their seats in it, even if they have a single seat, so
+// members sending only SignatureShareMessage are clients which do not support
+// it yet.
+var groupsSharesSupport = &sharesSupportRegistry{
+	groups: make(map[string]*sharesSupport),
+}
+
+type sharesSupportRegistry struct {
+	mutex  sync.Mutex
+	groups map[string]*sharesSupport
+}
+
+type sharesSupport struct {
+	supporting map[group.MemberIndex]bool
+	legacy     map[group.MemberIndex]bool
+}
+
+// record notes the member has sent its signature share in a message of the
+// given type.
+func (ssr *sharesSupportRegistry) record(
+	channelName string,
+	memberIndex group.MemberIndex,
+	isSharesMessage bool,
+) {
+	ssr.mutex.Lock()
+	defer ssr.mutex.Unlock()
+
+	support, ok := ssr.groups[channelName]
+	if !ok {
+		support = &sharesSupport{
+			supporting: make(map[group.MemberIndex]bool),
+			legacy:     make(map[group.MemberIndex]bool),
+		}
+		ssr.groups[channelName] = support
+	}
+
+	if isSharesMessage {
+		support.supporting[memberIndex] = true
+		delete(support.legacy, memberIndex)
+		return
+	}
+
+	if !support.supporting[memberIndex] {
+		support.legacy[memberIndex] = true
+	}
+}
+
+// requiresSeparateShares returns true if signature shares have to be sent
+// in a separate SignatureShareMessage for each seat so that all members of
+// the group can receive them. It is the case until shares of other members
+// are received in the group, since their clients are not known yet, and
+// whenever any member is known not to support SignatureSharesMessage.
+func (ssr *sharesSupportRegistry) requiresSeparateShares(
+	channelName string,
+) bool {
+	ssr.mutex.Lock()
+	defer ssr.mutex.Unlock()
+
+	support, ok := ssr.groups[channelName]
+	if !ok {
+		return true
+	}
+
+	return len(support.legacy) > 0
+}
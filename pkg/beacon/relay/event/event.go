@@ -10,7 +10,10 @@ import (
 // EntrySubmitted indicates that valid relay entry has been submitted to the
 // chain for the currently processed relay request. This event is intended to
 // be used by operators for tracking entry generation and submission progress.
+// Entry is the submitted relay entry; it is nil if the chain implementation
+// could not determine it.
 type EntrySubmitted struct {
+	Entry       []byte
 	BlockNumber uint64
 }
 
@@ -60,3 +63,23 @@ type DKGResultSubmission struct {
 
 	BlockNumber uint64
 }
+
+// GroupMemberRewardsWithdrawn represents an event of withdrawing rewards
+// accumulated by the operator as a member of the group with the given index.
+type GroupMemberRewardsWithdrawn struct {
+	Beneficiary []byte
+	Operator    []byte
+	Amount      *big.Int
+	GroupIndex  *big.Int
+
+	BlockNumber uint64
+}
+
+// Removed represents an event that has been already delivered to handlers and
+// then removed from the chain by a chain reorganization. Event is the removed
+// event, of the same type as delivered to handlers.
+type Removed struct {
+	Event interface{}
+
+	BlockNumber uint64
+}
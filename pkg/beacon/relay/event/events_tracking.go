@@ -1,6 +1,7 @@
 package event
 
 import (
+	"sort"
 	"sync"
 )
 
@@ -38,6 +39,15 @@ func (gst *GroupSelectionTrack) Remove(entry string) {
 	delete(gst.Data, entry)
 }
 
+// List returns all entries for which group selection is currently tracked,
+// in ascending order.
+func (gst *GroupSelectionTrack) List() []string {
+	gst.Mutex.Lock()
+	defer gst.Mutex.Unlock()
+
+	return sortedKeys(gst.Data)
+}
+
 // RelayRequestTrack is used to track requests for new entries after RelayEntryRequested
 // event is received. It is used to ensure that the process execution
 // is not duplicated, i.e. when the client receives the same event multiple times.
@@ -71,3 +81,22 @@ func (rrt *RelayRequestTrack) Remove(previousEntry string) {
 
 	delete(rrt.Data, previousEntry)
 }
+
+// List returns all previous entries for which a new relay entry is currently
+// tracked, in ascending order.
+func (rrt *RelayRequestTrack) List() []string {
+	rrt.Mutex.Lock()
+	defer rrt.Mutex.Unlock()
+
+	return sortedKeys(rrt.Data)
+}
+
+func sortedKeys(data map[string]bool) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
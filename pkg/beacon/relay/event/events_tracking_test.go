@@ -1,6 +1,7 @@
 package event
 
 import (
+	"reflect"
 	"sync"
 	"testing"
 )
@@ -142,3 +143,47 @@ func TestRelayRequestTrack_WhenEmpty(t *testing.T) {
 		t.Error("RelayEntryRequested event wasn't emitted before; should be added successfully")
 	}
 }
+
+func TestGroupSelectionTrackList(t *testing.T) {
+	gst := &GroupSelectionTrack{
+		Data:  make(map[string]bool),
+		Mutex: &sync.Mutex{},
+	}
+
+	gst.Add("0x67891")
+	gst.Add("0x12345")
+	gst.Add("0x54321")
+	gst.Remove("0x54321")
+
+	expected := []string{"0x12345", "0x67891"}
+	if !reflect.DeepEqual(expected, gst.List()) {
+		t.Errorf(
+			"unexpected entries\nexpected: %v\nactual:   %v",
+			expected,
+			gst.List(),
+		)
+	}
+}
+
+func TestRelayRequestTrackList(t *testing.T) {
+	rrt := &RelayRequestTrack{
+		Data:  make(map[string]bool),
+		Mutex: &sync.Mutex{},
+	}
+
+	if len(rrt.List()) != 0 {
+		t.Errorf("expected no previous entries")
+	}
+
+	rrt.Add("0x67891")
+	rrt.Add("0x12345")
+
+	expected := []string{"0x12345", "0x67891"}
+	if !reflect.DeepEqual(expected, rrt.List()) {
+		t.Errorf(
+			"unexpected previous entries\nexpected: %v\nactual:   %v",
+			expected,
+			rrt.List(),
+		)
+	}
+}
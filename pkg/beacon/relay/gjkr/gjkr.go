@@ -1,6 +1,7 @@
 package gjkr
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 
@@ -19,27 +20,36 @@ var logger = log.Logger("keep-gjkr")
 // message unmarshallers.
 // The channel needs to be fully initialized before Execute is called.
 func RegisterUnmarshallers(channel net.BroadcastChannel) {
-	channel.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
-		return &EphemeralPublicKeyMessage{}
-	})
-	channel.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
-		return &MemberCommitmentsMessage{}
-	})
-	channel.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
-		return &PeerSharesMessage{}
-	})
-	channel.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
-		return &SecretSharesAccusationsMessage{}
-	})
-	channel.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
-		return &MemberPublicKeySharePointsMessage{}
-	})
-	channel.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
-		return &PointsAccusationsMessage{}
-	})
-	channel.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
-		return &MisbehavedEphemeralKeysMessage{}
-	})
+	for _, unmarshaller := range messageUnmarshallers() {
+		channel.RegisterUnmarshaler(unmarshaller)
+	}
+}
+
+// messageUnmarshallers returns unmarshallers of all the DKG protocol messages.
+func messageUnmarshallers() []func() net.TaggedUnmarshaler {
+	return []func() net.TaggedUnmarshaler{
+		func() net.TaggedUnmarshaler {
+			return &EphemeralPublicKeyMessage{}
+		},
+		func() net.TaggedUnmarshaler {
+			return &MemberCommitmentsMessage{}
+		},
+		func() net.TaggedUnmarshaler {
+			return &PeerSharesMessage{}
+		},
+		func() net.TaggedUnmarshaler {
+			return &SecretSharesAccusationsMessage{}
+		},
+		func() net.TaggedUnmarshaler {
+			return &MemberPublicKeySharePointsMessage{}
+		},
+		func() net.TaggedUnmarshaler {
+			return &PointsAccusationsMessage{}
+		},
+		func() net.TaggedUnmarshaler {
+			return &MisbehavedEphemeralKeysMessage{}
+		},
+	}
 }
 
 // Execute runs the GJKR distributed key generation  protocol, given a
@@ -48,8 +58,16 @@ func RegisterUnmarshallers(channel net.BroadcastChannel) {
 // when DKG protocol should start.
 // If the generation is successful, it returns a threshold group member which
 // can participate in the signing group; if the generation fails, it returns an
-// error.
+// error. Execution is aborted when the provided context is done.
+//
+// If the transcript recorder is not nil, all messages sent and received by
+// the member are recorded with it, see TranscriptRecorder.
+//
+// If the seats are not nil, the member executes the protocol along with other
+// seats of the same operator, sharing the work with them, see Seats. Each
+// seat executes the protocol with a separate call of this function.
 func Execute(
+	ctx context.Context,
 	memberIndex group.MemberIndex,
 	groupSize int,
 	blockCounter chain.BlockCounter,
@@ -58,6 +76,8 @@ func Execute(
 	seed *big.Int,
 	membershipValidator group.MembershipValidator,
 	startBlockHeight uint64,
+	transcriptRecorder *TranscriptRecorder,
+	seats *Seats,
 ) (*Result, uint64, error) {
 	logger.Debugf("[member:%v] initializing member", memberIndex)
 
@@ -72,18 +92,56 @@ func Execute(
 		return nil, 0, fmt.Errorf("cannot create a new member: [%v]", err)
 	}
 
+	member.seats = seats
+	channel = seats.receiveChannel(channel)
+
+	if transcriptRecorder != nil {
+		transcriptRecorder.begin(member, seed, startBlockHeight, blockCounter)
+		channel = transcriptRecorder.channel(channel)
+	}
+
 	initialState := &ephemeralKeyPairGenerationState{
 		channel: channel,
 		member:  member.InitializeEphemeralKeysGeneration(),
 	}
 
+	return executeStateMachine(
+		ctx,
+		channel,
+		blockCounter,
+		initialState,
+		startBlockHeight,
+		transcriptRecorder,
+	)
+}
+
+// executeStateMachine executes the protocol starting from the given state
+// up to finalization and returns the protocol result.
+func executeStateMachine(
+	ctx context.Context,
+	channel net.BroadcastChannel,
+	blockCounter chain.BlockCounter,
+	initialState keyGenerationState,
+	startBlockHeight uint64,
+	transcriptRecorder *TranscriptRecorder,
+) (*Result, uint64, error) {
 	stateMachine := state.NewMachine(channel, blockCounter, initialState)
+	if transcriptRecorder != nil {
+		stateMachine.SetRecorder(transcriptRecorder)
+	}
 
-	lastState, endBlockHeight, err := stateMachine.Execute(startBlockHeight)
+	lastState, endBlockHeight, err := stateMachine.Execute(ctx, startBlockHeight)
+	if transcriptRecorder != nil {
+		transcriptRecorder.end(err)
+	}
 	if err != nil {
 		return nil, 0, err
 	}
 
+	if resumed, ok := lastState.(*resumedState); ok {
+		lastState = resumed.keyGenerationState
+	}
+
 	finalizationState, ok := lastState.(*finalizationState)
 	if !ok {
 		return nil, 0, fmt.Errorf("execution ended on state: %T", lastState)
@@ -40,6 +40,37 @@ func TestExecute_HappyPath(t *testing.T) {
 	dkgtest.AssertValidGroupPublicKey(t, result)
 }
 
+func TestExecute_HappyPath_seats(t *testing.T) {
+	t.Parallel()
+
+	groupSize := 5
+	honestThreshold := 3
+	seed := dkgtest.RandomSeed(t)
+
+	interceptor := func(msg net.TaggedMarshaler) net.TaggedMarshaler {
+		return msg
+	}
+
+	result, err := dkgtest.RunTestWithSeats(
+		groupSize,
+		honestThreshold,
+		seed,
+		interceptor,
+		[]group.MemberIndex{1, 2, 4},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dkgtest.AssertDkgResultPublished(t, result)
+	dkgtest.AssertSuccessfulSignersCount(t, result, groupSize)
+	dkgtest.AssertMemberFailuresCount(t, result, 0)
+	dkgtest.AssertSamePublicKey(t, result)
+	dkgtest.AssertNoMisbehavingMembers(t, result)
+	dkgtest.AssertValidGroupPublicKey(t, result)
+	dkgtest.AssertValidGroupPublicKeyShares(t, result)
+}
+
 func TestExecute_IA_member1_phase1(t *testing.T) {
 	t.Parallel()
 
@@ -712,6 +743,60 @@ func TestExecute_DQ_members14_invalidPublicKeyShare_phase9(t *testing.T) {
 	dkgtest.AssertResultSupportingMembers(t, result, []group.MemberIndex{2, 3, 5, 6}...)
 }
 
+// Phase 9 test case - the same as the previous one but honest members 2, 3
+// and 5 execute the protocol as seats of one operator. Public key share
+// points of the misbehaving members are verified and their shares are
+// reconstructed by the seats together.
+func TestExecute_DQ_members14_invalidPublicKeyShare_phase9_seats(t *testing.T) {
+	t.Parallel()
+
+	groupSize := 6
+	honestThreshold := 3
+	seed := dkgtest.RandomSeed(t)
+
+	interceptor := func(msg net.TaggedMarshaler) net.TaggedMarshaler {
+		publicKeyShareMessage, ok := msg.(*gjkr.MemberPublicKeySharePointsMessage)
+		if ok && publicKeyShareMessage.SenderID() == group.MemberIndex(1) {
+			publicKeyShareMessage.SetPublicKeyShare(
+				1,
+				new(bn256.G2).ScalarBaseMult(big.NewInt(5843)),
+			)
+			return publicKeyShareMessage
+		}
+
+		if ok && publicKeyShareMessage.SenderID() == group.MemberIndex(4) {
+			publicKeyShareMessage.SetPublicKeyShare(
+				2,
+				new(bn256.G2).ScalarBaseMult(big.NewInt(7456)),
+			)
+			return publicKeyShareMessage
+		}
+
+		return msg
+	}
+
+	result, err := dkgtest.RunTestWithSeats(
+		groupSize,
+		honestThreshold,
+		seed,
+		interceptor,
+		[]group.MemberIndex{2, 3, 5},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dkgtest.AssertDkgResultPublished(t, result)
+	dkgtest.AssertSuccessfulSignersCount(t, result, groupSize-2)
+	dkgtest.AssertSuccessfulSigners(t, result, []group.MemberIndex{2, 3, 5, 6}...)
+	dkgtest.AssertMemberFailuresCount(t, result, 2)
+	dkgtest.AssertSamePublicKey(t, result)
+	dkgtest.AssertMisbehavingMembers(t, result, []group.MemberIndex{1, 4}...)
+	dkgtest.AssertValidGroupPublicKey(t, result)
+	dkgtest.AssertResultSupportingMembers(t, result, []group.MemberIndex{2, 3, 5, 6}...)
+	dkgtest.AssertValidGroupPublicKeyShares(t, result)
+}
+
 // Phase 9 test case - a member misbehaved by performing a false accusation
 // against another member. The accusation is checked by another members
 // and because it is unfounded, the accuser is disqualified in phase 9.
@@ -25,6 +25,10 @@ type memberCore struct {
 
 	// Cryptographic protocol parameters, the same for all members in the group.
 	protocolParameters *protocolParameters
+
+	// Seats of the operator controlling this member, executing the protocol
+	// in the same process. Nil if the member executes the protocol alone.
+	seats *Seats
 }
 
 // LocalMember represents one member in a threshold group, prior to the
@@ -233,11 +237,11 @@ func NewMember(
 ) (*LocalMember, error) {
 	return &LocalMember{
 		memberCore: &memberCore{
-			memberID,
-			group.NewDkgGroup(dishonestThreshold, groupSize),
-			membershipValidator,
-			newDkgEvidenceLog(),
-			newProtocolParameters(seed),
+			ID:                  memberID,
+			group:               group.NewDkgGroup(dishonestThreshold, groupSize),
+			membershipValidator: membershipValidator,
+			evidenceLog:         newDkgEvidenceLog(),
+			protocolParameters:  newProtocolParameters(seed),
 		},
 	}, nil
 }
@@ -22,7 +22,8 @@ import (
 
 // GenerateEphemeralKeyPair takes the group member list and generates an
 // ephemeral ECDH keypair for every other group member. Generated public
-// ephemeral keys are broadcasted within the group.
+// ephemeral keys are broadcasted within the group. If the member has seats,
+// the key pair generated for the given member is shared with the other seats.
 //
 // See Phase 1 of the protocol specification.
 func (em *EphemeralKeyPairGeneratingMember) GenerateEphemeralKeyPair() (
@@ -38,7 +39,8 @@ func (em *EphemeralKeyPairGeneratingMember) GenerateEphemeralKeyPair() (
 			continue
 		}
 
-		ephemeralKeyPair, err := ephemeral.GenerateKeyPair()
+		// seats of the operator share ephemeral key pairs
+		ephemeralKeyPair, err := em.seats.ephemeralKeyPair(member)
 		if err != nil {
 			return nil, err
 		}
@@ -303,6 +305,9 @@ func (cm *CommittingMember) evaluateMemberShare(
 // - shares can not be decrypted
 // - shares are not valid against commitments
 //
+// Shares received from all members are verified against commitments in
+// a single batch, see findInvalidShares.
+//
 // See Phase 4 of the protocol specification.
 func (cvm *CommitmentsVerifyingMember) VerifyReceivedSharesAndCommitmentsMessages(
 	sharesMessages []*PeerSharesMessage,
@@ -313,6 +318,7 @@ func (cvm *CommitmentsVerifyingMember) VerifyReceivedSharesAndCommitmentsMessage
 	}
 
 	accusedMembersKeys := make(map[group.MemberIndex]*ephemeral.PrivateKey)
+	verifications := make([]*sharesVerification, 0, len(commitmentsMessages))
 	for _, commitmentsMessage := range commitmentsMessages {
 		if !cvm.isValidMemberCommitmentsMessage(commitmentsMessage) {
 			logger.Warningf(
@@ -385,25 +391,22 @@ func (cvm *CommitmentsVerifyingMember) VerifyReceivedSharesAndCommitmentsMessage
 					break
 				}
 
-				if !cvm.areSharesValidAgainstCommitments(
-					shareS,                         // s_ji
-					shareT,                         // t_ji
-					commitmentsMessage.commitments, // C_j
-					cvm.ID,                         // i
-				) {
-					logger.Warningf(
-						"[member:%v] shares from member [%v] invalid against "+
-							"commitments; disqualifying and accusing the member",
-						cvm.ID,
-						commitmentsMessage.senderID,
-					)
-					cvm.group.MarkMemberAsDisqualified(commitmentsMessage.senderID)
-					accusedMembersKeys[commitmentsMessage.senderID] =
-						cvm.ephemeralKeyPairs[commitmentsMessage.senderID].PrivateKey
+				// Shares calculated by other seats of the operator, in the
+				// same process, do not need to be verified.
+				if cvm.seats.isSeat(sharesMessage.senderID) {
+					cvm.receivedQualifiedSharesS[sharesMessage.senderID] = shareS
+					cvm.receivedQualifiedSharesT[sharesMessage.senderID] = shareT
 					break
 				}
-				cvm.receivedQualifiedSharesS[commitmentsMessage.senderID] = shareS
-				cvm.receivedQualifiedSharesT[commitmentsMessage.senderID] = shareT
+
+				// Shares are verified against commitments all at once,
+				// after all the messages are processed.
+				verifications = append(verifications, &sharesVerification{
+					senderID:    commitmentsMessage.senderID,    // j
+					shareS:      shareS,                         // s_ji
+					shareT:      shareT,                         // t_ji
+					commitments: commitmentsMessage.commitments, // C_j
+				})
 				break
 			}
 		}
@@ -414,6 +417,25 @@ func (cvm *CommitmentsVerifyingMember) VerifyReceivedSharesAndCommitmentsMessage
 		}
 	}
 
+	invalidSharesSenders := cvm.findInvalidShares(verifications)
+	for _, verification := range verifications {
+		if invalidSharesSenders[verification.senderID] {
+			logger.Warningf(
+				"[member:%v] shares from member [%v] invalid against "+
+					"commitments; disqualifying and accusing the member",
+				cvm.ID,
+				verification.senderID,
+			)
+			cvm.group.MarkMemberAsDisqualified(verification.senderID)
+			accusedMembersKeys[verification.senderID] =
+				cvm.ephemeralKeyPairs[verification.senderID].PrivateKey
+			continue
+		}
+
+		cvm.receivedQualifiedSharesS[verification.senderID] = verification.shareS
+		cvm.receivedQualifiedSharesT[verification.senderID] = verification.shareT
+	}
+
 	return &SecretSharesAccusationsMessage{
 		senderID:           cvm.ID,
 		accusedMembersKeys: accusedMembersKeys,
@@ -844,11 +866,14 @@ func (sm *SharingMember) VerifyPublicKeySharePoints(
 			continue
 		}
 
-		if !sm.isShareValidAgainstPublicKeySharePoints(
-			sm.ID,
-			sm.receivedQualifiedSharesS[message.senderID],
-			message.publicKeySharePoints,
-		) {
+		// Public key share points calculated by other seats of the operator,
+		// in the same process, do not need to be verified.
+		if !sm.seats.isSeat(message.senderID) &&
+			!sm.isShareValidAgainstPublicKeySharePoints(
+				sm.ID,
+				sm.receivedQualifiedSharesS[message.senderID],
+				message.publicKeySharePoints,
+			) {
 			logger.Warningf(
 				"[member:%v] member [%v] disqualified because of "+
 					"invalid public key share points",
@@ -925,22 +950,29 @@ func (sm *SharingMember) isShareValidAgainstPublicKeySharePoints(
 }
 
 // publicKeyShare returns public key share for given share receiver based on
-// given public key share points.
+// given public key share points. If the member has seats, the public key
+// share is calculated once for all of them.
 func (sm *SharingMember) publicKeyShare(
 	shareReceiverID group.MemberIndex,
 	publicKeySharePoints []*bn256.G2,
 ) *bn256.G2 {
-	var sum *bn256.G2
-	// Σ ( A_j[k] * (i^k) ) for `k` in `[0..T]`
-	for k, a := range publicKeySharePoints {
-		aj := new(bn256.G2).ScalarMult(a, pow(shareReceiverID, k)) // A_j[k] * (i^k)
-		if sum == nil {
-			sum = aj
-		} else {
-			sum = new(bn256.G2).Add(sum, aj)
-		}
-	}
-	return sum
+	return sm.seats.publicKeyShare(
+		shareReceiverID,
+		publicKeySharePoints,
+		func() *bn256.G2 {
+			var sum *bn256.G2
+			// Σ ( A_j[k] * (i^k) ) for `k` in `[0..T]`
+			for k, a := range publicKeySharePoints {
+				aj := new(bn256.G2).ScalarMult(a, pow(shareReceiverID, k)) // A_j[k] * (i^k)
+				if sum == nil {
+					sum = aj
+				} else {
+					sum = new(bn256.G2).Add(sum, aj)
+				}
+			}
+			return sum
+		},
+	)
 }
 
 // ResolvePublicKeySharePointsAccusationsMessages resolves complaints received
@@ -0,0 +1,483 @@
+package gjkr
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/ephemeral"
+)
+
+// Misbehavior describes a group member marked as inactive or disqualified
+// in the given protocol phase.
+type Misbehavior struct {
+	Phase       int
+	State       string
+	MemberIndex group.MemberIndex
+	Inactive    bool
+	Reason      string
+}
+
+// ReplayReport is the result of the offline protocol replay.
+type ReplayReport struct {
+	MemberIndex  group.MemberIndex
+	Misbehaviors []*Misbehavior
+	// LastPhase is the last protocol phase replayed.
+	LastPhase int
+	// GroupPublicKey is the group public key computed by the member. It is nil
+	// if the member has not reached the combination phase or there were not
+	// enough qualified members.
+	GroupPublicKey []byte
+	// Error is the error the recorded protocol execution failed with, if any.
+	Error string
+}
+
+// Replay executes the protocol offline for the member who recorded the given
+// transcript. All protocol states are entered in the same order as in the
+// recorded execution. Each state is initiated and receives the messages
+// recorded for its phase, so all the protocol checks are performed again.
+// Secrets generated by the member in phases 1 and 3 are restored from the
+// transcript instead of being generated again.
+//
+// The report lists, for each phase, the members marked as inactive or
+// disqualified by the member along with the reason.
+//
+// Signing is used to validate membership of message senders.
+func Replay(transcript *Transcript, signing chain.Signing) (*ReplayReport, error) {
+	member, err := NewMember(
+		transcript.MemberIndex,
+		transcript.GroupSize,
+		transcript.DishonestThreshold,
+		group.NewStakersMembershipValidator(transcript.GroupMembers, signing),
+		transcript.Seed,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create a new member: [%v]", err)
+	}
+
+	channel := &replayChannel{}
+
+	var currentState keyGenerationState = &ephemeralKeyPairGenerationState{
+		channel: channel,
+		member:  member.InitializeEphemeralKeysGeneration(),
+	}
+
+	report := &ReplayReport{
+		MemberIndex:  transcript.MemberIndex,
+		Misbehaviors: make([]*Misbehavior, 0),
+		Error:        transcript.Error,
+	}
+
+	for _, phase := range transcript.Phases {
+		if currentState == nil {
+			return nil, fmt.Errorf(
+				"transcript contains phase [%v] after the final state",
+				phase.Phase,
+			)
+		}
+
+		expectedPhase, _ := statePhase(currentState)
+		if phase.Phase != expectedPhase {
+			return nil, fmt.Errorf(
+				"unexpected phase [%v] in transcript; expected phase [%v]",
+				phase.Phase,
+				expectedPhase,
+			)
+		}
+
+		inactiveBefore := member.group.InactiveMemberIDs()
+		disqualifiedBefore := member.group.DisqualifiedMemberIDs()
+
+		channel.sent = nil
+		if combination, ok := currentState.(*combinationState); ok {
+			// Computation of group public key shares is skipped as it is
+			// not needed to tell misbehaving members.
+			combination.member.CombineGroupPublicKey()
+		} else if err := replayInitiate(
+			currentState,
+			transcript.Secrets,
+		); err != nil {
+			return nil, fmt.Errorf(
+				"could not initiate phase [%v]: [%v]",
+				phase.Phase,
+				err,
+			)
+		}
+
+		for _, inactive := range newMembers(
+			inactiveBefore,
+			member.group.InactiveMemberIDs(),
+		) {
+			report.Misbehaviors = append(
+				report.Misbehaviors,
+				newMisbehavior(currentState, channel.sent, inactive, true),
+			)
+		}
+		for _, disqualified := range newMembers(
+			disqualifiedBefore,
+			member.group.DisqualifiedMemberIDs(),
+		) {
+			report.Misbehaviors = append(
+				report.Misbehaviors,
+				newMisbehavior(currentState, channel.sent, disqualified, false),
+			)
+		}
+
+		if err := receiveTranscriptMessages(currentState, phase); err != nil {
+			return nil, err
+		}
+
+		report.LastPhase = phase.Phase
+
+		if combination, ok := currentState.(*combinationState); ok {
+			if groupPublicKey := combination.member.groupPublicKey; groupPublicKey != nil {
+				report.GroupPublicKey = groupPublicKey.Marshal()
+			}
+		}
+
+		currentState = currentState.Next()
+	}
+
+	return report, nil
+}
+
+// replayInitiate initiates the given state restoring secrets from the
+// transcript in phases in which they were generated instead of generating
+// them again.
+func replayInitiate(
+	keyGenerationState keyGenerationState,
+	secrets *TranscriptSecrets,
+) error {
+	switch replayedState := keyGenerationState.(type) {
+	case *ephemeralKeyPairGenerationState:
+		if secrets == nil {
+			return fmt.Errorf("no ephemeral keys in transcript")
+		}
+
+		for memberID, privateKeyBytes := range secrets.EphemeralPrivateKeys {
+			privateKey := ephemeral.UnmarshalPrivateKey(privateKeyBytes)
+			replayedState.member.ephemeralKeyPairs[memberID] = &ephemeral.KeyPair{
+				PrivateKey: privateKey,
+				PublicKey:  (*ephemeral.PublicKey)(&privateKey.PublicKey),
+			}
+		}
+		return nil
+
+	case *commitmentState:
+		if secrets == nil || len(secrets.SecretCoefficients) == 0 {
+			return fmt.Errorf("no secret coefficients in transcript")
+		}
+
+		replayedState.member.secretCoefficients = secrets.SecretCoefficients
+		replayedState.member.selfSecretShareS = secrets.SelfSecretShareS
+		replayedState.member.selfSecretShareT = secrets.SelfSecretShareT
+		return nil
+
+	default:
+		return keyGenerationState.Initiate(context.Background())
+	}
+}
+
+// receiveTranscriptMessages passes all the messages received in the given
+// phase, as recorded in the transcript, to the given state.
+func receiveTranscriptMessages(
+	keyGenerationState keyGenerationState,
+	phase *TranscriptPhase,
+) error {
+	for _, received := range phase.Received {
+		message, err := unmarshalTranscriptMessage(received)
+		if err != nil {
+			return fmt.Errorf(
+				"could not unmarshal message received in phase [%v]: [%v]",
+				phase.Phase,
+				err,
+			)
+		}
+
+		if err := keyGenerationState.Receive(message); err != nil {
+			return fmt.Errorf(
+				"could not receive message in phase [%v]: [%v]",
+				phase.Phase,
+				err,
+			)
+		}
+	}
+
+	return nil
+}
+
+func unmarshalTranscriptMessage(
+	transcriptMessage *TranscriptMessage,
+) (net.Message, error) {
+	for _, unmarshaller := range messageUnmarshallers() {
+		payload := unmarshaller()
+		if payload.Type() != transcriptMessage.Type {
+			continue
+		}
+
+		if err := payload.Unmarshal(transcriptMessage.Payload); err != nil {
+			return nil, err
+		}
+
+		return &replayMessage{
+			senderPublicKey: transcriptMessage.SenderPublicKey,
+			payload:         payload,
+		}, nil
+	}
+
+	return nil, fmt.Errorf(
+		"unknown message type [%v]",
+		transcriptMessage.Type,
+	)
+}
+
+// newMembers returns members from the after list which are not on the before
+// list.
+func newMembers(before, after []group.MemberIndex) []group.MemberIndex {
+	known := make(map[group.MemberIndex]bool)
+	for _, memberID := range before {
+		known[memberID] = true
+	}
+
+	members := make([]group.MemberIndex, 0)
+	for _, memberID := range after {
+		if !known[memberID] {
+			members = append(members, memberID)
+		}
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		return members[i] < members[j]
+	})
+
+	return members
+}
+
+// newMisbehavior describes why the given member has been marked as inactive
+// or disqualified when initiating the given state. Messages sent by the
+// replayed member when initiating the state are used to tell whether the
+// member has been accused by the replayed member.
+func newMisbehavior(
+	keyGenerationState keyGenerationState,
+	sent []net.TaggedMarshaler,
+	memberID group.MemberIndex,
+	inactive bool,
+) *Misbehavior {
+	phase, stateName := statePhase(keyGenerationState)
+
+	return &Misbehavior{
+		Phase:       phase,
+		State:       stateName,
+		MemberIndex: memberID,
+		Inactive:    inactive,
+		Reason:      misbehaviorReason(keyGenerationState, sent, memberID, inactive),
+	}
+}
+
+func misbehaviorReason(
+	keyGenerationState keyGenerationState,
+	sent []net.TaggedMarshaler,
+	memberID group.MemberIndex,
+	inactive bool,
+) string {
+	accusedBySelf := false
+	for _, message := range sent {
+		switch accusations := message.(type) {
+		case *SecretSharesAccusationsMessage:
+			if _, ok := accusations.accusedMembersKeys[memberID]; ok {
+				accusedBySelf = true
+			}
+		case *PointsAccusationsMessage:
+			if _, ok := accusations.accusedMembersKeys[memberID]; ok {
+				accusedBySelf = true
+			}
+		}
+	}
+
+	switch replayedState := keyGenerationState.(type) {
+	case *symmetricKeyGenerationState:
+		if inactive {
+			return "did not send ephemeral public keys in phase 1"
+		}
+		return "sent an invalid ephemeral public keys message in phase 1"
+
+	case *commitmentsVerificationState:
+		if inactive {
+			return "did not send shares and commitments in phase 3"
+		}
+		if accusedBySelf {
+			return "sent shares which could not be decrypted or do not " +
+				"match commitments in phase 3; accused by this member"
+		}
+		return "sent an invalid shares or commitments message in phase 3"
+
+	case *sharesJustificationState:
+		if inactive {
+			return "did not send secret shares accusations in phase 4"
+		}
+		accusers, accused := accusationParties(
+			memberID,
+			replayedState.previousPhaseAccusationsMessages,
+		)
+		return justificationReason("shares", accusers, accused)
+
+	case *pointsValidationState:
+		if inactive {
+			return "did not send public key share points in phase 7"
+		}
+		if accusedBySelf {
+			return "sent public key share points not matching shares " +
+				"in phase 7; accused by this member"
+		}
+		return "sent an invalid public key share points message in phase 7"
+
+	case *pointsJustificationState:
+		if inactive {
+			return "did not send points accusations in phase 8"
+		}
+		accusers, accused := accusationParties(
+			memberID,
+			replayedState.previousPhaseMessages,
+		)
+		return justificationReason("public key share points", accusers, accused)
+
+	case *reconstructionState:
+		if inactive {
+			return "did not reveal ephemeral keys of misbehaved members " +
+				"in phase 10"
+		}
+		return "revealed invalid ephemeral keys of misbehaved members " +
+			"in phase 10"
+
+	default:
+		if inactive {
+			return "marked as inactive"
+		}
+		return "disqualified"
+	}
+}
+
+// accusationParties returns members who accused the given member and
+// members accused by the given member in the given accusations messages.
+func accusationParties(
+	memberID group.MemberIndex,
+	messages interface{},
+) (accusers, accused []group.MemberIndex) {
+	check := func(
+		senderID group.MemberIndex,
+		accusedMembersKeys map[group.MemberIndex]*ephemeral.PrivateKey,
+	) {
+		if senderID == memberID {
+			for accusedID := range accusedMembersKeys {
+				accused = append(accused, accusedID)
+			}
+		} else if _, ok := accusedMembersKeys[memberID]; ok {
+			accusers = append(accusers, senderID)
+		}
+	}
+
+	switch accusationsMessages := messages.(type) {
+	case []*SecretSharesAccusationsMessage:
+		for _, message := range accusationsMessages {
+			check(message.senderID, message.accusedMembersKeys)
+		}
+	case []*PointsAccusationsMessage:
+		for _, message := range accusationsMessages {
+			check(message.senderID, message.accusedMembersKeys)
+		}
+	}
+
+	sort.Slice(accusers, func(i, j int) bool { return accusers[i] < accusers[j] })
+	sort.Slice(accused, func(i, j int) bool { return accused[i] < accused[j] })
+
+	return accusers, accused
+}
+
+func justificationReason(
+	subject string,
+	accusers, accused []group.MemberIndex,
+) string {
+	reasons := make([]string, 0)
+	if len(accusers) > 0 {
+		reasons = append(reasons, fmt.Sprintf(
+			"accused by members %v of sending invalid %v and the "+
+				"accusation has been confirmed",
+			accusers,
+			subject,
+		))
+	}
+	if len(accused) > 0 {
+		reasons = append(reasons, fmt.Sprintf(
+			"accused members %v of sending invalid %v and the "+
+				"accusation has not been confirmed",
+			accused,
+			subject,
+		))
+	}
+	if len(reasons) == 0 {
+		return fmt.Sprintf("sent an invalid %v accusations message", subject)
+	}
+
+	return strings.Join(reasons, "; ")
+}
+
+// replayChannel is a broadcast channel used during the protocol replay.
+// It does not deliver any messages but keeps the messages sent when
+// initiating the current state.
+type replayChannel struct {
+	sent []net.TaggedMarshaler
+}
+
+func (rc *replayChannel) Name() string {
+	return "replay"
+}
+
+func (rc *replayChannel) Send(
+	ctx context.Context,
+	message net.TaggedMarshaler,
+) error {
+	rc.sent = append(rc.sent, message)
+	return nil
+}
+
+func (rc *replayChannel) Recv(ctx context.Context, handler func(m net.Message)) {}
+
+func (rc *replayChannel) RegisterUnmarshaler(
+	unmarshaler func() net.TaggedUnmarshaler,
+) error {
+	return nil
+}
+
+func (rc *replayChannel) SetFilter(filter net.BroadcastChannelFilter) error {
+	return nil
+}
+
+// replayMessage is a protocol message restored from the transcript.
+type replayMessage struct {
+	senderPublicKey []byte
+	payload         interface{}
+}
+
+func (rm *replayMessage) TransportSenderID() net.TransportIdentifier {
+	return nil
+}
+
+func (rm *replayMessage) SenderPublicKey() []byte {
+	return rm.senderPublicKey
+}
+
+func (rm *replayMessage) Payload() interface{} {
+	return rm.payload
+}
+
+func (rm *replayMessage) Type() string {
+	return rm.payload.(net.TaggedMarshaler).Type()
+}
+
+func (rm *replayMessage) Seqno() uint64 {
+	return 0
+}
@@ -0,0 +1,162 @@
+package gjkr_test
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
+	"github.com/keep-network/keep-core/pkg/internal/dkgtest"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/ephemeral"
+)
+
+func TestReplay_IA_member1_phase1(t *testing.T) {
+	t.Parallel()
+
+	groupSize := 5
+	honestThreshold := 3
+	seed := dkgtest.RandomSeed(t)
+
+	interceptor := func(msg net.TaggedMarshaler) net.TaggedMarshaler {
+		publicKeyMessage, ok := msg.(*gjkr.EphemeralPublicKeyMessage)
+		if ok && publicKeyMessage.SenderID() == group.MemberIndex(1) {
+			return nil
+		}
+
+		return msg
+	}
+
+	result, err := dkgtest.RunTest(groupSize, honestThreshold, seed, interceptor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := replay(t, result, group.MemberIndex(2))
+
+	assertMisbehaviors(t, report, &gjkr.Misbehavior{
+		Phase:       2,
+		State:       "symmetric key generation",
+		MemberIndex: group.MemberIndex(1),
+		Inactive:    true,
+		Reason:      "did not send ephemeral public keys in phase 1",
+	})
+	assertReplayedGroupPublicKey(t, result, report)
+}
+
+func TestReplay_DQ_member4_falseAccusation_phase5(t *testing.T) {
+	t.Parallel()
+
+	groupSize := 5
+	honestThreshold := 3
+	seed := dkgtest.RandomSeed(t)
+
+	manInTheMiddle, err := newManInTheMiddle(
+		group.MemberIndex(4), // sender
+		groupSize,
+		honestThreshold,
+		seed,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	interceptor := func(msg net.TaggedMarshaler) net.TaggedMarshaler {
+		manInTheMiddle.interceptCommunication(msg)
+
+		accusationsMessage, ok := msg.(*gjkr.SecretSharesAccusationsMessage)
+		if ok && accusationsMessage.SenderID() == group.MemberIndex(4) {
+			accusedMembersKeys := make(map[group.MemberIndex]*ephemeral.PrivateKey)
+			accusedMembersKeys[group.MemberIndex(1)] =
+				manInTheMiddle.ephemeralKeyPairs[group.MemberIndex(1)].PrivateKey
+			accusationsMessage.SetAccusedMemberKeys(accusedMembersKeys)
+			return accusationsMessage
+		}
+
+		return msg
+	}
+
+	result, err := dkgtest.RunTest(groupSize, honestThreshold, seed, interceptor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := replay(t, result, group.MemberIndex(2))
+
+	assertMisbehaviors(t, report, &gjkr.Misbehavior{
+		Phase:       5,
+		State:       "shares justification",
+		MemberIndex: group.MemberIndex(4),
+		Inactive:    false,
+		Reason: "accused members [1] of sending invalid shares and " +
+			"the accusation has not been confirmed",
+	})
+	assertReplayedGroupPublicKey(t, result, report)
+}
+
+func replay(
+	t *testing.T,
+	result *dkgtest.Result,
+	memberIndex group.MemberIndex,
+) *gjkr.ReplayReport {
+	transcript := result.GetTranscript(memberIndex)
+	if transcript == nil {
+		t.Fatalf("no transcript recorded by member [%v]", memberIndex)
+	}
+
+	signing := chainLocal.Connect(5, 3, big.NewInt(20)).Signing()
+
+	report, err := gjkr.Replay(transcript, signing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.LastPhase != 13 {
+		t.Errorf(
+			"unexpected last phase\nexpected: [%v]\nactual:   [%v]",
+			13,
+			report.LastPhase,
+		)
+	}
+
+	return report
+}
+
+func assertMisbehaviors(
+	t *testing.T,
+	report *gjkr.ReplayReport,
+	expectedMisbehaviors ...*gjkr.Misbehavior,
+) {
+	if !reflect.DeepEqual(expectedMisbehaviors, report.Misbehaviors) {
+		for _, misbehavior := range report.Misbehaviors {
+			t.Logf("misbehavior: [%+v]", misbehavior)
+		}
+		t.Fatalf(
+			"unexpected misbehaviors\nexpected: [%v]\nactual:   [%v]",
+			len(expectedMisbehaviors),
+			len(report.Misbehaviors),
+		)
+	}
+}
+
+func assertReplayedGroupPublicKey(
+	t *testing.T,
+	result *dkgtest.Result,
+	report *gjkr.ReplayReport,
+) {
+	for _, signer := range result.GetSigners() {
+		if signer.MemberID() != report.MemberIndex {
+			continue
+		}
+
+		if !bytes.Equal(signer.GroupPublicKeyBytes(), report.GroupPublicKey) {
+			t.Errorf("replayed group public key does not match the generated one")
+		}
+		return
+	}
+
+	t.Errorf("no signer for member [%v]", report.MemberIndex)
+}
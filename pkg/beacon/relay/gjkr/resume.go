@@ -0,0 +1,151 @@
+package gjkr
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+// Resume resumes the protocol execution interrupted after the given
+// checkpoint has been taken, see TranscriptRecorder.OnCheckpoint.
+//
+// The member's state is restored by replaying all the checkpointed phases,
+// the same way Replay does it, without sending any messages. Execution then
+// continues in the last checkpointed phase, which is not initiated again, up
+// to finalization. Messages other members sent while the execution was
+// interrupted are not received and phases whose blocks have already passed
+// are completed immediately, so the execution has a chance to succeed only
+// if it has been interrupted for a short time.
+//
+// If the transcript recorder is not nil, recording continues from the
+// checkpoint.
+func Resume(
+	ctx context.Context,
+	checkpoint *Transcript,
+	blockCounter chain.BlockCounter,
+	channel net.BroadcastChannel,
+	membershipValidator group.MembershipValidator,
+	transcriptRecorder *TranscriptRecorder,
+) (*Result, uint64, error) {
+	if len(checkpoint.Phases) == 0 {
+		return nil, 0, fmt.Errorf("checkpoint contains no phases")
+	}
+
+	logger.Debugf(
+		"[member:%v] restoring member from checkpoint",
+		checkpoint.MemberIndex,
+	)
+
+	member, err := NewMember(
+		checkpoint.MemberIndex,
+		checkpoint.GroupSize,
+		checkpoint.DishonestThreshold,
+		membershipValidator,
+		checkpoint.Seed,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("cannot create a new member: [%v]", err)
+	}
+
+	if transcriptRecorder != nil {
+		transcriptRecorder.resume(checkpoint, blockCounter)
+		channel = transcriptRecorder.channel(channel)
+	}
+
+	restoringChannel := &restoringChannel{
+		BroadcastChannel: channel,
+		restoring:        true,
+	}
+
+	var currentState keyGenerationState = &ephemeralKeyPairGenerationState{
+		channel: restoringChannel,
+		member:  member.InitializeEphemeralKeysGeneration(),
+	}
+
+	for i, phase := range checkpoint.Phases {
+		if i > 0 {
+			currentState = currentState.Next()
+			if currentState == nil {
+				return nil, 0, fmt.Errorf(
+					"checkpoint contains phase [%v] after the final state",
+					phase.Phase,
+				)
+			}
+		}
+
+		expectedPhase, _ := statePhase(currentState)
+		if phase.Phase != expectedPhase {
+			return nil, 0, fmt.Errorf(
+				"unexpected phase [%v] in checkpoint; expected phase [%v]",
+				phase.Phase,
+				expectedPhase,
+			)
+		}
+
+		if err := replayInitiate(currentState, checkpoint.Secrets); err != nil {
+			return nil, 0, fmt.Errorf(
+				"could not restore phase [%v]: [%v]",
+				phase.Phase,
+				err,
+			)
+		}
+
+		if err := receiveTranscriptMessages(currentState, phase); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	restoringChannel.restoring = false
+
+	lastPhase := checkpoint.Phases[len(checkpoint.Phases)-1]
+
+	logger.Infof(
+		"[member:%v] resuming execution in phase [%v] started at block [%v]",
+		checkpoint.MemberIndex,
+		lastPhase.Phase,
+		lastPhase.StartBlockHeight,
+	)
+
+	return executeStateMachine(
+		ctx,
+		channel,
+		blockCounter,
+		&resumedState{currentState},
+		lastPhase.StartBlockHeight,
+		transcriptRecorder,
+	)
+}
+
+// resumedState is the state restored from a checkpoint. The state has been
+// already initiated before the execution was interrupted so it is not
+// initiated again.
+type resumedState struct {
+	keyGenerationState
+}
+
+func (rs *resumedState) Initiate(ctx context.Context) error {
+	return nil
+}
+
+// restoringChannel is a broadcast channel used by states restored from
+// a checkpoint. Messages are not sent while states are being restored
+// because they have been already sent before the execution was interrupted.
+type restoringChannel struct {
+	net.BroadcastChannel
+
+	restoring bool
+}
+
+func (rc *restoringChannel) Send(
+	ctx context.Context,
+	message net.TaggedMarshaler,
+) error {
+	if rc.restoring {
+		return nil
+	}
+
+	return rc.BroadcastChannel.Send(ctx, message)
+}
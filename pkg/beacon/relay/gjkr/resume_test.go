@@ -0,0 +1,118 @@
+package gjkr_test
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
+	"github.com/keep-network/keep-core/pkg/internal/dkgtest"
+	"github.com/keep-network/keep-core/pkg/net"
+	netLocal "github.com/keep-network/keep-core/pkg/net/local"
+)
+
+func TestResume_afterAllPhasesCheckpointed(t *testing.T) {
+	t.Parallel()
+
+	groupSize := 5
+	honestThreshold := 3
+	seed := dkgtest.RandomSeed(t)
+
+	interceptor := func(msg net.TaggedMarshaler) net.TaggedMarshaler {
+		return msg
+	}
+
+	result, err := dkgtest.RunTest(groupSize, honestThreshold, seed, interceptor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	memberIndex := group.MemberIndex(2)
+	checkpoint := result.GetTranscript(memberIndex)
+	if checkpoint == nil {
+		t.Fatalf("no transcript recorded by member [%v]", memberIndex)
+	}
+
+	chain := chainLocal.Connect(groupSize, honestThreshold, big.NewInt(20))
+	blockCounter, err := chain.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The checkpoint is resumed on a new chain, so the block heights are
+	// shifted for the last checkpointed phase to start at the current block.
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lastPhase := checkpoint.Phases[len(checkpoint.Phases)-1]
+	shift := lastPhase.StartBlockHeight - currentBlock
+	checkpoint.StartBlockHeight -= shift
+	for _, phase := range checkpoint.Phases {
+		phase.StartBlockHeight -= shift
+	}
+
+	channel, err := netLocal.Connect().BroadcastChannelFor("resume-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gjkr.RegisterUnmarshallers(channel)
+
+	transcriptRecorder := gjkr.NewTranscriptRecorder(checkpoint.GroupMembers)
+
+	resumedResult, _, err := gjkr.Resume(
+		context.Background(),
+		checkpoint,
+		blockCounter,
+		channel,
+		group.NewStakersMembershipValidator(
+			checkpoint.GroupMembers,
+			chain.Signing(),
+		),
+		transcriptRecorder,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, signer := range result.GetSigners() {
+		if signer.MemberID() != memberIndex {
+			continue
+		}
+
+		groupPublicKey, err := resumedResult.GroupPublicKeyBytes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(signer.GroupPublicKeyBytes(), groupPublicKey) {
+			t.Errorf("resumed group public key does not match the generated one")
+		}
+
+		publicKeyShares := resumedResult.GroupPublicKeyShares()
+		for memberID, share := range signer.GroupPublicKeyShares() {
+			resumedShare, ok := publicKeyShares[memberID]
+			if !ok || !bytes.Equal(share.Marshal(), resumedShare.Marshal()) {
+				t.Errorf(
+					"resumed group public key share of member [%v] does "+
+						"not match the generated one",
+					memberID,
+				)
+			}
+		}
+	}
+
+	transcript := transcriptRecorder.Transcript()
+	if len(transcript.Phases) != len(checkpoint.Phases) {
+		t.Errorf(
+			"unexpected number of recorded phases\nexpected: [%v]\nactual:   [%v]",
+			len(checkpoint.Phases),
+			len(transcript.Phases),
+		)
+	}
+	if len(transcript.Secrets.SecretCoefficients) == 0 {
+		t.Errorf("secrets restored from checkpoint have not been recorded")
+	}
+}
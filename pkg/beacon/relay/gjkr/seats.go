@@ -0,0 +1,236 @@
+package gjkr
+
+import (
+	"context"
+	"crypto/sha256"
+	"sync"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/ephemeral"
+)
+
+// Seats are members of the same group controlled by one operator and
+// executing the protocol in one process. Each seat is a separate group member
+// generating its own polynomials and sending its own messages, so peers see no
+// difference from seats executing the protocol independently. Messages of the
+// seats are not combined since peers executing the protocol for a single seat
+// expect messages from each member. However, the seats share the work which
+// does not have to be repeated by each of them.
+//
+// Ephemeral key pairs are generated once for all the seats; all the seats use
+// the same key pair generated for the given member, see ephemeralKeyPair.
+// Ephemeral private keys are revealed only for accused and disqualified
+// members so sharing them reveals no more than what those members and the
+// operator already know.
+//
+// Messages are received from the broadcast channel with a single handler and
+// dispatched to all the seats. Shares and public key share points sent by
+// a seat are not verified by other seats, as they have been calculated in the
+// same process. Public key shares evaluated from public key share points of
+// members are calculated once and used by all the seats, see publicKeyShare.
+//
+// All the seats must execute the protocol with the same broadcast channel.
+type Seats struct {
+	memberIndexes map[group.MemberIndex]bool
+
+	mutex             sync.Mutex
+	channel           *seatsChannel
+	ephemeralKeyPairs map[group.MemberIndex]*ephemeral.KeyPair
+	publicKeyShares   map[publicKeyShareKey]*sharedPublicKeyShare
+}
+
+// publicKeyShareKey identifies a public key share evaluated for the share
+// receiver from the given public key share points.
+type publicKeyShareKey struct {
+	shareReceiverID      group.MemberIndex
+	publicKeySharePoints [sha256.Size]byte
+}
+
+type sharedPublicKeyShare struct {
+	once           sync.Once
+	publicKeyShare *bn256.G2
+}
+
+// NewSeats creates seats for members with the given indexes.
+func NewSeats(memberIndexes []group.MemberIndex) *Seats {
+	seats := &Seats{
+		memberIndexes:     make(map[group.MemberIndex]bool),
+		ephemeralKeyPairs: make(map[group.MemberIndex]*ephemeral.KeyPair),
+		publicKeyShares:   make(map[publicKeyShareKey]*sharedPublicKeyShare),
+	}
+	for _, memberIndex := range memberIndexes {
+		seats.memberIndexes[memberIndex] = true
+	}
+
+	return seats
+}
+
+// isSeat returns true if the member with the given index is one of the seats.
+func (s *Seats) isSeat(memberIndex group.MemberIndex) bool {
+	if s == nil {
+		return false
+	}
+
+	return s.memberIndexes[memberIndex]
+}
+
+// receiveChannel returns a broadcast channel wrapping the given one, through
+// which all the seats receive messages. Messages are received from the given
+// channel with a single handler.
+func (s *Seats) receiveChannel(channel net.BroadcastChannel) net.BroadcastChannel {
+	if s == nil {
+		return channel
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.channel == nil {
+		s.channel = &seatsChannel{BroadcastChannel: channel}
+	}
+
+	return s.channel
+}
+
+// ephemeralKeyPair returns the ephemeral key pair generated for the member
+// with the given index. The key pair is generated once for all the seats.
+func (s *Seats) ephemeralKeyPair(
+	memberIndex group.MemberIndex,
+) (*ephemeral.KeyPair, error) {
+	if s == nil {
+		return ephemeral.GenerateKeyPair()
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if keyPair, ok := s.ephemeralKeyPairs[memberIndex]; ok {
+		return keyPair, nil
+	}
+
+	keyPair, err := ephemeral.GenerateKeyPair()
+	if err != nil {
+		return nil, err
+	}
+	s.ephemeralKeyPairs[memberIndex] = keyPair
+
+	return keyPair, nil
+}
+
+// publicKeyShare returns `Σ (A_j[k] * (i^k))` for `k` in `[0..T]`, where `i`
+// is the share receiver ID and `A_j` are public key share points of member
+// `j`. The value is calculated with the given function once for all the seats
+// and the same public key share points.
+func (s *Seats) publicKeyShare(
+	shareReceiverID group.MemberIndex,
+	publicKeySharePoints []*bn256.G2,
+	calculate func() *bn256.G2,
+) *bn256.G2 {
+	if s == nil {
+		return calculate()
+	}
+
+	key := publicKeyShareKey{
+		shareReceiverID:      shareReceiverID,
+		publicKeySharePoints: fingerprint(publicKeySharePoints),
+	}
+
+	s.mutex.Lock()
+	shared, ok := s.publicKeyShares[key]
+	if !ok {
+		shared = &sharedPublicKeyShare{}
+		s.publicKeyShares[key] = shared
+	}
+	s.mutex.Unlock()
+
+	shared.once.Do(func() {
+		shared.publicKeyShare = calculate()
+	})
+
+	return shared.publicKeyShare
+}
+
+func fingerprint(points []*bn256.G2) [sha256.Size]byte {
+	hash := sha256.New()
+	for _, point := range points {
+		hash.Write(point.Marshal())
+	}
+
+	var result [sha256.Size]byte
+	copy(result[:], hash.Sum(nil))
+	return result
+}
+
+// seatsChannel is a broadcast channel used by all the seats. It receives
+// messages from the wrapped channel with a single handler and dispatches them
+// to handlers installed by the seats.
+type seatsChannel struct {
+	net.BroadcastChannel
+
+	mutex         sync.Mutex
+	handlers      []*seatHandler
+	cancelReceive context.CancelFunc
+}
+
+type seatHandler struct {
+	ctx    context.Context
+	handle func(m net.Message)
+}
+
+// Recv installs a message handler that will receive messages from the
+// channel for the entire lifetime of the provided context. Messages are
+// received from the wrapped channel as long as there is at least one handler
+// installed.
+func (sc *seatsChannel) Recv(ctx context.Context, handle func(m net.Message)) {
+	handler := &seatHandler{ctx, handle}
+
+	sc.mutex.Lock()
+	sc.handlers = append(sc.handlers, handler)
+	if sc.cancelReceive == nil {
+		receiveCtx, cancelReceive := context.WithCancel(context.Background())
+		sc.cancelReceive = cancelReceive
+		sc.BroadcastChannel.Recv(receiveCtx, sc.dispatch)
+	}
+	sc.mutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		sc.removeHandler(handler)
+	}()
+}
+
+func (sc *seatsChannel) removeHandler(handler *seatHandler) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	for i, h := range sc.handlers {
+		if h == handler {
+			sc.handlers = append(sc.handlers[:i], sc.handlers[i+1:]...)
+			break
+		}
+	}
+
+	if len(sc.handlers) == 0 && sc.cancelReceive != nil {
+		sc.cancelReceive()
+		sc.cancelReceive = nil
+	}
+}
+
+func (sc *seatsChannel) dispatch(message net.Message) {
+	sc.mutex.Lock()
+	handlers := make([]*seatHandler, len(sc.handlers))
+	copy(handlers, sc.handlers)
+	sc.mutex.Unlock()
+
+	for _, handler := range handlers {
+		// Handlers are not called once their context is done, the same as
+		// handlers installed directly in the wrapped channel.
+		if handler.ctx.Err() != nil {
+			continue
+		}
+
+		handler.handle(message)
+	}
+}
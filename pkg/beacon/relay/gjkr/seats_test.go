@@ -0,0 +1,255 @@
+package gjkr
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+func TestSeatsIsSeat(t *testing.T) {
+	seats := NewSeats([]group.MemberIndex{2, 5})
+
+	for memberIndex, expected := range map[group.MemberIndex]bool{
+		1: false,
+		2: true,
+		3: false,
+		5: true,
+	} {
+		if seats.isSeat(memberIndex) != expected {
+			t.Errorf(
+				"unexpected seat check result for member [%v]\n"+
+					"expected: [%v]\nactual:   [%v]",
+				memberIndex,
+				expected,
+				!expected,
+			)
+		}
+	}
+
+	var noSeats *Seats
+	if noSeats.isSeat(1) {
+		t.Errorf("member should not be a seat if there are no seats")
+	}
+}
+
+func TestSeatsReceiveChannel(t *testing.T) {
+	channel := &testReceivingChannel{}
+	seats := NewSeats([]group.MemberIndex{1, 2})
+
+	receiveChannel := seats.receiveChannel(channel).(*seatsChannel)
+	if seats.receiveChannel(channel) != receiveChannel {
+		t.Fatal("all seats should use the same receive channel")
+	}
+
+	ctx1, cancelCtx1 := context.WithCancel(context.Background())
+	ctx2, cancelCtx2 := context.WithCancel(context.Background())
+
+	var mutex sync.Mutex
+	received := make(map[int]int)
+	handler := func(seat int) func(net.Message) {
+		return func(net.Message) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			received[seat]++
+		}
+	}
+
+	receiveChannel.Recv(ctx1, handler(1))
+	receiveChannel.Recv(ctx2, handler(2))
+
+	if channel.handlersCount() != 1 {
+		t.Fatalf(
+			"unexpected number of handlers installed in the channel\n"+
+				"expected: [1]\nactual:   [%v]",
+			channel.handlersCount(),
+		)
+	}
+
+	channel.deliver(nil)
+
+	cancelCtx1()
+	waitFor(t, func() bool {
+		receiveChannel.mutex.Lock()
+		defer receiveChannel.mutex.Unlock()
+		return len(receiveChannel.handlers) == 1
+	})
+
+	channel.deliver(nil)
+
+	mutex.Lock()
+	if received[1] != 1 || received[2] != 2 {
+		t.Errorf(
+			"unexpected number of received messages\n"+
+				"expected: [map[1:1 2:2]]\nactual:   [%v]",
+			received,
+		)
+	}
+	mutex.Unlock()
+
+	cancelCtx2()
+	waitFor(t, func() bool {
+		return channel.handlersCount() == 0
+	})
+}
+
+func TestSeatsEphemeralKeyPair(t *testing.T) {
+	seats := NewSeats([]group.MemberIndex{1, 2})
+
+	keyPair1, err := seats.ephemeralKeyPair(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPair2, err := seats.ephemeralKeyPair(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keyPair1 != keyPair2 {
+		t.Errorf("all seats should use the same key pair for the member")
+	}
+
+	otherKeyPair, err := seats.ephemeralKeyPair(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if otherKeyPair == keyPair1 {
+		t.Errorf("seats should use different key pairs for other members")
+	}
+
+	var noSeats *Seats
+	noSeatsKeyPair1, err := noSeats.ephemeralKeyPair(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	noSeatsKeyPair2, err := noSeats.ephemeralKeyPair(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if noSeatsKeyPair1 == noSeatsKeyPair2 {
+		t.Errorf("key pairs should be generated each time if there are no seats")
+	}
+}
+
+func TestSeatsPublicKeyShare(t *testing.T) {
+	seats := NewSeats([]group.MemberIndex{1, 2})
+
+	points := []*bn256.G2{
+		new(bn256.G2).ScalarBaseMult(big.NewInt(11)),
+		new(bn256.G2).ScalarBaseMult(big.NewInt(12)),
+	}
+	// The same points, received in another message.
+	samePoints := []*bn256.G2{
+		new(bn256.G2).ScalarBaseMult(big.NewInt(11)),
+		new(bn256.G2).ScalarBaseMult(big.NewInt(12)),
+	}
+	otherPoints := []*bn256.G2{
+		new(bn256.G2).ScalarBaseMult(big.NewInt(11)),
+		new(bn256.G2).ScalarBaseMult(big.NewInt(13)),
+	}
+
+	calculations := 0
+	calculate := func() *bn256.G2 {
+		calculations++
+		return new(bn256.G2).ScalarBaseMult(big.NewInt(int64(calculations)))
+	}
+
+	share1 := seats.publicKeyShare(3, points, calculate)
+	share2 := seats.publicKeyShare(3, samePoints, calculate)
+	if calculations != 1 {
+		t.Fatalf(
+			"public key share should be calculated once for the same points\n"+
+				"expected: [1]\nactual:   [%v]",
+			calculations,
+		)
+	}
+	if share1.String() != share2.String() {
+		t.Errorf("public key shares for the same points should be equal")
+	}
+
+	seats.publicKeyShare(4, points, calculate)
+	seats.publicKeyShare(3, otherPoints, calculate)
+	if calculations != 3 {
+		t.Fatalf(
+			"public key share should be calculated for each receiver and points\n"+
+				"expected: [3]\nactual:   [%v]",
+			calculations,
+		)
+	}
+
+	var noSeats *Seats
+	noSeats.publicKeyShare(3, points, calculate)
+	noSeats.publicKeyShare(3, points, calculate)
+	if calculations != 5 {
+		t.Fatalf(
+			"public key share should be calculated each time if there are no seats\n"+
+				"expected: [5]\nactual:   [%v]",
+			calculations,
+		)
+	}
+}
+
+// testReceivingChannel is a broadcast channel delivering messages to handlers
+// installed with Recv until their context is done.
+type testReceivingChannel struct {
+	net.BroadcastChannel
+
+	mutex    sync.Mutex
+	handlers map[*func(net.Message)]context.Context
+}
+
+func (trc *testReceivingChannel) Recv(
+	ctx context.Context,
+	handler func(m net.Message),
+) {
+	trc.mutex.Lock()
+	defer trc.mutex.Unlock()
+
+	if trc.handlers == nil {
+		trc.handlers = make(map[*func(net.Message)]context.Context)
+	}
+	trc.handlers[&handler] = ctx
+}
+
+func (trc *testReceivingChannel) handlersCount() int {
+	trc.mutex.Lock()
+	defer trc.mutex.Unlock()
+
+	count := 0
+	for _, ctx := range trc.handlers {
+		if ctx.Err() == nil {
+			count++
+		}
+	}
+	return count
+}
+
+func (trc *testReceivingChannel) deliver(message net.Message) {
+	trc.mutex.Lock()
+	handlers := make([]func(net.Message), 0, len(trc.handlers))
+	for handler, ctx := range trc.handlers {
+		if ctx.Err() == nil {
+			handlers = append(handlers, *handler)
+		}
+	}
+	trc.mutex.Unlock()
+
+	for _, handler := range handlers {
+		handler(message)
+	}
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	for i := 0; i < 100; i++ {
+		if condition() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatal("condition not met in time")
+}
@@ -0,0 +1,154 @@
+package gjkr
+
+import (
+	"math/big"
+	"math/bits"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/bls"
+)
+
+// sharesVerification holds shares `s_ji` and `t_ji` calculated by member `j`
+// for member `i` along with commitments `C_j` broadcast by member `j`.
+type sharesVerification struct {
+	senderID    group.MemberIndex // j
+	shareS      *big.Int          // s_ji
+	shareT      *big.Int          // t_ji
+	commitments []*bn256.G1       // C_j
+}
+
+// findInvalidShares verifies shares received by the current member `i` from
+// other group members against commitments and returns IDs of members whose
+// shares are not valid.
+//
+// All the checks `G * s_ji + H * t_ji == Σ (C_j[k] * (i^k))` are combined
+// into a single check using random factors `r_j`:
+// `G * Σ (r_j * s_ji) + H * Σ (r_j * t_ji) == Σ (r_j * Σ (C_j[k] * (i^k)))`
+//
+// If all the shares are valid, the combined check holds. If it does not, each
+// check is verified separately with areSharesValidAgainstCommitments to
+// identify members who sent invalid shares.
+func (cm *CommittingMember) findInvalidShares(
+	verifications []*sharesVerification,
+) map[group.MemberIndex]bool {
+	invalidSharesSenders := make(map[group.MemberIndex]bool)
+	if len(verifications) == 0 {
+		return invalidSharesSenders
+	}
+
+	valid, err := cm.areSharesValidInBatch(verifications)
+	if err != nil {
+		logger.Warningf(
+			"[member:%v] could not verify shares in batch; "+
+				"verifying shares of each member separately: [%v]",
+			cm.ID,
+			err,
+		)
+	} else if valid {
+		return invalidSharesSenders
+	}
+
+	for _, verification := range verifications {
+		if !cm.areSharesValidAgainstCommitments(
+			verification.shareS,
+			verification.shareT,
+			verification.commitments,
+			cm.ID,
+		) {
+			invalidSharesSenders[verification.senderID] = true
+		}
+	}
+
+	return invalidSharesSenders
+}
+
+// areSharesValidInBatch verifies all the given shares against commitments
+// with a single check combining them with random factors. Compared to
+// verifying shares of each member separately, the check computes just two
+// scalar multiplications of `G` and `H` in total, and commitments are
+// evaluated with Horner's method, see evaluateCommitments.
+func (cm *CommittingMember) areSharesValidInBatch(
+	verifications []*sharesVerification,
+) (bool, error) {
+	sumS := big.NewInt(0) // Σ (r_j * s_ji)
+	sumT := big.NewInt(0) // Σ (r_j * t_ji)
+	var sum *bn256.G1     // Σ (r_j * Σ (C_j[k] * (i^k)))
+
+	for _, verification := range verifications {
+		if len(verification.commitments) == 0 {
+			return false, nil
+		}
+
+		factor, err := bls.RandomBatchFactor() // r_j
+		if err != nil {
+			return false, err
+		}
+
+		sumS = new(big.Int).Mod(
+			new(big.Int).Add(
+				sumS,
+				new(big.Int).Mul(factor, verification.shareS),
+			),
+			bn256.Order,
+		)
+		sumT = new(big.Int).Mod(
+			new(big.Int).Add(
+				sumT,
+				new(big.Int).Mul(factor, verification.shareT),
+			),
+			bn256.Order,
+		)
+
+		ci := new(bn256.G1).ScalarMult(
+			evaluateCommitments(verification.commitments, cm.ID),
+			factor,
+		) // r_j * Σ (C_j[k] * (i^k))
+		if sum == nil {
+			sum = ci
+		} else {
+			sum = new(bn256.G1).Add(sum, ci)
+		}
+	}
+
+	commitment := cm.calculateCommitment(sumS, sumT) // G * Σ (r_j * s_ji) + H * Σ (r_j * t_ji)
+
+	return commitment.String() == sum.String(), nil
+}
+
+// evaluateCommitments calculates `Σ (C_j[k] * (i^k))` for `k` in `[0..T]`
+// with Horner's method:
+// `(...((C_j[T] * i + C_j[T-1]) * i + C_j[T-2]) * i + ...) * i + C_j[0]`
+//
+// Member indexes are small, so each multiplication by `i` takes just a few
+// point doublings and additions instead of a full scalar multiplication.
+func evaluateCommitments(
+	commitments []*bn256.G1, // C_j
+	memberID group.MemberIndex, // i
+) *bn256.G1 {
+	result := new(bn256.G1).Set(commitments[len(commitments)-1])
+	for k := len(commitments) - 2; k >= 0; k-- {
+		result = new(bn256.G1).Add(
+			multiplyByMemberIndex(result, memberID),
+			commitments[k],
+		)
+	}
+
+	return result
+}
+
+// multiplyByMemberIndex calculates `P * i` with the double-and-add method.
+func multiplyByMemberIndex(
+	point *bn256.G1, // P
+	memberID group.MemberIndex, // i
+) *bn256.G1 {
+	result := new(bn256.G1).Set(point)
+	for bit := bits.Len8(memberID) - 2; bit >= 0; bit-- {
+		result = new(bn256.G1).Add(result, result)
+		if (memberID>>uint(bit))&1 == 1 {
+			result = new(bn256.G1).Add(result, point)
+		}
+	}
+
+	return result
+}
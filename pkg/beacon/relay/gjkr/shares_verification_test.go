@@ -0,0 +1,231 @@
+package gjkr
+
+import (
+	crand "crypto/rand"
+	"reflect"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+)
+
+func TestEvaluateCommitments(t *testing.T) {
+	commitments := make([]*bn256.G1, 4)
+	for k := range commitments {
+		_, commitment, err := bn256.RandomG1(crand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		commitments[k] = commitment
+	}
+
+	for _, memberID := range []group.MemberIndex{1, 2, 3, 7, 64, 255} {
+		// Σ (C_j[k] * (i^k)) calculated with a scalar multiplication per
+		// commitment
+		var expected *bn256.G1
+		for k, commitment := range commitments {
+			ci := new(bn256.G1).ScalarMult(
+				commitment,
+				pow(memberID, k),
+			)
+			if expected == nil {
+				expected = ci
+			} else {
+				expected = new(bn256.G1).Add(expected, ci)
+			}
+		}
+
+		actual := evaluateCommitments(commitments, memberID)
+		if expected.String() != actual.String() {
+			t.Errorf(
+				"unexpected evaluation for member [%v]\nexpected: [%v]\nactual:   [%v]",
+				memberID,
+				expected,
+				actual,
+			)
+		}
+	}
+}
+
+func TestFindInvalidShares(t *testing.T) {
+	dishonestThreshold := 3
+	groupSize := 7
+
+	var tests = map[string]struct {
+		modifyShares           func(verifications []*sharesVerification)
+		expectedInvalidSenders map[group.MemberIndex]bool
+	}{
+		"all shares valid": {
+			expectedInvalidSenders: map[group.MemberIndex]bool{},
+		},
+		"invalid s share of one member": {
+			modifyShares: func(verifications []*sharesVerification) {
+				verifications[2].shareS = testutils.NewRandInt(
+					verifications[2].shareS,
+					bn256.Order,
+				)
+			},
+			expectedInvalidSenders: map[group.MemberIndex]bool{4: true},
+		},
+		"invalid s and t shares of two members": {
+			modifyShares: func(verifications []*sharesVerification) {
+				verifications[1].shareT = testutils.NewRandInt(
+					verifications[1].shareT,
+					bn256.Order,
+				)
+				verifications[4].shareS = testutils.NewRandInt(
+					verifications[4].shareS,
+					bn256.Order,
+				)
+			},
+			expectedInvalidSenders: map[group.MemberIndex]bool{
+				3: true,
+				6: true,
+			},
+		},
+		"missing commitments": {
+			modifyShares: func(verifications []*sharesVerification) {
+				verifications[0].commitments = []*bn256.G1{}
+			},
+			expectedInvalidSenders: map[group.MemberIndex]bool{2: true},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			member := initializeCommittingMember(dishonestThreshold, groupSize)
+
+			verifications, err := generateSharesVerifications(
+				member,
+				dishonestThreshold,
+				groupSize,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.modifyShares != nil {
+				test.modifyShares(verifications)
+			}
+
+			invalidSenders := member.findInvalidShares(verifications)
+			if !reflect.DeepEqual(test.expectedInvalidSenders, invalidSenders) {
+				t.Fatalf(
+					"unexpected invalid shares senders\nexpected: [%v]\nactual:   [%v]",
+					test.expectedInvalidSenders,
+					invalidSenders,
+				)
+			}
+		})
+	}
+}
+
+func BenchmarkVerifySharesSeparately(b *testing.B) {
+	member, verifications := benchmarkSharesVerifications(b)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, verification := range verifications {
+			if !member.areSharesValidAgainstCommitments(
+				verification.shareS,
+				verification.shareT,
+				verification.commitments,
+				member.ID,
+			) {
+				b.Fatalf("shares of member [%v] are not valid", verification.senderID)
+			}
+		}
+	}
+}
+
+func BenchmarkVerifySharesInBatch(b *testing.B) {
+	member, verifications := benchmarkSharesVerifications(b)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if invalidSenders := member.findInvalidShares(verifications); len(invalidSenders) > 0 {
+			b.Fatalf("shares of members [%v] are not valid", invalidSenders)
+		}
+	}
+}
+
+func benchmarkSharesVerifications(b *testing.B) (
+	*CommittingMember,
+	[]*sharesVerification,
+) {
+	dishonestThreshold := 31
+	groupSize := 64
+
+	member := initializeCommittingMember(dishonestThreshold, groupSize)
+
+	verifications, err := generateSharesVerifications(
+		member,
+		dishonestThreshold,
+		groupSize,
+	)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return member, verifications
+}
+
+// initializeCommittingMember initializes a single committing member with
+// index 1 without generating ephemeral and symmetric keys for the entire
+// group.
+func initializeCommittingMember(
+	dishonestThreshold int,
+	groupSize int,
+) *CommittingMember {
+	keyPairMembers := initializeEphemeralKeyPairMembersGroup(
+		dishonestThreshold,
+		groupSize,
+	)
+
+	return keyPairMembers[0].
+		InitializeSymmetricKeyGeneration().
+		InitializeCommitting()
+}
+
+// generateSharesVerifications generates valid shares and commitments for the
+// given member from all other members of the group.
+func generateSharesVerifications(
+	member *CommittingMember,
+	dishonestThreshold int,
+	groupSize int,
+) ([]*sharesVerification, error) {
+	var verifications []*sharesVerification
+	for i := 1; i <= groupSize; i++ {
+		senderID := group.MemberIndex(i)
+		if senderID == member.ID {
+			continue
+		}
+
+		coefficientsA, err := generatePolynomial(dishonestThreshold)
+		if err != nil {
+			return nil, err
+		}
+		coefficientsB, err := generatePolynomial(dishonestThreshold)
+		if err != nil {
+			return nil, err
+		}
+
+		commitments := make([]*bn256.G1, len(coefficientsA))
+		for k := range commitments {
+			commitments[k] = member.calculateCommitment(
+				coefficientsA[k],
+				coefficientsB[k],
+			)
+		}
+
+		verifications = append(verifications, &sharesVerification{
+			senderID:    senderID,
+			shareS:      member.evaluateMemberShare(member.ID, coefficientsA),
+			shareT:      member.evaluateMemberShare(member.ID, coefficientsB),
+			commitments: commitments,
+		})
+	}
+
+	return verifications, nil
+}
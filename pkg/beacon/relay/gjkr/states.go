@@ -36,6 +36,28 @@ const (
 	combinationStateActiveBlocks = 20
 )
 
+// ProtocolBlocks returns the total number of blocks it takes to execute all
+// the protocol states, from ephemeral key pair generation to finalization.
+func ProtocolBlocks() uint64 {
+	return ephemeralKeyPairStateDelayBlocks +
+		ephemeralKeyPairStateActiveBlocks +
+		commitmentStateDelayBlocks +
+		commitmentStateActiveBlocks +
+		commitmentVerificationStateDelayBlocks +
+		commitmentVerificationStateActiveBlocks +
+		pointsShareStateDelayBlocks +
+		pointsShareStateActiveBlocks +
+		pointsValidationStateDelayBlocks +
+		pointsValidationStateActiveBlocks +
+		keyRevealStateDelayBlocks +
+		keyRevealStateActiveBlocks +
+		combinationStateDelayBlocks +
+		combinationStateActiveBlocks +
+		// symmetric key generation, shares justification, qualification,
+		// points justification, reconstruction and finalization
+		6*(silentStateDelayBlocks+silentStateActiveBlocks)
+}
+
 // ephemeralKeyPairGenerationState is the state during which members broadcast
 // public ephemeral keys generated for other members of the group.
 // `EphemeralPublicKeyMessage`s are valid in this state.
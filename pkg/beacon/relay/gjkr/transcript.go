@@ -0,0 +1,502 @@
+package gjkr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+// Transcript is a record of the distributed key generation executed by
+// a single member. It contains all the protocol messages the member sent and
+// received, grouped by the protocol phase, along with the block heights at
+// which phases were entered and messages were sent and received.
+//
+// Transcript contains also secrets generated by the member in phases 1 and 3
+// so that the protocol execution can be replayed offline, see Replay.
+// Having those secrets, one can recover the group private key share of the
+// member. Transcript must never be revealed publicly and should be stored
+// only in an encrypted form.
+type Transcript struct {
+	Seed               *big.Int                   `json:"seed"`
+	MemberIndex        group.MemberIndex          `json:"memberIndex"`
+	GroupSize          int                        `json:"groupSize"`
+	DishonestThreshold int                        `json:"dishonestThreshold"`
+	GroupMembers       []relaychain.StakerAddress `json:"groupMembers"`
+	StartBlockHeight   uint64                     `json:"startBlockHeight"`
+
+	Phases  []*TranscriptPhase `json:"phases"`
+	Secrets *TranscriptSecrets `json:"secrets"`
+
+	// Error is the error the protocol execution failed with, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// TranscriptPhase is a record of a single protocol phase executed by
+// the member.
+type TranscriptPhase struct {
+	Phase int    `json:"phase"`
+	State string `json:"state"`
+	// StartBlockHeight is the block at which the member entered the phase.
+	StartBlockHeight uint64               `json:"startBlockHeight"`
+	Sent             []*TranscriptMessage `json:"sent"`
+	Received         []*TranscriptMessage `json:"received"`
+}
+
+// TranscriptMessage is a record of a single protocol message.
+type TranscriptMessage struct {
+	BlockHeight uint64 `json:"blockHeight"`
+	Type        string `json:"type"`
+	// SenderPublicKey is the network public key of the sender. It is not set
+	// for messages sent by the member.
+	SenderPublicKey []byte `json:"senderPublicKey,omitempty"`
+	Payload         []byte `json:"payload"`
+}
+
+// TranscriptSecrets are secrets generated by the member during the protocol
+// execution which are required to replay it.
+type TranscriptSecrets struct {
+	// Ephemeral private keys generated in phase 1 for other group members.
+	EphemeralPrivateKeys map[group.MemberIndex][]byte `json:"ephemeralPrivateKeys,omitempty"`
+	// Secret polynomial coefficients and shares generated for itself
+	// by the member in phase 3.
+	SecretCoefficients []*big.Int `json:"secretCoefficients,omitempty"`
+	SelfSecretShareS   *big.Int   `json:"selfSecretShareS,omitempty"`
+	SelfSecretShareT   *big.Int   `json:"selfSecretShareT,omitempty"`
+}
+
+// TranscriptRecorder records the transcript of the distributed key
+// generation executed by a single member. It should be passed to Execute or
+// Resume and used only for a single protocol execution.
+type TranscriptRecorder struct {
+	mutex sync.Mutex
+
+	transcript   *Transcript
+	blockCounter chain.BlockCounter
+	currentPhase *TranscriptPhase
+
+	// Members holding the secrets generated in phases 1 and 3.
+	ephemeralKeyPairGeneratingMember *EphemeralKeyPairGeneratingMember
+	committingMember                 *CommittingMember
+
+	// Secrets restored from the checkpoint the execution has been resumed
+	// from, if any.
+	restoredSecrets *TranscriptSecrets
+
+	checkpointHandler func(checkpoint *Transcript)
+}
+
+// NewTranscriptRecorder creates a recorder for the distributed key generation
+// executed by the group with the given members' addresses, ordered by their
+// member indexes.
+func NewTranscriptRecorder(
+	groupMembers []relaychain.StakerAddress,
+) *TranscriptRecorder {
+	return &TranscriptRecorder{
+		transcript: &Transcript{
+			GroupMembers: groupMembers,
+			Phases:       make([]*TranscriptPhase, 0),
+		},
+	}
+}
+
+// OnCheckpoint sets the handler called with a checkpoint each time the member
+// enters or initiates a protocol state. The checkpoint is the transcript
+// recorded so far, including the member's secrets, in which all the phases
+// have been already initiated. Execution interrupted after the checkpoint has
+// been taken can be resumed from it, see Resume.
+//
+// The handler is called synchronously by the protocol state machine. It has
+// to be set before the protocol execution starts.
+func (tr *TranscriptRecorder) OnCheckpoint(handler func(checkpoint *Transcript)) {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+
+	tr.checkpointHandler = handler
+}
+
+func (tr *TranscriptRecorder) begin(
+	member *LocalMember,
+	seed *big.Int,
+	startBlockHeight uint64,
+	blockCounter chain.BlockCounter,
+) {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+
+	tr.transcript.Seed = seed
+	tr.transcript.MemberIndex = member.ID
+	tr.transcript.GroupSize = member.group.GroupSize()
+	tr.transcript.DishonestThreshold = member.group.DishonestThreshold()
+	tr.transcript.StartBlockHeight = startBlockHeight
+	tr.blockCounter = blockCounter
+}
+
+// resume continues recording of the transcript from the given checkpoint.
+// The last checkpointed phase becomes the current phase.
+func (tr *TranscriptRecorder) resume(
+	checkpoint *Transcript,
+	blockCounter chain.BlockCounter,
+) {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+
+	transcript := *checkpoint
+	transcript.Phases = copyPhases(checkpoint.Phases)
+	transcript.Secrets = nil
+	transcript.Error = ""
+
+	tr.transcript = &transcript
+	tr.restoredSecrets = checkpoint.Secrets
+	tr.currentPhase = transcript.Phases[len(transcript.Phases)-1]
+	tr.blockCounter = blockCounter
+}
+
+func (tr *TranscriptRecorder) end(err error) {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+
+	if err != nil {
+		tr.transcript.Error = err.Error()
+	}
+}
+
+// StateEntered starts recording of the protocol phase executed in the given
+// state. Before that, a checkpoint of all the phases recorded so far is
+// taken. It implements the state.Recorder interface.
+func (tr *TranscriptRecorder) StateEntered(
+	enteredState state.State,
+	blockHeight uint64,
+) {
+	if _, ok := enteredState.(*resumedState); ok {
+		// The phase of the state restored from a checkpoint has been
+		// already recorded.
+		return
+	}
+
+	tr.checkpoint()
+
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+
+	switch keyGenerationState := enteredState.(type) {
+	case *ephemeralKeyPairGenerationState:
+		tr.ephemeralKeyPairGeneratingMember = keyGenerationState.member
+	case *commitmentState:
+		tr.committingMember = keyGenerationState.member
+	}
+
+	phase, stateName := statePhase(enteredState)
+	tr.currentPhase = &TranscriptPhase{
+		Phase:            phase,
+		State:            stateName,
+		StartBlockHeight: blockHeight,
+		Sent:             make([]*TranscriptMessage, 0),
+		Received:         make([]*TranscriptMessage, 0),
+	}
+	tr.transcript.Phases = append(tr.transcript.Phases, tr.currentPhase)
+}
+
+// StateInitiated takes a checkpoint including the phase executed in the given
+// state. It implements the state.Recorder interface.
+func (tr *TranscriptRecorder) StateInitiated(initiatedState state.State) {
+	if _, ok := initiatedState.(*resumedState); ok {
+		return
+	}
+
+	tr.checkpoint()
+}
+
+// checkpoint passes the transcript recorded so far to the checkpoint
+// handler, if it is set. No checkpoint is taken before the first phase is
+// recorded.
+func (tr *TranscriptRecorder) checkpoint() {
+	tr.mutex.Lock()
+	handler := tr.checkpointHandler
+	hasPhases := len(tr.transcript.Phases) > 0
+	tr.mutex.Unlock()
+
+	if handler == nil || !hasPhases {
+		return
+	}
+
+	handler(tr.Transcript())
+}
+
+// MessageReceived records the message received in the current protocol
+// phase. Messages other than the DKG protocol messages are not recorded.
+// It implements the state.Recorder interface.
+func (tr *TranscriptRecorder) MessageReceived(
+	receivingState state.State,
+	message net.Message,
+) {
+	payload, ok := message.Payload().(net.TaggedMarshaler)
+	if !ok || !isProtocolMessageType(payload.Type()) {
+		return
+	}
+
+	transcriptMessage, err := tr.newTranscriptMessage(payload)
+	if err != nil {
+		logger.Warningf("could not record received message: [%v]", err)
+		return
+	}
+	transcriptMessage.SenderPublicKey = message.SenderPublicKey()
+
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+
+	tr.currentPhase.Received = append(
+		tr.currentPhase.Received,
+		transcriptMessage,
+	)
+}
+
+func (tr *TranscriptRecorder) messageSent(message net.TaggedMarshaler) {
+	transcriptMessage, err := tr.newTranscriptMessage(message)
+	if err != nil {
+		logger.Warningf("could not record sent message: [%v]", err)
+		return
+	}
+
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+
+	tr.currentPhase.Sent = append(tr.currentPhase.Sent, transcriptMessage)
+}
+
+func (tr *TranscriptRecorder) newTranscriptMessage(
+	message net.TaggedMarshaler,
+) (*TranscriptMessage, error) {
+	payload, err := message.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not marshal message of type [%v]: [%v]",
+			message.Type(),
+			err,
+		)
+	}
+
+	blockHeight, err := tr.blockCounter.CurrentBlock()
+	if err != nil {
+		return nil, fmt.Errorf("could not get current block: [%v]", err)
+	}
+
+	return &TranscriptMessage{
+		BlockHeight: blockHeight,
+		Type:        message.Type(),
+		Payload:     payload,
+	}, nil
+}
+
+// Transcript returns the transcript recorded so far, including the member's
+// secrets generated until now.
+func (tr *TranscriptRecorder) Transcript() *Transcript {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+
+	secrets := &TranscriptSecrets{}
+	if tr.restoredSecrets != nil {
+		*secrets = *tr.restoredSecrets
+	}
+
+	if tr.ephemeralKeyPairGeneratingMember != nil {
+		secrets.EphemeralPrivateKeys = make(map[group.MemberIndex][]byte)
+		for memberID, keyPair := range tr.ephemeralKeyPairGeneratingMember.ephemeralKeyPairs {
+			secrets.EphemeralPrivateKeys[memberID] = keyPair.PrivateKey.Marshal()
+		}
+	}
+
+	if tr.committingMember != nil {
+		secrets.SecretCoefficients = tr.committingMember.secretCoefficients
+		secrets.SelfSecretShareS = tr.committingMember.selfSecretShareS
+		secrets.SelfSecretShareT = tr.committingMember.selfSecretShareT
+	}
+
+	transcript := *tr.transcript
+	transcript.Phases = copyPhases(tr.transcript.Phases)
+	transcript.Secrets = secrets
+
+	return &transcript
+}
+
+// copyPhases copies the given phases so that the copy is not modified when
+// messages are recorded in the original phases.
+func copyPhases(phases []*TranscriptPhase) []*TranscriptPhase {
+	phasesCopy := make([]*TranscriptPhase, len(phases))
+	for i, phase := range phases {
+		phaseCopy := *phase
+		phaseCopy.Sent = append([]*TranscriptMessage{}, phase.Sent...)
+		phaseCopy.Received = append([]*TranscriptMessage{}, phase.Received...)
+		phasesCopy[i] = &phaseCopy
+	}
+
+	return phasesCopy
+}
+
+func (tr *TranscriptRecorder) channel(
+	channel net.BroadcastChannel,
+) net.BroadcastChannel {
+	return &recordingChannel{channel, tr}
+}
+
+// recordingChannel records all messages sent over the underlying broadcast
+// channel with the transcript recorder.
+type recordingChannel struct {
+	net.BroadcastChannel
+
+	recorder *TranscriptRecorder
+}
+
+func (rc *recordingChannel) Send(
+	ctx context.Context,
+	message net.TaggedMarshaler,
+) error {
+	if err := rc.BroadcastChannel.Send(ctx, message); err != nil {
+		return err
+	}
+
+	rc.recorder.messageSent(message)
+	return nil
+}
+
+// statePhase returns the number of the protocol phase executed in the given
+// state along with the state name.
+func statePhase(keyGenerationState state.State) (int, string) {
+	if resumed, ok := keyGenerationState.(*resumedState); ok {
+		keyGenerationState = resumed.keyGenerationState
+	}
+
+	switch keyGenerationState.(type) {
+	case *ephemeralKeyPairGenerationState:
+		return 1, "ephemeral key pair generation"
+	case *symmetricKeyGenerationState:
+		return 2, "symmetric key generation"
+	case *commitmentState:
+		return 3, "commitment"
+	case *commitmentsVerificationState:
+		return 4, "commitments verification"
+	case *sharesJustificationState:
+		return 5, "shares justification"
+	case *qualificationState:
+		return 6, "qualification"
+	case *pointsShareState:
+		return 7, "points share"
+	case *pointsValidationState:
+		return 8, "points validation"
+	case *pointsJustificationState:
+		return 9, "points justification"
+	case *keyRevealState:
+		return 10, "key reveal"
+	case *reconstructionState:
+		return 11, "reconstruction"
+	case *combinationState:
+		return 12, "combination"
+	case *finalizationState:
+		return 13, "finalization"
+	default:
+		return 0, fmt.Sprintf("%T", keyGenerationState)
+	}
+}
+
+// isProtocolMessageType returns true if the given message type is a type of
+// one of the DKG protocol messages.
+func isProtocolMessageType(messageType string) bool {
+	for _, unmarshaller := range messageUnmarshallers() {
+		if unmarshaller().Type() == messageType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SignedTranscript is a transcript signed with the operator key of the member.
+// The signature lets to prove the transcript has been recorded by the member
+// and has not been modified since then.
+type SignedTranscript struct {
+	// Transcript is the marshalled transcript.
+	Transcript []byte `json:"transcript"`
+	PublicKey  []byte `json:"publicKey"`
+	Signature  []byte `json:"signature"`
+}
+
+// SignTranscript marshals and signs the given transcript with the provided
+// operator signing.
+func SignTranscript(
+	transcript *Transcript,
+	signing chain.Signing,
+) (*SignedTranscript, error) {
+	transcriptBytes, err := json.Marshal(transcript)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal transcript: [%v]", err)
+	}
+
+	signature, err := signing.Sign(transcriptBytes)
+	if err != nil {
+		return nil, fmt.Errorf("could not sign transcript: [%v]", err)
+	}
+
+	return &SignedTranscript{
+		Transcript: transcriptBytes,
+		PublicKey:  signing.PublicKey(),
+		Signature:  signature,
+	}, nil
+}
+
+// Marshal converts the signed transcript to a byte array.
+func (st *SignedTranscript) Marshal() ([]byte, error) {
+	return json.Marshal(st)
+}
+
+// Unmarshal converts a byte array back to the signed transcript.
+func (st *SignedTranscript) Unmarshal(bytes []byte) error {
+	return json.Unmarshal(bytes, st)
+}
+
+// Open verifies the signature of the transcript and returns the transcript
+// if the signature is valid and the signer is the member who recorded the
+// transcript.
+func (st *SignedTranscript) Open(signing chain.Signing) (*Transcript, error) {
+	isValid, err := signing.VerifyWithPublicKey(
+		st.Transcript,
+		st.Signature,
+		st.PublicKey,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not verify transcript signature: [%v]",
+			err,
+		)
+	}
+	if !isValid {
+		return nil, fmt.Errorf("invalid transcript signature")
+	}
+
+	transcript := &Transcript{}
+	if err := json.Unmarshal(st.Transcript, transcript); err != nil {
+		return nil, fmt.Errorf("could not unmarshal transcript: [%v]", err)
+	}
+
+	membershipValidator := group.NewStakersMembershipValidator(
+		transcript.GroupMembers,
+		signing,
+	)
+	if !membershipValidator.IsValidMembership(
+		transcript.MemberIndex,
+		st.PublicKey,
+	) {
+		return nil, fmt.Errorf(
+			"transcript has not been signed by member [%v]",
+			transcript.MemberIndex,
+		)
+	}
+
+	return transcript, nil
+}
@@ -0,0 +1,162 @@
+package gjkr
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/chain/local"
+)
+
+func newTestTranscript(operator []byte) *Transcript {
+	return &Transcript{
+		Seed:               big.NewInt(1234),
+		MemberIndex:        group.MemberIndex(2),
+		GroupSize:          3,
+		DishonestThreshold: 1,
+		GroupMembers: []relaychain.StakerAddress{
+			[]byte("operator-1"),
+			operator,
+			[]byte("operator-3"),
+		},
+		StartBlockHeight: 10,
+		Phases: []*TranscriptPhase{
+			{
+				Phase:            1,
+				State:            "ephemeral key pair generation",
+				StartBlockHeight: 10,
+				Sent: []*TranscriptMessage{
+					{
+						BlockHeight: 11,
+						Type:        "gjkr/ephemeral_public_key",
+						Payload:     []byte{0x01, 0x02},
+					},
+				},
+				Received: []*TranscriptMessage{},
+			},
+		},
+		Secrets: &TranscriptSecrets{
+			EphemeralPrivateKeys: map[group.MemberIndex][]byte{
+				1: {0x03},
+				3: {0x04},
+			},
+		},
+	}
+}
+
+func TestSignedTranscriptOpen(t *testing.T) {
+	signing := local.Connect(3, 2, big.NewInt(10)).Signing()
+	transcript := newTestTranscript(signing.PublicKey())
+
+	signedTranscript, err := SignTranscript(transcript, signing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transcriptBytes, err := signedTranscript.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unmarshalled := &SignedTranscript{}
+	if err := unmarshalled.Unmarshal(transcriptBytes); err != nil {
+		t.Fatal(err)
+	}
+
+	opened, err := unmarshalled.Open(signing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(transcript, opened) {
+		t.Fatalf(
+			"unexpected transcript\nexpected: [%+v]\nactual:   [%+v]",
+			transcript,
+			opened,
+		)
+	}
+}
+
+func TestSignedTranscriptOpenModified(t *testing.T) {
+	signing := local.Connect(3, 2, big.NewInt(10)).Signing()
+	transcript := newTestTranscript(signing.PublicKey())
+
+	signedTranscript, err := SignTranscript(transcript, signing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signedTranscript.Transcript[len(signedTranscript.Transcript)-2] ^= 0x01
+
+	_, err = signedTranscript.Open(signing)
+	if err == nil || err.Error() != "invalid transcript signature" {
+		t.Fatalf("expected invalid signature error; has: [%v]", err)
+	}
+}
+
+func TestSignedTranscriptOpenNotSignedByMember(t *testing.T) {
+	signing := local.Connect(3, 2, big.NewInt(10)).Signing()
+	transcript := newTestTranscript([]byte("operator-2"))
+
+	signedTranscript, err := SignTranscript(transcript, signing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = signedTranscript.Open(signing)
+	if err == nil {
+		t.Fatal("expected error for transcript not signed by the member")
+	}
+}
+
+func TestTranscriptRecorderCheckpoints(t *testing.T) {
+	recorder := NewTranscriptRecorder([]relaychain.StakerAddress{})
+
+	checkpoints := make([]*Transcript, 0)
+	recorder.OnCheckpoint(func(checkpoint *Transcript) {
+		checkpoints = append(checkpoints, checkpoint)
+	})
+
+	assertCheckpointedPhases := func(expectedPhases ...[]int) {
+		if len(checkpoints) != len(expectedPhases) {
+			t.Fatalf(
+				"unexpected number of checkpoints\nexpected: [%v]\nactual:   [%v]",
+				len(expectedPhases),
+				len(checkpoints),
+			)
+		}
+
+		for i, checkpoint := range checkpoints {
+			phases := make([]int, 0)
+			for _, phase := range checkpoint.Phases {
+				phases = append(phases, phase.Phase)
+			}
+
+			if !reflect.DeepEqual(expectedPhases[i], phases) {
+				t.Errorf(
+					"unexpected phases of checkpoint [%v]\n"+
+						"expected: [%v]\nactual:   [%v]",
+					i,
+					expectedPhases[i],
+					phases,
+				)
+			}
+		}
+	}
+
+	// No checkpoint is taken before the first phase is recorded.
+	recorder.StateEntered(&ephemeralKeyPairGenerationState{}, 10)
+	assertCheckpointedPhases()
+
+	recorder.StateInitiated(&ephemeralKeyPairGenerationState{})
+	recorder.StateEntered(&symmetricKeyGenerationState{}, 16)
+	recorder.StateInitiated(&symmetricKeyGenerationState{})
+	assertCheckpointedPhases([]int{1}, []int{1}, []int{1, 2})
+
+	// States restored from a checkpoint are not checkpointed again.
+	recorder.StateEntered(&resumedState{&commitmentState{}}, 16)
+	recorder.StateInitiated(&resumedState{&commitmentState{}})
+	assertCheckpointedPhases([]int{1}, []int{1}, []int{1, 2})
+}
@@ -30,6 +30,15 @@ const (
 	miningLag = uint64(12)
 )
 
+// Chain defines the subset of the relay chain interface used to submit
+// tickets.
+type Chain interface {
+	relaychain.GroupSelectionInterface
+	// CurrentGasPrice returns the gas price the chain currently expects
+	// transactions to be submitted with.
+	CurrentGasPrice() (*big.Int, error)
+}
+
 // Result represents the result of group selection protocol. It contains the
 // list of all stakers selected to the candidate group as well as the number of
 // block at which the group selection protocol completed.
@@ -60,6 +69,9 @@ type Result struct {
 // After the last round, there is a 12 blocks mining lag allowing all
 // outstanding ticket submissions to have a higher chance of being
 // mined before the deadline.
+//
+// If the profitability policy is provided, tickets are submitted only if
+// the reward expected for them covers the cost of their submission.
 func CandidateToNewGroup(
 	relayChain relaychain.Interface,
 	blockCounter chain.BlockCounter,
@@ -67,6 +79,7 @@ func CandidateToNewGroup(
 	staker chain.Staker,
 	newEntry *big.Int,
 	startBlockHeight uint64,
+	policy *ProfitabilityPolicy,
 	onGroupSelected func(*Result),
 ) error {
 	availableStake, err := staker.Stake()
@@ -92,6 +105,7 @@ func CandidateToNewGroup(
 		blockCounter,
 		chainConfig,
 		startBlockHeight,
+		policy,
 	)
 	if err != nil {
 		logger.Errorf("ticket submission terminated with error: [%v]", err)
@@ -132,10 +146,11 @@ func CandidateToNewGroup(
 
 func submitTickets(
 	tickets []*ticket,
-	relayChain relaychain.GroupSelectionInterface,
+	relayChain Chain,
 	blockCounter chain.BlockCounter,
 	chainConfig *config.Chain,
 	startBlockHeight uint64,
+	policy *ProfitabilityPolicy,
 ) error {
 	rounds, err := calculateRoundsCount(chainConfig.TicketSubmissionTimeout)
 	if err != nil {
@@ -171,6 +186,20 @@ func submitTickets(
 			return err
 		}
 
+		if policy != nil {
+			candidateTickets, err = profitableTickets(
+				relayChain,
+				policy,
+				candidateTickets,
+				roundIndex,
+				roundLeadingZeros,
+				chainConfig.GroupSize,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
 		logger.Infof(
 			"ticket submission round [%v] submitting "+
 				"[%v] tickets",
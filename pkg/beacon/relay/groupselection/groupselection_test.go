@@ -78,7 +78,8 @@ func TestSubmitTickets(t *testing.T) {
 				chain,
 				blockCounter,
 				chainConfig,
-				0, // start block height
+				0,   // start block height
+				nil, // submit all qualifying tickets
 			)
 			if err != nil {
 				t.Fatal(err)
@@ -317,8 +318,10 @@ func TestRoundCandidateTickets(t *testing.T) {
 }
 
 type stubGroupInterface struct {
-	groupSize        int
-	submittedTickets []*chain.Ticket
+	groupSize           int
+	submittedTickets    []*chain.Ticket
+	ticketSubmissionGas uint64
+	gasPrice            *big.Int
 }
 
 func (stg *stubGroupInterface) SubmitTicket(ticket *chain.Ticket) *async.EventGroupTicketSubmissionPromise {
@@ -346,6 +349,19 @@ func (stg *stubGroupInterface) SubmitTicket(ticket *chain.Ticket) *async.EventGr
 	return promise
 }
 
+func (stg *stubGroupInterface) SubmitTicketGasEstimate(
+	ticket *chain.Ticket,
+) (uint64, error) {
+	return stg.ticketSubmissionGas, nil
+}
+
+func (stg *stubGroupInterface) CurrentGasPrice() (*big.Int, error) {
+	if stg.gasPrice == nil {
+		return big.NewInt(0), nil
+	}
+	return stg.gasPrice, nil
+}
+
 func (stg *stubGroupInterface) GetSubmittedTickets() ([]uint64, error) {
 	tickets := make([]uint64, len(stg.submittedTickets))
 
@@ -0,0 +1,224 @@
+package groupselection
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// ticketValueSpace is the number of all possible ticket values, 2^64.
+var ticketValueSpace = math.Exp2(64)
+
+// ProfitabilityPolicy decides whether submitting a ticket is worth its gas
+// cost. A ticket is submitted only if the reward expected for a seat in
+// a group, weighted by the estimated probability of the ticket being
+// selected, is no lower than the cost of the ticket submission transaction.
+//
+// The policy does not depend on the chain, so it can be evaluated offline
+// for any set of submitted tickets, ticket submission round and cost.
+type ProfitabilityPolicy struct {
+	// SeatReward is the reward in wei the operator expects to earn for
+	// a single seat in a group over the lifetime of the group.
+	SeatReward *big.Int
+}
+
+// TicketEvaluation is the result of the profitability policy evaluation
+// for a single ticket. Amounts are in wei.
+type TicketEvaluation struct {
+	SelectionProbability float64
+	ExpectedReward       *big.Int
+	SubmissionCost       *big.Int
+}
+
+// IsProfitable returns true if the reward expected for the ticket covers the
+// cost of its submission.
+func (te *TicketEvaluation) IsProfitable() bool {
+	return te.ExpectedReward.Cmp(te.SubmissionCost) >= 0
+}
+
+// Evaluate estimates the reward expected for the ticket with the given value
+// submitted in the given round, and compares it against the submission cost.
+// Submitted tickets are the tickets already submitted on-chain by all
+// candidates.
+func (pp *ProfitabilityPolicy) Evaluate(
+	ticketValue uint64,
+	submittedTickets []uint64,
+	roundIndex uint64,
+	roundLeadingZeros uint64,
+	groupSize int,
+	submissionCost *big.Int,
+) *TicketEvaluation {
+	probability := SelectionProbability(
+		ticketValue,
+		submittedTickets,
+		roundIndex,
+		roundLeadingZeros,
+		groupSize,
+	)
+
+	expectedReward, _ := new(big.Float).Mul(
+		new(big.Float).SetInt(pp.SeatReward),
+		big.NewFloat(probability),
+	).Int(nil)
+
+	return &TicketEvaluation{
+		SelectionProbability: probability,
+		ExpectedReward:       expectedReward,
+		SubmissionCost:       submissionCost,
+	}
+}
+
+// SelectionProbability estimates the probability of the ticket with the
+// given value, submitted in the given round, being selected to the group of
+// the given size.
+//
+// Tickets with more leading zeros than the ones covered by the current round
+// have been due in the previous rounds, so the submitted tickets lower than
+// the round boundary, 2^(63-roundLeadingZeros), are considered complete.
+// Their number determines the density of all tickets over the ticket value
+// space. The number of not yet submitted tickets lower than the given ticket
+// is then estimated from that density and assumed to follow the Poisson
+// distribution. The ticket is selected if less than group size tickets in
+// total are lower than it.
+//
+// In the first round, no tickets have been due yet, so only the submitted
+// tickets are taken into account.
+func SelectionProbability(
+	ticketValue uint64,
+	submittedTickets []uint64,
+	roundIndex uint64,
+	roundLeadingZeros uint64,
+	groupSize int,
+) float64 {
+	completeBoundary := uint64(0)
+	if roundIndex > 0 && roundLeadingZeros < 64 {
+		completeBoundary = uint64(1) << (63 - roundLeadingZeros)
+	}
+
+	// The chain keeps at most group size lowest tickets. Tickets lower than
+	// the boundary are never dropped before the higher ones, so the number
+	// of complete tickets is exact as long as there are free seats left.
+	lowerSubmitted := 0
+	completeSubmitted := 0
+	for _, submittedTicket := range submittedTickets {
+		if submittedTicket < ticketValue {
+			lowerSubmitted++
+		}
+		if submittedTicket < completeBoundary {
+			completeSubmitted++
+		}
+	}
+
+	freeSeats := groupSize - lowerSubmitted
+	if freeSeats <= 0 {
+		return 0
+	}
+
+	if completeBoundary == 0 || ticketValue <= completeBoundary {
+		return 1
+	}
+
+	density := float64(completeSubmitted) / float64(completeBoundary)
+	lowerUnknown := density*float64(ticketValue-completeBoundary) -
+		float64(lowerSubmitted-completeSubmitted)
+	if lowerUnknown < 0 {
+		lowerUnknown = 0
+	}
+
+	return poissonCDF(lowerUnknown, freeSeats-1)
+}
+
+// poissonCDF returns the probability of a Poisson-distributed variable with
+// the given mean being no greater than k.
+func poissonCDF(mean float64, k int) float64 {
+	term := math.Exp(-mean)
+	cdf := term
+	for i := 1; i <= k; i++ {
+		term *= mean / float64(i)
+		cdf += term
+	}
+
+	return math.Min(cdf, 1)
+}
+
+// profitableTickets returns the leading candidate tickets, sorted in
+// ascending order by their value, which are profitable to submit according
+// to the given policy. Tickets with higher values have lower chances of
+// being selected, so the first unprofitable ticket ends the evaluation.
+func profitableTickets(
+	relayChain Chain,
+	policy *ProfitabilityPolicy,
+	candidateTickets []*ticket,
+	roundIndex uint64,
+	roundLeadingZeros uint64,
+	groupSize int,
+) ([]*ticket, error) {
+	if len(candidateTickets) == 0 {
+		return candidateTickets, nil
+	}
+
+	submissionCost, err := ticketSubmissionCost(relayChain, candidateTickets[0])
+	if err != nil {
+		return nil, err
+	}
+
+	submittedTickets, err := relayChain.GetSubmittedTickets()
+	if err != nil {
+		return nil, fmt.Errorf("could not get submitted tickets: [%v]", err)
+	}
+
+	for i, candidateTicket := range candidateTickets {
+		evaluation := policy.Evaluate(
+			candidateTicket.intValue().Uint64(),
+			submittedTickets,
+			roundIndex,
+			roundLeadingZeros,
+			groupSize,
+			submissionCost,
+		)
+
+		if !evaluation.IsProfitable() {
+			logger.Infof(
+				"skipping [%v] tickets starting from ticket [0x%x] "+
+					"with selection probability [%.4f]; expected reward "+
+					"[%v] wei is lower than submission cost [%v] wei",
+				len(candidateTickets)-i,
+				candidateTicket.value,
+				evaluation.SelectionProbability,
+				evaluation.ExpectedReward,
+				evaluation.SubmissionCost,
+			)
+			return candidateTickets[:i], nil
+		}
+	}
+
+	return candidateTickets, nil
+}
+
+// ticketSubmissionCost estimates the cost in wei of submitting the given
+// ticket with the current gas price. All ticket submissions consume about
+// the same amount of gas so the estimate is used for all tickets of a round.
+func ticketSubmissionCost(
+	relayChain Chain,
+	candidateTicket *ticket,
+) (*big.Int, error) {
+	chainTicket, err := toChainTicket(candidateTicket)
+	if err != nil {
+		return nil, err
+	}
+
+	gasEstimate, err := relayChain.SubmitTicketGasEstimate(chainTicket)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not estimate ticket submission gas: [%v]",
+			err,
+		)
+	}
+
+	gasPrice, err := relayChain.CurrentGasPrice()
+	if err != nil {
+		return nil, fmt.Errorf("could not get current gas price: [%v]", err)
+	}
+
+	return new(big.Int).Mul(new(big.Int).SetUint64(gasEstimate), gasPrice), nil
+}
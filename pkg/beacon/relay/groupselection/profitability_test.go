@@ -0,0 +1,177 @@
+package groupselection
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/config"
+	"github.com/keep-network/keep-core/pkg/chain/local"
+)
+
+func TestSelectionProbability(t *testing.T) {
+	roundBoundary := uint64(1) << 62
+
+	var tests = map[string]struct {
+		ticketValue         uint64
+		submittedTickets    []uint64
+		roundIndex          uint64
+		roundLeadingZeros   uint64
+		expectedProbability float64
+	}{
+		"first round with free seats": {
+			ticketValue:         1001,
+			submittedTickets:    []uint64{1000},
+			roundIndex:          0,
+			roundLeadingZeros:   5,
+			expectedProbability: 1,
+		},
+		"all seats taken by lower tickets": {
+			ticketValue:         1005,
+			submittedTickets:    []uint64{1001, 1002, 1003, 1004},
+			roundIndex:          0,
+			roundLeadingZeros:   5,
+			expectedProbability: 0,
+		},
+		"ticket lower than the round boundary": {
+			ticketValue:         roundBoundary - 1,
+			submittedTickets:    []uint64{1001, 1002, 1003},
+			roundIndex:          1,
+			roundLeadingZeros:   1,
+			expectedProbability: 1,
+		},
+		// Two tickets below the boundary let expect one more ticket in the
+		// first half of the range above it. The ticket is selected if there
+		// is at most one such ticket.
+		"ticket above the round boundary": {
+			ticketValue:         roundBoundary + roundBoundary/2,
+			submittedTickets:    []uint64{1001, 1002},
+			roundIndex:          1,
+			roundLeadingZeros:   1,
+			expectedProbability: 2 * math.Exp(-1),
+		},
+		"tickets above the round boundary submitted already": {
+			ticketValue:         roundBoundary + roundBoundary/2,
+			submittedTickets:    []uint64{1001, 1002, roundBoundary + 1},
+			roundIndex:          1,
+			roundLeadingZeros:   1,
+			expectedProbability: 1,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			probability := SelectionProbability(
+				test.ticketValue,
+				test.submittedTickets,
+				test.roundIndex,
+				test.roundLeadingZeros,
+				4,
+			)
+
+			if math.Abs(probability-test.expectedProbability) > 1e-9 {
+				t.Errorf(
+					"unexpected probability\nexpected: [%v]\nactual:   [%v]",
+					test.expectedProbability,
+					probability,
+				)
+			}
+		})
+	}
+}
+
+func TestProfitabilityPolicyEvaluate(t *testing.T) {
+	policy := &ProfitabilityPolicy{SeatReward: big.NewInt(1000000)}
+
+	evaluation := policy.Evaluate(
+		1001,
+		[]uint64{},
+		0,
+		5,
+		4,
+		big.NewInt(1000000),
+	)
+	if !evaluation.IsProfitable() {
+		t.Errorf("ticket covering its submission cost should be profitable")
+	}
+
+	evaluation = policy.Evaluate(
+		1001,
+		[]uint64{},
+		0,
+		5,
+		4,
+		big.NewInt(1000001),
+	)
+	if evaluation.IsProfitable() {
+		t.Errorf("ticket not covering its submission cost should not be " +
+			"profitable")
+	}
+}
+
+func TestSubmitOnlyProfitableTickets(t *testing.T) {
+	tickets := []*ticket{
+		newTestTicket(1, 1001),
+		newTestTicket(2, 1002),
+	}
+
+	var tests = map[string]struct {
+		seatReward               int64
+		expectedSubmittedTickets []uint64
+	}{
+		"reward covers the submission cost": {
+			seatReward:               2000000,
+			expectedSubmittedTickets: []uint64{1001, 1002},
+		},
+		"reward lower than the submission cost": {
+			seatReward:               1999999,
+			expectedSubmittedTickets: []uint64{},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			chainConfig := &config.Chain{
+				GroupSize:               4,
+				TicketSubmissionTimeout: 24,
+			}
+
+			chain := &stubGroupInterface{
+				groupSize:           chainConfig.GroupSize,
+				ticketSubmissionGas: 100000,
+				gasPrice:            big.NewInt(20),
+			}
+
+			blockCounter, err := local.BlockCounter()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = submitTickets(
+				tickets,
+				chain,
+				blockCounter,
+				chainConfig,
+				0, // start block height
+				&ProfitabilityPolicy{SeatReward: big.NewInt(test.seatReward)},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			submittedTickets, err := chain.GetSubmittedTickets()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(test.expectedSubmittedTickets, submittedTickets) {
+				t.Fatalf(
+					"unexpected submitted tickets\nexpected: [%v]\nactual:   [%v]",
+					test.expectedSubmittedTickets,
+					submittedTickets,
+				)
+			}
+		})
+	}
+}
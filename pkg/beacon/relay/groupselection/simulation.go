@@ -0,0 +1,261 @@
+package groupselection
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/config"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/gen/async"
+	"github.com/keep-network/keep-core/pkg/subscription"
+)
+
+// SimulatedStaker is a staker taking part in a simulated group selection.
+type SimulatedStaker struct {
+	Address relaychain.StakerAddress
+	Stake   *big.Int
+}
+
+// SimulationParameters are the parameters of a simulated group selection.
+type SimulationParameters struct {
+	// ChainConfig provides the group size, the minimum stake and the ticket
+	// submission timeout determining the number of submission rounds.
+	ChainConfig *config.Chain
+	// TicketSubmissionGas is the gas used by a single ticket submission.
+	TicketSubmissionGas uint64
+	// GasPrice is the gas price in wei ticket submissions are paid with.
+	GasPrice *big.Int
+	// Policy, if set, is applied by all stakers to decide which tickets are
+	// worth submitting.
+	Policy *ProfitabilityPolicy
+}
+
+// StakerSimulation summarizes a simulated group selection for a single
+// staker.
+type StakerSimulation struct {
+	Address          relaychain.StakerAddress
+	Stake            *big.Int
+	Tickets          int
+	SubmittedTickets int
+	Seats            int
+	GasUsed          uint64
+}
+
+// Simulation is the result of a simulated group selection.
+type Simulation struct {
+	Seed             *big.Int
+	Stakers          []*StakerSimulation
+	SelectedStakers  []relaychain.StakerAddress
+	SubmittedTickets int
+}
+
+// Simulate runs the group selection with the given seed for the given
+// stakers without interacting with any chain. Every staker generates tickets
+// and submits them in rounds just like CandidateToNewGroup does. Tickets
+// submitted in a round become visible to other stakers in the next round.
+// The group is selected from the lowest tickets submitted in all rounds.
+func Simulate(
+	seed *big.Int,
+	stakers []*SimulatedStaker,
+	parameters *SimulationParameters,
+) (*Simulation, error) {
+	chainConfig := parameters.ChainConfig
+
+	rounds, err := calculateRoundsCount(chainConfig.TicketSubmissionTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	relayChain := newSimulatedChain(stakers, parameters)
+
+	stakerTickets := make([][]*ticket, len(stakers))
+	simulation := &Simulation{
+		Seed:    seed,
+		Stakers: make([]*StakerSimulation, len(stakers)),
+	}
+	for i, staker := range stakers {
+		stakerTickets[i], err = generateTickets(
+			seed.Bytes(),
+			staker.Address,
+			staker.Stake,
+			chainConfig.MinimumStake,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not generate tickets of staker [0x%x]: [%v]",
+				staker.Address,
+				err,
+			)
+		}
+
+		simulation.Stakers[i] = &StakerSimulation{
+			Address: staker.Address,
+			Stake:   staker.Stake,
+			Tickets: len(stakerTickets[i]),
+		}
+	}
+
+	for roundIndex := uint64(0); roundIndex <= rounds; roundIndex++ {
+		roundLeadingZeros := rounds - roundIndex
+
+		for i := range stakers {
+			candidateTickets, err := roundCandidateTickets(
+				relayChain,
+				stakerTickets[i],
+				roundIndex,
+				roundLeadingZeros,
+				chainConfig.GroupSize,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			if parameters.Policy != nil {
+				candidateTickets, err = profitableTickets(
+					relayChain,
+					parameters.Policy,
+					candidateTickets,
+					roundIndex,
+					roundLeadingZeros,
+					chainConfig.GroupSize,
+				)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			submitTicketsOnChain(candidateTickets, relayChain)
+
+			simulation.Stakers[i].SubmittedTickets += len(candidateTickets)
+			simulation.Stakers[i].GasUsed +=
+				uint64(len(candidateTickets)) * parameters.TicketSubmissionGas
+			simulation.SubmittedTickets += len(candidateTickets)
+		}
+
+		relayChain.mineRound()
+	}
+
+	for _, selected := range relayChain.submitted {
+		simulation.Stakers[selected.staker].Seats++
+	}
+
+	simulation.SelectedStakers, err = relayChain.GetSelectedParticipants()
+	if err != nil {
+		return nil, err
+	}
+
+	return simulation, nil
+}
+
+type simulatedTicket struct {
+	value  uint64
+	staker int
+}
+
+// simulatedChain keeps tickets submitted in a simulated group selection.
+// Like the operator contract, it keeps only the group size lowest tickets.
+// Tickets submitted in the current round are pending until the round is
+// mined.
+type simulatedChain struct {
+	parameters *SimulationParameters
+	addresses  []relaychain.StakerAddress
+	// key is the staker value of tickets as a decimal string
+	stakers map[string]int
+
+	submitted []*simulatedTicket
+	pending   []*simulatedTicket
+}
+
+func newSimulatedChain(
+	stakers []*SimulatedStaker,
+	parameters *SimulationParameters,
+) *simulatedChain {
+	addresses := make([]relaychain.StakerAddress, len(stakers))
+	stakerIndices := make(map[string]int)
+	for i, staker := range stakers {
+		addresses[i] = staker.Address
+		stakerIndices[new(big.Int).SetBytes(staker.Address).String()] = i
+	}
+
+	return &simulatedChain{
+		parameters: parameters,
+		addresses:  addresses,
+		stakers:    stakerIndices,
+	}
+}
+
+func (sc *simulatedChain) OnGroupSelectionStarted(
+	func(groupSelectionStarted *event.GroupSelectionStart),
+) (subscription.EventSubscription, error) {
+	return nil, fmt.Errorf("simulated chain does not emit events")
+}
+
+func (sc *simulatedChain) SubmitTicket(
+	ticket *relaychain.Ticket,
+) *async.EventGroupTicketSubmissionPromise {
+	promise := &async.EventGroupTicketSubmissionPromise{}
+
+	staker, ok := sc.stakers[ticket.Proof.StakerValue.String()]
+	if !ok {
+		promise.Fail(fmt.Errorf("unknown staker of ticket [0x%x]", ticket.Value))
+		return promise
+	}
+
+	value := new(big.Int).SetBytes(ticket.Value[:])
+	sc.pending = append(sc.pending, &simulatedTicket{
+		value:  value.Uint64(),
+		staker: staker,
+	})
+
+	promise.Fulfill(&event.GroupTicketSubmission{TicketValue: value})
+
+	return promise
+}
+
+func (sc *simulatedChain) SubmitTicketGasEstimate(
+	ticket *relaychain.Ticket,
+) (uint64, error) {
+	return sc.parameters.TicketSubmissionGas, nil
+}
+
+func (sc *simulatedChain) GetSubmittedTickets() ([]uint64, error) {
+	tickets := make([]uint64, len(sc.submitted))
+	for i, ticket := range sc.submitted {
+		tickets[i] = ticket.value
+	}
+
+	return tickets, nil
+}
+
+func (sc *simulatedChain) GetSelectedParticipants() (
+	[]relaychain.StakerAddress,
+	error,
+) {
+	selected := make([]relaychain.StakerAddress, len(sc.submitted))
+	for i, ticket := range sc.submitted {
+		selected[i] = sc.addresses[ticket.staker]
+	}
+
+	return selected, nil
+}
+
+func (sc *simulatedChain) CurrentGasPrice() (*big.Int, error) {
+	return sc.parameters.GasPrice, nil
+}
+
+// mineRound makes tickets submitted in the current round visible to all
+// stakers and drops all but the group size lowest tickets.
+func (sc *simulatedChain) mineRound() {
+	sc.submitted = append(sc.submitted, sc.pending...)
+	sc.pending = nil
+
+	sort.SliceStable(sc.submitted, func(i, j int) bool {
+		return sc.submitted[i].value < sc.submitted[j].value
+	})
+
+	if groupSize := sc.parameters.ChainConfig.GroupSize; len(sc.submitted) > groupSize {
+		sc.submitted = sc.submitted[:groupSize]
+	}
+}
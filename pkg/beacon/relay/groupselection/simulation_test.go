@@ -0,0 +1,127 @@
+package groupselection
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/config"
+)
+
+func newSimulatedStakers() []*SimulatedStaker {
+	return []*SimulatedStaker{
+		{
+			Address: relaychain.StakerAddress([]byte("staker-1")),
+			Stake:   big.NewInt(3000),
+		},
+		{
+			Address: relaychain.StakerAddress([]byte("staker-2")),
+			Stake:   big.NewInt(1000),
+		},
+		{
+			Address: relaychain.StakerAddress([]byte("staker-3")),
+			Stake:   big.NewInt(500),
+		},
+	}
+}
+
+func newSimulationParameters(groupSize int) *SimulationParameters {
+	return &SimulationParameters{
+		ChainConfig: &config.Chain{
+			GroupSize:               groupSize,
+			TicketSubmissionTimeout: 6*11 + 12,
+			MinimumStake:            big.NewInt(10),
+		},
+		TicketSubmissionGas: 100000,
+		GasPrice:            big.NewInt(20),
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	stakers := newSimulatedStakers()
+	parameters := newSimulationParameters(16)
+
+	simulation, err := Simulate(big.NewInt(1234567), stakers, parameters)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(simulation.SelectedStakers) != 16 {
+		t.Fatalf(
+			"unexpected number of selected stakers\nexpected: [%v]\nactual:   [%v]",
+			16,
+			len(simulation.SelectedStakers),
+		)
+	}
+
+	seats := 0
+	submitted := 0
+	for i, staker := range simulation.Stakers {
+		expectedTickets := int(
+			new(big.Int).Div(stakers[i].Stake, big.NewInt(10)).Int64(),
+		)
+		if staker.Tickets != expectedTickets {
+			t.Errorf(
+				"unexpected number of tickets of staker [%v]\n"+
+					"expected: [%v]\nactual:   [%v]",
+				i,
+				expectedTickets,
+				staker.Tickets,
+			)
+		}
+		if staker.Seats > staker.SubmittedTickets {
+			t.Errorf("staker [%v] has more seats than submitted tickets", i)
+		}
+		if staker.GasUsed != uint64(staker.SubmittedTickets)*100000 {
+			t.Errorf("unexpected gas used by staker [%v]", i)
+		}
+
+		seats += staker.Seats
+		submitted += staker.SubmittedTickets
+	}
+
+	if seats != 16 {
+		t.Errorf("unexpected number of seats\nexpected: [%v]\nactual:   [%v]", 16, seats)
+	}
+	if submitted != simulation.SubmittedTickets {
+		t.Errorf("submitted tickets of stakers do not sum up to the total")
+	}
+
+	// The protocol should not require submitting all tickets.
+	if submitted >= 450 {
+		t.Errorf("all [%v] tickets have been submitted", submitted)
+	}
+
+	repeated, err := Simulate(big.NewInt(1234567), stakers, parameters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(simulation, repeated) {
+		t.Errorf("simulation with the same seed should give the same result")
+	}
+}
+
+func TestSimulateWithProfitabilityPolicy(t *testing.T) {
+	stakers := newSimulatedStakers()
+	parameters := newSimulationParameters(16)
+
+	// A seat reward lower than the cost of a single ticket submission.
+	parameters.Policy = &ProfitabilityPolicy{SeatReward: big.NewInt(1999999)}
+
+	simulation, err := Simulate(big.NewInt(1234567), stakers, parameters)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if simulation.SubmittedTickets != 0 {
+		t.Errorf(
+			"unexpected number of submitted tickets\nexpected: [%v]\nactual:   [%v]",
+			0,
+			simulation.SubmittedTickets,
+		)
+	}
+	if len(simulation.SelectedStakers) != 0 {
+		t.Errorf("no stakers should be selected")
+	}
+}
@@ -83,6 +83,12 @@ func (mgi *mockGroupInterface) SubmitTicket(
 	panic("unexpected")
 }
 
+func (mgi *mockGroupInterface) SubmitTicketGasEstimate(
+	ticket *chain.Ticket,
+) (uint64, error) {
+	panic("not implemented")
+}
+
 func (mgi *mockGroupInterface) GetSubmittedTickets() ([]uint64, error) {
 	panic("not implemented")
 }
@@ -6,6 +6,7 @@ import (
 	"crypto/rand"
 	"fmt"
 	"math/big"
+	"sort"
 	"testing"
 
 	"github.com/keep-network/keep-core/pkg/beacon/relay/entry"
@@ -282,6 +283,118 @@ func TestSigningWithInvalidSignatureShare(t *testing.T) {
 	}
 }
 
+// Success: members 1, 3 and 4 are seats of one node and sign in one session,
+// broadcasting their shares in one message.
+func TestAllMembersSigningWithSeats(t *testing.T) {
+	t.Parallel()
+
+	interceptor := func(msg net.TaggedMarshaler) net.TaggedMarshaler {
+		return msg
+	}
+
+	signingMembersCount := groupSize
+	dkgResult, signingResult := runTestWithSeats(
+		t,
+		groupSize,
+		honestThreshold,
+		signingMembersCount,
+		interceptor,
+		[]group.MemberIndex{1, 3, 4},
+	)
+
+	dkgtest.AssertDkgResultPublished(t, dkgResult)
+	dkgtest.AssertSamePublicKey(t, dkgResult)
+	entrytest.AssertEntryPublished(t, signingResult)
+	entrytest.AssertNoSignerFailures(t, signingResult)
+
+	groupPublicKey, err := getFirstGroupPublicKey(dkgResult)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newEntry, err := signingResult.EntryValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bls.VerifyG1(groupPublicKey, previousEntryG1(), newEntry) {
+		t.Errorf("threshold signature failed BLS verification")
+	}
+}
+
+// Success: members 1, 2 and 3 are seats of one node and member 2 share sent
+// in their common message, as well as in its own message, is invalid. Other
+// shares from the message are accepted.
+func TestSigningWithSeatsAndInvalidSignatureShare(t *testing.T) {
+	t.Parallel()
+
+	interceptor := func(msg net.TaggedMarshaler) net.TaggedMarshaler {
+		_, randomG1, err := bn256.RandomG1(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if signatureShareMessage, ok := msg.(*entry.SignatureShareMessage); ok {
+			if signatureShareMessage.SenderID() == group.MemberIndex(2) {
+				return entry.NewSignatureShareMessage(
+					signatureShareMessage.SenderID(),
+					randomG1.Marshal(),
+				)
+			}
+			return msg
+		}
+
+		signatureSharesMessage, ok := msg.(*entry.SignatureSharesMessage)
+		if !ok {
+			return msg
+		}
+
+		shares := make([]*entry.SignatureShareMessage, 0)
+		for _, share := range signatureSharesMessage.Shares() {
+			if share.SenderID() == group.MemberIndex(2) {
+				share = entry.NewSignatureShareMessage(
+					share.SenderID(),
+					randomG1.Marshal(),
+				)
+			}
+			shares = append(shares, share)
+		}
+
+		return entry.NewSignatureSharesMessage(shares)
+	}
+
+	// Only the seats and three other members sign, so the invalid share
+	// must be rejected and shares of members 1 and 3 accepted to reach the
+	// honest threshold.
+	signingMembersCount := honestThreshold
+	dkgResult, signingResult := runTestWithSeats(
+		t,
+		groupSize,
+		honestThreshold,
+		signingMembersCount,
+		interceptor,
+		[]group.MemberIndex{1, 2, 3},
+	)
+
+	dkgtest.AssertDkgResultPublished(t, dkgResult)
+	dkgtest.AssertSamePublicKey(t, dkgResult)
+	entrytest.AssertEntryPublished(t, signingResult)
+
+	groupPublicKey, err := getFirstGroupPublicKey(dkgResult)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newEntry, err := signingResult.EntryValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bls.VerifyG1(groupPublicKey, previousEntryG1(), newEntry) {
+		t.Errorf("threshold signature failed BLS verification")
+	}
+}
+
 func runTest(t *testing.T, groupSize, honestThreshold, honestSignersCount int) (
 	*dkgtest.Result,
 	*entrytest.Result,
@@ -306,20 +419,52 @@ func runTestWithInterceptor(
 ) (
 	*dkgtest.Result,
 	*entrytest.Result,
+) {
+	return runTestWithSeats(
+		t,
+		groupSize,
+		honestThreshold,
+		honestSignersCount,
+		interceptor,
+		nil,
+	)
+}
+
+func runTestWithSeats(
+	t *testing.T,
+	groupSize, honestThreshold, honestSignersCount int,
+	interceptor func(msg net.TaggedMarshaler) net.TaggedMarshaler,
+	seatIndexes []group.MemberIndex,
+) (
+	*dkgtest.Result,
+	*entrytest.Result,
 ) {
 	dkgSeed := dkgtest.RandomSeed(t)
-	dkgResult, err := dkgtest.RunTest(groupSize, honestThreshold, dkgSeed, interceptor)
+	dkgResult, err := dkgtest.RunTestWithSeats(
+		groupSize,
+		honestThreshold,
+		dkgSeed,
+		interceptor,
+		seatIndexes,
+	)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	signers := dkgResult.GetSigners()[0:honestSignersCount]
+	// Signers are ordered by member index, so that seats with the lowest
+	// indexes are among the signing members.
+	signers := dkgResult.GetSigners()
+	sort.Slice(signers, func(i, j int) bool {
+		return signers[i].MemberID() < signers[j].MemberID()
+	})
+	signers = signers[0:honestSignersCount]
 
-	signingResult, err := entrytest.RunTest(
+	signingResult, err := entrytest.RunTestWithSeats(
 		signers,
 		honestThreshold,
 		interceptor,
 		previousEntry(),
+		seatIndexes,
 	)
 	if err != nil {
 		t.Fatal(err)
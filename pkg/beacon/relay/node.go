@@ -2,12 +2,16 @@ package relay
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"sort"
 	"sync"
+	"time"
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-common/pkg/persistence"
 	"github.com/keep-network/keep-core/pkg/altbn128"
 
 	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
@@ -16,6 +20,8 @@ import (
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/config"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/entry"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/groupselection"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
 	"github.com/keep-network/keep-core/pkg/chain"
@@ -36,6 +42,142 @@ type Node struct {
 	chainConfig  *config.Chain
 
 	groupRegistry *registry.Groups
+
+	// persistence stores checkpoints of distributed key generation
+	// processes and relay entry signing sessions executed by the node.
+	persistence persistence.Handle
+
+	// transcriptPersistence stores transcripts of distributed key
+	// generation processes executed by the node. Transcripts are never read
+	// by the node so they are stored apart from the data read on start.
+	transcriptPersistence persistence.Handle
+
+	// draining is set when the node no longer accepts new work and waits
+	// for the already running signing sessions to complete.
+	draining        bool
+	signingSessions sync.WaitGroup
+
+	keyGenerations map[keyGenerationID]*KeyGeneration
+
+	// restoredSigningSessions are persisted signing sessions restored on
+	// start, by previous entry, waiting to be resumed.
+	restoredSigningSessions map[string]*entry.Session
+}
+
+// KeyGeneration describes a distributed key generation process this node
+// participates in.
+type KeyGeneration struct {
+	Seed        string            `json:"seed"`
+	MemberIndex group.MemberIndex `json:"memberIndex"`
+	StartBlock  uint64            `json:"startBlock"`
+}
+
+type keyGenerationID struct {
+	seed        string
+	memberIndex group.MemberIndex
+}
+
+// KeyGenerations returns all distributed key generation processes this node
+// currently participates in, ordered by the seed and member index.
+func (n *Node) KeyGenerations() []KeyGeneration {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	keyGenerations := make([]KeyGeneration, 0, len(n.keyGenerations))
+	for _, keyGeneration := range n.keyGenerations {
+		keyGenerations = append(keyGenerations, *keyGeneration)
+	}
+
+	sort.Slice(keyGenerations, func(i, j int) bool {
+		if keyGenerations[i].Seed != keyGenerations[j].Seed {
+			return keyGenerations[i].Seed < keyGenerations[j].Seed
+		}
+		return keyGenerations[i].MemberIndex < keyGenerations[j].MemberIndex
+	})
+
+	return keyGenerations
+}
+
+// startKeyGeneration registers a new key generation process. Returns false
+// if the same process is already in progress and should not be started.
+func (n *Node) startKeyGeneration(keyGeneration *KeyGeneration) bool {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.keyGenerations == nil {
+		n.keyGenerations = make(map[keyGenerationID]*KeyGeneration)
+	}
+
+	id := keyGenerationID{keyGeneration.Seed, keyGeneration.MemberIndex}
+	if _, ok := n.keyGenerations[id]; ok {
+		return false
+	}
+
+	n.keyGenerations[id] = keyGeneration
+	return true
+}
+
+func (n *Node) completeKeyGeneration(keyGeneration *KeyGeneration) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	id := keyGenerationID{keyGeneration.Seed, keyGeneration.MemberIndex}
+	delete(n.keyGenerations, id)
+}
+
+// Drain switches the node into the drain mode in which it refuses to join
+// new groups and to start new signing sessions. It then waits for all already
+// running signing sessions to complete. If they do not complete before the
+// provided timeout, an error is returned.
+func (n *Node) Drain(timeout time.Duration) error {
+	n.mutex.Lock()
+	n.draining = true
+	n.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		n.signingSessions.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(timeout):
+		return fmt.Errorf(
+			"signing sessions did not complete within [%v]",
+			timeout,
+		)
+	}
+}
+
+// IsDraining returns true if the node is in the drain mode and does not
+// accept any new work.
+func (n *Node) IsDraining() bool {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	return n.draining
+}
+
+// startSigningSession registers a new signing session unless the node is
+// in the drain mode. Returns false if the session should not be started.
+// Each successfully started session must be completed with
+// completeSigningSession.
+func (n *Node) startSigningSession() bool {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.draining {
+		return false
+	}
+
+	n.signingSessions.Add(1)
+	return true
+}
+
+func (n *Node) completeSigningSession() {
+	n.signingSessions.Done()
 }
 
 // IsInGroup checks if this node is a member of the group which was selected to
@@ -52,12 +194,24 @@ func (n *Node) IsInGroup(groupPublicKey []byte) bool {
 //
 // Indirectly, the completion of the process is signaled by the formation of an
 // on-chain group containing at least one of this node's virtual stakers.
+//
+// Key generation is aborted when the provided context is done. If the node is
+// in the drain mode, it does not join the group.
 func (n *Node) JoinGroupIfEligible(
+	ctx context.Context,
 	relayChain relaychain.Interface,
 	signing chain.Signing,
 	groupSelectionResult *groupselection.Result,
 	newEntry *big.Int,
 ) {
+	if n.IsDraining() {
+		logger.Warningf(
+			"node is draining; not joining group selected with seed [0x%x]",
+			newEntry,
+		)
+		return
+	}
+
 	dkgStartBlockHeight := groupSelectionResult.GroupSelectionEndBlock
 
 	if len(groupSelectionResult.SelectedStakers) > maxGroupSize {
@@ -76,73 +230,469 @@ func (n *Node) JoinGroupIfEligible(
 		}
 	}
 
-	// create temporary broadcast channel name for DKG using the
-	// group selection seed
-	channelName := newEntry.Text(16)
-
 	if len(indexes) > 0 {
-		broadcastChannel, err := n.netProvider.BroadcastChannelFor(channelName)
+		broadcastChannel, membershipValidator, err := n.keyGenerationChannel(
+			newEntry,
+			groupSelectionResult.SelectedStakers,
+			signing,
+		)
 		if err != nil {
 			logger.Errorf("failed to get broadcast channel: [%v]", err)
 			return
 		}
 
-		membershipValidator := group.NewStakersMembershipValidator(
-			groupSelectionResult.SelectedStakers,
+		// If the node has been selected to the group more than once, its
+		// members execute the key generation as seats sharing the work.
+		var seats *gjkr.Seats
+		if len(indexes) > 1 {
+			memberIndexes := make([]group.MemberIndex, len(indexes))
+			for i, index := range indexes {
+				memberIndexes[i] = group.MemberIndex(index + 1)
+			}
+			seats = gjkr.NewSeats(memberIndexes)
+		}
+
+		for _, index := range indexes {
+			go n.generateGroupKey(
+				ctx,
+				relayChain,
+				signing,
+				newEntry,
+				index,
+				groupSelectionResult.SelectedStakers,
+				dkgStartBlockHeight,
+				broadcastChannel,
+				membershipValidator,
+				nil,
+				seats,
+			)
+		}
+	}
+
+	return
+}
+
+// ResumeKeyGenerationsIfEligible enables a client to rejoin distributed key
+// generations it was taking part in before it was crashed or restarted. Key
+// generations are resumed from the latest checkpoints stored with the node
+// persistence, if the current block is still inside the GJKR protocol window.
+// Checkpoints of key generations which can no longer be resumed are archived.
+func (n *Node) ResumeKeyGenerationsIfEligible(
+	ctx context.Context,
+	relayChain relaychain.Interface,
+	signing chain.Signing,
+) {
+	if n.persistence == nil || n.IsDraining() {
+		return
+	}
+
+	checkpoints := dkg.ReadCheckpoints(n.persistence)
+	if len(checkpoints) == 0 {
+		return
+	}
+
+	currentBlock, err := n.blockCounter.CurrentBlock()
+	if err != nil {
+		logger.Errorf("could not get the current block: [%v]", err)
+		return
+	}
+
+	for _, checkpoint := range checkpoints {
+		memberIndex := checkpoint.MemberIndex
+
+		protocolEndBlock := checkpoint.StartBlockHeight + gjkr.ProtocolBlocks()
+		if currentBlock >= protocolEndBlock {
+			logger.Warningf(
+				"[member:%v] could not resume key generation with seed "+
+					"[0x%x]; protocol ended at block [%v]",
+				memberIndex,
+				checkpoint.Seed,
+				protocolEndBlock,
+			)
+			n.archiveCheckpoints(checkpoint.Seed, memberIndex)
+			continue
+		}
+
+		if memberIndex < 1 ||
+			int(memberIndex) > len(checkpoint.GroupMembers) ||
+			int(memberIndex) > maxGroupSize ||
+			!bytes.Equal(
+				checkpoint.GroupMembers[memberIndex-1],
+				n.Staker.Address(),
+			) {
+			logger.Warningf(
+				"[member:%v] not resuming key generation with seed [0x%x]; "+
+					"member has not been selected to the group",
+				memberIndex,
+				checkpoint.Seed,
+			)
+			continue
+		}
+
+		broadcastChannel, membershipValidator, err := n.keyGenerationChannel(
+			checkpoint.Seed,
+			checkpoint.GroupMembers,
 			signing,
 		)
+		if err != nil {
+			logger.Errorf("failed to get broadcast channel: [%v]", err)
+			continue
+		}
 
-		err = broadcastChannel.SetFilter(membershipValidator.IsInGroup)
+		logger.Infof(
+			"[member:%v] attempting to resume key generation with seed [0x%x]",
+			memberIndex,
+			checkpoint.Seed,
+		)
+
+		go n.generateGroupKey(
+			ctx,
+			relayChain,
+			signing,
+			checkpoint.Seed,
+			uint8(memberIndex-1),
+			checkpoint.GroupMembers,
+			checkpoint.StartBlockHeight,
+			broadcastChannel,
+			membershipValidator,
+			checkpoint,
+			nil,
+		)
+	}
+}
+
+// keyGenerationChannel returns the broadcast channel for the distributed key
+// generation with the given seed. The channel accepts only messages from the
+// selected group members.
+func (n *Node) keyGenerationChannel(
+	seed *big.Int,
+	selectedStakers []relaychain.StakerAddress,
+	signing chain.Signing,
+) (net.BroadcastChannel, group.MembershipValidator, error) {
+	// create temporary broadcast channel name for DKG using the
+	// group selection seed
+	channelName := seed.Text(16)
+
+	broadcastChannel, err := n.netProvider.BroadcastChannelFor(channelName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	membershipValidator := group.NewStakersMembershipValidator(
+		selectedStakers,
+		signing,
+	)
+
+	err = broadcastChannel.SetFilter(membershipValidator.IsInGroup)
+	if err != nil {
+		logger.Errorf(
+			"could not set filter for channel [%v]: [%v]",
+			broadcastChannel.Name(),
+			err,
+		)
+	}
+
+	return broadcastChannel, membershipValidator, nil
+}
+
+// generateGroupKey executes the distributed key generation as the member with
+// the given index, starting with 0, and registers the group once the key
+// generation succeeds. If the checkpoint is not nil, the key generation is
+// resumed from it. The member state is checkpointed with the node
+// persistence as the protocol proceeds. Checkpoints are archived once the key
+// generation ends, unless it is interrupted by the context being done. If the
+// seats are not nil, the member executes the key generation along with other
+// seats of the node, see gjkr.Seats. Key generations resumed from checkpoints
+// are executed by each member separately.
+func (n *Node) generateGroupKey(
+	ctx context.Context,
+	relayChain relaychain.Interface,
+	signing chain.Signing,
+	seed *big.Int,
+	playerIndex uint8,
+	selectedStakers []relaychain.StakerAddress,
+	startBlockHeight uint64,
+	broadcastChannel net.BroadcastChannel,
+	membershipValidator group.MembershipValidator,
+	checkpoint *gjkr.Transcript,
+	seats *gjkr.Seats,
+) {
+	keyGeneration := &KeyGeneration{
+		Seed:        seed.Text(16),
+		MemberIndex: group.MemberIndex(playerIndex + 1),
+		StartBlock:  startBlockHeight,
+	}
+	if !n.startKeyGeneration(keyGeneration) {
+		logger.Warningf(
+			"[member:%v] key generation with seed [0x%x] is already "+
+				"in progress",
+			keyGeneration.MemberIndex,
+			seed,
+		)
+		return
+	}
+	defer n.completeKeyGeneration(keyGeneration)
+
+	transcriptRecorder := gjkr.NewTranscriptRecorder(selectedStakers)
+	transcriptRecorder.OnCheckpoint(n.saveCheckpoint)
+
+	var signer *dkg.ThresholdSigner
+	var err error
+	if checkpoint == nil {
+		signer, err = dkg.ExecuteDKG(
+			ctx,
+			seed,
+			playerIndex,
+			n.chainConfig.GroupSize,
+			n.chainConfig.DishonestThreshold(),
+			membershipValidator,
+			startBlockHeight,
+			n.blockCounter,
+			relayChain,
+			signing,
+			broadcastChannel,
+			transcriptRecorder,
+			seats,
+		)
+	} else {
+		signer, err = dkg.ResumeDKG(
+			ctx,
+			checkpoint,
+			membershipValidator,
+			n.blockCounter,
+			relayChain,
+			signing,
+			broadcastChannel,
+			transcriptRecorder,
+		)
+	}
+
+	n.saveTranscript(transcriptRecorder.Transcript(), signing)
+
+	if ctx.Err() != nil {
+		// The key generation has been interrupted, most probably because
+		// the client is shutting down. Checkpoints are kept so that the key
+		// generation can be resumed on the next start.
+		logger.Warningf(
+			"[member:%v] key generation with seed [0x%x] interrupted: [%v]",
+			keyGeneration.MemberIndex,
+			seed,
+			ctx.Err(),
+		)
+		return
+	}
+
+	n.archiveCheckpoints(seed, keyGeneration.MemberIndex)
+
+	if err != nil {
+		logger.Errorf("failed to execute dkg: [%v]", err)
+		return
+	}
+
+	// final broadcast channel name for group is the compressed
+	// public key of the group
+	channelName := hex.EncodeToString(
+		signer.GroupPublicKeyBytesCompressed(),
+	)
+
+	err = n.groupRegistry.RegisterGroup(signer, channelName)
+	if err != nil {
+		logger.Errorf("failed to register a group: [%v]", err)
+	}
+
+	logger.Infof(
+		"[member:%v] ready to operate in the group",
+		signer.MemberID(),
+	)
+}
+
+// saveTranscript stores the transcript of the distributed key generation
+// so that it can be replayed offline later.
+func (n *Node) saveTranscript(transcript *gjkr.Transcript, signing chain.Signing) {
+	if n.transcriptPersistence == nil {
+		return
+	}
+
+	err := dkg.SaveTranscript(n.transcriptPersistence, transcript, signing)
+	if err != nil {
+		logger.Errorf(
+			"[member:%v] could not save DKG transcript: [%v]",
+			transcript.MemberIndex,
+			err,
+		)
+	}
+}
+
+// saveCheckpoint stores the checkpoint of the distributed key generation so
+// that it can be resumed if the client is restarted.
+func (n *Node) saveCheckpoint(checkpoint *gjkr.Transcript) {
+	if n.persistence == nil {
+		return
+	}
+
+	if err := dkg.SaveCheckpoint(n.persistence, checkpoint); err != nil {
+		logger.Errorf(
+			"[member:%v] could not save DKG checkpoint: [%v]",
+			checkpoint.MemberIndex,
+			err,
+		)
+	}
+}
+
+// archiveCheckpoints archives checkpoints of the distributed key generation
+// which has completed or can no longer be resumed.
+func (n *Node) archiveCheckpoints(seed *big.Int, memberIndex group.MemberIndex) {
+	if n.persistence == nil {
+		return
+	}
+
+	if err := dkg.ArchiveCheckpoints(n.persistence, seed, memberIndex); err != nil {
+		logger.Errorf(
+			"[member:%v] could not archive DKG checkpoints: [%v]",
+			memberIndex,
+			err,
+		)
+	}
+}
+
+// RestoreSigningSessions reads relay entry signing sessions persisted with
+// the node persistence handle before the client was restarted. The session
+// of the relay request currently in progress is resumed once the node starts
+// signing for that request again. Other sessions can no longer complete and
+// are archived.
+func (n *Node) RestoreSigningSessions(relayChain relayChain.Interface) {
+	if n.persistence == nil {
+		return
+	}
+
+	sessions := entry.ReadSessions(n.persistence)
+	if len(sessions) == 0 {
+		return
+	}
+
+	// Sessions are kept persisted if the current request could not be
+	// determined, so that they are restored on the next start.
+	isEntryInProgress, err := relayChain.IsEntryInProgress()
+	if err != nil {
+		logger.Errorf(
+			"failed checking if an entry is in progress: [%v]",
+			err,
+		)
+		return
+	}
+
+	var previousEntry, groupPublicKey []byte
+	if isEntryInProgress {
+		previousEntry, err = relayChain.CurrentRequestPreviousEntry()
 		if err != nil {
 			logger.Errorf(
-				"could not set filter for channel [%v]: [%v]",
-				broadcastChannel.Name(),
+				"failed to get a previous entry for the current request: [%v]",
 				err,
 			)
+			return
 		}
+		groupPublicKey, err = relayChain.CurrentRequestGroupPublicKey()
+		if err != nil {
+			logger.Errorf(
+				"failed to get a group public key for the current request: [%v]",
+				err,
+			)
+			return
+		}
+	}
 
-		for _, index := range indexes {
-			// capture player index for goroutine
-			playerIndex := index
-
-			go func() {
-				signer, err := dkg.ExecuteDKG(
-					newEntry,
-					playerIndex,
-					n.chainConfig.GroupSize,
-					n.chainConfig.DishonestThreshold(),
-					membershipValidator,
-					dkgStartBlockHeight,
-					n.blockCounter,
-					relayChain,
-					signing,
-					broadcastChannel,
-				)
-				if err != nil {
-					logger.Errorf("failed to execute dkg: [%v]", err)
-					return
-				}
-
-				// final broadcast channel name for group is the compressed
-				// public key of the group
-				channelName := hex.EncodeToString(
-					signer.GroupPublicKeyBytesCompressed(),
-				)
-
-				err = n.groupRegistry.RegisterGroup(signer, channelName)
-				if err != nil {
-					logger.Errorf("failed to register a group: [%v]", err)
-				}
-
-				logger.Infof(
-					"[member:%v] ready to operate in the group",
-					signer.MemberID(),
-				)
-			}()
+	isCurrentRequest := func(session *entry.Session) bool {
+		return isEntryInProgress &&
+			bytes.Equal(session.PreviousEntry, previousEntry) &&
+			bytes.Equal(session.GroupPublicKey, groupPublicKey)
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	for _, session := range sessions {
+		if !isCurrentRequest(session) {
+			logger.Infof(
+				"archiving signing session for previous entry [0x%x]; "+
+					"relay request is no longer in progress",
+				session.PreviousEntry,
+			)
+			n.archiveSigningSession(session.PreviousEntry)
+			continue
+		}
+
+		if n.restoredSigningSessions == nil {
+			n.restoredSigningSessions = make(map[string]*entry.Session)
 		}
+
+		logger.Infof(
+			"restored signing session for previous entry [0x%x] "+
+				"with [%v] valid signature shares",
+			session.PreviousEntry,
+			len(session.ValidShares),
+		)
+		n.restoredSigningSessions[hex.EncodeToString(session.PreviousEntry)] =
+			session
+	}
+}
+
+// takeSigningSession returns the restored signing session of the relay
+// request with the given previous entry, group public key and start block.
+// The session is returned only once. It returns nil if there is no such
+// session.
+func (n *Node) takeSigningSession(
+	previousEntry []byte,
+	groupPublicKey []byte,
+	startBlockHeight uint64,
+) *entry.Session {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	key := hex.EncodeToString(previousEntry)
+	session, ok := n.restoredSigningSessions[key]
+	if !ok {
+		return nil
 	}
+	delete(n.restoredSigningSessions, key)
 
-	return
+	if !bytes.Equal(session.GroupPublicKey, groupPublicKey) ||
+		session.StartBlockHeight != startBlockHeight {
+		return nil
+	}
+
+	return session
+}
+
+// saveSigningSession stores the relay entry signing session so that it can be
+// resumed if the client is restarted.
+func (n *Node) saveSigningSession(session *entry.Session) {
+	if n.persistence == nil {
+		return
+	}
+
+	if err := entry.SaveSession(n.persistence, session); err != nil {
+		logger.Errorf(
+			"could not save signing session for previous entry [0x%x]: [%v]",
+			session.PreviousEntry,
+			err,
+		)
+	}
+}
+
+// archiveSigningSession archives the relay entry signing session which has
+// completed or can no longer be resumed.
+func (n *Node) archiveSigningSession(previousEntry []byte) {
+	if n.persistence == nil {
+		return
+	}
+
+	if err := entry.ArchiveSession(n.persistence, previousEntry); err != nil {
+		logger.Errorf(
+			"could not archive signing session for previous entry [0x%x]: [%v]",
+			previousEntry,
+			err,
+		)
+	}
 }
 
 // ForwardSignatureShares enables the ability to forward signature shares
@@ -160,7 +710,10 @@ func (n *Node) ForwardSignatureShares(groupPublicKeyBytes []byte) {
 
 // ResumeSigningIfEligible enables a client to rejoin the ongoing signing process
 // after it was crashed or restarted and if it belongs to the signing group.
+// If the signing session has been restored with RestoreSigningSessions, it is
+// resumed with all the signature shares collected before the restart.
 func (n *Node) ResumeSigningIfEligible(
+	ctx context.Context,
 	relayChain relayChain.Interface,
 	signing chain.Signing,
 ) {
@@ -204,6 +757,7 @@ func (n *Node) ResumeSigningIfEligible(
 			groupPublicKey,
 		)
 		n.GenerateRelayEntry(
+			ctx,
 			previousEntry,
 			relayChain,
 			signing,
@@ -22,6 +22,8 @@ type Groups struct {
 	relayChain relaychain.GroupRegistrationInterface
 
 	storage storage
+
+	registrationHandlers []func(membership *Membership)
 }
 
 // Membership represents a member of a group
@@ -66,9 +68,22 @@ func (g *Groups) RegisterGroup(
 
 	g.myGroups[groupPublicKey] = append(g.myGroups[groupPublicKey], membership)
 
+	for _, handler := range g.registrationHandlers {
+		go handler(membership)
+	}
+
 	return nil
 }
 
+// OnGroupRegistered registers a handler which is invoked every time a new
+// membership is registered in the registry.
+func (g *Groups) OnGroupRegistered(handler func(membership *Membership)) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.registrationHandlers = append(g.registrationHandlers, handler)
+}
+
 // GetGroup gets a group by a groupPublicKey
 func (g *Groups) GetGroup(groupPublicKey []byte) []*Membership {
 	g.mutex.Lock()
@@ -77,6 +92,20 @@ func (g *Groups) GetGroup(groupPublicKey []byte) []*Membership {
 	return g.myGroups[groupKeyToString(groupPublicKey)]
 }
 
+// GetGroups returns memberships in all groups this client is a member of,
+// keyed by the group public key in an uncompressed, hexadecimal form.
+func (g *Groups) GetGroups() map[string][]*Membership {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	groups := make(map[string][]*Membership, len(g.myGroups))
+	for groupPublicKey, memberships := range g.myGroups {
+		groups[groupPublicKey] = append([]*Membership{}, memberships...)
+	}
+
+	return groups
+}
+
 // UnregisterStaleGroups lookup for groups that have been marked as stale
 // on-chain. A stale group is a group that has expired and a certain time passed
 // after the group expiration. This guarantees the group will not be selected to
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"reflect"
 	"testing"
+	"time"
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
 	"github.com/keep-network/keep-common/pkg/persistence"
@@ -75,6 +76,64 @@ func TestRegisterGroup(t *testing.T) {
 	}
 }
 
+func TestOnGroupRegistered(t *testing.T) {
+	chain := chainLocal.Connect(5, 3, big.NewInt(200)).ThresholdRelay()
+
+	gr := NewGroupRegistry(chain, persistenceMock)
+
+	registeredMemberships := make(chan *Membership, 1)
+	gr.OnGroupRegistered(func(membership *Membership) {
+		registeredMemberships <- membership
+	})
+
+	gr.RegisterGroup(signer1, channelName1)
+
+	select {
+	case membership := <-registeredMemberships:
+		if membership.Signer != signer1 {
+			t.Errorf("unexpected signer of the registered membership")
+		}
+		if membership.ChannelName != channelName1 {
+			t.Errorf(
+				"unexpected channel name\nexpected: [%v]\nactual:   [%v]",
+				channelName1,
+				membership.ChannelName,
+			)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("registration handler has not been invoked")
+	}
+}
+
+func TestGetGroups(t *testing.T) {
+	chain := chainLocal.Connect(5, 3, big.NewInt(200)).ThresholdRelay()
+
+	gr := NewGroupRegistry(chain, persistenceMock)
+
+	gr.RegisterGroup(signer1, channelName1)
+	gr.RegisterGroup(signer2, channelName2)
+	gr.RegisterGroup(signer4, channelName2)
+
+	groups := gr.GetGroups()
+
+	if len(groups) != 2 {
+		t.Fatalf(
+			"Unexpected number of groups \nExpected: [%+v]\nActual:   [%+v]",
+			2,
+			len(groups),
+		)
+	}
+
+	memberships := groups[hex.EncodeToString(signer2.GroupPublicKeyBytes())]
+	if len(memberships) != 2 {
+		t.Fatalf(
+			"Unexpected number of group memberships \nExpected: [%+v]\nActual:   [%+v]",
+			2,
+			len(memberships),
+		)
+	}
+}
+
 func TestLoadGroup(t *testing.T) {
 	chain := chainLocal.Connect(5, 3, big.NewInt(200)).ThresholdRelay()
 	gr := NewGroupRegistry(chain, persistenceMock)
@@ -206,12 +265,14 @@ func (phm *persistenceHandleMock) ReadAll() (<-chan persistence.DataDescriptor,
 		ChannelName: channelName2,
 	}).Marshal()
 
-	outputData := make(chan persistence.DataDescriptor, 3)
+	outputData := make(chan persistence.DataDescriptor, 4)
 	outputErrors := make(chan error)
 
-	outputData <- &testDataDescriptor{"1", "dir", membershipBytes1}
-	outputData <- &testDataDescriptor{"2", "dir", membershipBytes2}
-	outputData <- &testDataDescriptor{"3", "dir", membershipBytes3}
+	outputData <- &testDataDescriptor{"membership_1", "dir", membershipBytes1}
+	outputData <- &testDataDescriptor{"membership_2", "dir", membershipBytes2}
+	outputData <- &testDataDescriptor{"membership_3", "dir", membershipBytes3}
+	// Data other than memberships stored with the same handle.
+	outputData <- &testDataDescriptor{"other", "other_dir", []byte{0x01}}
 
 	close(outputData)
 	close(outputErrors)
@@ -2,6 +2,7 @@ package registry
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/keep-network/keep-common/pkg/persistence"
@@ -9,6 +10,11 @@ import (
 	"encoding/hex"
 )
 
+// membershipFilePrefix is the prefix of names of all files storing group
+// memberships. Other files may be stored with the same persistence handle
+// and they are not read by the registry.
+const membershipFilePrefix = "membership_"
+
 type storage interface {
 	save(membership *Membership) error
 	readAll() (<-chan *Membership, <-chan error)
@@ -33,7 +39,7 @@ func (ps *persistentStorage) save(membership *Membership) error {
 
 	hexGroupPublicKey := hex.EncodeToString(membership.Signer.GroupPublicKeyBytesCompressed())
 
-	return ps.handle.Save(membershipBytes, hexGroupPublicKey, "/membership_"+fmt.Sprint(membership.Signer.MemberID()))
+	return ps.handle.Save(membershipBytes, hexGroupPublicKey, "/"+membershipFilePrefix+fmt.Sprint(membership.Signer.MemberID()))
 }
 
 func (ps *persistentStorage) archive(groupPublicKeyCompressed []byte) error {
@@ -79,6 +85,10 @@ func (ps *persistentStorage) readAll() (<-chan *Membership, <-chan error) {
 	// error to an output errors channel.
 	go func() {
 		for descriptor := range inputData {
+			if !strings.HasPrefix(descriptor.Name(), membershipFilePrefix) {
+				continue
+			}
+
 			content, err := descriptor.Content()
 			if err != nil {
 				outputErrors <- fmt.Errorf(
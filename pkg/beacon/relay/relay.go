@@ -1,10 +1,14 @@
 package relay
 
 import (
+	"context"
+
 	"github.com/ipfs/go-log"
+	"github.com/keep-network/keep-common/pkg/persistence"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 
 	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/entry"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 
@@ -26,13 +30,17 @@ func NewNode(
 	blockCounter chain.BlockCounter,
 	chainConfig *config.Chain,
 	groupRegistry *registry.Groups,
+	persistence persistence.Handle,
+	transcriptPersistence persistence.Handle,
 ) Node {
 	return Node{
-		Staker:        staker,
-		netProvider:   netProvider,
-		blockCounter:  blockCounter,
-		chainConfig:   chainConfig,
-		groupRegistry: groupRegistry,
+		Staker:                staker,
+		netProvider:           netProvider,
+		blockCounter:          blockCounter,
+		chainConfig:           chainConfig,
+		groupRegistry:         groupRegistry,
+		persistence:           persistence,
+		transcriptPersistence: transcriptPersistence,
 	}
 }
 
@@ -40,7 +48,9 @@ func NewNode(
 // When a processing group which is supposed to deliver a relay entry does not
 // fulfill its work, then this Node notifies the chain about it. In the case of
 // delivering a relay entry by a processing group, this Node does nothing.
+// Monitoring stops when the provided context is done.
 func (n *Node) MonitorRelayEntry(
+	ctx context.Context,
 	relayChain relayChain.Interface,
 	relayRequestBlockNumber uint64,
 	chainConfig *config.Chain,
@@ -85,6 +95,10 @@ func (n *Node) MonitorRelayEntry(
 				entry.BlockNumber,
 			)
 			return
+		case <-ctx.Done():
+			subscription.Unsubscribe()
+			logger.Infof("stopped monitoring chain for a new relay entry")
+			return
 		}
 	}
 }
@@ -96,7 +110,17 @@ func (n *Node) MonitorRelayEntry(
 // Note that this function returns immediately after determining whether the
 // node is or is not a member of the requested group, and signature creation
 // and submission is performed in a background goroutine.
+//
+// Signing is aborted when the provided context is done. If the node is in the
+// drain mode, no new signing session is started. Signing sessions started
+// before the node entered the drain mode are allowed to complete.
+//
+// The signing session is persisted with the node persistence handle so that
+// it can be resumed if the client is restarted. If a session for the same
+// relay request has been restored, see RestoreSigningSessions, signing is
+// resumed from it.
 func (n *Node) GenerateRelayEntry(
+	ctx context.Context,
 	previousEntry []byte,
 	relayChain relayChain.Interface,
 	signing chain.Signing,
@@ -137,24 +161,57 @@ func (n *Node) GenerateRelayEntry(
 		)
 	}
 
-	for _, member := range memberships {
-		go func(member *registry.Membership) {
-			err = entry.SignAndSubmit(
-				n.blockCounter,
-				channel,
-				relayChain,
-				previousEntry,
-				n.chainConfig.HonestThreshold,
-				member.Signer,
-				startBlockHeight,
-			)
-			if err != nil {
-				logger.Errorf(
-					"error creating threshold signature: [%v]",
-					err,
-				)
-				return
-			}
-		}(member)
+	signers := make([]*dkg.ThresholdSigner, len(memberships))
+	for i, member := range memberships {
+		signers[i] = member.Signer
+	}
+
+	if !n.startSigningSession() {
+		logger.Warningf(
+			"node is draining; not signing relay entry "+
+				"for previous entry [0x%x]",
+			previousEntry,
+		)
+		return
 	}
+
+	session := n.takeSigningSession(
+		previousEntry,
+		groupPublicKey,
+		startBlockHeight,
+	)
+
+	// All the seats this node has in the group sign in one session, sharing
+	// the message loop and broadcasting their shares together.
+	go func() {
+		defer n.completeSigningSession()
+
+		err := entry.SignAndSubmit(
+			ctx,
+			n.blockCounter,
+			channel,
+			relayChain,
+			previousEntry,
+			n.chainConfig.HonestThreshold,
+			signers,
+			membershipValidator,
+			startBlockHeight,
+			session,
+			n.saveSigningSession,
+		)
+
+		// Session cancelled because the client is shutting down is kept so
+		// that it can be resumed on restart.
+		if ctx.Err() == nil {
+			n.archiveSigningSession(previousEntry)
+		}
+
+		if err != nil {
+			logger.Errorf(
+				"error creating threshold signature: [%v]",
+				err,
+			)
+			return
+		}
+	}()
 }
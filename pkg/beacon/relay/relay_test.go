@@ -1,12 +1,22 @@
 package relay
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
 	"math/big"
+	"os"
+	"reflect"
 	"testing"
+	"time"
 
+	"github.com/keep-network/keep-common/pkg/persistence"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/config"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/entry"
 	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
+	netLocal "github.com/keep-network/keep-core/pkg/net/local"
 )
 
 var address = "0x65ea55c1f10491038425725dc00dffeab2a1e28a"
@@ -33,6 +43,7 @@ func TestMonitorRelayEntryOnChain_EntrySubmitted(t *testing.T) {
 	}
 
 	go node.MonitorRelayEntry(
+		context.Background(),
 		relayChain,
 		startBlockHeight,
 		chainConfig,
@@ -91,6 +102,7 @@ func TestMonitorRelayEntryOnChain_EntryNotSubmitted(t *testing.T) {
 	}
 
 	go node.MonitorRelayEntry(
+		context.Background(),
 		relayChain,
 		startBlockHeight,
 		chainConfig,
@@ -121,3 +133,232 @@ func TestMonitorRelayEntryOnChain_EntryNotSubmitted(t *testing.T) {
 		)
 	}
 }
+
+func TestDrain_NoSigningSessions(t *testing.T) {
+	node := &Node{}
+
+	err := node.Drain(100 * time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+
+	if !node.IsDraining() {
+		t.Errorf("node should be draining")
+	}
+}
+
+func TestDrain_WaitsForSigningSessions(t *testing.T) {
+	node := &Node{}
+
+	if !node.startSigningSession() {
+		t.Fatalf("signing session should be started")
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		node.completeSigningSession()
+	}()
+
+	err := node.Drain(time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+}
+
+func TestDrain_Timeout(t *testing.T) {
+	node := &Node{}
+
+	if !node.startSigningSession() {
+		t.Fatalf("signing session should be started")
+	}
+	defer node.completeSigningSession()
+
+	err := node.Drain(50 * time.Millisecond)
+	if err == nil {
+		t.Fatalf("expected drain timeout error")
+	}
+}
+
+func TestDrain_RefusesNewSigningSessions(t *testing.T) {
+	node := &Node{}
+
+	err := node.Drain(100 * time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+
+	if node.startSigningSession() {
+		t.Errorf("signing session should not be started when draining")
+	}
+}
+
+func TestKeyGenerations(t *testing.T) {
+	node := &Node{}
+
+	keyGeneration1 := &KeyGeneration{Seed: "ff", MemberIndex: 2, StartBlock: 10}
+	keyGeneration2 := &KeyGeneration{Seed: "ff", MemberIndex: 1, StartBlock: 10}
+	keyGeneration3 := &KeyGeneration{Seed: "aa", MemberIndex: 5, StartBlock: 20}
+
+	node.startKeyGeneration(keyGeneration1)
+	node.startKeyGeneration(keyGeneration2)
+	node.startKeyGeneration(keyGeneration3)
+	node.completeKeyGeneration(keyGeneration1)
+
+	expected := []KeyGeneration{*keyGeneration3, *keyGeneration2}
+	if !reflect.DeepEqual(expected, node.KeyGenerations()) {
+		t.Errorf(
+			"unexpected key generations\nexpected: [%+v]\nactual:   [%+v]",
+			expected,
+			node.KeyGenerations(),
+		)
+	}
+}
+
+func TestStartKeyGenerationAlreadyInProgress(t *testing.T) {
+	node := &Node{}
+
+	keyGeneration := &KeyGeneration{Seed: "ff", MemberIndex: 2, StartBlock: 10}
+
+	if !node.startKeyGeneration(keyGeneration) {
+		t.Fatal("key generation should be started")
+	}
+	if node.startKeyGeneration(keyGeneration) {
+		t.Fatal("key generation in progress should not be started again")
+	}
+
+	node.completeKeyGeneration(keyGeneration)
+
+	if !node.startKeyGeneration(keyGeneration) {
+		t.Fatal("completed key generation should be started again")
+	}
+}
+
+func TestTakeSigningSession(t *testing.T) {
+	session := &entry.Session{
+		GroupPublicKey:   []byte{0xaa},
+		PreviousEntry:    []byte{0x01},
+		StartBlockHeight: 10,
+	}
+
+	node := &Node{
+		restoredSigningSessions: map[string]*entry.Session{
+			"01": session,
+		},
+	}
+
+	if node.takeSigningSession([]byte{0x02}, []byte{0xaa}, 10) != nil {
+		t.Fatal("session for other previous entry should not be taken")
+	}
+	if node.takeSigningSession([]byte{0x01}, []byte{0xaa}, 10) != session {
+		t.Fatal("restored session should be taken")
+	}
+	if node.takeSigningSession([]byte{0x01}, []byte{0xaa}, 10) != nil {
+		t.Fatal("restored session should be taken only once")
+	}
+
+	node.restoredSigningSessions["01"] = session
+	if node.takeSigningSession([]byte{0x01}, []byte{0xaa}, 11) != nil {
+		t.Fatal("session for other start block should not be taken")
+	}
+}
+
+func TestGenerateGroupKeyKeepsCheckpointsWhenInterrupted(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "relay_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	handle, err := persistence.NewDiskHandle(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chain := chainLocal.Connect(3, 2, big.NewInt(200))
+	blockCounter, err := chain.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainConfig, err := chain.ThresholdRelay().GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	startBlockHeight, err := blockCounter.CurrentBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node := &Node{
+		netProvider:  netLocal.Connect(),
+		blockCounter: blockCounter,
+		chainConfig:  chainConfig,
+		persistence:  handle,
+	}
+
+	signing := chain.Signing()
+	staker := relaychain.StakerAddress(
+		signing.PublicKeyBytesToAddress(signing.PublicKey()),
+	)
+	// Other members never send their messages so the key generation can
+	// not proceed past the first phase.
+	selectedStakers := []relaychain.StakerAddress{staker, staker, staker}
+	seed := big.NewInt(31415926535)
+
+	broadcastChannel, membershipValidator, err := node.keyGenerationChannel(
+		seed,
+		selectedStakers,
+		signing,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	done := make(chan struct{})
+	go func() {
+		node.generateGroupKey(
+			ctx,
+			chain.ThresholdRelay(),
+			signing,
+			seed,
+			0,
+			selectedStakers,
+			startBlockHeight,
+			broadcastChannel,
+			membershipValidator,
+			nil,
+			nil,
+		)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for len(dkg.ReadCheckpoints(handle)) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("no checkpoint taken")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancelCtx()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("key generation not interrupted")
+	}
+
+	checkpoints := dkg.ReadCheckpoints(handle)
+	if len(checkpoints) != 1 {
+		t.Fatalf("expected [1] checkpoint; has: [%v]", len(checkpoints))
+	}
+	if checkpoints[0].Seed.Cmp(seed) != 0 {
+		t.Errorf(
+			"unexpected checkpoint seed\nexpected: [%v]\nactual:   [%v]",
+			seed,
+			checkpoints[0].Seed,
+		)
+	}
+}
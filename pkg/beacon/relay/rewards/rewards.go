@@ -0,0 +1,351 @@
+// Package rewards contains the implementation of automatic withdrawal of
+// rewards accumulated by the operator as a member of groups which are no
+// longer performing any operations.
+package rewards
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-log"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+)
+
+var logger = log.Logger("keep-rewards")
+
+// Chain defines the subset of the relay chain interface used to withdraw
+// group member rewards.
+type Chain interface {
+	relaychain.GroupRegistrationInterface
+	relaychain.RewardsInterface
+}
+
+// GroupRegistry is a source of groups the operator is a member of.
+type GroupRegistry interface {
+	// GetGroups returns memberships in all groups this client is a member of,
+	// keyed by the group public key in an uncompressed, hexadecimal form.
+	GetGroups() map[string][]*registry.Membership
+}
+
+// Config contains the configuration of automatic rewards withdrawal.
+type Config struct {
+	// WithdrawalInterval is the interval in which stale groups are checked
+	// for rewards to withdraw. If not set, stale groups are checked only
+	// when a new group is registered on-chain.
+	WithdrawalInterval time.Duration
+	// MaxGasPrice is the maximum gas price the operator is willing to pay for
+	// the withdrawal transaction. If not set, rewards are withdrawn regardless
+	// of the current gas price.
+	MaxGasPrice *big.Int
+}
+
+// Claim represents group member rewards withdrawn by the operator.
+type Claim struct {
+	GroupPublicKey string   `json:"groupPublicKey"`
+	GroupIndex     *big.Int `json:"groupIndex"`
+	Beneficiary    string   `json:"beneficiary"`
+	Amount         *big.Int `json:"amount"`
+	BlockNumber    uint64   `json:"blockNumber"`
+}
+
+// Withdrawer withdraws rewards accumulated by the operator as a member of
+// stale groups. Stale groups found in the group registry are remembered as
+// pending until their rewards are withdrawn, so the rewards can be withdrawn
+// later even if the group has been already removed from the registry.
+type Withdrawer struct {
+	operator      relaychain.StakerAddress
+	relayChain    Chain
+	groupRegistry GroupRegistry
+	maxGasPrice   *big.Int
+
+	mutex sync.Mutex
+	// key is group public key in uncompressed, hexadecimal form
+	pendingGroups map[string][]byte
+	// key is group public key in uncompressed, hexadecimal form
+	withdrawalsInProgress map[string]bool
+	claims                []*Claim
+}
+
+// NewWithdrawer creates a new rewards withdrawer for the given operator.
+// If the max gas price is set, withdrawals are postponed for as long as the
+// current gas price of the chain is higher than the max gas price.
+func NewWithdrawer(
+	operator relaychain.StakerAddress,
+	relayChain Chain,
+	groupRegistry GroupRegistry,
+	maxGasPrice *big.Int,
+) *Withdrawer {
+	return &Withdrawer{
+		operator:              operator,
+		relayChain:            relayChain,
+		groupRegistry:         groupRegistry,
+		maxGasPrice:           maxGasPrice,
+		pendingGroups:         make(map[string][]byte),
+		withdrawalsInProgress: make(map[string]bool),
+	}
+}
+
+// WithdrawStaleGroupRewards looks for stale groups in the group registry and
+// withdraws rewards from all stale groups the operator has not withdrawn
+// rewards from yet. It should be called before stale groups are removed from
+// the registry.
+func (w *Withdrawer) WithdrawStaleGroupRewards() {
+	w.collectStaleGroups()
+	w.withdrawPendingRewards()
+}
+
+// WithdrawPeriodically calls WithdrawStaleGroupRewards with the given interval
+// until the provided context is done.
+func (w *Withdrawer) WithdrawPeriodically(
+	ctx context.Context,
+	interval time.Duration,
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.WithdrawStaleGroupRewards()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Claims returns all rewards claimed by this withdrawer so far, ordered from
+// the oldest to the newest claim.
+func (w *Withdrawer) Claims() []Claim {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	claims := make([]Claim, len(w.claims))
+	for i, claim := range w.claims {
+		claims[i] = *claim
+	}
+
+	return claims
+}
+
+// PendingGroups returns public keys of stale groups rewards have not been
+// withdrawn from yet, in an uncompressed, hexadecimal form.
+func (w *Withdrawer) PendingGroups() []string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	groups := make([]string, 0, len(w.pendingGroups))
+	for groupPublicKey := range w.pendingGroups {
+		groups = append(groups, groupPublicKey)
+	}
+	sort.Strings(groups)
+
+	return groups
+}
+
+func (w *Withdrawer) collectStaleGroups() {
+	for groupPublicKey := range w.groupRegistry.GetGroups() {
+		w.mutex.Lock()
+		_, isPending := w.pendingGroups[groupPublicKey]
+		w.mutex.Unlock()
+
+		if isPending {
+			continue
+		}
+
+		groupPublicKeyBytes, err := hex.DecodeString(groupPublicKey)
+		if err != nil {
+			logger.Errorf(
+				"could not decode public key of group [%s]: [%v]",
+				groupPublicKey,
+				err,
+			)
+			continue
+		}
+
+		isStaleGroup, err := w.relayChain.IsStaleGroup(groupPublicKeyBytes)
+		if err != nil {
+			logger.Errorf(
+				"failed to check if stale for group with public key [%s]: [%v]",
+				groupPublicKey,
+				err,
+			)
+			continue
+		}
+
+		if isStaleGroup {
+			w.mutex.Lock()
+			w.pendingGroups[groupPublicKey] = groupPublicKeyBytes
+			w.mutex.Unlock()
+		}
+	}
+}
+
+func (w *Withdrawer) withdrawPendingRewards() {
+	w.mutex.Lock()
+	pendingGroups := make(map[string][]byte, len(w.pendingGroups))
+	for groupPublicKey, groupPublicKeyBytes := range w.pendingGroups {
+		pendingGroups[groupPublicKey] = groupPublicKeyBytes
+	}
+	w.mutex.Unlock()
+
+	if len(pendingGroups) == 0 {
+		return
+	}
+
+	if w.maxGasPrice != nil && w.maxGasPrice.Sign() > 0 {
+		gasPrice, err := w.relayChain.CurrentGasPrice()
+		if err != nil {
+			logger.Errorf("could not get the current gas price: [%v]", err)
+			return
+		}
+
+		if gasPrice.Cmp(w.maxGasPrice) > 0 {
+			logger.Infof(
+				"current gas price [%v] is higher than the max gas price [%v]; "+
+					"postponing withdrawal of rewards from [%v] groups",
+				gasPrice,
+				w.maxGasPrice,
+				len(pendingGroups),
+			)
+			return
+		}
+	}
+
+	for groupPublicKey, groupPublicKeyBytes := range pendingGroups {
+		err := w.withdraw(groupPublicKey, groupPublicKeyBytes)
+		if err != nil {
+			logger.Errorf(
+				"could not withdraw rewards from group [%s]: [%v]",
+				groupPublicKey,
+				err,
+			)
+		}
+	}
+}
+
+func (w *Withdrawer) withdraw(
+	groupPublicKey string,
+	groupPublicKeyBytes []byte,
+) error {
+	// The withdrawal is marked as in progress before any chain call so that
+	// concurrent attempts for the same group do not submit duplicate
+	// withdrawal transactions. The mark is cleared if no withdrawal is
+	// submitted.
+	w.mutex.Lock()
+	if w.withdrawalsInProgress[groupPublicKey] {
+		w.mutex.Unlock()
+		return nil
+	}
+	w.withdrawalsInProgress[groupPublicKey] = true
+	w.mutex.Unlock()
+
+	clearInProgress := func() {
+		w.mutex.Lock()
+		delete(w.withdrawalsInProgress, groupPublicKey)
+		w.mutex.Unlock()
+	}
+
+	hasWithdrawn, err := w.relayChain.HasWithdrawnRewards(
+		w.operator,
+		groupPublicKeyBytes,
+	)
+	if err != nil {
+		clearInProgress()
+		return fmt.Errorf("could not check if rewards were withdrawn: [%v]", err)
+	}
+	if hasWithdrawn {
+		logger.Infof(
+			"rewards from group [%s] have been already withdrawn",
+			groupPublicKey,
+		)
+		clearInProgress()
+		w.removePendingGroup(groupPublicKey)
+		return nil
+	}
+
+	rewards, err := w.relayChain.GetGroupMemberRewards(groupPublicKeyBytes)
+	if err != nil {
+		clearInProgress()
+		return fmt.Errorf("could not get group member rewards: [%v]", err)
+	}
+	if rewards.Sign() == 0 {
+		logger.Infof("group [%s] has no rewards to withdraw", groupPublicKey)
+		clearInProgress()
+		w.removePendingGroup(groupPublicKey)
+		return nil
+	}
+
+	logger.Infof(
+		"withdrawing rewards of [%v] per member from group [%s]",
+		rewards,
+		groupPublicKey,
+	)
+
+	w.relayChain.WithdrawGroupMemberRewards(
+		w.operator,
+		groupPublicKeyBytes,
+	).OnComplete(func(
+		withdrawal *event.GroupMemberRewardsWithdrawn,
+		err error,
+	) {
+		if err != nil {
+			logger.Errorf(
+				"withdrawal of rewards from group [%s] failed: [%v]",
+				groupPublicKey,
+				err,
+			)
+
+			clearInProgress()
+			return
+		}
+
+		w.recordClaim(groupPublicKey, withdrawal)
+	})
+
+	return nil
+}
+
+func (w *Withdrawer) recordClaim(
+	groupPublicKey string,
+	withdrawal *event.GroupMemberRewardsWithdrawn,
+) {
+	claim := &Claim{
+		GroupPublicKey: groupPublicKey,
+		GroupIndex:     withdrawal.GroupIndex,
+		Beneficiary:    hex.EncodeToString(withdrawal.Beneficiary),
+		Amount:         withdrawal.Amount,
+		BlockNumber:    withdrawal.BlockNumber,
+	}
+
+	w.mutex.Lock()
+	w.claims = append(w.claims, claim)
+	delete(w.pendingGroups, groupPublicKey)
+	delete(w.withdrawalsInProgress, groupPublicKey)
+	w.mutex.Unlock()
+
+	logger.Infof(
+		"withdrawn rewards of [%v] from group [%s] with index [%v] "+
+			"to beneficiary [0x%s] at block [%v]",
+		claim.Amount,
+		groupPublicKey,
+		claim.GroupIndex,
+		claim.Beneficiary,
+		claim.BlockNumber,
+	)
+}
+
+func (w *Withdrawer) removePendingGroup(groupPublicKey string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	delete(w.pendingGroups, groupPublicKey)
+	delete(w.withdrawalsInProgress, groupPublicKey)
+}
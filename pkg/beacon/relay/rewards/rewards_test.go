@@ -0,0 +1,346 @@
+package rewards
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+	"github.com/keep-network/keep-core/pkg/gen/async"
+	"github.com/keep-network/keep-core/pkg/subscription"
+)
+
+var operator = relaychain.StakerAddress([]byte{0x01, 0x02})
+
+var (
+	activeGroup       = []byte{0x0a}
+	staleGroup        = []byte{0x0b}
+	anotherStaleGroup = []byte{0x0c}
+)
+
+func TestWithdrawStaleGroupRewards(t *testing.T) {
+	chain := newMockChain(staleGroup, anotherStaleGroup)
+	chain.withdrawn[hex.EncodeToString(anotherStaleGroup)] = true
+
+	withdrawer := NewWithdrawer(
+		operator,
+		chain,
+		newMockGroupRegistry(activeGroup, staleGroup, anotherStaleGroup),
+		nil,
+	)
+
+	withdrawer.WithdrawStaleGroupRewards()
+	waitFor(t, func() bool { return len(withdrawer.Claims()) == 1 })
+
+	expectedWithdrawals := []string{hex.EncodeToString(staleGroup)}
+	if !reflect.DeepEqual(expectedWithdrawals, chain.getWithdrawals()) {
+		t.Errorf(
+			"unexpected withdrawals\nexpected: [%v]\nactual:   [%v]",
+			expectedWithdrawals,
+			chain.getWithdrawals(),
+		)
+	}
+
+	expectedClaims := []Claim{
+		{
+			GroupPublicKey: hex.EncodeToString(staleGroup),
+			GroupIndex:     big.NewInt(1),
+			Beneficiary:    hex.EncodeToString(operator),
+			Amount:         big.NewInt(100),
+			BlockNumber:    10,
+		},
+	}
+	if !reflect.DeepEqual(expectedClaims, withdrawer.Claims()) {
+		t.Errorf(
+			"unexpected claims\nexpected: [%+v]\nactual:   [%+v]",
+			expectedClaims,
+			withdrawer.Claims(),
+		)
+	}
+
+	if len(withdrawer.PendingGroups()) != 0 {
+		t.Errorf(
+			"expected no pending groups; has: [%v]",
+			withdrawer.PendingGroups(),
+		)
+	}
+
+	// Rewards from the same group must not be withdrawn twice.
+	withdrawer.WithdrawStaleGroupRewards()
+
+	if !reflect.DeepEqual(expectedWithdrawals, chain.getWithdrawals()) {
+		t.Errorf(
+			"unexpected withdrawals\nexpected: [%v]\nactual:   [%v]",
+			expectedWithdrawals,
+			chain.getWithdrawals(),
+		)
+	}
+}
+
+func TestWithdrawStaleGroupRewardsAboveMaxGasPrice(t *testing.T) {
+	chain := newMockChain(staleGroup)
+	groupRegistry := newMockGroupRegistry(staleGroup)
+
+	withdrawer := NewWithdrawer(
+		operator,
+		chain,
+		groupRegistry,
+		big.NewInt(10),
+	)
+
+	chain.gasPrice = big.NewInt(11)
+	withdrawer.WithdrawStaleGroupRewards()
+
+	if len(chain.getWithdrawals()) != 0 {
+		t.Fatalf(
+			"expected no withdrawals; has: [%v]",
+			chain.getWithdrawals(),
+		)
+	}
+
+	expectedPendingGroups := []string{hex.EncodeToString(staleGroup)}
+	if !reflect.DeepEqual(expectedPendingGroups, withdrawer.PendingGroups()) {
+		t.Fatalf(
+			"unexpected pending groups\nexpected: [%v]\nactual:   [%v]",
+			expectedPendingGroups,
+			withdrawer.PendingGroups(),
+		)
+	}
+
+	// The stale group has been removed from the registry in the meantime
+	// but the withdrawer still remembers it.
+	groupRegistry.groups = make(map[string][]*registry.Membership)
+
+	chain.gasPrice = big.NewInt(10)
+	withdrawer.WithdrawStaleGroupRewards()
+	waitFor(t, func() bool { return len(withdrawer.Claims()) == 1 })
+
+	expectedWithdrawals := []string{hex.EncodeToString(staleGroup)}
+	if !reflect.DeepEqual(expectedWithdrawals, chain.getWithdrawals()) {
+		t.Errorf(
+			"unexpected withdrawals\nexpected: [%v]\nactual:   [%v]",
+			expectedWithdrawals,
+			chain.getWithdrawals(),
+		)
+	}
+
+	if len(withdrawer.PendingGroups()) != 0 {
+		t.Errorf(
+			"expected no pending groups; has: [%v]",
+			withdrawer.PendingGroups(),
+		)
+	}
+}
+
+func TestWithdrawStaleGroupRewardsRetriesFailedWithdrawal(t *testing.T) {
+	chain := newMockChain(staleGroup)
+	chain.withdrawalErr = fmt.Errorf("transaction failed")
+
+	withdrawer := NewWithdrawer(
+		operator,
+		chain,
+		newMockGroupRegistry(staleGroup),
+		nil,
+	)
+
+	withdrawer.WithdrawStaleGroupRewards()
+	waitFor(t, func() bool {
+		withdrawer.mutex.Lock()
+		defer withdrawer.mutex.Unlock()
+		return len(withdrawer.withdrawalsInProgress) == 0
+	})
+
+	if len(withdrawer.Claims()) != 0 {
+		t.Fatalf("expected no claims; has: [%v]", len(withdrawer.Claims()))
+	}
+	if len(withdrawer.PendingGroups()) != 1 {
+		t.Fatalf(
+			"expected one pending group; has: [%v]",
+			len(withdrawer.PendingGroups()),
+		)
+	}
+
+	chain.withdrawalErr = nil
+	withdrawer.WithdrawStaleGroupRewards()
+	waitFor(t, func() bool { return len(withdrawer.Claims()) == 1 })
+
+	if len(withdrawer.PendingGroups()) != 0 {
+		t.Errorf(
+			"expected no pending groups; has: [%v]",
+			withdrawer.PendingGroups(),
+		)
+	}
+}
+
+func TestWithdrawStaleGroupRewardsConcurrently(t *testing.T) {
+	chain := newMockChain(staleGroup)
+	// Checking rewards takes a while so that concurrent withdrawal attempts
+	// overlap.
+	chain.rewardsLatency = 50 * time.Millisecond
+
+	withdrawer := NewWithdrawer(
+		operator,
+		chain,
+		newMockGroupRegistry(staleGroup),
+		nil,
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			withdrawer.WithdrawStaleGroupRewards()
+		}()
+	}
+	wg.Wait()
+	waitFor(t, func() bool { return len(withdrawer.Claims()) == 1 })
+
+	expectedWithdrawals := []string{hex.EncodeToString(staleGroup)}
+	if !reflect.DeepEqual(expectedWithdrawals, chain.getWithdrawals()) {
+		t.Errorf(
+			"unexpected withdrawals\nexpected: [%v]\nactual:   [%v]",
+			expectedWithdrawals,
+			chain.getWithdrawals(),
+		)
+	}
+}
+
+type mockGroupRegistry struct {
+	groups map[string][]*registry.Membership
+}
+
+func newMockGroupRegistry(groupPublicKeys ...[]byte) *mockGroupRegistry {
+	groups := make(map[string][]*registry.Membership)
+	for _, groupPublicKey := range groupPublicKeys {
+		groups[hex.EncodeToString(groupPublicKey)] = nil
+	}
+
+	return &mockGroupRegistry{groups}
+}
+
+func (mgr *mockGroupRegistry) GetGroups() map[string][]*registry.Membership {
+	return mgr.groups
+}
+
+type mockChain struct {
+	mutex sync.Mutex
+
+	staleGroups   [][]byte
+	withdrawn     map[string]bool
+	withdrawals   []string
+	gasPrice      *big.Int
+	withdrawalErr error
+
+	rewardsLatency time.Duration
+}
+
+func newMockChain(staleGroups ...[]byte) *mockChain {
+	return &mockChain{
+		staleGroups: staleGroups,
+		withdrawn:   make(map[string]bool),
+		gasPrice:    big.NewInt(1),
+	}
+}
+
+func (mc *mockChain) getWithdrawals() []string {
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+
+	return append([]string{}, mc.withdrawals...)
+}
+
+func (mc *mockChain) OnGroupRegistered(
+	func(groupRegistration *event.GroupRegistration),
+) (subscription.EventSubscription, error) {
+	panic("not implemented")
+}
+
+func (mc *mockChain) IsStaleGroup(groupPublicKey []byte) (bool, error) {
+	for _, staleGroup := range mc.staleGroups {
+		if reflect.DeepEqual(staleGroup, groupPublicKey) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (mc *mockChain) GetGroupMembers(
+	groupPublicKey []byte,
+) ([]relaychain.StakerAddress, error) {
+	return nil, nil // no-op
+}
+
+func (mc *mockChain) GetGroupMemberRewards(
+	groupPublicKey []byte,
+) (*big.Int, error) {
+	time.Sleep(mc.rewardsLatency)
+	return big.NewInt(100), nil
+}
+
+func (mc *mockChain) HasWithdrawnRewards(
+	operator relaychain.StakerAddress,
+	groupPublicKey []byte,
+) (bool, error) {
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+
+	return mc.withdrawn[hex.EncodeToString(groupPublicKey)], nil
+}
+
+func (mc *mockChain) WithdrawGroupMemberRewards(
+	operator relaychain.StakerAddress,
+	groupPublicKey []byte,
+) *async.EventGroupMemberRewardsWithdrawnPromise {
+	promise := &async.EventGroupMemberRewardsWithdrawnPromise{}
+
+	if mc.withdrawalErr != nil {
+		promise.Fail(mc.withdrawalErr)
+		return promise
+	}
+
+	mc.mutex.Lock()
+	mc.withdrawn[hex.EncodeToString(groupPublicKey)] = true
+	mc.withdrawals = append(mc.withdrawals, hex.EncodeToString(groupPublicKey))
+	mc.mutex.Unlock()
+
+	promise.Fulfill(&event.GroupMemberRewardsWithdrawn{
+		Beneficiary: operator,
+		Operator:    operator,
+		Amount:      big.NewInt(100),
+		GroupIndex:  big.NewInt(1),
+		BlockNumber: 10,
+	})
+
+	return promise
+}
+
+func (mc *mockChain) OnGroupMemberRewardsWithdrawn(
+	func(withdrawal *event.GroupMemberRewardsWithdrawn),
+) (subscription.EventSubscription, error) {
+	panic("not implemented")
+}
+
+func (mc *mockChain) CurrentGasPrice() (*big.Int, error) {
+	return mc.gasPrice, nil
+}
+
+// waitFor waits until the given condition is met. Promise callbacks are
+// executed in separate goroutines so the withdrawer state is updated
+// asynchronously.
+func waitFor(t *testing.T, condition func() bool) {
+	timeout := time.After(time.Second)
+	for !condition() {
+		select {
+		case <-timeout:
+			t.Fatal("condition not met within the timeout")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
@@ -19,6 +19,25 @@ type Machine struct {
 	channel      net.BroadcastChannel
 	blockCounter chain.BlockCounter
 	initialState State // first state from which execution starts
+	recorder     Recorder
+}
+
+// Recorder is notified about the progress of the state machine execution.
+// It lets to keep a record of all states entered and all messages received
+// during the execution.
+type Recorder interface {
+	// StateEntered is called when the state machine enters the given state,
+	// before the state is initiated. Block height is the height at which the
+	// previous state ended.
+	StateEntered(state State, blockHeight uint64)
+
+	// StateInitiated is called when the given state has been successfully
+	// initiated.
+	StateInitiated(state State)
+
+	// MessageReceived is called for each message received by the state
+	// machine, before the message is passed to the current state.
+	MessageReceived(state State, msg net.Message)
 }
 
 // NewMachine returns a new state machine. It requires a broadcast channel and
@@ -35,16 +54,30 @@ func NewMachine(
 	}
 }
 
+// SetRecorder sets the recorder notified about states entered and messages
+// received during the execution. It has to be set before Execute is called.
+func (m *Machine) SetRecorder(recorder Recorder) {
+	m.recorder = recorder
+}
+
 // Execute state machine starting with initial state up to finalization. It
-// requires the broadcast channel to be pre-initialized.
-func (m *Machine) Execute(startBlockHeight uint64) (State, uint64, error) {
+// requires the broadcast channel to be pre-initialized. Execution is aborted
+// with an error when the provided context is done.
+func (m *Machine) Execute(
+	parentCtx context.Context,
+	startBlockHeight uint64,
+) (State, uint64, error) {
 	recvChan := make(chan net.Message, receiveBuffer)
 	handler := func(msg net.Message) {
 		recvChan <- msg
 	}
 
 	currentState := m.initialState
-	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	runningMachines.register(m, currentState)
+	defer runningMachines.unregister(m)
+
+	ctx, cancelCtx := context.WithCancel(parentCtx)
 	m.channel.Recv(ctx, handler)
 
 	logger.Infof(
@@ -53,13 +86,24 @@ func (m *Machine) Execute(startBlockHeight uint64) (State, uint64, error) {
 		m.channel.Name()[:5],
 		startBlockHeight,
 	)
-	err := m.blockCounter.WaitForBlockHeight(startBlockHeight)
+	startBlockWaiter, err := m.blockCounter.BlockHeightWaiter(startBlockHeight)
 	if err != nil {
+		cancelCtx()
 		return nil, 0, fmt.Errorf("failed to wait for the execution start block")
 	}
+	select {
+	case <-startBlockWaiter:
+	case <-parentCtx.Done():
+		cancelCtx()
+		return nil, 0, fmt.Errorf(
+			"execution cancelled before start block: [%v]",
+			parentCtx.Err(),
+		)
+	}
 
 	lastStateEndBlockHeight := startBlockHeight
 
+	m.recordStateEntered(currentState, lastStateEndBlockHeight)
 	blockWaiter, err := stateTransition(
 		ctx,
 		currentState,
@@ -71,10 +115,16 @@ func (m *Machine) Execute(startBlockHeight uint64) (State, uint64, error) {
 		cancelCtx()
 		return nil, 0, err
 	}
+	m.recordStateInitiated(currentState)
+	runningMachines.update(m, currentState, lastStateEndBlockHeight)
 
 	for {
 		select {
 		case msg := <-recvChan:
+			if m.recorder != nil {
+				m.recorder.MessageReceived(currentState, msg)
+			}
+
 			err := currentState.Receive(msg)
 			if err != nil {
 				logger.Errorf(
@@ -101,9 +151,10 @@ func (m *Machine) Execute(startBlockHeight uint64) (State, uint64, error) {
 			}
 
 			currentState = nextState
-			ctx, cancelCtx = context.WithCancel(context.Background())
+			ctx, cancelCtx = context.WithCancel(parentCtx)
 			m.channel.Recv(ctx, handler)
 
+			m.recordStateEntered(currentState, lastStateEndBlockHeight)
 			blockWaiter, err = stateTransition(
 				ctx,
 				currentState,
@@ -115,12 +166,36 @@ func (m *Machine) Execute(startBlockHeight uint64) (State, uint64, error) {
 				cancelCtx()
 				return nil, 0, err
 			}
+			m.recordStateInitiated(currentState)
+			runningMachines.update(m, currentState, lastStateEndBlockHeight)
 
 			continue
+
+		case <-parentCtx.Done():
+			cancelCtx()
+			return nil, 0, fmt.Errorf(
+				"[member:%v,channel:%s,state:%T] execution cancelled: [%v]",
+				currentState.MemberIndex(),
+				m.channel.Name()[:5],
+				currentState,
+				parentCtx.Err(),
+			)
 		}
 	}
 }
 
+func (m *Machine) recordStateEntered(state State, blockHeight uint64) {
+	if m.recorder != nil {
+		m.recorder.StateEntered(state, blockHeight)
+	}
+}
+
+func (m *Machine) recordStateInitiated(state State) {
+	if m.recorder != nil {
+		m.recorder.StateInitiated(state)
+	}
+}
+
 func stateTransition(
 	ctx context.Context,
 	currentState State,
@@ -56,7 +56,7 @@ func TestExecute(t *testing.T) {
 
 	stateMachine := NewMachine(channel, blockCounter, initialState)
 
-	finalState, endBlockHeight, err := stateMachine.Execute(1)
+	finalState, endBlockHeight, err := stateMachine.Execute(context.Background(), 1)
 	if err != nil {
 		t.Errorf("unexpected error [%v]", err)
 	}
@@ -0,0 +1,95 @@
+package state
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+)
+
+// MachineStatus describes the current state of a running state machine.
+type MachineStatus struct {
+	Channel          string            `json:"channel"`
+	MemberIndex      group.MemberIndex `json:"memberIndex"`
+	State            string            `json:"state"`
+	StateEnterBlock  uint64            `json:"stateEnterBlock"`
+	ExecutionStarted bool              `json:"executionStarted"`
+}
+
+// runningMachines keeps track of all state machines being currently executed
+// so that their current states can be inspected.
+var runningMachines = &machineRegistry{
+	machines: make(map[*Machine]*MachineStatus),
+}
+
+type machineRegistry struct {
+	mutex    sync.Mutex
+	machines map[*Machine]*MachineStatus
+}
+
+func (mr *machineRegistry) register(machine *Machine, currentState State) {
+	mr.mutex.Lock()
+	defer mr.mutex.Unlock()
+
+	mr.machines[machine] = &MachineStatus{
+		Channel:     machine.channel.Name(),
+		MemberIndex: currentState.MemberIndex(),
+		State:       stateName(currentState),
+	}
+}
+
+func (mr *machineRegistry) update(
+	machine *Machine,
+	currentState State,
+	stateEnterBlock uint64,
+) {
+	mr.mutex.Lock()
+	defer mr.mutex.Unlock()
+
+	status, ok := mr.machines[machine]
+	if !ok {
+		return
+	}
+
+	status.State = stateName(currentState)
+	status.StateEnterBlock = stateEnterBlock
+	status.ExecutionStarted = true
+}
+
+func (mr *machineRegistry) unregister(machine *Machine) {
+	mr.mutex.Lock()
+	defer mr.mutex.Unlock()
+
+	delete(mr.machines, machine)
+}
+
+func (mr *machineRegistry) snapshot() []MachineStatus {
+	mr.mutex.Lock()
+	defer mr.mutex.Unlock()
+
+	statuses := make([]MachineStatus, 0, len(mr.machines))
+	for _, status := range mr.machines {
+		statuses = append(statuses, *status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Channel != statuses[j].Channel {
+			return statuses[i].Channel < statuses[j].Channel
+		}
+		return statuses[i].MemberIndex < statuses[j].MemberIndex
+	})
+
+	return statuses
+}
+
+// RunningMachines returns the current status of all state machines being
+// executed at the moment in this process. The returned statuses are ordered
+// by the channel name and member index.
+func RunningMachines() []MachineStatus {
+	return runningMachines.snapshot()
+}
+
+func stateName(state State) string {
+	return fmt.Sprintf("%T", state)
+}
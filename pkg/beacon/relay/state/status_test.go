@@ -0,0 +1,69 @@
+package state
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	netLocal "github.com/keep-network/keep-core/pkg/net/local"
+)
+
+func TestMachineRegistry(t *testing.T) {
+	provider := netLocal.Connect()
+	channel, err := provider.BroadcastChannelFor("status_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	registry := &machineRegistry{
+		machines: make(map[*Machine]*MachineStatus),
+	}
+
+	initialState := testState1{
+		memberIndex: group.MemberIndex(3),
+		channel:     channel,
+	}
+	machine := NewMachine(channel, nil, initialState)
+
+	registry.register(machine, initialState)
+
+	expectedStatuses := []MachineStatus{
+		{
+			Channel:     "status_test",
+			MemberIndex: group.MemberIndex(3),
+			State:       "state.testState1",
+		},
+	}
+	if !reflect.DeepEqual(expectedStatuses, registry.snapshot()) {
+		t.Errorf(
+			"unexpected statuses\nexpected: [%+v]\nactual:   [%+v]",
+			expectedStatuses,
+			registry.snapshot(),
+		)
+	}
+
+	registry.update(machine, &testState2{initialState}, 10)
+
+	expectedStatuses = []MachineStatus{
+		{
+			Channel:          "status_test",
+			MemberIndex:      group.MemberIndex(3),
+			State:            "*state.testState2",
+			StateEnterBlock:  10,
+			ExecutionStarted: true,
+		},
+	}
+	if !reflect.DeepEqual(expectedStatuses, registry.snapshot()) {
+		t.Errorf(
+			"unexpected statuses\nexpected: [%+v]\nactual:   [%+v]",
+			expectedStatuses,
+			registry.snapshot(),
+		)
+	}
+
+	registry.unregister(machine)
+
+	if len(registry.snapshot()) != 0 {
+		t.Errorf("registry should be empty after unregistering the machine")
+	}
+}
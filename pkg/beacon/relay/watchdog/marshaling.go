@@ -0,0 +1,34 @@
+package watchdog
+
+import (
+	"github.com/keep-network/keep-core/pkg/beacon/relay/watchdog/gen/pb"
+)
+
+// Type returns a string describing an UnauthorizedSigningMessage's type.
+func (*UnauthorizedSigningMessage) Type() string {
+	return "relay/unauthorized_signing"
+}
+
+// Marshal converts this UnauthorizedSigningMessage to a byte array suitable
+// for network communication.
+func (usm *UnauthorizedSigningMessage) Marshal() ([]byte, error) {
+	pbUnauthorizedSigning := pb.UnauthorizedSigning{
+		Signature: usm.signature,
+	}
+
+	return pbUnauthorizedSigning.Marshal()
+}
+
+// Unmarshal converts a byte array produced by Marshal to
+// an UnauthorizedSigningMessage.
+func (usm *UnauthorizedSigningMessage) Unmarshal(bytes []byte) error {
+	pbUnauthorizedSigning := pb.UnauthorizedSigning{}
+	err := pbUnauthorizedSigning.Unmarshal(bytes)
+	if err != nil {
+		return err
+	}
+
+	usm.signature = pbUnauthorizedSigning.Signature
+
+	return nil
+}
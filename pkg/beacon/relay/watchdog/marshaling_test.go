@@ -0,0 +1,42 @@
+package watchdog
+
+import (
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+
+	"github.com/keep-network/keep-core/pkg/internal/pbutils"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+)
+
+func TestUnauthorizedSigningMessageRoundTrip(t *testing.T) {
+	msg := NewUnauthorizedSigningMessage([]byte{0x01, 0x02, 0x03})
+	unmarshaled := &UnauthorizedSigningMessage{}
+
+	err := pbutils.RoundTrip(msg, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertBytesEqual(t, msg.signature, unmarshaled.signature)
+}
+
+func TestFuzzUnauthorizedSigningMessageRoundtrip(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var signature []byte
+
+		f := fuzz.New().NilChance(0.1).NumElements(0, 512)
+
+		f.Fuzz(&signature)
+
+		message := &UnauthorizedSigningMessage{
+			signature: signature,
+		}
+
+		_ = pbutils.RoundTrip(message, &UnauthorizedSigningMessage{})
+	}
+}
+
+func TestFuzzUnauthorizedSigningMessageUnmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&UnauthorizedSigningMessage{})
+}
@@ -0,0 +1,20 @@
+package watchdog
+
+// UnauthorizedSigningMessage is a message payload that carries a signature
+// over the operator address made with a group private key. Such a signature
+// can be produced only if the group private key has leaked and proves
+// unauthorized use of the group key.
+type UnauthorizedSigningMessage struct {
+	signature []byte
+}
+
+// NewUnauthorizedSigningMessage creates a new message carrying the given
+// signature over the operator address.
+func NewUnauthorizedSigningMessage(signature []byte) *UnauthorizedSigningMessage {
+	return &UnauthorizedSigningMessage{signature}
+}
+
+// Signature returns the signature carried by the message.
+func (usm *UnauthorizedSigningMessage) Signature() []byte {
+	return usm.signature
+}
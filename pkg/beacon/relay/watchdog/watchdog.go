@@ -0,0 +1,266 @@
+// Package watchdog contains the implementation of the unauthorized signing
+// watchdog. The watchdog looks for proofs that the private key of a group the
+// operator is a member of has been leaked and reports them to the chain.
+package watchdog
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/ipfs/go-log"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+	"github.com/keep-network/keep-core/pkg/bls"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+var logger = log.Logger("keep-watchdog")
+
+// signatureQueueSize is the maximum number of received signatures waiting to
+// be checked. Signatures received when the queue is full are dropped so that
+// flooding group channels with messages can not exhaust the client.
+const signatureQueueSize = 32
+
+// GroupRegistry is a source of groups the operator is a member of.
+type GroupRegistry interface {
+	// GetGroups returns memberships in all groups this client is a member of,
+	// keyed by the group public key in an uncompressed, hexadecimal form.
+	GetGroups() map[string][]*registry.Membership
+}
+
+// Watchdog looks for signatures over the operator address made with the
+// private key of any group the operator is a member of. Group members never
+// produce such a signature so it proves the group private key has leaked.
+// Every such signature is reported to the chain which terminates the group.
+type Watchdog struct {
+	operator      relaychain.StakerAddress
+	relayChain    relaychain.UnauthorizedSigningInterface
+	groupRegistry GroupRegistry
+	netProvider   net.Provider
+
+	// Received signatures are checked one by one in the order they are
+	// received.
+	signatures chan *receivedSignature
+
+	mutex sync.Mutex
+	// key is group public key in uncompressed, hexadecimal form
+	reportedGroups map[string]bool
+	// key is broadcast channel name
+	watchedChannels  map[string]context.CancelFunc
+	isCheckerStarted bool
+}
+
+// receivedSignature is a signature received over the group broadcast channel
+// with the given name.
+type receivedSignature struct {
+	channelName string
+	signature   []byte
+}
+
+// NewWatchdog creates a new unauthorized signing watchdog for the given
+// operator.
+func NewWatchdog(
+	operator relaychain.StakerAddress,
+	relayChain relaychain.UnauthorizedSigningInterface,
+	groupRegistry GroupRegistry,
+	netProvider net.Provider,
+) *Watchdog {
+	return &Watchdog{
+		operator:        operator,
+		relayChain:      relayChain,
+		groupRegistry:   groupRegistry,
+		netProvider:     netProvider,
+		signatures:      make(chan *receivedSignature, signatureQueueSize),
+		reportedGroups:  make(map[string]bool),
+		watchedChannels: make(map[string]context.CancelFunc),
+	}
+}
+
+// WatchGroupChannels starts watching broadcast channels of all groups from
+// the group registry for unauthorized signing messages and stops watching
+// channels of groups no longer present in the registry. Channels are watched
+// until the provided context is done. It should be called every time groups
+// in the registry change.
+func (w *Watchdog) WatchGroupChannels(ctx context.Context) {
+	channelNames := make(map[string]bool)
+	for _, memberships := range w.groupRegistry.GetGroups() {
+		for _, membership := range memberships {
+			channelNames[membership.ChannelName] = true
+		}
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if !w.isCheckerStarted {
+		go w.checkSignatures(ctx)
+		w.isCheckerStarted = true
+	}
+
+	for channelName, cancelWatch := range w.watchedChannels {
+		if !channelNames[channelName] {
+			cancelWatch()
+			delete(w.watchedChannels, channelName)
+		}
+	}
+
+	for channelName := range channelNames {
+		channelName := channelName
+
+		if _, isWatched := w.watchedChannels[channelName]; isWatched {
+			continue
+		}
+
+		channel, err := w.netProvider.BroadcastChannelFor(channelName)
+		if err != nil {
+			logger.Errorf(
+				"could not get broadcast channel [%v]: [%v]",
+				channelName,
+				err,
+			)
+			continue
+		}
+
+		err = channel.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
+			return &UnauthorizedSigningMessage{}
+		})
+		if err != nil {
+			logger.Errorf(
+				"could not register unmarshaler for channel [%v]: [%v]",
+				channelName,
+				err,
+			)
+			continue
+		}
+
+		watchCtx, cancelWatch := context.WithCancel(ctx)
+		channel.Recv(watchCtx, func(message net.Message) {
+			unauthorizedSigning, ok :=
+				message.Payload().(*UnauthorizedSigningMessage)
+			if !ok {
+				return
+			}
+
+			select {
+			case w.signatures <- &receivedSignature{
+				channelName: channelName,
+				signature:   unauthorizedSigning.Signature(),
+			}:
+			default:
+				logger.Warningf(
+					"dropping signature received over channel [%v]; "+
+						"too many signatures waiting to be checked",
+					channelName,
+				)
+			}
+		})
+
+		w.watchedChannels[channelName] = cancelWatch
+	}
+}
+
+// checkSignatures checks received signatures until the provided context is
+// done.
+func (w *Watchdog) checkSignatures(ctx context.Context) {
+	for {
+		select {
+		case received := <-w.signatures:
+			_, err := w.checkSignature(received.channelName, received.signature)
+			if err != nil {
+				logger.Errorf(
+					"could not check signature received over channel [%v]: [%v]",
+					received.channelName,
+					err,
+				)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// checkSignature checks if the given signature, received over the group
+// broadcast channel with the given name, is a signature over the operator
+// address made with the private key of the group the channel belongs to. If
+// so, it reports unauthorized signing of that group to the chain and returns
+// true.
+func (w *Watchdog) checkSignature(
+	channelName string,
+	signature []byte,
+) (bool, error) {
+	signaturePoint := new(bn256.G1)
+	if _, err := signaturePoint.Unmarshal(signature); err != nil {
+		return false, fmt.Errorf("could not unmarshal signature: [%v]", err)
+	}
+
+	for groupPublicKey, memberships := range w.groupRegistry.GetGroups() {
+		if len(memberships) == 0 ||
+			memberships[0].ChannelName != channelName {
+			continue
+		}
+
+		groupPublicKeyPoint := new(bn256.G2)
+		_, err := groupPublicKeyPoint.Unmarshal(
+			memberships[0].Signer.GroupPublicKeyBytes(),
+		)
+		if err != nil {
+			logger.Errorf(
+				"could not unmarshal public key of group [%s]: [%v]",
+				groupPublicKey,
+				err,
+			)
+			continue
+		}
+
+		if !bls.Verify(groupPublicKeyPoint, w.operator, signaturePoint) {
+			continue
+		}
+
+		logger.Warningf(
+			"found signature over the operator address made with the key "+
+				"of group [%s]; group private key has leaked",
+			groupPublicKey,
+		)
+
+		return true, w.report(groupPublicKey, signature)
+	}
+
+	return false, nil
+}
+
+func (w *Watchdog) report(groupPublicKey string, signature []byte) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.reportedGroups[groupPublicKey] {
+		logger.Infof(
+			"unauthorized signing of group [%s] has been already reported",
+			groupPublicKey,
+		)
+		return nil
+	}
+
+	groupPublicKeyBytes, err := hex.DecodeString(groupPublicKey)
+	if err != nil {
+		return fmt.Errorf("could not decode group public key: [%v]", err)
+	}
+
+	err = w.relayChain.ReportUnauthorizedSigning(groupPublicKeyBytes, signature)
+	if err != nil {
+		return fmt.Errorf(
+			"could not report unauthorized signing of group [%s]: [%v]",
+			groupPublicKey,
+			err,
+		)
+	}
+
+	w.reportedGroups[groupPublicKey] = true
+
+	logger.Infof("reported unauthorized signing of group [%s]", groupPublicKey)
+
+	return nil
+}
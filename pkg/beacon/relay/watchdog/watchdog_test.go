@@ -0,0 +1,227 @@
+package watchdog
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-common/pkg/persistence"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+	"github.com/keep-network/keep-core/pkg/bls"
+	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
+	"github.com/keep-network/keep-core/pkg/net"
+	netLocal "github.com/keep-network/keep-core/pkg/net/local"
+)
+
+var (
+	operator = relaychain.StakerAddress([]byte{
+		0x65, 0xea, 0x55, 0xc1, 0xf1, 0x0e, 0x0c, 0x1f, 0x6e, 0x3f,
+		0x8e, 0x4c, 0x0b, 0x59, 0xd5, 0x3f, 0x27, 0x96, 0x53, 0xc4,
+	})
+
+	groupPrivateKey = big.NewInt(123)
+	channelName     = "watchdog_test"
+)
+
+func TestCheckSignature(t *testing.T) {
+	chain, groupRegistry := initialize(t)
+
+	watchdog := NewWatchdog(operator, chain.ThresholdRelay(), groupRegistry, netLocal.Connect())
+
+	var tests = map[string]struct {
+		signature []byte
+		// Name of the channel the signature is received over if other than
+		// the group channel.
+		channelName     string
+		expectedReports [][]byte
+		expectedError   bool
+	}{
+		"signature over the operator address with the group key": {
+			signature:       bls.Sign(groupPrivateKey, operator).Marshal(),
+			expectedReports: [][]byte{groupPublicKey().Marshal()},
+		},
+		"signature over another message with the group key": {
+			signature: bls.Sign(groupPrivateKey, []byte("message")).Marshal(),
+		},
+		"signature over the operator address with another key": {
+			signature: bls.Sign(big.NewInt(321), operator).Marshal(),
+		},
+		"signature received over channel of another group": {
+			signature:   bls.Sign(groupPrivateKey, operator).Marshal(),
+			channelName: "another_channel",
+		},
+		"malformed signature": {
+			signature:     []byte{0x01, 0x02},
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			chain, groupRegistry := initialize(t)
+
+			watchdog := NewWatchdog(
+				operator,
+				chain.ThresholdRelay(),
+				groupRegistry,
+				netLocal.Connect(),
+			)
+
+			receivedOver := channelName
+			if test.channelName != "" {
+				receivedOver = test.channelName
+			}
+
+			reported, err := watchdog.checkSignature(receivedOver, test.signature)
+			if test.expectedError != (err != nil) {
+				t.Fatalf("unexpected error: [%v]", err)
+			}
+
+			if reported != (len(test.expectedReports) > 0) {
+				t.Errorf("unexpected report result: [%v]", reported)
+			}
+
+			reports := chain.GetUnauthorizedSigningReports()
+			if len(reports) != len(test.expectedReports) ||
+				(len(reports) > 0 && !reflect.DeepEqual(reports, test.expectedReports)) {
+				t.Errorf(
+					"unexpected reports\nexpected: [%x]\nactual:   [%x]",
+					test.expectedReports,
+					reports,
+				)
+			}
+		})
+	}
+
+	// The same group should be reported only once.
+	signature := bls.Sign(groupPrivateKey, operator).Marshal()
+	for i := 0; i < 2; i++ {
+		if _, err := watchdog.checkSignature(channelName, signature); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(chain.GetUnauthorizedSigningReports()) != 1 {
+		t.Errorf(
+			"expected one report; has: [%v]",
+			len(chain.GetUnauthorizedSigningReports()),
+		)
+	}
+}
+
+func TestWatchGroupChannels(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	chain, groupRegistry := initialize(t)
+	netProvider := netLocal.Connect()
+
+	watchdog := NewWatchdog(operator, chain.ThresholdRelay(), groupRegistry, netProvider)
+	watchdog.WatchGroupChannels(ctx)
+
+	channel, err := netLocal.Connect().BroadcastChannelFor(channelName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = channel.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
+		return &UnauthorizedSigningMessage{}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = channel.Send(
+		ctx,
+		NewUnauthorizedSigningMessage(
+			bls.Sign(groupPrivateKey, operator).Marshal(),
+		),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for len(chain.GetUnauthorizedSigningReports()) == 0 {
+		select {
+		case <-ctx.Done():
+			t.Fatal("unauthorized signing has not been reported")
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	expectedReports := [][]byte{groupPublicKey().Marshal()}
+	if !reflect.DeepEqual(expectedReports, chain.GetUnauthorizedSigningReports()) {
+		t.Errorf(
+			"unexpected reports\nexpected: [%x]\nactual:   [%x]",
+			expectedReports,
+			chain.GetUnauthorizedSigningReports(),
+		)
+	}
+}
+
+func groupPublicKey() *bn256.G2 {
+	return new(bn256.G2).ScalarBaseMult(groupPrivateKey)
+}
+
+func initialize(t *testing.T) (chainLocal.Chain, *registry.Groups) {
+	chain := chainLocal.Connect(5, 3, big.NewInt(200))
+	relayChain := chain.ThresholdRelay()
+
+	signer := dkg.NewThresholdSigner(
+		group.MemberIndex(1),
+		groupPublicKey(),
+		big.NewInt(1),
+		make(map[group.MemberIndex]*bn256.G2),
+	)
+
+	// Register the group on-chain.
+	relayChain.SubmitDKGResult(
+		relaychain.GroupMemberIndex(1),
+		&relaychain.DKGResult{GroupPublicKey: signer.GroupPublicKeyBytes()},
+		map[relaychain.GroupMemberIndex][]byte{
+			1: []byte{101},
+			2: []byte{102},
+			3: []byte{103},
+		},
+	)
+
+	groupRegistry := registry.NewGroupRegistry(relayChain, &persistenceHandleMock{})
+	err := groupRegistry.RegisterGroup(signer, channelName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return chain, groupRegistry
+}
+
+type persistenceHandleMock struct{}
+
+func (phm *persistenceHandleMock) Save(
+	data []byte,
+	directory string,
+	name string,
+) error {
+	return nil
+}
+
+func (phm *persistenceHandleMock) ReadAll() (
+	<-chan persistence.DataDescriptor,
+	<-chan error,
+) {
+	outputData := make(chan persistence.DataDescriptor)
+	outputErrors := make(chan error)
+
+	close(outputData)
+	close(outputErrors)
+
+	return outputData, outputErrors
+}
+
+func (phm *persistenceHandleMock) Archive(directory string) error {
+	return nil
+}
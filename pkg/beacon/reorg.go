@@ -0,0 +1,121 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+)
+
+// relayRequestCancellers holds functions aborting relay entry signing started
+// for relay requests, so that the signing can be aborted when the request is
+// removed from the chain by a chain reorganization.
+type relayRequestCancellers struct {
+	mutex   sync.Mutex
+	cancels map[string]context.CancelFunc
+}
+
+func newRelayRequestCancellers() *relayRequestCancellers {
+	return &relayRequestCancellers{
+		cancels: make(map[string]context.CancelFunc),
+	}
+}
+
+// The same previous entry may be requested again at another block after
+// a reorganization so the block number is a part of the key.
+func relayRequestKey(request *event.Request) string {
+	return fmt.Sprintf("%x-%v", request.PreviousEntry, request.BlockNumber)
+}
+
+// add returns a context for the relay entry signing started for the given
+// request, derived from the provided parent context. The returned function
+// has to be called once the signing completes.
+func (rrc *relayRequestCancellers) add(
+	parent context.Context,
+	request *event.Request,
+) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(parent)
+	key := relayRequestKey(request)
+
+	rrc.mutex.Lock()
+	rrc.cancels[key] = cancel
+	rrc.mutex.Unlock()
+
+	return ctx, func() {
+		rrc.mutex.Lock()
+		delete(rrc.cancels, key)
+		rrc.mutex.Unlock()
+
+		cancel()
+	}
+}
+
+// cancel aborts the relay entry signing started for the given request.
+// It returns false if there is no signing in progress for the request.
+func (rrc *relayRequestCancellers) cancel(request *event.Request) bool {
+	key := relayRequestKey(request)
+
+	rrc.mutex.Lock()
+	cancel, ok := rrc.cancels[key]
+	delete(rrc.cancels, key)
+	rrc.mutex.Unlock()
+
+	if ok {
+		cancel()
+	}
+
+	return ok
+}
+
+// handleEventRemoval reacts to an event removed from the chain by a chain
+// reorganization after it has been handled by the beacon. Relay entry signing
+// for removed relay requests is aborted. Other removed events are only
+// reported since the work they started either completes against the new
+// chain or fails on its own.
+func handleEventRemoval(
+	removal *event.Removed,
+	relayRequests *relayRequestCancellers,
+) {
+	switch removed := removal.Event.(type) {
+	case *event.Request:
+		if relayRequests.cancel(removed) {
+			logger.Warningf(
+				"relay entry request with previous entry [0x%x] from block "+
+					"[%v] has been removed by chain reorganization; "+
+					"aborted relay entry signing",
+				removed.PreviousEntry,
+				removal.BlockNumber,
+			)
+		} else {
+			logger.Infof(
+				"relay entry request with previous entry [0x%x] from block "+
+					"[%v] has been removed by chain reorganization",
+				removed.PreviousEntry,
+				removal.BlockNumber,
+			)
+		}
+	case *event.GroupSelectionStart:
+		logger.Warningf(
+			"group selection with seed [0x%x] from block [%v] has been "+
+				"removed by chain reorganization; submitted tickets and "+
+				"key generation may fail",
+			removed.NewEntry,
+			removal.BlockNumber,
+		)
+	case *event.GroupRegistration:
+		logger.Warningf(
+			"registration of group [0x%x] from block [%v] has been "+
+				"removed by chain reorganization",
+			removed.GroupPublicKey,
+			removal.BlockNumber,
+		)
+	default:
+		logger.Debugf(
+			"event [%T] from block [%v] has been removed by chain "+
+				"reorganization",
+			removal.Event,
+			removal.BlockNumber,
+		)
+	}
+}
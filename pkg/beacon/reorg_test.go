@@ -0,0 +1,81 @@
+package beacon
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain/local"
+)
+
+func TestAbortSigningForRequestRemovedByReorg(t *testing.T) {
+	chain := local.Connect(5, 3, big.NewInt(200))
+	relayChain := chain.ThresholdRelay()
+
+	cancellers := newRelayRequestCancellers()
+
+	signingContexts := make(chan context.Context, 1)
+	requestSubscription, err := relayChain.OnRelayEntryRequested(
+		func(request *event.Request) {
+			signingCtx, _ := cancellers.add(context.Background(), request)
+			signingContexts <- signingCtx
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer requestSubscription.Unsubscribe()
+
+	removalSubscription, err := relayChain.OnEventRemoved(
+		func(removal *event.Removed) {
+			handleEventRemoval(removal, cancellers)
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removalSubscription.Unsubscribe()
+
+	chain.RequestRelayEntry([]byte{1}, []byte{2})
+
+	var signingCtx context.Context
+	select {
+	case signingCtx = <-signingContexts:
+	case <-time.After(time.Second):
+		t.Fatal("relay entry request not received")
+	}
+
+	if err := chain.SimulateReorg(5); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-signingCtx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("signing for removed relay request should be aborted")
+	}
+}
+
+func TestRelayRequestCancellers(t *testing.T) {
+	cancellers := newRelayRequestCancellers()
+
+	request := &event.Request{PreviousEntry: []byte{1}, BlockNumber: 10}
+	reemittedRequest := &event.Request{PreviousEntry: []byte{1}, BlockNumber: 12}
+
+	ctx, done := cancellers.add(context.Background(), request)
+
+	if cancellers.cancel(reemittedRequest) {
+		t.Errorf("request emitted at another block should not be cancelled")
+	}
+	if ctx.Err() != nil {
+		t.Errorf("signing should not be aborted")
+	}
+
+	done()
+
+	if cancellers.cancel(request) {
+		t.Errorf("completed signing should not be cancelled")
+	}
+}
@@ -0,0 +1,93 @@
+package beacon
+
+import (
+	"encoding/hex"
+	"sort"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/rewards"
+)
+
+// GroupStatus describes a group this node is a member of.
+type GroupStatus struct {
+	// GroupPublicKey is the hexadecimal representation of the compressed
+	// group public key.
+	GroupPublicKey string              `json:"groupPublicKey"`
+	ChannelName    string              `json:"channelName"`
+	MemberIndexes  []group.MemberIndex `json:"memberIndexes"`
+}
+
+// RewardsStatus describes rewards automatically withdrawn by this node.
+type RewardsStatus struct {
+	Claims []rewards.Claim `json:"claims"`
+	// PendingGroups are uncompressed public keys of stale groups rewards
+	// have not been withdrawn from yet, in a hexadecimal form.
+	PendingGroups []string `json:"pendingGroups"`
+}
+
+// Groups returns all groups this node is currently a member of, ordered by
+// the group public key.
+func (h *Handle) Groups() []GroupStatus {
+	groups := make([]GroupStatus, 0)
+
+	for _, memberships := range h.groupRegistry.GetGroups() {
+		if len(memberships) == 0 {
+			continue
+		}
+
+		memberIndexes := make([]group.MemberIndex, len(memberships))
+		for i, membership := range memberships {
+			memberIndexes[i] = membership.Signer.MemberID()
+		}
+		sort.Slice(memberIndexes, func(i, j int) bool {
+			return memberIndexes[i] < memberIndexes[j]
+		})
+
+		groups = append(groups, GroupStatus{
+			GroupPublicKey: hex.EncodeToString(
+				memberships[0].Signer.GroupPublicKeyBytesCompressed(),
+			),
+			ChannelName:   memberships[0].ChannelName,
+			MemberIndexes: memberIndexes,
+		})
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].GroupPublicKey < groups[j].GroupPublicKey
+	})
+
+	return groups
+}
+
+// RelayRequests returns previous entries of all relay requests this node
+// is currently processing.
+func (h *Handle) RelayRequests() []string {
+	return h.pendingRelayRequests.List()
+}
+
+// GroupSelections returns seeds of all group selections this node currently
+// participates in.
+func (h *Handle) GroupSelections() []string {
+	return h.pendingGroupSelections.List()
+}
+
+// KeyGenerations returns all distributed key generation processes this node
+// currently participates in.
+func (h *Handle) KeyGenerations() []relay.KeyGeneration {
+	return h.node.KeyGenerations()
+}
+
+// Rewards returns rewards automatically withdrawn by this node so far along
+// with stale groups rewards are still to be withdrawn from. Returns nil if
+// automatic rewards withdrawal is not enabled.
+func (h *Handle) Rewards() *RewardsStatus {
+	if h.rewardsWithdrawer == nil {
+		return nil
+	}
+
+	return &RewardsStatus{
+		Claims:        h.rewardsWithdrawer.Claims(),
+		PendingGroups: h.rewardsWithdrawer.PendingGroups(),
+	}
+}
@@ -0,0 +1,116 @@
+package bls
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+// batchFactorBits is the bit length of random factors used to combine
+// checks into a single batch check. The batch check holds for invalid
+// values with a probability of at most 2^-128.
+const batchFactorBits = 128
+
+// BatchVerifyG1 checks if the signatures are correct for the provided G1
+// point message and the corresponding public keys. The signature at the given
+// position is verified against the public key at the same position. It
+// returns positions of signatures which are not correct, in ascending order.
+//
+// Instead of performing a pairing check per signature, the signatures and
+// public keys are combined with random factors `r_i` and verified with a
+// single pairing check:
+// `e(Σ (r_i * signature_i), G2) == e(message, Σ (r_i * publicKey_i))`
+//
+// If the check fails, signatures are split in halves which are verified
+// separately until all the incorrect signatures are located. Locating a
+// single incorrect signature takes a number of pairing checks logarithmic in
+// the number of signatures.
+func BatchVerifyG1(
+	publicKeys []*bn256.G2,
+	message *bn256.G1,
+	signatures []*bn256.G1,
+) ([]int, error) {
+	if len(publicKeys) != len(signatures) {
+		return nil, fmt.Errorf(
+			"number of public keys [%v] does not match number of signatures [%v]",
+			len(publicKeys),
+			len(signatures),
+		)
+	}
+
+	batch := make([]*batchItem, len(signatures))
+	for i := range signatures {
+		factor, err := RandomBatchFactor()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not generate batch factor: [%v]",
+				err,
+			)
+		}
+
+		batch[i] = &batchItem{
+			position:  i,
+			publicKey: new(bn256.G2).ScalarMult(publicKeys[i], factor),
+			signature: new(bn256.G1).ScalarMult(signatures[i], factor),
+		}
+	}
+
+	return findInvalid(batch, message), nil
+}
+
+// batchItem is a signature and the corresponding public key, both multiplied
+// by the same random factor.
+type batchItem struct {
+	position  int
+	publicKey *bn256.G2
+	signature *bn256.G1
+}
+
+// findInvalid verifies the batch with a single pairing check and bisects it
+// if the check fails. It returns positions of invalid signatures.
+func findInvalid(batch []*batchItem, message *bn256.G1) []int {
+	if len(batch) == 0 || isBatchValid(batch, message) {
+		return []int{}
+	}
+
+	if len(batch) == 1 {
+		return []int{batch[0].position}
+	}
+
+	middle := len(batch) / 2
+	return append(
+		findInvalid(batch[:middle], message),
+		findInvalid(batch[middle:], message)...,
+	)
+}
+
+func isBatchValid(batch []*batchItem, message *bn256.G1) bool {
+	publicKeys := make([]*bn256.G2, len(batch))
+	signatures := make([]*bn256.G1, len(batch))
+	for i, item := range batch {
+		publicKeys[i] = item.publicKey
+		signatures[i] = item.signature
+	}
+
+	return VerifyG1(
+		AggregateG2Points(publicKeys),
+		message,
+		AggregateG1Points(signatures),
+	)
+}
+
+// RandomBatchFactor generates a random factor in range `[1, 2^128]` to
+// combine checks of multiple values, like signatures or secret shares, into
+// a single batch check.
+func RandomBatchFactor() (*big.Int, error) {
+	max := new(big.Int).Lsh(big.NewInt(1), batchFactorBits)
+
+	factor, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return nil, err
+	}
+
+	return factor.Add(factor, big.NewInt(1)), nil
+}
@@ -0,0 +1,151 @@
+package bls
+
+import (
+	"crypto/rand"
+	"math/big"
+	"reflect"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+func TestBatchVerifyG1(t *testing.T) {
+	var tests = map[string]struct {
+		signaturesCount    int
+		invalidSignatures  []int
+		expectedInvalid    []int
+		publicKeysMismatch bool
+		expectedError      bool
+	}{
+		"no signatures": {
+			signaturesCount: 0,
+			expectedInvalid: []int{},
+		},
+		"all signatures valid": {
+			signaturesCount: 10,
+			expectedInvalid: []int{},
+		},
+		"single invalid signature": {
+			signaturesCount:   1,
+			invalidSignatures: []int{0},
+			expectedInvalid:   []int{0},
+		},
+		"one invalid signature": {
+			signaturesCount:   10,
+			invalidSignatures: []int{7},
+			expectedInvalid:   []int{7},
+		},
+		"multiple invalid signatures": {
+			signaturesCount:   10,
+			invalidSignatures: []int{9, 0, 4, 5},
+			expectedInvalid:   []int{0, 4, 5, 9},
+		},
+		"all signatures invalid": {
+			signaturesCount:   3,
+			invalidSignatures: []int{0, 1, 2},
+			expectedInvalid:   []int{0, 1, 2},
+		},
+		"public keys count mismatch": {
+			signaturesCount:    3,
+			publicKeysMismatch: true,
+			expectedError:      true,
+		},
+	}
+
+	message := new(bn256.G1).ScalarBaseMult(big.NewInt(1337))
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			publicKeys, signatures, err := generateSignatures(
+				message,
+				test.signaturesCount,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, i := range test.invalidSignatures {
+				signatures[i] = SignG1(big.NewInt(int64(i+1)), message)
+			}
+
+			if test.publicKeysMismatch {
+				publicKeys = publicKeys[1:]
+			}
+
+			invalid, err := BatchVerifyG1(publicKeys, message, signatures)
+			if test.expectedError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(test.expectedInvalid, invalid) {
+				t.Fatalf(
+					"unexpected invalid signatures\nexpected: [%v]\nactual:   [%v]",
+					test.expectedInvalid,
+					invalid,
+				)
+			}
+		})
+	}
+}
+
+func BenchmarkVerifyG1(b *testing.B) {
+	message := new(bn256.G1).ScalarBaseMult(big.NewInt(1337))
+	publicKeys, signatures, err := generateSignatures(message, 64)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for i := range signatures {
+			if !VerifyG1(publicKeys[i], message, signatures[i]) {
+				b.Fatalf("signature [%v] is not valid", i)
+			}
+		}
+	}
+}
+
+func BenchmarkBatchVerifyG1(b *testing.B) {
+	message := new(bn256.G1).ScalarBaseMult(big.NewInt(1337))
+	publicKeys, signatures, err := generateSignatures(message, 64)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		invalid, err := BatchVerifyG1(publicKeys, message, signatures)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(invalid) > 0 {
+			b.Fatalf("signatures [%v] are not valid", invalid)
+		}
+	}
+}
+
+func generateSignatures(message *bn256.G1, count int) (
+	[]*bn256.G2,
+	[]*bn256.G1,
+	error,
+) {
+	publicKeys := make([]*bn256.G2, count)
+	signatures := make([]*bn256.G1, count)
+	for i := 0; i < count; i++ {
+		secretKey, publicKey, err := bn256.RandomG2(rand.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		publicKeys[i] = publicKey
+		signatures[i] = SignG1(secretKey, message)
+	}
+
+	return publicKeys, signatures, nil
+}
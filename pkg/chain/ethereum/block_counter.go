@@ -0,0 +1,204 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	// headSubscriptionRetryDelay is the time after which a failed
+	// subscription to new block headers is created again.
+	headSubscriptionRetryDelay = 5 * time.Second
+
+	// headPollInterval is the interval in which the head is polled when the
+	// Ethereum endpoint does not support subscriptions, like HTTP endpoints.
+	headPollInterval = 2 * time.Second
+)
+
+// headBackend is the part of the Ethereum client used by the block counter.
+type headBackend interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	SubscribeNewHead(
+		ctx context.Context,
+		ch chan<- *types.Header,
+	) (ethereum.Subscription, error)
+}
+
+// headBlockCounter counts blocks based on headers of new blocks received from
+// the Ethereum client. Unlike the block counter from keep-common, it works
+// with any client implementation so it keeps counting blocks when the
+// failover client switches the Ethereum endpoint. Headers lower than the
+// highest header seen so far, received for example from an endpoint slightly
+// behind the previous one, are ignored.
+type headBlockCounter struct {
+	mutex             sync.Mutex
+	latestBlockHeight uint64
+	waiters           map[uint64][]chan uint64
+	watchers          []chan uint64
+}
+
+// newHeadBlockCounter creates a block counter starting at the current head of
+// the given client and subscribes to new block headers.
+func newHeadBlockCounter(backend headBackend) (*headBlockCounter, error) {
+	header, err := backend.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get initial block from the chain: [%v]",
+			err,
+		)
+	}
+
+	blockCounter := &headBlockCounter{
+		latestBlockHeight: header.Number.Uint64(),
+		waiters:           make(map[uint64][]chan uint64),
+	}
+
+	go blockCounter.subscribeHeads(backend)
+
+	return blockCounter, nil
+}
+
+// subscribeHeads keeps the subscription to new block headers established. If
+// the client does not support subscriptions, the head is polled instead.
+func (hbc *headBlockCounter) subscribeHeads(backend headBackend) {
+	for {
+		headers := make(chan *types.Header)
+
+		subscription, err := backend.SubscribeNewHead(
+			context.Background(),
+			headers,
+		)
+		if err == rpc.ErrNotificationsUnsupported {
+			header, err := backend.HeaderByNumber(context.Background(), nil)
+			if err != nil {
+				logger.Warningf("could not poll the current block: [%v]", err)
+			} else {
+				hbc.receiveBlock(header.Number.Uint64())
+			}
+			time.Sleep(headPollInterval)
+			continue
+		}
+		if err != nil {
+			logger.Warningf(
+				"could not create subscription to new blocks: [%v]",
+				err,
+			)
+			time.Sleep(headSubscriptionRetryDelay)
+			continue
+		}
+
+		// Blocks mined while the subscription was not established are
+		// caught up with the current head.
+		header, err := backend.HeaderByNumber(context.Background(), nil)
+		if err == nil {
+			hbc.receiveBlock(header.Number.Uint64())
+		}
+
+	receive:
+		for {
+			select {
+			case header := <-headers:
+				hbc.receiveBlock(header.Number.Uint64())
+			case err := <-subscription.Err():
+				logger.Warningf(
+					"subscription to new blocks interrupted: [%v]",
+					err,
+				)
+				subscription.Unsubscribe()
+				break receive
+			}
+		}
+
+		time.Sleep(headSubscriptionRetryDelay)
+	}
+}
+
+// receiveBlock notifies waiters and watchers about all the blocks up to the
+// given height not seen yet.
+func (hbc *headBlockCounter) receiveBlock(height uint64) {
+	hbc.mutex.Lock()
+	defer hbc.mutex.Unlock()
+
+	for hbc.latestBlockHeight < height {
+		hbc.latestBlockHeight++
+		block := hbc.latestBlockHeight
+
+		for _, waiter := range hbc.waiters[block] {
+			go func(waiter chan uint64) { waiter <- block }(waiter)
+		}
+		delete(hbc.waiters, block)
+
+		for _, watcher := range hbc.watchers {
+			select {
+			case watcher <- block:
+			default:
+				// Slow watchers do not receive the block.
+			}
+		}
+	}
+}
+
+func (hbc *headBlockCounter) WaitForBlockHeight(blockNumber uint64) error {
+	waiter, err := hbc.BlockHeightWaiter(blockNumber)
+	if err != nil {
+		return err
+	}
+	<-waiter
+	return nil
+}
+
+func (hbc *headBlockCounter) BlockHeightWaiter(
+	blockNumber uint64,
+) (<-chan uint64, error) {
+	waiter := make(chan uint64)
+
+	hbc.mutex.Lock()
+	defer hbc.mutex.Unlock()
+
+	if blockNumber <= hbc.latestBlockHeight {
+		go func() { waiter <- blockNumber }()
+	} else {
+		hbc.waiters[blockNumber] = append(hbc.waiters[blockNumber], waiter)
+	}
+
+	return waiter, nil
+}
+
+func (hbc *headBlockCounter) CurrentBlock() (uint64, error) {
+	hbc.mutex.Lock()
+	defer hbc.mutex.Unlock()
+
+	return hbc.latestBlockHeight, nil
+}
+
+func (hbc *headBlockCounter) WatchBlocks(ctx context.Context) <-chan uint64 {
+	watcher := make(chan uint64)
+
+	hbc.mutex.Lock()
+	hbc.watchers = append(hbc.watchers, watcher)
+	hbc.mutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		hbc.mutex.Lock()
+		defer hbc.mutex.Unlock()
+
+		for i, w := range hbc.watchers {
+			if w == watcher {
+				hbc.watchers = append(hbc.watchers[:i], hbc.watchers[i+1:]...)
+				break
+			}
+		}
+		close(watcher)
+	}()
+
+	return watcher
+}
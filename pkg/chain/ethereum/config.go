@@ -0,0 +1,78 @@
+package ethereum
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum"
+)
+
+// Config is the configuration of the connection to the Ethereum network. It
+// extends the common Ethereum configuration with options specific to the
+// Keep client.
+type Config struct {
+	ethereum.Config
+
+	// ConfirmationDepth is the number of blocks which have to be mined on
+	// top of the block with an event before the event is delivered to
+	// handlers. Events are delivered as soon as they are seen when the depth
+	// is zero.
+	ConfirmationDepth uint64
+
+	// Endpoints are URLs of additional Ethereum endpoints. When the endpoint
+	// configured with URL is not healthy, the client fails over to the first
+	// healthy endpoint from the list. WebSocket endpoints are preferred; with
+	// HTTP endpoints, contract events are polled instead of subscribed to.
+	// Example: ["ws://192.168.0.158:8546", "ws://192.168.0.159:8546"].
+	Endpoints []string
+
+	// EndpointHealthCheckInterval is the interval in seconds in which health
+	// of the configured endpoints is checked.
+	EndpointHealthCheckInterval int
+
+	// EndpointMaxHeadLag is the number of blocks the head of an endpoint can
+	// be behind the highest head among all the configured endpoints for the
+	// endpoint to be considered healthy.
+	EndpointMaxHeadLag uint64
+
+	// ExternalSigner is the URL of an external signer exposing the Clef
+	// JSON-RPC interface, like "http://127.0.0.1:8550" or a path to an IPC
+	// socket. When set, transactions submitted by the operator accounts and
+	// messages signed on their behalf are signed by the external signer
+	// instead of the client. The external signer has to manage all the
+	// operator accounts.
+	ExternalSigner string
+
+	// ExternalSignerAccounts are hex-encoded addresses of the operator
+	// accounts managed by the external signer, the primary operator first.
+	// When the external signer is used, they replace the account and the
+	// additional operators configured with key files, and no key file of an
+	// operator account is read. The client hosts one operator account with
+	// the external signer.
+	ExternalSignerAccounts []string
+}
+
+// ExternalSignerAddresses returns addresses of the operator accounts managed
+// by the external signer, in the configured order.
+func (c Config) ExternalSignerAddresses() ([]common.Address, error) {
+	if len(c.ExternalSignerAccounts) == 0 {
+		return nil, fmt.Errorf(
+			"no operator accounts configured for the external signer",
+		)
+	}
+
+	addresses := make([]common.Address, len(c.ExternalSignerAccounts))
+	for i, account := range c.ExternalSignerAccounts {
+		if !common.IsHexAddress(account) {
+			return nil, fmt.Errorf(
+				"configured external signer account [%v] is not valid "+
+					"hex address",
+				account,
+			)
+		}
+
+		addresses[i] = common.HexToAddress(account)
+	}
+
+	return addresses, nil
+}
@@ -0,0 +1,239 @@
+package ethereum
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/subscription"
+)
+
+// reorgTrackingBlocks is the number of blocks for which delivered and removed
+// logs are tracked. Chain reorganizations deeper than this are not reported.
+const reorgTrackingBlocks = 128
+
+// logID uniquely identifies a log emitted in a block.
+type logID struct {
+	blockHash common.Hash
+	txHash    common.Hash
+	index     uint
+}
+
+func newLogID(log types.Log) logID {
+	return logID{log.BlockHash, log.TxHash, log.Index}
+}
+
+// deliveredLog holds events delivered to handlers for a single log, keyed by
+// the type of the event. The same log may be delivered as events of different
+// types, for example the DKG result submission log is delivered both as DKG
+// result submission and group registration.
+type deliveredLog struct {
+	blockNumber uint64
+	events      map[string]interface{}
+}
+
+// eventConfirmer delivers events to handlers once blocks they were emitted in
+// have the configured confirmation depth and notifies removal handlers about
+// already delivered events removed by a chain reorganization.
+type eventConfirmer struct {
+	depth        uint64
+	blockCounter chain.BlockCounter
+	// blockHash returns the hash of the canonical chain block with the given
+	// number.
+	blockHash func(blockNumber uint64) (common.Hash, error)
+
+	mutex           sync.Mutex
+	deliveredLogs   map[logID]*deliveredLog
+	removedLogs     map[logID]uint64
+	removedHandlers map[int]func(removal *event.Removed)
+	lastPruneBlock  uint64
+}
+
+func newEventConfirmer(
+	depth uint64,
+	blockCounter chain.BlockCounter,
+	blockHash func(blockNumber uint64) (common.Hash, error),
+) *eventConfirmer {
+	return &eventConfirmer{
+		depth:           depth,
+		blockCounter:    blockCounter,
+		blockHash:       blockHash,
+		deliveredLogs:   make(map[logID]*deliveredLog),
+		removedLogs:     make(map[logID]uint64),
+		removedHandlers: make(map[int]func(removal *event.Removed)),
+	}
+}
+
+// handle processes the event built from the given log. If the log has been
+// removed, handlers registered for removals are notified about events
+// delivered for that log before. Otherwise, deliver is called once the log
+// is confirmed, unless it gets removed in the meantime.
+func (ec *eventConfirmer) handle(
+	log types.Log,
+	value interface{},
+	deliver func(),
+) {
+	ec.prune(log.BlockNumber)
+
+	if log.Removed {
+		ec.remove(log)
+		return
+	}
+
+	if ec.depth == 0 {
+		ec.deliver(log, value, deliver)
+		return
+	}
+
+	go ec.confirm(log, value, deliver)
+}
+
+func (ec *eventConfirmer) confirm(
+	log types.Log,
+	value interface{},
+	deliver func(),
+) {
+	err := ec.blockCounter.WaitForBlockHeight(log.BlockNumber + ec.depth)
+	if err != nil {
+		logger.Errorf(
+			"could not wait for confirmation of event [%T] "+
+				"emitted at block [%v]: [%v]",
+			value,
+			log.BlockNumber,
+			err,
+		)
+		return
+	}
+
+	canonicalHash, err := ec.blockHash(log.BlockNumber)
+	if err != nil {
+		// Not delivering the event could make the client miss its duties.
+		// If the event turns out to be removed later, removal handlers
+		// are still notified about it.
+		logger.Warningf(
+			"could not confirm event [%T] emitted at block [%v]; "+
+				"delivering it unconfirmed: [%v]",
+			value,
+			log.BlockNumber,
+			err,
+		)
+	} else if canonicalHash != log.BlockHash {
+		logger.Infof(
+			"event [%T] emitted at block [%v] has been removed by "+
+				"chain reorganization before confirmation",
+			value,
+			log.BlockNumber,
+		)
+		return
+	}
+
+	ec.deliver(log, value, deliver)
+}
+
+func (ec *eventConfirmer) deliver(
+	log types.Log,
+	value interface{},
+	deliver func(),
+) {
+	id := newLogID(log)
+
+	ec.mutex.Lock()
+	if _, removed := ec.removedLogs[id]; removed {
+		ec.mutex.Unlock()
+		return
+	}
+
+	delivered, ok := ec.deliveredLogs[id]
+	if !ok {
+		delivered = &deliveredLog{
+			blockNumber: log.BlockNumber,
+			events:      make(map[string]interface{}),
+		}
+		ec.deliveredLogs[id] = delivered
+	}
+	delivered.events[fmt.Sprintf("%T", value)] = value
+	ec.mutex.Unlock()
+
+	deliver()
+}
+
+func (ec *eventConfirmer) remove(log types.Log) {
+	id := newLogID(log)
+
+	ec.mutex.Lock()
+	ec.removedLogs[id] = log.BlockNumber
+
+	delivered, ok := ec.deliveredLogs[id]
+	delete(ec.deliveredLogs, id)
+
+	handlers := make([]func(removal *event.Removed), 0)
+	for _, handler := range ec.removedHandlers {
+		handlers = append(handlers, handler)
+	}
+	ec.mutex.Unlock()
+
+	if !ok {
+		return
+	}
+
+	for _, value := range delivered.events {
+		logger.Warningf(
+			"event [%T] emitted at block [%v] has been removed by "+
+				"chain reorganization",
+			value,
+			log.BlockNumber,
+		)
+
+		removal := &event.Removed{
+			Event:       value,
+			BlockNumber: log.BlockNumber,
+		}
+		for _, handler := range handlers {
+			go handler(removal)
+		}
+	}
+}
+
+// prune stops tracking logs emitted more than reorgTrackingBlocks before the
+// given block.
+func (ec *eventConfirmer) prune(currentBlock uint64) {
+	ec.mutex.Lock()
+	defer ec.mutex.Unlock()
+
+	if currentBlock < ec.lastPruneBlock+reorgTrackingBlocks {
+		return
+	}
+	ec.lastPruneBlock = currentBlock
+
+	for id, delivered := range ec.deliveredLogs {
+		if delivered.blockNumber+reorgTrackingBlocks < currentBlock {
+			delete(ec.deliveredLogs, id)
+		}
+	}
+	for id, blockNumber := range ec.removedLogs {
+		if blockNumber+reorgTrackingBlocks < currentBlock {
+			delete(ec.removedLogs, id)
+		}
+	}
+}
+
+func (ec *eventConfirmer) onEventRemoved(
+	handler func(removal *event.Removed),
+) subscription.EventSubscription {
+	ec.mutex.Lock()
+	defer ec.mutex.Unlock()
+
+	handlerID := rand.Int()
+	ec.removedHandlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		ec.mutex.Lock()
+		defer ec.mutex.Unlock()
+
+		delete(ec.removedHandlers, handlerID)
+	})
+}
@@ -0,0 +1,181 @@
+package ethereum
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+// testBlockCounter reaches any block height once the height channel is closed.
+type testBlockCounter struct {
+	chain.BlockCounter
+
+	height chan struct{}
+}
+
+func (tbc *testBlockCounter) WaitForBlockHeight(blockNumber uint64) error {
+	<-tbc.height
+	return nil
+}
+
+func newTestConfirmer(
+	depth uint64,
+	canonicalHash common.Hash,
+) (*eventConfirmer, chan struct{}) {
+	height := make(chan struct{})
+	confirmer := newEventConfirmer(
+		depth,
+		&testBlockCounter{height: height},
+		func(blockNumber uint64) (common.Hash, error) {
+			return canonicalHash, nil
+		},
+	)
+	return confirmer, height
+}
+
+func testLog(blockHash common.Hash) types.Log {
+	return types.Log{
+		BlockNumber: 10,
+		BlockHash:   blockHash,
+		TxHash:      common.HexToHash("0x02"),
+		Index:       1,
+	}
+}
+
+func TestDeliverEventAfterConfirmation(t *testing.T) {
+	blockHash := common.HexToHash("0x01")
+	confirmer, height := newTestConfirmer(6, blockHash)
+
+	delivered := make(chan struct{}, 1)
+	confirmer.handle(testLog(blockHash), &event.Request{}, func() {
+		delivered <- struct{}{}
+	})
+
+	select {
+	case <-delivered:
+		t.Fatal("event delivered before confirmation")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(height)
+
+	select {
+	case <-delivered:
+	case <-time.After(time.Second):
+		t.Fatal("confirmed event not delivered")
+	}
+}
+
+func TestDoNotDeliverEventNotInCanonicalChain(t *testing.T) {
+	confirmer, height := newTestConfirmer(6, common.HexToHash("0x01"))
+	close(height)
+
+	delivered := make(chan struct{}, 1)
+	confirmer.handle(
+		testLog(common.HexToHash("0x03")),
+		&event.Request{},
+		func() { delivered <- struct{}{} },
+	)
+
+	select {
+	case <-delivered:
+		t.Fatal("event from reorganized block should not be delivered")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestDoNotDeliverEventRemovedBeforeConfirmation(t *testing.T) {
+	blockHash := common.HexToHash("0x01")
+	confirmer, height := newTestConfirmer(6, blockHash)
+
+	removals := make(chan *event.Removed, 1)
+	confirmer.onEventRemoved(func(removal *event.Removed) {
+		removals <- removal
+	})
+
+	delivered := make(chan struct{}, 1)
+	log := testLog(blockHash)
+	confirmer.handle(log, &event.Request{}, func() {
+		delivered <- struct{}{}
+	})
+
+	log.Removed = true
+	confirmer.handle(log, &event.Request{}, func() {
+		t.Error("removed log should not be delivered")
+	})
+
+	close(height)
+
+	select {
+	case <-delivered:
+		t.Fatal("removed event should not be delivered")
+	case <-removals:
+		t.Fatal("undelivered event should not be reported as removed")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestNotifyAboutRemovedEvents(t *testing.T) {
+	blockHash := common.HexToHash("0x01")
+	confirmer, _ := newTestConfirmer(0, blockHash)
+
+	removals := make(chan *event.Removed, 3)
+	confirmer.onEventRemoved(func(removal *event.Removed) {
+		removals <- removal
+	})
+
+	log := testLog(blockHash)
+	submission := &event.DKGResultSubmission{BlockNumber: log.BlockNumber}
+	registration := &event.GroupRegistration{BlockNumber: log.BlockNumber}
+
+	deliveries := 0
+	confirmer.handle(log, submission, func() { deliveries++ })
+	confirmer.handle(log, registration, func() { deliveries++ })
+	confirmer.handle(log, registration, func() { deliveries++ })
+
+	if deliveries != 3 {
+		t.Fatalf(
+			"unexpected number of deliveries\nexpected: [%v]\nactual:   [%v]",
+			3,
+			deliveries,
+		)
+	}
+
+	log.Removed = true
+	confirmer.handle(log, submission, func() {})
+	confirmer.handle(log, registration, func() {})
+
+	removedTypes := make(map[string]bool)
+	for i := 0; i < 2; i++ {
+		select {
+		case removal := <-removals:
+			if removal.BlockNumber != log.BlockNumber {
+				t.Errorf(
+					"unexpected block number\nexpected: [%v]\nactual:   [%v]",
+					log.BlockNumber,
+					removal.BlockNumber,
+				)
+			}
+			removedTypes[fmt.Sprintf("%T", removal.Event)] = true
+		case <-time.After(time.Second):
+			t.Fatal("removal notification not received")
+		}
+	}
+
+	for _, expected := range []interface{}{submission, registration} {
+		if !removedTypes[fmt.Sprintf("%T", expected)] {
+			t.Errorf("expected removal notification for [%T]", expected)
+		}
+	}
+
+	select {
+	case removal := <-removals:
+		t.Errorf("unexpected removal notification for [%T]", removal.Event)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -1,6 +1,8 @@
 package ethereum
 
 import (
+	"context"
+	"crypto/ecdsa"
 	"fmt"
 	"math/big"
 	"sync"
@@ -9,12 +11,11 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum"
-	"github.com/keep-network/keep-common/pkg/chain/ethereum/blockcounter"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/ledger"
+	"github.com/keep-network/keep-core/pkg/chain/gen/abi"
 	"github.com/keep-network/keep-core/pkg/chain/gen/contract"
 )
 
@@ -34,14 +35,20 @@ var (
 )
 
 type ethereumChain struct {
-	config                           ethereum.Config
-	client                           bind.ContractBackend
-	clientRPC                        *rpc.Client
-	clientWS                         *rpc.Client
-	keepRandomBeaconOperatorContract *contract.KeepRandomBeaconOperator
-	stakingContract                  *contract.TokenStaking
-	accountKey                       *keystore.Key
-	blockCounter                     *blockcounter.EthereumBlockCounter
+	config                             Config
+	client                             bind.ContractBackend
+	failoverClient                     *failoverClient
+	keepRandomBeaconOperatorContract   *contract.KeepRandomBeaconOperator
+	keepRandomBeaconOperatorFilterer   *abi.KeepRandomBeaconOperatorFilterer
+	keepRandomBeaconOperatorEvents     *contractEvents
+	keepRandomBeaconOperatorTransactor *abi.KeepRandomBeaconOperatorTransactor
+	stakingContract                    *contract.TokenStaking
+	accountAddress                     common.Address
+	accountKey                         *keystore.Key
+	externalSigner                     *externalSigner
+	blockCounter                       *headBlockCounter
+	eventConfirmer                     *eventConfirmer
+	transactionManager                 *transactionManager
 
 	// transactionMutex allows interested parties to forcibly serialize
 	// transaction submission.
@@ -54,8 +61,14 @@ type ethereumChain struct {
 	// and uses that value for the transaction. Unfortunately, if multiple
 	// transactions are submitted in short order, they may all get the same
 	// nonce. Serializing submission ensures that each nonce is requested after
-	// a previous transaction has been submitted.
+	// a previous transaction has been submitted. The mutex is shared by all
+	// handles submitting transactions from the same account.
 	transactionMutex *sync.Mutex
+
+	// feeLedger records fees of protocol transactions submitted by the
+	// operator, if set.
+	feeLedgerMutex *sync.Mutex
+	feeLedger      *ledger.Ledger
 }
 
 type ethereumUtilityChain struct {
@@ -64,8 +77,26 @@ type ethereumUtilityChain struct {
 	keepRandomBeaconServiceContract *contract.KeepRandomBeaconService
 }
 
-func connect(config ethereum.Config) (*ethereumChain, error) {
-	client, clientWS, clientRPC, err := ethutil.ConnectClients(config.URL, config.URLRPC)
+func connect(config Config) (*ethereumChain, error) {
+	client, err := connectClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return connectWithClient(config, client)
+}
+
+// connectClient establishes connections with the Ethereum endpoints from the
+// given configuration and starts monitoring their health.
+func connectClient(config Config) (*failoverClient, error) {
+	urls := append([]string{config.URL}, config.Endpoints...)
+
+	maxHeadLag := DefaultEndpointMaxHeadLag
+	if config.EndpointMaxHeadLag != 0 {
+		maxHeadLag = config.EndpointMaxHeadLag
+	}
+
+	client, err := newFailoverClient(urls, maxHeadLag, dialEndpoint)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"error connecting to Ethereum server: %s [%v]",
@@ -74,17 +105,31 @@ func connect(config ethereum.Config) (*ethereumChain, error) {
 		)
 	}
 
-	return connectWithClient(config, client, clientWS, clientRPC)
+	healthCheckInterval := DefaultEndpointHealthCheckInterval
+	if config.EndpointHealthCheckInterval != 0 {
+		healthCheckInterval =
+			time.Duration(config.EndpointHealthCheckInterval) * time.Second
+	}
+
+	if len(urls) > 1 {
+		logger.Infof(
+			"using [%v] Ethereum endpoints with [%v] health check interval "+
+				"and [%v] blocks max head lag",
+			len(urls),
+			healthCheckInterval,
+			maxHeadLag,
+		)
+	}
+	client.monitorHealth(healthCheckInterval)
+
+	return client, nil
 }
 
 func connectWithClient(
-	config ethereum.Config,
-	client *ethclient.Client,
-	clientWS *rpc.Client,
-	clientRPC *rpc.Client,
+	config Config,
+	client *failoverClient,
 ) (*ethereumChain, error) {
-
-	blockCounter, err := blockcounter.CreateBlockCounter(client)
+	blockCounter, err := newHeadBlockCounter(client)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"failed to create Ethereum blockcounter: [%v]",
@@ -92,30 +137,118 @@ func connectWithClient(
 		)
 	}
 
+	if config.ExternalSigner != "" {
+		addresses, err := config.ExternalSignerAddresses()
+		if err != nil {
+			return nil, err
+		}
+
+		return connectAccount(
+			config,
+			addresses[0],
+			nil,
+			client,
+			blockCounter,
+		)
+	}
+
+	accountKey, err := ethutil.DecryptKeyFile(
+		config.Account.KeyFile,
+		config.Account.KeyFilePassword,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to read KeyFile: %s: [%v]",
+			config.Account.KeyFile,
+			err,
+		)
+	}
+
+	return connectAccount(
+		config,
+		accountKey.Address,
+		accountKey,
+		client,
+		blockCounter,
+	)
+}
+
+// connectAccount creates a handle to the chain interface submitting
+// transactions from the account with the given address, using the provided,
+// already established connection to the Ethereum network. Transactions are
+// signed with the given account key or, if the external signer is
+// configured, by the external signer, in which case the key is not needed
+// and should be nil.
+func connectAccount(
+	config Config,
+	accountAddress common.Address,
+	accountKey *keystore.Key,
+	client *failoverClient,
+	blockCounter *headBlockCounter,
+) (*ethereumChain, error) {
+	accountNonceManager := nonceManagerFor(accountAddress, client)
+
 	pv := &ethereumChain{
 		config:           config,
 		client:           ethutil.WrapCallLogging(logger, client),
-		clientRPC:        clientRPC,
-		clientWS:         clientWS,
-		transactionMutex: &sync.Mutex{},
+		failoverClient:   client,
+		accountAddress:   accountAddress,
+		accountKey:       accountKey,
+		transactionMutex: accountNonceManager.mutex,
 		blockCounter:     blockCounter,
+		feeLedgerMutex:   &sync.Mutex{},
 	}
 
-	if pv.accountKey == nil {
-		key, err := ethutil.DecryptKeyFile(
-			config.Account.KeyFile,
-			config.Account.KeyFilePassword,
+	var transactorOptions *bind.TransactOpts
+	if config.ExternalSigner != "" {
+		signer, err := dialExternalSigner(
+			config.ExternalSigner,
+			accountAddress,
 		)
 		if err != nil {
 			return nil, fmt.Errorf(
-				"failed to read KeyFile: %s: [%v]",
-				config.Account.KeyFile,
+				"error connecting to external signer: [%v]",
 				err,
 			)
 		}
-		pv.accountKey = key
+
+		logger.Infof(
+			"using external signer [%v] for account [%v]",
+			config.ExternalSigner,
+			accountAddress.Hex(),
+		)
+		pv.externalSigner = signer
+		transactorOptions = signer.transactorOptions()
+	} else {
+		if accountKey == nil {
+			return nil, fmt.Errorf(
+				"no key of account [%v] to sign transactions with",
+				accountAddress.Hex(),
+			)
+		}
+
+		transactorOptions = bind.NewKeyedTransactor(accountKey.PrivateKey)
 	}
 
+	logger.Infof(
+		"using [%v] blocks event confirmation depth",
+		config.ConfirmationDepth,
+	)
+	pv.eventConfirmer = newEventConfirmer(
+		config.ConfirmationDepth,
+		blockCounter,
+		func(blockNumber uint64) (common.Hash, error) {
+			header, err := client.HeaderByNumber(
+				context.Background(),
+				new(big.Int).SetUint64(blockNumber),
+			)
+			if err != nil {
+				return common.Hash{}, err
+			}
+			return header.Hash(), nil
+		},
+	)
+
 	checkInterval := DefaultMiningCheckInterval
 	maxGasPrice := DefaultMaxGasPrice
 	if config.MiningCheckInterval != 0 {
@@ -129,20 +262,20 @@ func connectWithClient(
 	logger.Infof("using [%v] wei max gas price", maxGasPrice)
 	miningWaiter := ethutil.NewMiningWaiter(client, checkInterval, maxGasPrice)
 
-	address, err := addressForContract(config, "KeepRandomBeaconOperator")
+	address, err := addressForContract(config.Config, "KeepRandomBeaconOperator")
 	if err != nil {
 		return nil, fmt.Errorf("error resolving KeepRandomBeaconOperator contract: [%v]", err)
 	}
 
 	nonceManager := ethutil.NewNonceManager(
-		pv.accountKey.Address,
+		pv.accountAddress,
 		pv.client,
 	)
 
 	keepRandomBeaconOperatorContract, err :=
 		contract.NewKeepRandomBeaconOperator(
 			*address,
-			pv.accountKey,
+			pv.contractKey(),
 			pv.client,
 			nonceManager,
 			miningWaiter,
@@ -153,7 +286,52 @@ func connectWithClient(
 	}
 	pv.keepRandomBeaconOperatorContract = keepRandomBeaconOperatorContract
 
-	address, err = addressForContract(config, "TokenStaking")
+	keepRandomBeaconOperatorFilterer, err :=
+		abi.NewKeepRandomBeaconOperatorFilterer(*address, pv.client)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error attaching to KeepRandomBeaconOperator contract events: [%v]",
+			err,
+		)
+	}
+	pv.keepRandomBeaconOperatorFilterer = keepRandomBeaconOperatorFilterer
+
+	keepRandomBeaconOperatorEvents, err := newContractEvents(
+		*address,
+		abi.KeepRandomBeaconOperatorABI,
+		pv.client,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error attaching to KeepRandomBeaconOperator contract events: [%v]",
+			err,
+		)
+	}
+	pv.keepRandomBeaconOperatorEvents = keepRandomBeaconOperatorEvents
+
+	keepRandomBeaconOperatorTransactor, err :=
+		abi.NewKeepRandomBeaconOperatorTransactor(*address, pv.client)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error attaching to KeepRandomBeaconOperator contract "+
+				"transactions: [%v]",
+			err,
+		)
+	}
+	pv.keepRandomBeaconOperatorTransactor = keepRandomBeaconOperatorTransactor
+
+	pv.transactionManager = &transactionManager{
+		backend:             client,
+		blockCounter:        blockCounter,
+		transactorOptions:   transactorOptions,
+		nonceManager:        accountNonceManager,
+		replacementInterval: checkInterval,
+		maxGasPrice:         maxGasPrice,
+		gasPriceCeiling:     keepRandomBeaconOperatorContract.GasPriceCeiling,
+		minedHandler:        pv.recordTransaction,
+	}
+
+	address, err = addressForContract(config.Config, "TokenStaking")
 	if err != nil {
 		return nil, fmt.Errorf("error resolving TokenStaking contract: [%v]", err)
 	}
@@ -161,7 +339,7 @@ func connectWithClient(
 	stakingContract, err :=
 		contract.NewTokenStaking(
 			*address,
-			pv.accountKey,
+			pv.contractKey(),
 			pv.client,
 			nonceManager,
 			miningWaiter,
@@ -175,22 +353,40 @@ func connectWithClient(
 	return pv, nil
 }
 
+// contractKey returns the account key for the generated contract wrappers.
+// Wrappers take the key to learn the account making calls and gas estimates.
+// When the external signer is used, the returned key holds only the public
+// key of the account; wrappers never sign transactions then, as all
+// operator transactions are submitted with the transaction manager.
+func (ec *ethereumChain) contractKey() *keystore.Key {
+	if ec.externalSigner == nil {
+		return ec.accountKey
+	}
+
+	return &keystore.Key{
+		Address: ec.accountAddress,
+		PrivateKey: &ecdsa.PrivateKey{
+			PublicKey: *ec.externalSigner.publicKey,
+		},
+	}
+}
+
 // ConnectUtility makes the network connection to the Ethereum network and
 // returns a utility handle to the chain interface with additional methods for
-// non- standard client interactions. Note: for other things to work correctly
+// non- standard client interactions. Utility transactions are signed with the
+// key of the configured account, so the key file is read even if the
+// external signer is configured. Note: for other things to work correctly
 // the configuration will need to reference a websocket, "ws://", or local IPC
 // connection.
-func ConnectUtility(config ethereum.Config) (chain.Utility, error) {
-	client, clientWS, clientRPC, err := ethutil.ConnectClients(config.URL, config.URLRPC)
+func ConnectUtility(config Config) (chain.Utility, error) {
+	config.ExternalSigner = ""
+
+	client, err := connectClient(config)
 	if err != nil {
-		return nil, fmt.Errorf(
-			"error connecting to Ethereum server: %s [%v]",
-			config.URL,
-			err,
-		)
+		return nil, err
 	}
 
-	base, err := connectWithClient(config, client, clientWS, clientRPC)
+	base, err := connectWithClient(config, client)
 	if err != nil {
 		return nil, err
 	}
@@ -206,13 +402,13 @@ func ConnectUtility(config ethereum.Config) (chain.Utility, error) {
 
 	miningWaiter := ethutil.NewMiningWaiter(client, checkInterval, maxGasPrice)
 
-	address, err := addressForContract(config, "KeepRandomBeaconService")
+	address, err := addressForContract(config.Config, "KeepRandomBeaconService")
 	if err != nil {
 		return nil, fmt.Errorf("error resolving KeepRandomBeaconService contract: [%v]", err)
 	}
 
 	nonceManager := ethutil.NewNonceManager(
-		base.accountKey.Address,
+		base.accountAddress,
 		base.client,
 	)
 
@@ -239,10 +435,66 @@ func ConnectUtility(config ethereum.Config) (chain.Utility, error) {
 // standard handle to the chain interface. Note: for other things to work
 // correctly the configuration will need to reference a websocket, "ws://", or
 // local IPC connection.
-func Connect(config ethereum.Config) (chain.Handle, error) {
+func Connect(config Config) (chain.Handle, error) {
 	return connect(config)
 }
 
+// ConnectOperators makes a single network connection to the Ethereum network
+// and returns a standard handle to the chain interface for each of the given
+// operator accounts, in the same order as the account addresses. Account
+// keys are the already decrypted keys of the accounts, in the same order;
+// they are nil if the external signer is configured. All the handles share
+// the connection and the block counter but each of them submits
+// transactions from its own account.
+func ConnectOperators(
+	config Config,
+	accountAddresses []common.Address,
+	accountKeys []*keystore.Key,
+) ([]chain.Handle, error) {
+	if len(accountAddresses) != len(accountKeys) {
+		return nil, fmt.Errorf(
+			"[%v] account keys provided for [%v] accounts",
+			len(accountKeys),
+			len(accountAddresses),
+		)
+	}
+
+	client, err := connectClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	blockCounter, err := newHeadBlockCounter(client)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to create Ethereum blockcounter: [%v]",
+			err,
+		)
+	}
+
+	handles := make([]chain.Handle, len(accountAddresses))
+	for i, accountAddress := range accountAddresses {
+		handle, err := connectAccount(
+			config,
+			accountAddress,
+			accountKeys[i],
+			client,
+			blockCounter,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not connect account [%v]: [%v]",
+				accountAddress.Hex(),
+				err,
+			)
+		}
+
+		handles[i] = handle
+	}
+
+	return handles, nil
+}
+
 func addressForContract(config ethereum.Config, contractName string) (*common.Address, error) {
 	addressString, exists := config.ContractAddresses[contractName]
 	if !exists {
@@ -78,3 +78,55 @@ func TestResolveContractByName(t *testing.T) {
 		})
 	}
 }
+
+func TestExternalSignerAddresses(t *testing.T) {
+	var tests = map[string]struct {
+		accounts          []string
+		expectedAddresses []common.Address
+		expectedError     error
+	}{
+		"configured accounts": {
+			accounts: []string{
+				"0x0b185C37E1C9D01437c800a8B60fA0845742c271",
+				"0x1895e4A71d0956553cf80f2ccac69642a2f1bFF4",
+			},
+			expectedAddresses: []common.Address{
+				common.HexToAddress("0x0b185C37E1C9D01437c800a8B60fA0845742c271"),
+				common.HexToAddress("0x1895e4A71d0956553cf80f2ccac69642a2f1bFF4"),
+			},
+		},
+		"no accounts": {
+			expectedError: fmt.Errorf(
+				"no operator accounts configured for the external signer",
+			),
+		},
+		"invalid account address": {
+			accounts: []string{"0xYOLO"},
+			expectedError: fmt.Errorf(
+				"configured external signer account [0xYOLO] is not valid hex address",
+			),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			config := Config{ExternalSignerAccounts: test.accounts}
+
+			addresses, err := config.ExternalSignerAddresses()
+			if !reflect.DeepEqual(test.expectedAddresses, addresses) {
+				t.Errorf(
+					"unexpected addresses\nexpected: [%v]\nactual:   [%v]",
+					test.expectedAddresses,
+					addresses,
+				)
+			}
+			if !reflect.DeepEqual(test.expectedError, err) {
+				t.Errorf(
+					"unexpected error\nexpected: [%v]\nactual:   [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	ethevent "github.com/ethereum/go-ethereum/event"
+)
+
+// contractEvents subscribes to and fetches logs of events emitted by a single
+// contract. Logs are not bound to event specific types so that all events
+// can be watched the same way; they are unpacked by the event handlers.
+type contractEvents struct {
+	address  common.Address
+	abi      ethabi.ABI
+	contract *bind.BoundContract
+	backend  bind.ContractFilterer
+}
+
+func newContractEvents(
+	address common.Address,
+	contractABI string,
+	backend bind.ContractFilterer,
+) (*contractEvents, error) {
+	parsed, err := ethabi.JSON(strings.NewReader(contractABI))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse contract ABI: [%v]", err)
+	}
+
+	return &contractEvents{
+		address:  address,
+		abi:      parsed,
+		contract: bind.NewBoundContract(address, parsed, nil, nil, backend),
+		backend:  backend,
+	}, nil
+}
+
+// subscribe subscribes to logs of the event with the given name. Logs are
+// sent to the provided sink.
+func (ce *contractEvents) subscribe(
+	eventName string,
+	sink chan<- types.Log,
+) (ethevent.Subscription, error) {
+	query, err := ce.query(eventName)
+	if err != nil {
+		return nil, err
+	}
+
+	return ce.backend.SubscribeFilterLogs(context.Background(), query, sink)
+}
+
+// fetch returns logs of the event with the given name emitted in the given
+// range of blocks.
+func (ce *contractEvents) fetch(
+	eventName string,
+	fromBlock uint64,
+	toBlock uint64,
+) ([]types.Log, error) {
+	query, err := ce.query(eventName)
+	if err != nil {
+		return nil, err
+	}
+
+	query.FromBlock = new(big.Int).SetUint64(fromBlock)
+	query.ToBlock = new(big.Int).SetUint64(toBlock)
+
+	return ce.backend.FilterLogs(context.Background(), query)
+}
+
+// unpack unpacks the log of the event with the given name into the event
+// specific type generated for the contract.
+func (ce *contractEvents) unpack(
+	out interface{},
+	eventName string,
+	log types.Log,
+) error {
+	return ce.contract.UnpackLog(out, eventName, log)
+}
+
+func (ce *contractEvents) query(eventName string) (ethereum.FilterQuery, error) {
+	event, ok := ce.abi.Events[eventName]
+	if !ok {
+		return ethereum.FilterQuery{}, fmt.Errorf(
+			"no event [%v] in contract ABI",
+			eventName,
+		)
+	}
+
+	return ethereum.FilterQuery{
+		Addresses: []common.Address{ce.address},
+		Topics:    [][]common.Hash{{event.ID()}},
+	}, nil
+}
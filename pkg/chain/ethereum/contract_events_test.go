@@ -0,0 +1,120 @@
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/keep-network/keep-core/pkg/chain/gen/abi"
+)
+
+// testLogFilterer returns the same logs for every query and records the
+// queries.
+type testLogFilterer struct {
+	logs    []types.Log
+	queries []ethereum.FilterQuery
+}
+
+func (tlf *testLogFilterer) FilterLogs(
+	ctx context.Context,
+	query ethereum.FilterQuery,
+) ([]types.Log, error) {
+	tlf.queries = append(tlf.queries, query)
+	return tlf.logs, nil
+}
+
+func (tlf *testLogFilterer) SubscribeFilterLogs(
+	ctx context.Context,
+	query ethereum.FilterQuery,
+	ch chan<- types.Log,
+) (ethereum.Subscription, error) {
+	tlf.queries = append(tlf.queries, query)
+	return nil, ethereum.NotFound
+}
+
+func TestContractEventsFetchAndUnpack(t *testing.T) {
+	address := common.HexToAddress("0x5a3b2c7d04e1e0e6d7a4b4c8ba5e6a95f1b1d9c2")
+	beneficiary := common.HexToAddress("0x1f0b16d4a8a3c8f7b1a2e5b1a7c3e9d2f4b6a8c0")
+	operator := common.HexToAddress("0x9c4e2b7d1a3f5e6c8b0d2a4f6e8c0b2d4f6a8e1c")
+	amount := big.NewInt(1000)
+	groupIndex := big.NewInt(3)
+
+	filterer := &testLogFilterer{}
+	events, err := newContractEvents(
+		address,
+		abi.KeepRandomBeaconOperatorABI,
+		filterer,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	withdrawnEvent := events.abi.Events["GroupMemberRewardsWithdrawn"]
+	data, err := withdrawnEvent.Inputs.NonIndexed().Pack(
+		operator,
+		amount,
+		groupIndex,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filterer.logs = []types.Log{{
+		Address: address,
+		Topics: []common.Hash{
+			withdrawnEvent.ID(),
+			common.BytesToHash(beneficiary.Bytes()),
+		},
+		Data:        data,
+		BlockNumber: 12,
+	}}
+
+	logs, err := events.fetch("GroupMemberRewardsWithdrawn", 10, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedQuery := ethereum.FilterQuery{
+		FromBlock: big.NewInt(10),
+		ToBlock:   big.NewInt(20),
+		Addresses: []common.Address{address},
+		Topics:    [][]common.Hash{{withdrawnEvent.ID()}},
+	}
+	if !reflect.DeepEqual([]ethereum.FilterQuery{expectedQuery}, filterer.queries) {
+		t.Errorf(
+			"unexpected queries\nexpected: [%+v]\nactual:   [%+v]",
+			expectedQuery,
+			filterer.queries,
+		)
+	}
+
+	if len(logs) != 1 {
+		t.Fatalf("expected [1] log; has: [%v]", len(logs))
+	}
+
+	withdrawn := new(abi.KeepRandomBeaconOperatorGroupMemberRewardsWithdrawn)
+	err = events.unpack(withdrawn, "GroupMemberRewardsWithdrawn", logs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if withdrawn.Beneficiary != beneficiary {
+		t.Errorf("unexpected beneficiary [%v]", withdrawn.Beneficiary.Hex())
+	}
+	if withdrawn.Operator != operator {
+		t.Errorf("unexpected operator [%v]", withdrawn.Operator.Hex())
+	}
+	if withdrawn.Amount.Cmp(amount) != 0 {
+		t.Errorf("unexpected amount [%v]", withdrawn.Amount)
+	}
+	if withdrawn.GroupIndex.Cmp(groupIndex) != 0 {
+		t.Errorf("unexpected group index [%v]", withdrawn.GroupIndex)
+	}
+
+	if _, err := events.fetch("UnknownEvent", 10, 20); err == nil {
+		t.Errorf("expected error for an event not in the contract ABI")
+	}
+}
@@ -1,19 +1,27 @@
 package ethereum
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"math/big"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/ipfs/go-log"
 
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	ethevent "github.com/ethereum/go-ethereum/event"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	relayconfig "github.com/keep-network/keep-core/pkg/beacon/relay/config"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain/gen/abi"
 	"github.com/keep-network/keep-core/pkg/gen/async"
 	"github.com/keep-network/keep-core/pkg/operator"
 	"github.com/keep-network/keep-core/pkg/subscription"
@@ -21,12 +29,28 @@ import (
 
 var logger = log.Logger("keep-chain-ethereum")
 
+// dkgDurationBlocks is the number of blocks the off-chain part of the
+// distributed key generation takes, before the result can be published. It is
+// the same as the DKG time set in the operator contract which does not expose
+// it.
+const dkgDurationBlocks = uint64(5*(1+5) + 2*(1+10) + 20)
+
+// transactionFetchTimeout is the timeout of fetching a transaction in order
+// to read data not carried by the event the transaction emitted.
+const transactionFetchTimeout = 10 * time.Second
+
 // ThresholdRelay converts from ethereumChain to beacon.ChainInterface.
 func (ec *ethereumChain) ThresholdRelay() relaychain.Interface {
 	return ec
 }
 
+// GetKeys returns keys of the operator account. The private key is nil if
+// the account key is held by the external signer.
 func (ec *ethereumChain) GetKeys() (*operator.PrivateKey, *operator.PublicKey) {
+	if ec.externalSigner != nil {
+		return nil, ec.externalSigner.publicKey
+	}
+
 	return operator.EthereumKeyToOperatorKey(ec.accountKey)
 }
 
@@ -101,10 +125,22 @@ func (ec *ethereumChain) SubmitTicket(ticket *chain.Ticket) *async.EventGroupTic
 
 	ticketBytes := ec.packTicket(ticket)
 
-	_, err := ec.keepRandomBeaconOperatorContract.SubmitTicket(
-		ticketBytes,
-		ethutil.TransactionOptions{
-			GasLimit: 250000,
+	// Tickets are accepted only during the ticket submission period.
+	deadline, err := ec.ticketSubmissionDeadline()
+	if err != nil {
+		failPromise(err)
+		return submittedTicketPromise
+	}
+
+	_, err = ec.transactionManager.submit(
+		"submitTicket",
+		250000,
+		deadline,
+		func(options *bind.TransactOpts) (*types.Transaction, error) {
+			return ec.keepRandomBeaconOperatorTransactor.SubmitTicket(
+				options,
+				ticketBytes,
+			)
 		},
 	)
 	if err != nil {
@@ -116,6 +152,14 @@ func (ec *ethereumChain) SubmitTicket(ticket *chain.Ticket) *async.EventGroupTic
 	return submittedTicketPromise
 }
 
+func (ec *ethereumChain) SubmitTicketGasEstimate(
+	ticket *relaychain.Ticket,
+) (uint64, error) {
+	return ec.keepRandomBeaconOperatorContract.SubmitTicketGasEstimate(
+		ec.packTicket(ticket),
+	)
+}
+
 func (ec *ethereumChain) packTicket(ticket *relaychain.Ticket) [32]uint8 {
 	ticketBytes := []uint8{}
 	ticketBytes = append(ticketBytes, ticket.Value[:]...)
@@ -232,22 +276,47 @@ func (ec *ethereumChain) SubmitRelayEntry(
 		}
 	}()
 
+	// Simulate the submission first so that a rejected entry does not cost
+	// gas and the reason of the rejection is known.
+	if err := ec.keepRandomBeaconOperatorContract.CallRelayEntry(
+		entry,
+		nil,
+	); err != nil {
+		subscription.Unsubscribe()
+		close(generatedEntry)
+		failPromise(newSubmissionError(err))
+		return relayEntryPromise
+	}
+
 	gasEstimate, err := ec.keepRandomBeaconOperatorContract.RelayEntryGasEstimate(entry)
 	if err != nil {
 		logger.Errorf("failed to estimate gas [%v]", err)
 	}
 
+	deadline, err := ec.relayEntryDeadline()
+	if err != nil {
+		subscription.Unsubscribe()
+		close(generatedEntry)
+		failPromise(err)
+		return relayEntryPromise
+	}
+
 	gasEstimateWithMargin := float64(gasEstimate) * float64(1.2) // 20% more than original
-	_, err = ec.keepRandomBeaconOperatorContract.RelayEntry(
-		entry,
-		ethutil.TransactionOptions{
-			GasLimit: uint64(gasEstimateWithMargin),
+	_, err = ec.transactionManager.submit(
+		"relayEntry",
+		uint64(gasEstimateWithMargin),
+		deadline,
+		func(options *bind.TransactOpts) (*types.Transaction, error) {
+			return ec.keepRandomBeaconOperatorTransactor.RelayEntry(
+				options,
+				entry,
+			)
 		},
 	)
 	if err != nil {
 		subscription.Unsubscribe()
 		close(generatedEntry)
-		failPromise(err)
+		failPromise(newSubmissionError(err))
 	}
 
 	return relayEntryPromise
@@ -256,86 +325,171 @@ func (ec *ethereumChain) SubmitRelayEntry(
 func (ec *ethereumChain) OnRelayEntrySubmitted(
 	handle func(entry *event.EntrySubmitted),
 ) (subscription.EventSubscription, error) {
-	return ec.keepRandomBeaconOperatorContract.WatchRelayEntrySubmitted(
-		func(blockNumber uint64) {
-			handle(&event.EntrySubmitted{
-				BlockNumber: blockNumber,
-			})
+	return ec.watchOperatorEvent(
+		"RelayEntrySubmitted",
+		func(log types.Log) (interface{}, error) {
+			relayEntry, err := ec.submittedRelayEntry(log.TxHash)
+			if err != nil {
+				logger.Warningf(
+					"could not determine relay entry submitted at block "+
+						"[%v]: [%v]",
+					log.BlockNumber,
+					err,
+				)
+			}
+
+			return &event.EntrySubmitted{
+				Entry:       relayEntry,
+				BlockNumber: log.BlockNumber,
+			}, nil
 		},
-		func(err error) error {
-			return fmt.Errorf(
-				"watch relay entry generated failed with [%v]",
-				err,
-			)
+		func(entry interface{}) {
+			handle(entry.(*event.EntrySubmitted))
 		},
+	), nil
+}
+
+// submittedRelayEntry reads the relay entry from the calldata of the
+// transaction with the given hash. The relay entry submitted event does not
+// carry the entry so this is the only way to get it without waiting for the
+// next relay request.
+func (ec *ethereumChain) submittedRelayEntry(txHash common.Hash) ([]byte, error) {
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		transactionFetchTimeout,
 	)
+	defer cancelCtx()
+
+	transaction, _, err := ec.failoverClient.TransactionByHash(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not get transaction [%v]: [%v]",
+			txHash.Hex(),
+			err,
+		)
+	}
+
+	return unpackRelayEntry(transaction.Data())
+}
+
+// unpackRelayEntry unpacks the relay entry from the calldata of a relayEntry
+// operator contract call.
+func unpackRelayEntry(calldata []byte) ([]byte, error) {
+	operatorABI, err := ethabi.JSON(
+		strings.NewReader(abi.KeepRandomBeaconOperatorABI),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse operator contract ABI: [%v]", err)
+	}
+
+	if len(calldata) < 4 {
+		return nil, fmt.Errorf("calldata too short to contain method id")
+	}
+
+	method, err := operatorABI.MethodById(calldata[:4])
+	if err != nil {
+		return nil, fmt.Errorf("could not find called method: [%v]", err)
+	}
+	if method.Name != "relayEntry" {
+		return nil, fmt.Errorf("unexpected called method [%v]", method.Name)
+	}
+
+	values, err := method.Inputs.UnpackValues(calldata[4:])
+	if err != nil {
+		return nil, fmt.Errorf("could not unpack calldata: [%v]", err)
+	}
+	if len(values) != 1 {
+		return nil, fmt.Errorf("unexpected number of arguments [%v]", len(values))
+	}
+
+	entry, ok := values[0].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected relay entry type [%T]", values[0])
+	}
+
+	return entry, nil
 }
 
 func (ec *ethereumChain) OnRelayEntryRequested(
 	handle func(request *event.Request),
 ) (subscription.EventSubscription, error) {
-	return ec.keepRandomBeaconOperatorContract.WatchRelayEntryRequested(
-		func(
-			previousEntry []byte,
-			groupPublicKey []byte,
-			blockNumber uint64,
-		) {
-			handle(&event.Request{
-				PreviousEntry:  previousEntry,
-				GroupPublicKey: groupPublicKey,
-				BlockNumber:    blockNumber,
-			})
-		},
-		func(err error) error {
-			return fmt.Errorf(
-				"watch relay entry requested failed with [%v]",
-				err,
+	return ec.watchOperatorEvent(
+		"RelayEntryRequested",
+		func(log types.Log) (interface{}, error) {
+			requested := new(abi.KeepRandomBeaconOperatorRelayEntryRequested)
+			err := ec.keepRandomBeaconOperatorEvents.unpack(
+				requested,
+				"RelayEntryRequested",
+				log,
 			)
+			if err != nil {
+				return nil, err
+			}
+
+			return &event.Request{
+				PreviousEntry:  requested.PreviousEntry,
+				GroupPublicKey: requested.GroupPublicKey,
+				BlockNumber:    log.BlockNumber,
+			}, nil
 		},
-	)
+		func(request interface{}) {
+			handle(request.(*event.Request))
+		},
+	), nil
 }
 
 func (ec *ethereumChain) OnGroupSelectionStarted(
 	handle func(groupSelectionStart *event.GroupSelectionStart),
 ) (subscription.EventSubscription, error) {
-	return ec.keepRandomBeaconOperatorContract.WatchGroupSelectionStarted(
-		func(
-			newEntry *big.Int,
-			blockNumber uint64,
-		) {
-			handle(&event.GroupSelectionStart{
-				NewEntry:    newEntry,
-				BlockNumber: blockNumber,
-			})
-		},
-		func(err error) error {
-			return fmt.Errorf(
-				"watch group selection started failed with [%v]",
-				err,
+	return ec.watchOperatorEvent(
+		"GroupSelectionStarted",
+		func(log types.Log) (interface{}, error) {
+			started := new(abi.KeepRandomBeaconOperatorGroupSelectionStarted)
+			err := ec.keepRandomBeaconOperatorEvents.unpack(
+				started,
+				"GroupSelectionStarted",
+				log,
 			)
+			if err != nil {
+				return nil, err
+			}
+
+			return &event.GroupSelectionStart{
+				NewEntry:    started.NewEntry,
+				BlockNumber: log.BlockNumber,
+			}, nil
 		},
-	)
+		func(groupSelectionStart interface{}) {
+			handle(groupSelectionStart.(*event.GroupSelectionStart))
+		},
+	), nil
 }
 
 func (ec *ethereumChain) OnGroupRegistered(
 	handle func(groupRegistration *event.GroupRegistration),
 ) (subscription.EventSubscription, error) {
-	return ec.keepRandomBeaconOperatorContract.WatchDkgResultSubmittedEvent(
-		func(
-			memberIndex *big.Int,
-			groupPublicKey []byte,
-			misbehaved []byte,
-			blockNumber uint64,
-		) {
-			handle(&event.GroupRegistration{
-				GroupPublicKey: groupPublicKey,
-				BlockNumber:    blockNumber,
-			})
+	return ec.watchOperatorEvent(
+		"DkgResultSubmittedEvent",
+		func(log types.Log) (interface{}, error) {
+			submitted := new(abi.KeepRandomBeaconOperatorDkgResultSubmittedEvent)
+			err := ec.keepRandomBeaconOperatorEvents.unpack(
+				submitted,
+				"DkgResultSubmittedEvent",
+				log,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			return &event.GroupRegistration{
+				GroupPublicKey: submitted.GroupPubKey,
+				BlockNumber:    log.BlockNumber,
+			}, nil
 		},
-		func(err error) error {
-			return fmt.Errorf("entry of group key failed with: [%v]", err)
+		func(groupRegistration interface{}) {
+			handle(groupRegistration.(*event.GroupRegistration))
 		},
-	)
+	), nil
 }
 
 func (ec *ethereumChain) IsGroupRegistered(groupPublicKey []byte) (bool, error) {
@@ -368,36 +522,75 @@ func (ec *ethereumChain) GetGroupMembers(groupPublicKey []byte) (
 func (ec *ethereumChain) OnDKGResultSubmitted(
 	handler func(dkgResultPublication *event.DKGResultSubmission),
 ) (subscription.EventSubscription, error) {
-	return ec.keepRandomBeaconOperatorContract.WatchDkgResultSubmittedEvent(
-		func(
-			memberIndex *big.Int,
-			groupPublicKey []byte,
-			misbehaved []byte,
-			blockNumber uint64,
-		) {
-			handler(&event.DKGResultSubmission{
-				MemberIndex:    uint32(memberIndex.Uint64()),
-				GroupPublicKey: groupPublicKey,
-				Misbehaved:     misbehaved,
-				BlockNumber:    blockNumber,
-			})
+	return ec.watchOperatorEvent(
+		"DkgResultSubmittedEvent",
+		func(log types.Log) (interface{}, error) {
+			submitted := new(abi.KeepRandomBeaconOperatorDkgResultSubmittedEvent)
+			err := ec.keepRandomBeaconOperatorEvents.unpack(
+				submitted,
+				"DkgResultSubmittedEvent",
+				log,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			return &event.DKGResultSubmission{
+				MemberIndex:    uint32(submitted.MemberIndex.Uint64()),
+				GroupPublicKey: submitted.GroupPubKey,
+				Misbehaved:     submitted.Misbehaved,
+				BlockNumber:    log.BlockNumber,
+			}, nil
 		},
-		func(err error) error {
-			return fmt.Errorf(
-				"watch DKG result published failed with: [%v]",
-				err,
+		func(dkgResultPublication interface{}) {
+			handler(dkgResultPublication.(*event.DKGResultSubmission))
+		},
+	), nil
+}
+
+func (ec *ethereumChain) ReportRelayEntryTimeout() error {
+	// Once the timeout is reported, a new relay request can be made and
+	// time out no earlier than the relay entry timeout from now so the
+	// report is no longer needed by then.
+	deadline, err := ec.deadlineAfter(
+		ec.keepRandomBeaconOperatorContract.RelayEntryTimeout,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = ec.transactionManager.submit(
+		"reportRelayEntryTimeout",
+		0,
+		deadline,
+		func(options *bind.TransactOpts) (*types.Transaction, error) {
+			return ec.keepRandomBeaconOperatorTransactor.ReportRelayEntryTimeout(
+				options,
 			)
 		},
 	)
+
+	return err
 }
 
-func (ec *ethereumChain) ReportRelayEntryTimeout() error {
-	_, err := ec.keepRandomBeaconOperatorContract.ReportRelayEntryTimeout()
+func (ec *ethereumChain) ReportUnauthorizedSigning(
+	groupPublicKey []byte,
+	signedOperatorAddress []byte,
+) error {
+	groupIndex, err := ec.getGroupIndex(groupPublicKey)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return ec.transact(
+		func(options *bind.TransactOpts) (*types.Transaction, error) {
+			return ec.keepRandomBeaconOperatorTransactor.ReportUnauthorizedSigning(
+				options,
+				groupIndex,
+				signedOperatorAddress,
+			)
+		},
+	)
 }
 
 func (ec *ethereumChain) IsEntryInProgress() (bool, error) {
@@ -486,21 +679,250 @@ func (ec *ethereumChain) SubmitDKGResult(
 		return resultPublicationPromise
 	}
 
-	if _, err = ec.keepRandomBeaconOperatorContract.SubmitDkgResult(
+	// The result is accepted until all group members had their turn to
+	// submit it.
+	deadline, err := ec.dkgResultSubmissionDeadline()
+	if err != nil {
+		subscription.Unsubscribe()
+		close(publishedResult)
+		failPromise(err)
+		return resultPublicationPromise
+	}
+
+	// Simulate the submission first so that a rejected result does not cost
+	// gas and the reason of the rejection is known.
+	if err := ec.keepRandomBeaconOperatorContract.CallSubmitDkgResult(
 		big.NewInt(int64(participantIndex)),
 		result.GroupPublicKey,
 		result.Misbehaved,
 		signaturesOnChainFormat,
 		membersIndicesOnChainFormat,
+		nil,
 	); err != nil {
 		subscription.Unsubscribe()
 		close(publishedResult)
-		failPromise(err)
+		failPromise(ec.dkgResultSubmissionError(result, err))
+		return resultPublicationPromise
+	}
+
+	if _, err = ec.transactionManager.submit(
+		"submitDkgResult",
+		0,
+		deadline,
+		func(options *bind.TransactOpts) (*types.Transaction, error) {
+			return ec.keepRandomBeaconOperatorTransactor.SubmitDkgResult(
+				options,
+				big.NewInt(int64(participantIndex)),
+				result.GroupPublicKey,
+				result.Misbehaved,
+				signaturesOnChainFormat,
+				membersIndicesOnChainFormat,
+			)
+		},
+	); err != nil {
+		subscription.Unsubscribe()
+		close(publishedResult)
+		failPromise(ec.dkgResultSubmissionError(result, err))
 	}
 
 	return resultPublicationPromise
 }
 
+// dkgResultSubmissionError classifies the given error of the DKG result
+// submission. Once the result is accepted, the group selection is finished
+// and further submissions revert with a reason not related to the result, so
+// the result is recognized as already submitted if its group is registered.
+func (ec *ethereumChain) dkgResultSubmissionError(
+	result *relaychain.DKGResult,
+	err error,
+) error {
+	submissionError := newSubmissionError(err)
+
+	switch submissionError.Failure {
+	case relaychain.Reverted, relaychain.UnknownFailure:
+		registered, registeredErr := ec.IsGroupRegistered(result.GroupPublicKey)
+		if registeredErr != nil {
+			logger.Warningf(
+				"could not check if the group of the rejected DKG result "+
+					"is registered: [%v]",
+				registeredErr,
+			)
+		} else if registered {
+			submissionError.Failure = relaychain.AlreadySubmitted
+		}
+	}
+
+	return submissionError
+}
+
+// transact sends a transaction other than a protocol transaction, signed with
+// the account key or by the external signer if it is configured. The nonce
+// is assigned by the nonce manager shared with protocol transactions of the
+// account. Unlike protocol transactions, the transaction is not replaced
+// when it is not mined in time.
+func (ec *ethereumChain) transact(transact transactFn) error {
+	tm := ec.transactionManager
+
+	gasPrice, err := tm.initialGasPrice()
+	if err != nil {
+		return fmt.Errorf("could not determine gas price: [%v]", err)
+	}
+
+	transaction, err := tm.nonceManager.transact(
+		func(nonce uint64) (*types.Transaction, error) {
+			return signedTransact(transact, tm.options(nonce, gasPrice, 0))
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof(
+		"submitted transaction [%v] with nonce [%v]",
+		transaction.Hash().TerminalString(),
+		transaction.Nonce(),
+	)
+
+	return nil
+}
+
+// deadlineAfter returns the block after the number of blocks returned by the
+// provided function passes from the current block.
+func (ec *ethereumChain) deadlineAfter(
+	blocks func() (*big.Int, error),
+) (uint64, error) {
+	duration, err := blocks()
+	if err != nil {
+		return 0, fmt.Errorf("could not determine deadline: [%v]", err)
+	}
+
+	currentBlock, err := ec.blockCounter.CurrentBlock()
+	if err != nil {
+		return 0, fmt.Errorf("could not determine current block: [%v]", err)
+	}
+
+	return currentBlock + duration.Uint64(), nil
+}
+
+// ticketSubmissionDeadline returns the block at which the ticket submission
+// of the group selection in progress ends.
+func (ec *ethereumChain) ticketSubmissionDeadline() (uint64, error) {
+	ticketSubmissionTimeout, err :=
+		ec.keepRandomBeaconOperatorContract.TicketSubmissionTimeout()
+	if err != nil {
+		return 0, fmt.Errorf(
+			"could not determine ticket submission timeout: [%v]",
+			err,
+		)
+	}
+
+	startBlock, err := ec.groupSelectionStartBlock(
+		ticketSubmissionTimeout.Uint64(),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return startBlock + ticketSubmissionTimeout.Uint64(), nil
+}
+
+// dkgResultSubmissionDeadline returns the block at which the DKG result
+// submission of the group selection in progress times out. It is the block
+// at which the group selection ended, that is, the ticket submission ended,
+// increased by the DKG time and the time all group members have to submit
+// the result.
+func (ec *ethereumChain) dkgResultSubmissionDeadline() (uint64, error) {
+	ticketSubmissionTimeout, err :=
+		ec.keepRandomBeaconOperatorContract.TicketSubmissionTimeout()
+	if err != nil {
+		return 0, fmt.Errorf(
+			"could not determine ticket submission timeout: [%v]",
+			err,
+		)
+	}
+
+	groupSize, err := ec.keepRandomBeaconOperatorContract.GroupSize()
+	if err != nil {
+		return 0, fmt.Errorf("could not determine group size: [%v]", err)
+	}
+
+	step, err := ec.keepRandomBeaconOperatorContract.ResultPublicationBlockStep()
+	if err != nil {
+		return 0, fmt.Errorf(
+			"could not determine result publication block step: [%v]",
+			err,
+		)
+	}
+
+	timeout := ticketSubmissionTimeout.Uint64() +
+		dkgDurationBlocks +
+		groupSize.Uint64()*step.Uint64()
+
+	startBlock, err := ec.groupSelectionStartBlock(timeout)
+	if err != nil {
+		return 0, err
+	}
+
+	return startBlock + timeout, nil
+}
+
+// groupSelectionStartBlock returns the block at which the group selection in
+// progress started. The operator contract does not expose it so it is taken
+// from the most recent group selection started event emitted no earlier than
+// the given number of blocks ago.
+func (ec *ethereumChain) groupSelectionStartBlock(
+	lookBackBlocks uint64,
+) (uint64, error) {
+	currentBlock, err := ec.blockCounter.CurrentBlock()
+	if err != nil {
+		return 0, fmt.Errorf("could not determine current block: [%v]", err)
+	}
+
+	fromBlock := uint64(0)
+	if currentBlock > lookBackBlocks {
+		fromBlock = currentBlock - lookBackBlocks
+	}
+
+	events, err := ec.PastGroupSelectionStartedEvents(fromBlock, currentBlock)
+	if err != nil {
+		return 0, fmt.Errorf(
+			"could not determine group selection start block: [%v]",
+			err,
+		)
+	}
+
+	if len(events) == 0 {
+		return 0, fmt.Errorf(
+			"no group selection started since block [%v]",
+			fromBlock,
+		)
+	}
+
+	return events[len(events)-1].BlockNumber, nil
+}
+
+// relayEntryDeadline returns the block at which the current relay request
+// times out.
+func (ec *ethereumChain) relayEntryDeadline() (uint64, error) {
+	startBlock, err := ec.keepRandomBeaconOperatorContract.CurrentRequestStartBlock()
+	if err != nil {
+		return 0, fmt.Errorf(
+			"could not determine current request start block: [%v]",
+			err,
+		)
+	}
+
+	timeout, err := ec.keepRandomBeaconOperatorContract.RelayEntryTimeout()
+	if err != nil {
+		return 0, fmt.Errorf(
+			"could not determine relay entry timeout: [%v]",
+			err,
+		)
+	}
+
+	return startBlock.Uint64() + timeout.Uint64(), nil
+}
+
 // convertSignaturesToChainFormat converts signatures map to two slices. First
 // slice contains indices of members from the map, second slice is a slice of
 // concatenated signatures. Signatures and member indices are returned in the
@@ -542,3 +964,292 @@ func (ec *ethereumChain) CalculateDKGResultHash(
 
 	return relaychain.DKGResultHashFromBytes(hash)
 }
+
+func (ec *ethereumChain) GetGroupMemberRewards(
+	groupPublicKey []byte,
+) (*big.Int, error) {
+	return ec.keepRandomBeaconOperatorContract.GetGroupMemberRewards(
+		groupPublicKey,
+	)
+}
+
+func (ec *ethereumChain) HasWithdrawnRewards(
+	operator relaychain.StakerAddress,
+	groupPublicKey []byte,
+) (bool, error) {
+	groupIndex, err := ec.getExpiredGroupIndex(groupPublicKey)
+	if err != nil {
+		return false, err
+	}
+
+	return ec.keepRandomBeaconOperatorContract.HasWithdrawnRewards(
+		common.BytesToAddress(operator),
+		groupIndex,
+	)
+}
+
+func (ec *ethereumChain) WithdrawGroupMemberRewards(
+	operator relaychain.StakerAddress,
+	groupPublicKey []byte,
+) *async.EventGroupMemberRewardsWithdrawnPromise {
+	withdrawalPromise := &async.EventGroupMemberRewardsWithdrawnPromise{}
+
+	failPromise := func(err error) {
+		failErr := withdrawalPromise.Fail(err)
+		if failErr != nil {
+			logger.Errorf(
+				"failed to fail promise for [%v]: [%v]",
+				err,
+				failErr,
+			)
+		}
+	}
+
+	groupIndex, err := ec.getExpiredGroupIndex(groupPublicKey)
+	if err != nil {
+		failPromise(err)
+		return withdrawalPromise
+	}
+
+	// The promise is completed only once, either with the first matching
+	// withdrawal event or with the submission error. Further events, for
+	// example redelivered after a reorg, are ignored.
+	var completeOnce sync.Once
+	completed := make(chan struct{})
+	complete := func(completeFn func()) {
+		completeOnce.Do(func() {
+			close(completed)
+			completeFn()
+		})
+	}
+
+	subscription, err := ec.OnGroupMemberRewardsWithdrawn(
+		func(withdrawal *event.GroupMemberRewardsWithdrawn) {
+			if !bytes.Equal(withdrawal.Operator, operator) ||
+				withdrawal.GroupIndex.Cmp(groupIndex) != 0 {
+				return
+			}
+
+			complete(func() {
+				err := withdrawalPromise.Fulfill(withdrawal)
+				if err != nil {
+					logger.Errorf("failed to fulfill promise: [%v]", err)
+				}
+			})
+		},
+	)
+	if err != nil {
+		failPromise(err)
+		return withdrawalPromise
+	}
+
+	go func() {
+		<-completed
+		subscription.Unsubscribe()
+	}()
+
+	err = ec.transact(
+		func(options *bind.TransactOpts) (*types.Transaction, error) {
+			return ec.keepRandomBeaconOperatorTransactor.WithdrawGroupMemberRewards(
+				options,
+				common.BytesToAddress(operator),
+				groupIndex,
+			)
+		},
+	)
+	if err != nil {
+		complete(func() { failPromise(err) })
+	}
+
+	return withdrawalPromise
+}
+
+func (ec *ethereumChain) OnGroupMemberRewardsWithdrawn(
+	handle func(withdrawal *event.GroupMemberRewardsWithdrawn),
+) (subscription.EventSubscription, error) {
+	return ec.watchOperatorEvent(
+		"GroupMemberRewardsWithdrawn",
+		func(log types.Log) (interface{}, error) {
+			withdrawn := new(abi.KeepRandomBeaconOperatorGroupMemberRewardsWithdrawn)
+			err := ec.keepRandomBeaconOperatorEvents.unpack(
+				withdrawn,
+				"GroupMemberRewardsWithdrawn",
+				log,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			return &event.GroupMemberRewardsWithdrawn{
+				Beneficiary: withdrawn.Beneficiary.Bytes(),
+				Operator:    withdrawn.Operator.Bytes(),
+				Amount:      withdrawn.Amount,
+				GroupIndex:  withdrawn.GroupIndex,
+				BlockNumber: log.BlockNumber,
+			}, nil
+		},
+		func(withdrawal interface{}) {
+			handle(withdrawal.(*event.GroupMemberRewardsWithdrawn))
+		},
+	), nil
+}
+
+// watchOperatorEvent watches the operator contract event with the given name.
+// Every log of the event is converted to the chain event with the convert
+// function and the chain event is passed to the handle function once the log
+// is confirmed. Logs seen more than once, for example both by the
+// subscription and when fetching missed events, are converted and handled
+// only once.
+func (ec *ethereumChain) watchOperatorEvent(
+	eventName string,
+	convert func(log types.Log) (interface{}, error),
+	handle func(chainEvent interface{}),
+) subscription.EventSubscription {
+	logs := newLogDeduplicator()
+	process := func(log types.Log) {
+		if !logs.firstSeen(log) {
+			return
+		}
+
+		chainEvent, err := convert(log)
+		if err != nil {
+			logger.Errorf(
+				"could not convert [%v] event from block [%v]: [%v]",
+				eventName,
+				log.BlockNumber,
+				err,
+			)
+			return
+		}
+
+		ec.eventConfirmer.handle(
+			log,
+			chainEvent,
+			func() { handle(chainEvent) },
+		)
+	}
+
+	events := ec.keepRandomBeaconOperatorEvents
+
+	return watchEvent(
+		eventName,
+		ec.blockCounter,
+		func(quit <-chan struct{}) (ethevent.Subscription, error) {
+			sink := make(chan types.Log)
+			eventSubscription, err := events.subscribe(eventName, sink)
+			if err != nil {
+				return nil, err
+			}
+
+			go func() {
+				for {
+					select {
+					case log := <-sink:
+						process(log)
+					case <-quit:
+						return
+					}
+				}
+			}()
+
+			return eventSubscription, nil
+		},
+		func(fromBlock, toBlock uint64) error {
+			fetched, err := events.fetch(eventName, fromBlock, toBlock)
+			if err != nil {
+				return err
+			}
+
+			for _, log := range fetched {
+				process(log)
+			}
+
+			return nil
+		},
+	)
+}
+
+// OnEventRemoved registers a handler notified about events removed by a chain
+// reorganization after they have been delivered to handlers.
+func (ec *ethereumChain) OnEventRemoved(
+	handle func(removal *event.Removed),
+) (subscription.EventSubscription, error) {
+	return ec.eventConfirmer.onEventRemoved(handle), nil
+}
+
+func (ec *ethereumChain) CurrentGasPrice() (*big.Int, error) {
+	return ec.client.SuggestGasPrice(context.Background())
+}
+
+// getGroupIndex looks up the on-chain index of a group with the given public
+// key. Active and terminated groups are looked through first, starting from
+// the first active group, and then the expired groups.
+func (ec *ethereumChain) getGroupIndex(groupPublicKey []byte) (*big.Int, error) {
+	firstActiveGroupIndex, err :=
+		ec.keepRandomBeaconOperatorContract.GetFirstActiveGroupIndex()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not get the first active group index: [%v]",
+			err,
+		)
+	}
+
+	// The contract does not expose the total number of groups. Looking up
+	// the public key of a group with an index out of bounds fails, which
+	// marks the end of the groups list.
+	for index := firstActiveGroupIndex.Int64(); ; index++ {
+		groupIndex := big.NewInt(index)
+
+		publicKey, err :=
+			ec.keepRandomBeaconOperatorContract.GetGroupPublicKey(groupIndex)
+		if err != nil {
+			break
+		}
+
+		if bytes.Equal(publicKey, groupPublicKey) {
+			return groupIndex, nil
+		}
+	}
+
+	return ec.getExpiredGroupIndex(groupPublicKey)
+}
+
+// getExpiredGroupIndex looks up the on-chain index of an expired group with
+// the given public key. Rewards can be withdrawn only from stale groups and
+// every stale group is expired, so only the expired groups are looked through,
+// starting from the most recently expired one.
+func (ec *ethereumChain) getExpiredGroupIndex(
+	groupPublicKey []byte,
+) (*big.Int, error) {
+	firstActiveGroupIndex, err :=
+		ec.keepRandomBeaconOperatorContract.GetFirstActiveGroupIndex()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not get the first active group index: [%v]",
+			err,
+		)
+	}
+
+	for index := firstActiveGroupIndex.Int64() - 1; index >= 0; index-- {
+		groupIndex := big.NewInt(index)
+
+		publicKey, err :=
+			ec.keepRandomBeaconOperatorContract.GetGroupPublicKey(groupIndex)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not get public key of group with index [%v]: [%v]",
+				groupIndex,
+				err,
+			)
+		}
+
+		if bytes.Equal(publicKey, groupPublicKey) {
+			return groupIndex, nil
+		}
+	}
+
+	return nil, fmt.Errorf(
+		"group with public key [0x%x] is not expired",
+		groupPublicKey,
+	)
+}
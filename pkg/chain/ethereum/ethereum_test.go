@@ -6,11 +6,14 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
+	"strings"
 	"testing"
 
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/chain/gen/abi"
 )
 
 // TestCalculateDKGResultHash validates if calculated DKG result hash matches
@@ -211,3 +214,44 @@ func TestPackTicket(t *testing.T) {
 		})
 	}
 }
+
+func TestUnpackRelayEntry(t *testing.T) {
+	operatorABI, err := ethabi.JSON(
+		strings.NewReader(abi.KeepRandomBeaconOperatorABI),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := common.FromHex(
+		"0x1f1954b33144db2b5c90da089e8bde287ec7089d5d6433f3b6becaefdb678b1b" +
+			"2a9de38d14bef2cf9afc3c698a4211fa7ada7b4f036a2dfef0dc122b423259d0",
+	)
+
+	relayEntryCalldata, err := operatorABI.Pack("relayEntry", entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unpackedEntry, err := unpackRelayEntry(relayEntryCalldata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(entry, unpackedEntry) {
+		t.Errorf(
+			"unexpected relay entry\nexpected: [%x]\nactual:   [%x]",
+			entry,
+			unpackedEntry,
+		)
+	}
+
+	otherCalldata, err := operatorABI.Pack("reportRelayEntryTimeout")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = unpackRelayEntry(otherCalldata)
+	if err == nil {
+		t.Errorf("expected error for calldata of another method")
+	}
+}
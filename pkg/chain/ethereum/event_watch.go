@@ -0,0 +1,329 @@
+package ethereum
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	ethevent "github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/subscription"
+)
+
+var (
+	// minResubscriptionDelay is the time after which a failed event
+	// subscription is established again. The delay is doubled after each
+	// consecutive failure, up to maxResubscriptionDelay.
+	minResubscriptionDelay = time.Second
+
+	// maxResubscriptionDelay is the maximum time after which a failed event
+	// subscription is established again.
+	maxResubscriptionDelay = 2 * time.Minute
+
+	// eventPollInterval is the interval in which events are fetched when the
+	// Ethereum endpoint does not support subscriptions, like HTTP endpoints.
+	eventPollInterval = 15 * time.Second
+)
+
+// gapFillLookback is the number of blocks before the failure of an event
+// subscription for which events are fetched again once the subscription is
+// re-established, since events emitted just before the failure may have been
+// lost in transit. It is lower than reorgTrackingBlocks so that events
+// fetched again are recognized as already delivered.
+const gapFillLookback = 16
+
+// eventWatch keeps an event subscription established and makes sure no
+// events are missed while the subscription is not established.
+type eventWatch struct {
+	eventName    string
+	blockCounter chain.BlockCounter
+	subscribe    func(quit <-chan struct{}) (ethevent.Subscription, error)
+	fetch        func(fromBlock, toBlock uint64) error
+	unsubscribe  chan struct{}
+
+	minRetryDelay time.Duration
+	maxRetryDelay time.Duration
+	pollInterval  time.Duration
+}
+
+// watchEvent keeps the event subscription created by the subscribe function
+// established until it is unsubscribed. Failed subscription is created again
+// with an exponential backoff. Once it is created again, the fetch function
+// is called for the range of blocks the subscription has not been
+// established for, so the events emitted in the meantime are delivered as
+// well. If the Ethereum endpoint does not support subscriptions, events are
+// periodically fetched with the fetch function instead. The quit channel
+// passed to the subscribe function is closed when the created subscription
+// is no longer used.
+//
+// The same event may be seen both by the subscription and the fetch function
+// so they should pass logs through a logDeduplicator.
+func watchEvent(
+	eventName string,
+	blockCounter chain.BlockCounter,
+	subscribe func(quit <-chan struct{}) (ethevent.Subscription, error),
+	fetch func(fromBlock, toBlock uint64) error,
+) subscription.EventSubscription {
+	watch := &eventWatch{
+		eventName:    eventName,
+		blockCounter: blockCounter,
+		subscribe:    subscribe,
+		fetch:        fetch,
+		unsubscribe:  make(chan struct{}),
+
+		minRetryDelay: minResubscriptionDelay,
+		maxRetryDelay: maxResubscriptionDelay,
+		pollInterval:  eventPollInterval,
+	}
+
+	go watch.run()
+
+	return subscription.NewEventSubscription(func() {
+		close(watch.unsubscribe)
+	})
+}
+
+func (ew *eventWatch) run() {
+	retryDelay := ew.minRetryDelay
+
+	// When gap is set, events have not been delivered starting from the
+	// gapStart block.
+	gap := false
+	var gapStart uint64
+	openGap := func(fromBlock uint64) {
+		if !gap || fromBlock < gapStart {
+			gapStart = fromBlock
+		}
+		gap = true
+	}
+
+	for {
+		quit := make(chan struct{})
+		eventSubscription, err := ew.subscribe(quit)
+
+		if err == rpc.ErrNotificationsUnsupported {
+			close(quit)
+			if !gap {
+				currentBlock, ok := ew.currentBlock()
+				if !ok {
+					return
+				}
+				openGap(currentBlock)
+			}
+
+			if !ew.wait(ew.pollInterval) {
+				return
+			}
+
+			toBlock, ok := ew.currentBlock()
+			if !ok {
+				return
+			}
+			if toBlock < gapStart {
+				continue
+			}
+			if err := ew.fetch(gapStart, toBlock); err != nil {
+				logger.Warningf(
+					"could not fetch [%v] events from blocks [%v-%v]: [%v]",
+					ew.eventName,
+					gapStart,
+					toBlock,
+					err,
+				)
+				continue
+			}
+			gapStart = toBlock + 1
+			continue
+		}
+
+		if err != nil {
+			close(quit)
+			currentBlock, ok := ew.currentBlock()
+			if !ok {
+				return
+			}
+			openGap(currentBlock)
+
+			logger.Warningf(
+				"could not subscribe to [%v] events; retrying after [%v]: [%v]",
+				ew.eventName,
+				retryDelay,
+				err,
+			)
+			if !ew.wait(retryDelay) {
+				return
+			}
+			retryDelay = ew.increasedDelay(retryDelay)
+			continue
+		}
+
+		retryDelay = ew.minRetryDelay
+
+		subscribedAt, ok := ew.currentBlock()
+		if !ok {
+			eventSubscription.Unsubscribe()
+			close(quit)
+			return
+		}
+		if gap && gapStart <= subscribedAt {
+			go ew.fillGap(gapStart, subscribedAt)
+		}
+		gap = false
+
+		select {
+		case err := <-eventSubscription.Err():
+			close(quit)
+
+			failedAt, ok := ew.currentBlock()
+			if !ok {
+				return
+			}
+			if failedAt > subscribedAt+gapFillLookback {
+				openGap(failedAt - gapFillLookback)
+			} else {
+				openGap(subscribedAt)
+			}
+
+			logger.Warningf(
+				"subscription to [%v] events failed at block [%v]; "+
+					"retrying after [%v]: [%v]",
+				ew.eventName,
+				failedAt,
+				retryDelay,
+				err,
+			)
+			if !ew.wait(retryDelay) {
+				return
+			}
+			retryDelay = ew.increasedDelay(retryDelay)
+		case <-ew.unsubscribe:
+			eventSubscription.Unsubscribe()
+			close(quit)
+			return
+		}
+	}
+}
+
+// fillGap fetches events emitted in the given range of blocks. Fetching is
+// retried until it succeeds or the watch is unsubscribed.
+func (ew *eventWatch) fillGap(fromBlock, toBlock uint64) {
+	retryDelay := ew.minRetryDelay
+
+	for {
+		err := ew.fetch(fromBlock, toBlock)
+		if err == nil {
+			logger.Infof(
+				"fetched [%v] events missed in blocks [%v-%v]",
+				ew.eventName,
+				fromBlock,
+				toBlock,
+			)
+			return
+		}
+
+		logger.Warningf(
+			"could not fetch [%v] events missed in blocks [%v-%v]; "+
+				"retrying after [%v]: [%v]",
+			ew.eventName,
+			fromBlock,
+			toBlock,
+			retryDelay,
+			err,
+		)
+		if !ew.wait(retryDelay) {
+			return
+		}
+		retryDelay = ew.increasedDelay(retryDelay)
+	}
+}
+
+// wait waits for the given time. It returns false if the watch has been
+// unsubscribed in the meantime.
+func (ew *eventWatch) wait(delay time.Duration) bool {
+	select {
+	case <-time.After(delay):
+		return true
+	case <-ew.unsubscribe:
+		return false
+	}
+}
+
+// currentBlock returns the current block. Determining the current block is
+// retried with an exponential backoff until it succeeds, so that ranges of
+// blocks events are fetched for are never based on an unknown block. It
+// returns false if the watch has been unsubscribed in the meantime.
+func (ew *eventWatch) currentBlock() (uint64, bool) {
+	retryDelay := ew.minRetryDelay
+
+	for {
+		currentBlock, err := ew.blockCounter.CurrentBlock()
+		if err == nil {
+			return currentBlock, true
+		}
+
+		logger.Warningf(
+			"could not determine current block for [%v] events; "+
+				"retrying after [%v]: [%v]",
+			ew.eventName,
+			retryDelay,
+			err,
+		)
+		if !ew.wait(retryDelay) {
+			return 0, false
+		}
+		retryDelay = ew.increasedDelay(retryDelay)
+	}
+}
+
+func (ew *eventWatch) increasedDelay(delay time.Duration) time.Duration {
+	if delay*2 > ew.maxRetryDelay {
+		return ew.maxRetryDelay
+	}
+
+	return delay * 2
+}
+
+// logDeduplicator tracks logs seen by a single event watch so that logs seen
+// both by the subscription and when fetching missed events are handled only
+// once. Logs are tracked for reorgTrackingBlocks blocks.
+type logDeduplicator struct {
+	mutex          sync.Mutex
+	seen           map[logID]uint64
+	lastPruneBlock uint64
+}
+
+func newLogDeduplicator() *logDeduplicator {
+	return &logDeduplicator{
+		seen: make(map[logID]uint64),
+	}
+}
+
+// firstSeen returns true if the given log has not been seen before. Removed
+// logs are always considered as seen for the first time so that removals of
+// seen logs are handled.
+func (ld *logDeduplicator) firstSeen(log types.Log) bool {
+	if log.Removed {
+		return true
+	}
+
+	ld.mutex.Lock()
+	defer ld.mutex.Unlock()
+
+	id := newLogID(log)
+	if _, ok := ld.seen[id]; ok {
+		return false
+	}
+	ld.seen[id] = log.BlockNumber
+
+	if log.BlockNumber > ld.lastPruneBlock+reorgTrackingBlocks {
+		for id, blockNumber := range ld.seen {
+			if blockNumber+reorgTrackingBlocks < log.BlockNumber {
+				delete(ld.seen, id)
+			}
+		}
+		ld.lastPruneBlock = log.BlockNumber
+	}
+
+	return true
+}
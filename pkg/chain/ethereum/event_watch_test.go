@@ -0,0 +1,275 @@
+package ethereum
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	ethevent "github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// testEventSource creates event subscriptions failing on demand and records
+// ranges of blocks events were fetched for.
+type testEventSource struct {
+	mutex         sync.Mutex
+	subscribeErr  error
+	subscriptions int
+	failures      []chan error
+	fetchedRanges [][2]uint64
+}
+
+func (tes *testEventSource) subscribe(
+	quit <-chan struct{},
+) (ethevent.Subscription, error) {
+	tes.mutex.Lock()
+	defer tes.mutex.Unlock()
+
+	if tes.subscribeErr != nil {
+		return nil, tes.subscribeErr
+	}
+
+	failure := make(chan error)
+	tes.failures = append(tes.failures, failure)
+	tes.subscriptions++
+
+	return ethevent.NewSubscription(func(unsubscribe <-chan struct{}) error {
+		select {
+		case err := <-failure:
+			return err
+		case <-unsubscribe:
+			return nil
+		}
+	}), nil
+}
+
+func (tes *testEventSource) fetch(fromBlock, toBlock uint64) error {
+	tes.mutex.Lock()
+	defer tes.mutex.Unlock()
+
+	tes.fetchedRanges = append(tes.fetchedRanges, [2]uint64{fromBlock, toBlock})
+	return nil
+}
+
+// failSubscription fails the most recently created subscription.
+func (tes *testEventSource) failSubscription() {
+	tes.mutex.Lock()
+	failure := tes.failures[len(tes.failures)-1]
+	tes.mutex.Unlock()
+
+	failure <- fmt.Errorf("connection lost")
+}
+
+func (tes *testEventSource) waitForSubscriptions(t *testing.T, count int) {
+	for i := 0; i < 200; i++ {
+		tes.mutex.Lock()
+		subscriptions := tes.subscriptions
+		tes.mutex.Unlock()
+
+		if subscriptions >= count {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	t.Fatalf("expected [%v] subscriptions", count)
+}
+
+func (tes *testEventSource) ranges() [][2]uint64 {
+	tes.mutex.Lock()
+	defer tes.mutex.Unlock()
+
+	return append([][2]uint64{}, tes.fetchedRanges...)
+}
+
+func setTestWatchDelays() func() {
+	defaultMinDelay := minResubscriptionDelay
+	defaultPollInterval := eventPollInterval
+
+	minResubscriptionDelay = 10 * time.Millisecond
+	eventPollInterval = 10 * time.Millisecond
+
+	return func() {
+		minResubscriptionDelay = defaultMinDelay
+		eventPollInterval = defaultPollInterval
+	}
+}
+
+func TestFetchEventsMissedWhileResubscribing(t *testing.T) {
+	defer setTestWatchDelays()()
+
+	source := &testEventSource{}
+	blockCounter := &testCurrentBlockCounter{currentBlock: 100}
+
+	watch := watchEvent("Test", blockCounter, source.subscribe, source.fetch)
+	defer watch.Unsubscribe()
+
+	source.waitForSubscriptions(t, 1)
+
+	blockCounter.setCurrentBlock(110)
+	source.failSubscription()
+
+	source.waitForSubscriptions(t, 2)
+	time.Sleep(20 * time.Millisecond)
+
+	// Events are fetched starting from the block the failed subscription
+	// was established at since it failed within the gap fill lookback.
+	expectedRanges := [][2]uint64{{100, 110}}
+	if ranges := source.ranges(); !reflect.DeepEqual(expectedRanges, ranges) {
+		t.Errorf(
+			"unexpected fetched ranges\nexpected: [%v]\nactual:   [%v]",
+			expectedRanges,
+			ranges,
+		)
+	}
+}
+
+func TestFetchEventsFromGapFillLookback(t *testing.T) {
+	defer setTestWatchDelays()()
+
+	source := &testEventSource{}
+	blockCounter := &testCurrentBlockCounter{currentBlock: 100}
+
+	watch := watchEvent("Test", blockCounter, source.subscribe, source.fetch)
+	defer watch.Unsubscribe()
+
+	source.waitForSubscriptions(t, 1)
+
+	blockCounter.setCurrentBlock(200)
+	source.failSubscription()
+
+	source.waitForSubscriptions(t, 2)
+	time.Sleep(20 * time.Millisecond)
+
+	expectedRanges := [][2]uint64{{200 - gapFillLookback, 200}}
+	if ranges := source.ranges(); !reflect.DeepEqual(expectedRanges, ranges) {
+		t.Errorf(
+			"unexpected fetched ranges\nexpected: [%v]\nactual:   [%v]",
+			expectedRanges,
+			ranges,
+		)
+	}
+}
+
+func TestDoNotFetchEventsFromUnknownBlock(t *testing.T) {
+	defer setTestWatchDelays()()
+
+	source := &testEventSource{subscribeErr: fmt.Errorf("connection refused")}
+	blockCounter := &testCurrentBlockCounter{}
+	blockCounter.setCurrentBlockErr(fmt.Errorf("connection refused"))
+
+	watch := watchEvent("Test", blockCounter, source.subscribe, source.fetch)
+	defer watch.Unsubscribe()
+
+	time.Sleep(25 * time.Millisecond)
+	source.mutex.Lock()
+	source.subscribeErr = nil
+	source.mutex.Unlock()
+	blockCounter.setCurrentBlock(100)
+
+	source.waitForSubscriptions(t, 1)
+	time.Sleep(20 * time.Millisecond)
+
+	// The gap is opened at the block known once the current block could be
+	// determined, not from the genesis block.
+	expectedRanges := [][2]uint64{{100, 100}}
+	if ranges := source.ranges(); !reflect.DeepEqual(expectedRanges, ranges) {
+		t.Errorf(
+			"unexpected fetched ranges\nexpected: [%v]\nactual:   [%v]",
+			expectedRanges,
+			ranges,
+		)
+	}
+}
+
+func TestPollEventsWhenSubscriptionsUnsupported(t *testing.T) {
+	defer setTestWatchDelays()()
+
+	source := &testEventSource{subscribeErr: rpc.ErrNotificationsUnsupported}
+	blockCounter := &testCurrentBlockCounter{currentBlock: 100}
+
+	watch := watchEvent("Test", blockCounter, source.subscribe, source.fetch)
+
+	time.Sleep(25 * time.Millisecond)
+	blockCounter.setCurrentBlock(105)
+	time.Sleep(25 * time.Millisecond)
+	blockCounter.setCurrentBlock(110)
+	time.Sleep(25 * time.Millisecond)
+
+	watch.Unsubscribe()
+
+	ranges := source.ranges()
+	if len(ranges) == 0 {
+		t.Fatal("events should be polled")
+	}
+
+	// Polled ranges cover all the blocks without overlapping.
+	nextBlock := uint64(100)
+	for _, blockRange := range ranges {
+		if blockRange[0] != nextBlock || blockRange[1] < blockRange[0] {
+			t.Fatalf("unexpected polled ranges [%v]", ranges)
+		}
+		nextBlock = blockRange[1] + 1
+	}
+	if nextBlock != 111 {
+		t.Errorf("events should be polled up to block 110: [%v]", ranges)
+	}
+}
+
+func TestIncreaseResubscriptionDelay(t *testing.T) {
+	watch := &eventWatch{
+		minRetryDelay: minResubscriptionDelay,
+		maxRetryDelay: maxResubscriptionDelay,
+	}
+
+	delay := watch.minRetryDelay
+	for i := 0; i < 20; i++ {
+		next := watch.increasedDelay(delay)
+		if next != 2*delay && next != maxResubscriptionDelay {
+			t.Fatalf("unexpected delay [%v] after [%v]", next, delay)
+		}
+		delay = next
+	}
+
+	if delay != maxResubscriptionDelay {
+		t.Errorf("delay should reach the maximum delay")
+	}
+}
+
+func TestLogDeduplicator(t *testing.T) {
+	logs := newLogDeduplicator()
+
+	log := types.Log{
+		BlockNumber: 10,
+		BlockHash:   common.HexToHash("0x01"),
+		TxHash:      common.HexToHash("0x02"),
+		Index:       1,
+	}
+
+	if !logs.firstSeen(log) {
+		t.Errorf("log should be seen for the first time")
+	}
+	if logs.firstSeen(log) {
+		t.Errorf("log should be already seen")
+	}
+
+	removed := log
+	removed.Removed = true
+	if !logs.firstSeen(removed) {
+		t.Errorf("removed log should always be seen for the first time")
+	}
+
+	// Logs are not tracked once they are too deep.
+	later := log
+	later.BlockNumber = log.BlockNumber + reorgTrackingBlocks + 1
+	later.Index = 2
+	logs.firstSeen(later)
+
+	if !logs.firstSeen(log) {
+		t.Errorf("log should not be tracked anymore")
+	}
+}
@@ -0,0 +1,302 @@
+package ethereum
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// externalSignerCallTimeout is the maximum time the external signer has to
+// respond to a signing request.
+const externalSignerCallTimeout = 30 * time.Second
+
+// externalSignerProbeMessage is signed by the external signer when the
+// connection is established to learn the public key of the account.
+const externalSignerProbeMessage = "keep-client external signer probe"
+
+// externalSigner signs transactions and messages of a single account by
+// delegating to an external signer exposing the Clef JSON-RPC interface:
+// `account_list`, `account_signTransaction` and `account_signData`.
+type externalSigner struct {
+	client    *rpc.Client
+	address   common.Address
+	publicKey *ecdsa.PublicKey
+}
+
+// signerTransactionArgs are the arguments of the `account_signTransaction`
+// call.
+type signerTransactionArgs struct {
+	From     common.Address  `json:"from"`
+	To       *common.Address `json:"to"`
+	Gas      hexutil.Uint64  `json:"gas"`
+	GasPrice hexutil.Big     `json:"gasPrice"`
+	Value    hexutil.Big     `json:"value"`
+	Nonce    hexutil.Uint64  `json:"nonce"`
+	Data     hexutil.Bytes   `json:"data"`
+}
+
+// signerTransactionResult is the result of the `account_signTransaction`
+// call.
+type signerTransactionResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+// dialExternalSigner connects to the external signer at the given URL and
+// makes sure it manages and signs for the account with the given address.
+func dialExternalSigner(
+	url string,
+	address common.Address,
+) (*externalSigner, error) {
+	client, err := rpc.Dial(url)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not connect to external signer [%v]: [%v]",
+			url,
+			err,
+		)
+	}
+
+	signer := &externalSigner{
+		client:  client,
+		address: address,
+	}
+
+	var accounts []common.Address
+	if err := signer.call(&accounts, "account_list"); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("could not list accounts: [%v]", err)
+	}
+
+	managed := false
+	for _, account := range accounts {
+		if account == address {
+			managed = true
+			break
+		}
+	}
+	if !managed {
+		client.Close()
+		return nil, fmt.Errorf(
+			"account [%v] is not managed by the external signer",
+			address.Hex(),
+		)
+	}
+
+	probeMessage := []byte(externalSignerProbeMessage)
+	signature, err := signer.signData(probeMessage)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("could not sign probe message: [%v]", err)
+	}
+
+	recoverySignature := make([]byte, len(signature))
+	copy(recoverySignature, signature)
+	recoverySignature[len(recoverySignature)-1] -= 27
+
+	publicKey, err := crypto.SigToPub(
+		prefixedMessageHash(probeMessage),
+		recoverySignature,
+	)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf(
+			"could not recover public key from probe message "+
+				"signature: [%v]",
+			err,
+		)
+	}
+	if crypto.PubkeyToAddress(*publicKey) != address {
+		client.Close()
+		return nil, fmt.Errorf(
+			"probe message signed for account [%v] instead of [%v]",
+			crypto.PubkeyToAddress(*publicKey).Hex(),
+			address.Hex(),
+		)
+	}
+	signer.publicKey = publicKey
+
+	return signer, nil
+}
+
+// transactorOptions returns options signing transactions with the external
+// signer.
+func (es *externalSigner) transactorOptions() *bind.TransactOpts {
+	return &bind.TransactOpts{
+		From: es.address,
+		Signer: func(
+			_ types.Signer,
+			address common.Address,
+			transaction *types.Transaction,
+		) (*types.Transaction, error) {
+			if address != es.address {
+				return nil, fmt.Errorf(
+					"external signer is not used for account [%v]",
+					address.Hex(),
+				)
+			}
+			return es.signTransaction(transaction)
+		},
+	}
+}
+
+// signTransaction signs the given transaction with the external signer. The
+// external signer chooses the signature scheme so the transaction may be
+// signed with replay protection.
+func (es *externalSigner) signTransaction(
+	transaction *types.Transaction,
+) (*types.Transaction, error) {
+	args := signerTransactionArgs{
+		From:     es.address,
+		To:       transaction.To(),
+		Gas:      hexutil.Uint64(transaction.Gas()),
+		GasPrice: hexutil.Big(*transaction.GasPrice()),
+		Value:    hexutil.Big(*transaction.Value()),
+		Nonce:    hexutil.Uint64(transaction.Nonce()),
+		Data:     transaction.Data(),
+	}
+
+	var result signerTransactionResult
+	if err := es.call(&result, "account_signTransaction", args); err != nil {
+		return nil, fmt.Errorf("could not sign transaction: [%v]", err)
+	}
+
+	signed := new(types.Transaction)
+	if err := rlp.DecodeBytes(result.Raw, signed); err != nil {
+		return nil, fmt.Errorf(
+			"could not decode transaction signed by external signer: [%v]",
+			err,
+		)
+	}
+
+	var signer types.Signer = types.HomesteadSigner{}
+	if signed.Protected() {
+		signer = types.NewEIP155Signer(signed.ChainId())
+	}
+	sender, err := types.Sender(signer, signed)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not determine sender of transaction signed by "+
+				"external signer: [%v]",
+			err,
+		)
+	}
+	if sender != es.address {
+		return nil, fmt.Errorf(
+			"transaction signed for account [%v] instead of [%v]",
+			sender.Hex(),
+			es.address.Hex(),
+		)
+	}
+	if signed.Nonce() != transaction.Nonce() {
+		return nil, fmt.Errorf(
+			"nonce of the transaction changed by external signer from "+
+				"[%v] to [%v]",
+			transaction.Nonce(),
+			signed.Nonce(),
+		)
+	}
+
+	return signed, nil
+}
+
+// signData signs the given message as `text/plain` data, that is, with the
+// Ethereum signed message prefix. The returned signature has the recovery
+// id, V, equal to 27 or 28.
+func (es *externalSigner) signData(message []byte) ([]byte, error) {
+	var signature hexutil.Bytes
+	if err := es.call(
+		&signature,
+		"account_signData",
+		"text/plain",
+		es.address,
+		hexutil.Bytes(message),
+	); err != nil {
+		return nil, err
+	}
+
+	if len(signature) != SignatureSize {
+		return nil, fmt.Errorf(
+			"signature should have [%v] bytes; has: [%v]",
+			SignatureSize,
+			len(signature),
+		)
+	}
+	if signature[SignatureSize-1] < 27 {
+		signature[SignatureSize-1] += 27
+	}
+
+	return signature, nil
+}
+
+func (es *externalSigner) call(
+	result interface{},
+	method string,
+	args ...interface{},
+) error {
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		externalSignerCallTimeout,
+	)
+	defer cancelCtx()
+
+	return es.client.CallContext(ctx, result, method, args...)
+}
+
+// externalSigning is a chain.Signing implementation signing messages with the
+// external signer. Signatures are the same as the ones produced by the
+// signing with the operator key available to the client.
+type externalSigning struct {
+	signer *externalSigner
+}
+
+func (es *externalSigning) PublicKey() []byte {
+	publicKey := es.signer.publicKey
+	return elliptic.Marshal(publicKey.Curve, publicKey.X, publicKey.Y)
+}
+
+func (es *externalSigning) Sign(message []byte) ([]byte, error) {
+	return es.signer.signData(message)
+}
+
+func (es *externalSigning) Verify(
+	message []byte,
+	signature []byte,
+) (bool, error) {
+	return verifySignature(message, signature, es.signer.publicKey)
+}
+
+func (es *externalSigning) VerifyWithPublicKey(
+	message []byte,
+	signature []byte,
+	publicKey []byte,
+) (bool, error) {
+	unmarshalledPubKey, err := unmarshalPublicKey(
+		publicKey,
+		es.signer.publicKey.Curve,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return verifySignature(message, signature, unmarshalledPubKey)
+}
+
+func (es *externalSigning) PublicKeyToAddress(
+	publicKey ecdsa.PublicKey,
+) []byte {
+	return crypto.PubkeyToAddress(publicKey).Bytes()
+}
+
+func (es *externalSigning) PublicKeyBytesToAddress(publicKey []byte) []byte {
+	return crypto.Keccak256(publicKey[1:])[12:]
+}
@@ -0,0 +1,259 @@
+package ethereum
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// testSignerAPI is a stand-in for an external signer. It implements the
+// subset of the Clef `account` namespace used by the client.
+type testSignerAPI struct {
+	key     *ecdsa.PrivateKey
+	chainID *big.Int
+
+	// nonceOffset is added to the nonce of signed transactions to simulate
+	// a misbehaving signer.
+	nonceOffset uint64
+}
+
+func (tsa *testSignerAPI) List() []common.Address {
+	return []common.Address{crypto.PubkeyToAddress(tsa.key.PublicKey)}
+}
+
+func (tsa *testSignerAPI) SignTransaction(
+	args signerTransactionArgs,
+) (*signerTransactionResult, error) {
+	if args.From != crypto.PubkeyToAddress(tsa.key.PublicKey) {
+		return nil, fmt.Errorf("unknown account [%v]", args.From.Hex())
+	}
+
+	transaction := types.NewTransaction(
+		uint64(args.Nonce)+tsa.nonceOffset,
+		*args.To,
+		args.Value.ToInt(),
+		uint64(args.Gas),
+		args.GasPrice.ToInt(),
+		args.Data,
+	)
+
+	signed, err := types.SignTx(
+		transaction,
+		types.NewEIP155Signer(tsa.chainID),
+		tsa.key,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := rlp.EncodeToBytes(signed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &signerTransactionResult{Raw: raw}, nil
+}
+
+func (tsa *testSignerAPI) SignData(
+	contentType string,
+	address common.Address,
+	data hexutil.Bytes,
+) (hexutil.Bytes, error) {
+	if contentType != "text/plain" {
+		return nil, fmt.Errorf("unsupported content type [%v]", contentType)
+	}
+	if address != crypto.PubkeyToAddress(tsa.key.PublicKey) {
+		return nil, fmt.Errorf("unknown account [%v]", address.Hex())
+	}
+
+	signature, err := crypto.Sign(prefixedMessageHash(data), tsa.key)
+	if err != nil {
+		return nil, err
+	}
+	signature[SignatureSize-1] += 27
+
+	return signature, nil
+}
+
+// startTestSigner serves the given test signer API over HTTP. It returns the
+// URL of the signer and a function stopping it.
+func startTestSigner(t *testing.T, api *testSignerAPI) (string, func()) {
+	server := rpc.NewServer()
+	if err := server.RegisterName("account", api); err != nil {
+		t.Fatal(err)
+	}
+
+	httpServer := httptest.NewServer(server)
+
+	return httpServer.URL, func() {
+		httpServer.Close()
+		server.Stop()
+	}
+}
+
+func newTestSignerAPI(t *testing.T) *testSignerAPI {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testSignerAPI{key: key, chainID: big.NewInt(1101)}
+}
+
+func TestExternalSignerSignsTransactions(t *testing.T) {
+	api := newTestSignerAPI(t)
+	address := crypto.PubkeyToAddress(api.key.PublicKey)
+
+	url, stop := startTestSigner(t, api)
+	defer stop()
+
+	signer, err := dialExternalSigner(url, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transaction := types.NewTransaction(
+		7,
+		common.HexToAddress("0x0102"),
+		big.NewInt(0),
+		250000,
+		big.NewInt(20000000000),
+		[]byte{0x01, 0x02},
+	)
+
+	options := signer.transactorOptions()
+	if options.From != address {
+		t.Errorf("unexpected transaction sender [%v]", options.From.Hex())
+	}
+
+	signed, err := options.Signer(types.HomesteadSigner{}, address, transaction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sender, err := types.Sender(types.NewEIP155Signer(api.chainID), signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sender != address {
+		t.Errorf(
+			"unexpected sender\nexpected: [%v]\nactual:   [%v]",
+			address.Hex(),
+			sender.Hex(),
+		)
+	}
+	if signed.Nonce() != 7 || !bytes.Equal(signed.Data(), []byte{0x01, 0x02}) {
+		t.Errorf("signed transaction does not match the submitted one")
+	}
+
+	if _, err := options.Signer(
+		types.HomesteadSigner{},
+		common.HexToAddress("0x03"),
+		transaction,
+	); err == nil {
+		t.Errorf("expected an error for transaction of another account")
+	}
+}
+
+func TestExternalSignerRejectsTransactionWithChangedNonce(t *testing.T) {
+	api := newTestSignerAPI(t)
+	api.nonceOffset = 1
+	address := crypto.PubkeyToAddress(api.key.PublicKey)
+
+	url, stop := startTestSigner(t, api)
+	defer stop()
+
+	signer, err := dialExternalSigner(url, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = signer.signTransaction(types.NewTransaction(
+		7,
+		common.HexToAddress("0x0102"),
+		big.NewInt(0),
+		250000,
+		big.NewInt(20000000000),
+		nil,
+	))
+	if err == nil {
+		t.Errorf("expected an error for transaction with changed nonce")
+	}
+}
+
+func TestExternalSigningMatchesLocalSigning(t *testing.T) {
+	api := newTestSignerAPI(t)
+
+	url, stop := startTestSigner(t, api)
+	defer stop()
+
+	signer, err := dialExternalSigner(
+		url,
+		crypto.PubkeyToAddress(api.key.PublicKey),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	externalSigning := &externalSigning{signer}
+	localSigning := NewSigning(api.key)
+
+	if !bytes.Equal(externalSigning.PublicKey(), localSigning.PublicKey()) {
+		t.Errorf("public key recovered from the external signer does not " +
+			"match the account key")
+	}
+
+	message := []byte("DKG result hash")
+
+	signature, err := externalSigning.Sign(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ok, err := localSigning.Verify(message, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Errorf("external signature should be verified by local signing")
+	}
+
+	localSignature, err := localSigning.Sign(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ok, err = externalSigning.VerifyWithPublicKey(
+		message,
+		localSignature,
+		localSigning.PublicKey(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Errorf("local signature should be verified by external signing")
+	}
+}
+
+func TestExternalSignerRejectsUnmanagedAccount(t *testing.T) {
+	api := newTestSignerAPI(t)
+
+	url, stop := startTestSigner(t, api)
+	defer stop()
+
+	_, err := dialExternalSigner(url, common.HexToAddress("0x0304"))
+	if err == nil {
+		t.Errorf("expected an error for account not managed by the signer")
+	}
+}
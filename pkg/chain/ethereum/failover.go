@@ -0,0 +1,511 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+var (
+	// DefaultEndpointHealthCheckInterval is the default interval in which
+	// health of configured Ethereum endpoints is checked. This value can be
+	// overwritten in the configuration file.
+	DefaultEndpointHealthCheckInterval = 15 * time.Second
+
+	// DefaultEndpointMaxHeadLag is the default number of blocks the head of
+	// an Ethereum endpoint can be behind the highest head seen among all the
+	// configured endpoints for the endpoint to be considered healthy. This
+	// value can be overwritten in the configuration file.
+	DefaultEndpointMaxHeadLag = uint64(5)
+)
+
+// endpointCallTimeout is the timeout of calls checking endpoint health.
+const endpointCallTimeout = 10 * time.Second
+
+// errEndpointSwitched is reported by subscriptions created with the active
+// endpoint when another endpoint becomes active.
+var errEndpointSwitched = fmt.Errorf("active Ethereum endpoint switched")
+
+// endpointBackend is the part of the Ethereum client used to communicate with
+// a single Ethereum endpoint.
+type endpointBackend interface {
+	CodeAt(
+		ctx context.Context,
+		contract common.Address,
+		blockNumber *big.Int,
+	) ([]byte, error)
+	CallContract(
+		ctx context.Context,
+		call ethereum.CallMsg,
+		blockNumber *big.Int,
+	) ([]byte, error)
+	PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	FilterLogs(
+		ctx context.Context,
+		query ethereum.FilterQuery,
+	) ([]types.Log, error)
+	SubscribeFilterLogs(
+		ctx context.Context,
+		query ethereum.FilterQuery,
+		ch chan<- types.Log,
+	) (ethereum.Subscription, error)
+	TransactionReceipt(
+		ctx context.Context,
+		txHash common.Hash,
+	) (*types.Receipt, error)
+	TransactionByHash(
+		ctx context.Context,
+		txHash common.Hash,
+	) (*types.Transaction, bool, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	SubscribeNewHead(
+		ctx context.Context,
+		ch chan<- *types.Header,
+	) (ethereum.Subscription, error)
+}
+
+func dialEndpoint(url string) (endpointBackend, error) {
+	client, err := ethclient.Dial(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// EndpointStatus describes the state of one of the configured Ethereum
+// endpoints.
+type EndpointStatus struct {
+	URL     string
+	Active  bool
+	Healthy bool
+	Head    uint64
+}
+
+// EndpointsStatus describes the state of all the configured Ethereum
+// endpoints.
+type EndpointsStatus struct {
+	// Endpoints holds the state of endpoints in the configured order, the
+	// primary endpoint first.
+	Endpoints []EndpointStatus
+	// Switches is the number of times the active endpoint has been switched.
+	Switches uint64
+}
+
+// EndpointsStatusOf returns the state of Ethereum endpoints used by the given
+// handle. If the handle is not connected to Ethereum, empty status is
+// returned.
+func EndpointsStatusOf(handle chain.Handle) EndpointsStatus {
+	ethereumHandle, ok := handle.(*ethereumChain)
+	if !ok {
+		return EndpointsStatus{}
+	}
+
+	return ethereumHandle.failoverClient.status()
+}
+
+type endpoint struct {
+	url string
+	// backend is nil until a connection with the endpoint is established.
+	backend endpointBackend
+	healthy bool
+	head    uint64
+}
+
+// failoverClient is an Ethereum client communicating with one of the
+// configured Ethereum endpoints at a time. Health of all the endpoints is
+// checked periodically. An endpoint is healthy if it responds and its head is
+// not lagging more than the configured number of blocks behind the highest
+// head among all the endpoints. When the active endpoint becomes unhealthy,
+// the first healthy endpoint in the configured order becomes active. All the
+// subsequent calls are made with that endpoint and subscriptions created with
+// the previously active endpoint fail so that they can be created again with
+// the new one.
+type failoverClient struct {
+	dial       func(url string) (endpointBackend, error)
+	maxHeadLag uint64
+
+	mutex     sync.RWMutex
+	endpoints []*endpoint
+	active    int
+	switches  uint64
+	// switched is closed and replaced with a new channel each time the active
+	// endpoint is switched.
+	switched chan struct{}
+}
+
+// newFailoverClient connects to the given endpoints. The first endpoint a
+// connection could be established with becomes active. It fails if no
+// connection could be established. Connections with other endpoints are
+// retried during health checks.
+func newFailoverClient(
+	urls []string,
+	maxHeadLag uint64,
+	dial func(url string) (endpointBackend, error),
+) (*failoverClient, error) {
+	client := &failoverClient{
+		dial:       dial,
+		maxHeadLag: maxHeadLag,
+		active:     -1,
+		switched:   make(chan struct{}),
+	}
+
+	for i, url := range urls {
+		backend, err := dial(url)
+		if err != nil {
+			logger.Warningf(
+				"could not connect to Ethereum endpoint [%v]: [%v]",
+				url,
+				err,
+			)
+		}
+
+		client.endpoints = append(client.endpoints, &endpoint{
+			url:     url,
+			backend: backend,
+			healthy: err == nil,
+		})
+
+		if err == nil && client.active == -1 {
+			client.active = i
+		}
+	}
+
+	if client.active == -1 {
+		return nil, fmt.Errorf(
+			"could not connect to any of Ethereum endpoints %v",
+			urls,
+		)
+	}
+
+	logger.Infof(
+		"using Ethereum endpoint [%v]",
+		client.endpoints[client.active].url,
+	)
+
+	return client, nil
+}
+
+// monitorHealth checks health of the endpoints in the given interval. It
+// does nothing if there is only one endpoint configured since there is
+// nothing to fail over to.
+func (fc *failoverClient) monitorHealth(interval time.Duration) {
+	if len(fc.endpoints) < 2 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			fc.checkHealth()
+		}
+	}()
+}
+
+// checkHealth updates health of all the endpoints and switches the active
+// endpoint if it is not healthy.
+func (fc *failoverClient) checkHealth() {
+	fc.mutex.RLock()
+	endpoints := make([]endpoint, len(fc.endpoints))
+	for i, endpoint := range fc.endpoints {
+		endpoints[i] = *endpoint
+	}
+	fc.mutex.RUnlock()
+
+	// Network calls are made without holding the lock not to block calls
+	// made with the active endpoint.
+	responding := make([]bool, len(endpoints))
+	var highestHead uint64
+	for i := range endpoints {
+		endpoint := &endpoints[i]
+
+		if endpoint.backend == nil {
+			backend, err := fc.dial(endpoint.url)
+			if err != nil {
+				logger.Debugf(
+					"could not connect to Ethereum endpoint [%v]: [%v]",
+					endpoint.url,
+					err,
+				)
+				continue
+			}
+			endpoint.backend = backend
+		}
+
+		ctx, cancel := context.WithTimeout(
+			context.Background(),
+			endpointCallTimeout,
+		)
+		header, err := endpoint.backend.HeaderByNumber(ctx, nil)
+		cancel()
+		if err != nil {
+			logger.Warningf(
+				"Ethereum endpoint [%v] does not respond: [%v]",
+				endpoint.url,
+				err,
+			)
+			continue
+		}
+
+		responding[i] = true
+		endpoint.head = header.Number.Uint64()
+		if endpoint.head > highestHead {
+			highestHead = endpoint.head
+		}
+	}
+
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	for i, endpoint := range fc.endpoints {
+		wasHealthy := endpoint.healthy
+
+		endpoint.backend = endpoints[i].backend
+		endpoint.head = endpoints[i].head
+		endpoint.healthy = responding[i] &&
+			highestHead-endpoint.head <= fc.maxHeadLag
+
+		if wasHealthy && !endpoint.healthy && responding[i] {
+			logger.Warningf(
+				"Ethereum endpoint [%v] head [%v] is lagging behind "+
+					"the highest head [%v]",
+				endpoint.url,
+				endpoint.head,
+				highestHead,
+			)
+		}
+	}
+
+	if fc.endpoints[fc.active].healthy {
+		return
+	}
+
+	for i, endpoint := range fc.endpoints {
+		if endpoint.healthy {
+			fc.switchEndpoint(i)
+			return
+		}
+	}
+
+	logger.Errorf(
+		"none of Ethereum endpoints is healthy; staying with [%v]",
+		fc.endpoints[fc.active].url,
+	)
+}
+
+// switchEndpoint makes the endpoint with the given index active. It has to
+// be called with the mutex locked.
+func (fc *failoverClient) switchEndpoint(index int) {
+	logger.Warningf(
+		"switching Ethereum endpoint from [%v] to [%v]",
+		fc.endpoints[fc.active].url,
+		fc.endpoints[index].url,
+	)
+
+	fc.active = index
+	fc.switches++
+
+	close(fc.switched)
+	fc.switched = make(chan struct{})
+}
+
+func (fc *failoverClient) activeBackend() endpointBackend {
+	fc.mutex.RLock()
+	defer fc.mutex.RUnlock()
+
+	return fc.endpoints[fc.active].backend
+}
+
+func (fc *failoverClient) status() EndpointsStatus {
+	fc.mutex.RLock()
+	defer fc.mutex.RUnlock()
+
+	status := EndpointsStatus{Switches: fc.switches}
+	for i, endpoint := range fc.endpoints {
+		status.Endpoints = append(status.Endpoints, EndpointStatus{
+			URL:     endpoint.url,
+			Active:  i == fc.active,
+			Healthy: endpoint.healthy,
+			Head:    endpoint.head,
+		})
+	}
+
+	return status
+}
+
+// subscribe creates a subscription with the active endpoint. The returned
+// subscription fails when another endpoint becomes active.
+func (fc *failoverClient) subscribe(
+	subscribe func(backend endpointBackend) (ethereum.Subscription, error),
+) (ethereum.Subscription, error) {
+	fc.mutex.RLock()
+	backend := fc.endpoints[fc.active].backend
+	switched := fc.switched
+	fc.mutex.RUnlock()
+
+	subscription, err := subscribe(backend)
+	if err != nil {
+		return nil, err
+	}
+
+	failoverSubscription := &failoverSubscription{
+		subscription: subscription,
+		switched:     switched,
+		unsubscribe:  make(chan struct{}),
+		done:         make(chan struct{}),
+		err:          make(chan error, 1),
+	}
+	go failoverSubscription.run()
+
+	return failoverSubscription, nil
+}
+
+func (fc *failoverClient) CodeAt(
+	ctx context.Context,
+	contract common.Address,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	return fc.activeBackend().CodeAt(ctx, contract, blockNumber)
+}
+
+func (fc *failoverClient) CallContract(
+	ctx context.Context,
+	call ethereum.CallMsg,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	return fc.activeBackend().CallContract(ctx, call, blockNumber)
+}
+
+func (fc *failoverClient) PendingCodeAt(
+	ctx context.Context,
+	account common.Address,
+) ([]byte, error) {
+	return fc.activeBackend().PendingCodeAt(ctx, account)
+}
+
+func (fc *failoverClient) PendingNonceAt(
+	ctx context.Context,
+	account common.Address,
+) (uint64, error) {
+	return fc.activeBackend().PendingNonceAt(ctx, account)
+}
+
+func (fc *failoverClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return fc.activeBackend().SuggestGasPrice(ctx)
+}
+
+func (fc *failoverClient) EstimateGas(
+	ctx context.Context,
+	call ethereum.CallMsg,
+) (uint64, error) {
+	return fc.activeBackend().EstimateGas(ctx, call)
+}
+
+func (fc *failoverClient) SendTransaction(
+	ctx context.Context,
+	tx *types.Transaction,
+) error {
+	return fc.activeBackend().SendTransaction(ctx, tx)
+}
+
+func (fc *failoverClient) FilterLogs(
+	ctx context.Context,
+	query ethereum.FilterQuery,
+) ([]types.Log, error) {
+	return fc.activeBackend().FilterLogs(ctx, query)
+}
+
+func (fc *failoverClient) SubscribeFilterLogs(
+	ctx context.Context,
+	query ethereum.FilterQuery,
+	ch chan<- types.Log,
+) (ethereum.Subscription, error) {
+	return fc.subscribe(
+		func(backend endpointBackend) (ethereum.Subscription, error) {
+			return backend.SubscribeFilterLogs(ctx, query, ch)
+		},
+	)
+}
+
+func (fc *failoverClient) TransactionReceipt(
+	ctx context.Context,
+	txHash common.Hash,
+) (*types.Receipt, error) {
+	return fc.activeBackend().TransactionReceipt(ctx, txHash)
+}
+
+func (fc *failoverClient) TransactionByHash(
+	ctx context.Context,
+	txHash common.Hash,
+) (*types.Transaction, bool, error) {
+	return fc.activeBackend().TransactionByHash(ctx, txHash)
+}
+
+func (fc *failoverClient) HeaderByNumber(
+	ctx context.Context,
+	number *big.Int,
+) (*types.Header, error) {
+	return fc.activeBackend().HeaderByNumber(ctx, number)
+}
+
+func (fc *failoverClient) SubscribeNewHead(
+	ctx context.Context,
+	ch chan<- *types.Header,
+) (ethereum.Subscription, error) {
+	return fc.subscribe(
+		func(backend endpointBackend) (ethereum.Subscription, error) {
+			return backend.SubscribeNewHead(ctx, ch)
+		},
+	)
+}
+
+// failoverSubscription wraps a subscription created with the active endpoint
+// and fails with errEndpointSwitched when another endpoint becomes active.
+type failoverSubscription struct {
+	subscription ethereum.Subscription
+	switched     <-chan struct{}
+
+	unsubscribeOnce sync.Once
+	unsubscribe     chan struct{}
+	done            chan struct{}
+	err             chan error
+}
+
+func (fs *failoverSubscription) run() {
+	defer close(fs.done)
+	defer fs.subscription.Unsubscribe()
+
+	select {
+	case err := <-fs.subscription.Err():
+		fs.err <- err
+	case <-fs.switched:
+		fs.err <- errEndpointSwitched
+	case <-fs.unsubscribe:
+	}
+}
+
+func (fs *failoverSubscription) Unsubscribe() {
+	fs.unsubscribeOnce.Do(func() {
+		close(fs.unsubscribe)
+		<-fs.done
+		close(fs.err)
+	})
+}
+
+func (fs *failoverSubscription) Err() <-chan error {
+	return fs.err
+}
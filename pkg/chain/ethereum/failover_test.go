@@ -0,0 +1,311 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+type testEndpointBackend struct {
+	endpointBackend
+
+	mutex      sync.Mutex
+	head       uint64
+	responding bool
+	gasPrice   *big.Int
+	headers    chan<- *types.Header
+}
+
+func newTestEndpointBackend(head uint64, gasPrice int64) *testEndpointBackend {
+	return &testEndpointBackend{
+		head:       head,
+		responding: true,
+		gasPrice:   big.NewInt(gasPrice),
+	}
+}
+
+func (teb *testEndpointBackend) setHead(head uint64, responding bool) {
+	teb.mutex.Lock()
+	defer teb.mutex.Unlock()
+
+	teb.head = head
+	teb.responding = responding
+}
+
+func (teb *testEndpointBackend) HeaderByNumber(
+	ctx context.Context,
+	number *big.Int,
+) (*types.Header, error) {
+	teb.mutex.Lock()
+	defer teb.mutex.Unlock()
+
+	if !teb.responding {
+		return nil, fmt.Errorf("endpoint not responding")
+	}
+
+	return &types.Header{Number: new(big.Int).SetUint64(teb.head)}, nil
+}
+
+func (teb *testEndpointBackend) SuggestGasPrice(
+	ctx context.Context,
+) (*big.Int, error) {
+	return teb.gasPrice, nil
+}
+
+func (teb *testEndpointBackend) SubscribeFilterLogs(
+	ctx context.Context,
+	query ethereum.FilterQuery,
+	ch chan<- types.Log,
+) (ethereum.Subscription, error) {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	}), nil
+}
+
+func (teb *testEndpointBackend) SubscribeNewHead(
+	ctx context.Context,
+	ch chan<- *types.Header,
+) (ethereum.Subscription, error) {
+	teb.mutex.Lock()
+	teb.headers = ch
+	teb.mutex.Unlock()
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	}), nil
+}
+
+func (teb *testEndpointBackend) waitForHeadSubscriber() {
+	for {
+		teb.mutex.Lock()
+		subscribed := teb.headers != nil
+		teb.mutex.Unlock()
+
+		if subscribed {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// mine sends a header of the given block to the new heads subscriber.
+func (teb *testEndpointBackend) mine(block uint64) {
+	teb.mutex.Lock()
+	headers := teb.headers
+	teb.mutex.Unlock()
+
+	headers <- &types.Header{Number: new(big.Int).SetUint64(block)}
+}
+
+func newTestFailoverClient(
+	t *testing.T,
+	backends ...*testEndpointBackend,
+) *failoverClient {
+	urls := make([]string, len(backends))
+	for i := range backends {
+		urls[i] = fmt.Sprintf("ws://endpoint-%v", i)
+	}
+
+	client, err := newFailoverClient(
+		urls,
+		5,
+		func(url string) (endpointBackend, error) {
+			for i := range urls {
+				if urls[i] == url && backends[i] != nil {
+					return backends[i], nil
+				}
+			}
+			return nil, fmt.Errorf("could not dial [%v]", url)
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+func assertGasPrice(t *testing.T, client *failoverClient, expected int64) {
+	gasPrice, err := client.SuggestGasPrice(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gasPrice.Int64() != expected {
+		t.Errorf(
+			"call made with unexpected endpoint\nexpected gas price: [%v]\n"+
+				"actual gas price:   [%v]",
+			expected,
+			gasPrice,
+		)
+	}
+}
+
+func TestFailOverLaggingEndpoint(t *testing.T) {
+	primary := newTestEndpointBackend(100, 1)
+	secondary := newTestEndpointBackend(110, 2)
+	client := newTestFailoverClient(t, primary, secondary)
+
+	assertGasPrice(t, client, 1)
+
+	client.checkHealth()
+
+	assertGasPrice(t, client, 2)
+
+	status := client.status()
+	if status.Switches != 1 {
+		t.Errorf("unexpected number of switches [%v]", status.Switches)
+	}
+	if status.Endpoints[0].Healthy || status.Endpoints[0].Active {
+		t.Errorf("lagging endpoint should be unhealthy and inactive")
+	}
+	if !status.Endpoints[1].Healthy || !status.Endpoints[1].Active {
+		t.Errorf("endpoint with the highest head should be healthy and active")
+	}
+}
+
+func TestDoNotFailOverEndpointWithinMaxHeadLag(t *testing.T) {
+	primary := newTestEndpointBackend(105, 1)
+	secondary := newTestEndpointBackend(110, 2)
+	client := newTestFailoverClient(t, primary, secondary)
+
+	client.checkHealth()
+
+	assertGasPrice(t, client, 1)
+}
+
+func TestFailOverUnresponsiveEndpoint(t *testing.T) {
+	primary := newTestEndpointBackend(100, 1)
+	secondary := newTestEndpointBackend(100, 2)
+	client := newTestFailoverClient(t, primary, secondary)
+
+	subscription, err := client.SubscribeFilterLogs(
+		context.Background(),
+		ethereum.FilterQuery{},
+		make(chan types.Log),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer subscription.Unsubscribe()
+
+	primary.setHead(100, false)
+	client.checkHealth()
+
+	assertGasPrice(t, client, 2)
+
+	select {
+	case err := <-subscription.Err():
+		if err != errEndpointSwitched {
+			t.Errorf("unexpected subscription error [%v]", err)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("subscription should fail after endpoint switch")
+	}
+
+	// The recovered endpoint does not become active again as long as the
+	// active endpoint is healthy.
+	primary.setHead(100, true)
+	client.checkHealth()
+
+	assertGasPrice(t, client, 2)
+}
+
+func TestConnectEndpointDuringHealthCheck(t *testing.T) {
+	primary := newTestEndpointBackend(100, 1)
+	secondary := newTestEndpointBackend(110, 2)
+
+	dialable := false
+	client, err := newFailoverClient(
+		[]string{"ws://primary", "ws://secondary"},
+		5,
+		func(url string) (endpointBackend, error) {
+			if url == "ws://primary" {
+				return primary, nil
+			}
+			if dialable {
+				return secondary, nil
+			}
+			return nil, fmt.Errorf("could not dial [%v]", url)
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if client.status().Endpoints[1].Healthy {
+		t.Errorf("not connected endpoint should not be healthy")
+	}
+
+	dialable = true
+	client.checkHealth()
+
+	assertGasPrice(t, client, 2)
+}
+
+func TestFailToConnectToAnyEndpoint(t *testing.T) {
+	_, err := newFailoverClient(
+		[]string{"ws://primary", "ws://secondary"},
+		5,
+		func(url string) (endpointBackend, error) {
+			return nil, fmt.Errorf("could not dial [%v]", url)
+		},
+	)
+	if err == nil {
+		t.Errorf("expected an error")
+	}
+}
+
+func TestHeadBlockCounterIgnoresLowerHeads(t *testing.T) {
+	defaultRetryDelay := headSubscriptionRetryDelay
+	headSubscriptionRetryDelay = 10 * time.Millisecond
+	defer func() { headSubscriptionRetryDelay = defaultRetryDelay }()
+
+	primary := newTestEndpointBackend(100, 1)
+	secondary := newTestEndpointBackend(97, 2)
+	client := newTestFailoverClient(t, primary, secondary)
+
+	blockCounter, err := newHeadBlockCounter(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	waiter, err := blockCounter.BlockHeightWaiter(102)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	primary.waitForHeadSubscriber()
+	primary.mine(101)
+
+	// The secondary endpoint is behind the primary one but still within the
+	// max head lag.
+	primary.setHead(101, false)
+	client.checkHealth()
+
+	secondary.waitForHeadSubscriber()
+	secondary.mine(98)
+
+	if block, _ := blockCounter.CurrentBlock(); block != 101 {
+		t.Errorf("unexpected current block [%v]", block)
+	}
+
+	secondary.mine(102)
+
+	select {
+	case block := <-waiter:
+		if block != 102 {
+			t.Errorf("unexpected block [%v]", block)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("block counter should reach block 102")
+	}
+}
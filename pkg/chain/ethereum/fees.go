@@ -0,0 +1,196 @@
+package ethereum
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/ledger"
+)
+
+// transactionKinds maps names of protocol transactions to kinds of ledger
+// entries their fees are recorded as.
+var transactionKinds = map[string]ledger.Kind{
+	"submitTicket":            ledger.Ticket,
+	"submitDkgResult":         ledger.DKGResult,
+	"relayEntry":              ledger.RelayEntry,
+	"reportRelayEntryTimeout": ledger.RelayEntryTimeout,
+}
+
+// UseLedger makes the given handle record fees of protocol transactions
+// submitted by its operator, DKG reimbursements and group member rewards
+// withdrawn for the operator in the given ledger. Handles not connected to
+// Ethereum are skipped.
+func UseLedger(handle chain.Handle, feeLedger *ledger.Ledger) error {
+	ethereumHandle, ok := handle.(*ethereumChain)
+	if !ok {
+		return nil
+	}
+
+	ethereumHandle.feeLedgerMutex.Lock()
+	ethereumHandle.feeLedger = feeLedger
+	ethereumHandle.feeLedgerMutex.Unlock()
+
+	_, err := ethereumHandle.OnGroupMemberRewardsWithdrawn(
+		ethereumHandle.recordRewardsWithdrawal,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"could not watch group member rewards withdrawals: [%v]",
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (ec *ethereumChain) getFeeLedger() *ledger.Ledger {
+	ec.feeLedgerMutex.Lock()
+	defer ec.feeLedgerMutex.Unlock()
+
+	return ec.feeLedger
+}
+
+// recordTransaction records the fee of the given mined protocol transaction.
+// For an accepted DKG result, the reimbursement paid by the operator
+// contract is recorded as well.
+func (ec *ethereumChain) recordTransaction(
+	name string,
+	transaction *types.Transaction,
+	receipt *types.Receipt,
+) {
+	feeLedger := ec.getFeeLedger()
+	if feeLedger == nil {
+		return
+	}
+
+	kind, ok := transactionKinds[name]
+	if !ok {
+		return
+	}
+
+	gasUsed := new(big.Int).SetUint64(receipt.GasUsed)
+	entry := &ledger.Entry{
+		Operator:    ec.accountAddress.Hex(),
+		Kind:        kind,
+		Reference:   receipt.TxHash.Hex(),
+		BlockNumber: receipt.BlockNumber.Uint64(),
+		GasUsed:     receipt.GasUsed,
+		GasPrice:    transaction.GasPrice(),
+		Fee:         new(big.Int).Mul(gasUsed, transaction.GasPrice()),
+		Reverted:    receipt.Status == types.ReceiptStatusFailed,
+		RecordedAt:  time.Now(),
+	}
+	if err := feeLedger.Append(entry); err != nil {
+		logger.Errorf("could not record [%v] transaction fee: [%v]", name, err)
+	}
+
+	if kind != ledger.DKGResult || entry.Reverted {
+		return
+	}
+
+	reimbursement, err := ec.dkgReimbursement(
+		receipt.BlockNumber,
+		transaction.GasPrice(),
+	)
+	if err != nil {
+		logger.Errorf("could not determine DKG reimbursement: [%v]", err)
+		return
+	}
+
+	if err := feeLedger.Append(&ledger.Entry{
+		Operator:    ec.accountAddress.Hex(),
+		Kind:        ledger.DKGReimbursement,
+		Reference:   receipt.TxHash.Hex(),
+		BlockNumber: receipt.BlockNumber.Uint64(),
+		Income:      reimbursement,
+		RecordedAt:  time.Now(),
+	}); err != nil {
+		logger.Errorf("could not record DKG reimbursement: [%v]", err)
+	}
+}
+
+// dkgReimbursement calculates the reimbursement the operator contract paid
+// for the DKG result submitted in the given block with the given gas price.
+// The contract pays the DKG gas estimate multiplied by the gas price, but
+// not more than the gas price ceiling, and no more than the reimbursement
+// fee paid by the DKG requester. Contract parameters are read as of the
+// block before the submission.
+func (ec *ethereumChain) dkgReimbursement(
+	blockNumber *big.Int,
+	gasPrice *big.Int,
+) (*big.Int, error) {
+	contract := ec.keepRandomBeaconOperatorContract
+	previousBlock := new(big.Int).Sub(blockNumber, big.NewInt(1))
+
+	gasEstimate, err := contract.DkgGasEstimateAtBlock(previousBlock)
+	if err != nil {
+		return nil, err
+	}
+	ceiling, err := contract.GasPriceCeilingAtBlock(previousBlock)
+	if err != nil {
+		return nil, err
+	}
+	maxReimbursement, err := contract.DkgSubmitterReimbursementFeeAtBlock(
+		previousBlock,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return calculateDkgReimbursement(
+		gasEstimate,
+		gasPrice,
+		ceiling,
+		maxReimbursement,
+	), nil
+}
+
+func calculateDkgReimbursement(
+	gasEstimate *big.Int,
+	gasPrice *big.Int,
+	ceiling *big.Int,
+	maxReimbursement *big.Int,
+) *big.Int {
+	reimbursedGasPrice := ceiling
+	if gasPrice.Sign() > 0 && gasPrice.Cmp(ceiling) < 0 {
+		reimbursedGasPrice = gasPrice
+	}
+
+	reimbursement := new(big.Int).Mul(gasEstimate, reimbursedGasPrice)
+	if reimbursement.Cmp(maxReimbursement) > 0 {
+		return new(big.Int).Set(maxReimbursement)
+	}
+
+	return reimbursement
+}
+
+// recordRewardsWithdrawal records group member rewards withdrawn for the
+// operator of the handle.
+func (ec *ethereumChain) recordRewardsWithdrawal(
+	withdrawal *event.GroupMemberRewardsWithdrawn,
+) {
+	if !bytes.Equal(withdrawal.Operator, ec.accountAddress.Bytes()) {
+		return
+	}
+
+	feeLedger := ec.getFeeLedger()
+	if feeLedger == nil {
+		return
+	}
+
+	if err := feeLedger.Append(&ledger.Entry{
+		Operator:    ec.accountAddress.Hex(),
+		Kind:        ledger.GroupMemberReward,
+		Reference:   withdrawal.GroupIndex.String(),
+		BlockNumber: withdrawal.BlockNumber,
+		Income:      withdrawal.Amount,
+		RecordedAt:  time.Now(),
+	}); err != nil {
+		logger.Errorf("could not record group member reward: [%v]", err)
+	}
+}
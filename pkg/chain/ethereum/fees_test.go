@@ -0,0 +1,58 @@
+package ethereum
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestCalculateDkgReimbursement(t *testing.T) {
+	gasEstimate := big.NewInt(1740000)
+	ceiling := big.NewInt(60000000000)
+	maxReimbursement := new(big.Int).Mul(gasEstimate, ceiling)
+
+	var tests = map[string]struct {
+		gasPrice              *big.Int
+		maxReimbursement      *big.Int
+		expectedReimbursement *big.Int
+	}{
+		"gas price below the ceiling": {
+			gasPrice:              big.NewInt(20000000000),
+			maxReimbursement:      maxReimbursement,
+			expectedReimbursement: big.NewInt(34800000000000000),
+		},
+		"gas price above the ceiling": {
+			gasPrice:              big.NewInt(90000000000),
+			maxReimbursement:      maxReimbursement,
+			expectedReimbursement: maxReimbursement,
+		},
+		"zero gas price": {
+			gasPrice:              big.NewInt(0),
+			maxReimbursement:      maxReimbursement,
+			expectedReimbursement: maxReimbursement,
+		},
+		"reimbursement fee lower than the estimate": {
+			gasPrice:              big.NewInt(20000000000),
+			maxReimbursement:      big.NewInt(1000),
+			expectedReimbursement: big.NewInt(1000),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			reimbursement := calculateDkgReimbursement(
+				gasEstimate,
+				test.gasPrice,
+				ceiling,
+				test.maxReimbursement,
+			)
+
+			if reimbursement.Cmp(test.expectedReimbursement) != 0 {
+				t.Errorf(
+					"unexpected reimbursement\nexpected: [%v]\nactual:   [%v]",
+					test.expectedReimbursement,
+					reimbursement,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,293 @@
+// Package ledger keeps track of what operating the random beacon costs the
+// operators hosted by the client and what they earn for it.
+package ledger
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FileName is the name of the ledger file created in the data directory.
+const FileName = "operator_fee_ledger.log"
+
+// Kind is the kind of a ledger entry.
+type Kind string
+
+const (
+	// Ticket is a fee of a ticket submission transaction.
+	Ticket Kind = "ticket"
+	// DKGResult is a fee of a DKG result submission transaction.
+	DKGResult Kind = "dkgResult"
+	// RelayEntry is a fee of a relay entry submission transaction.
+	RelayEntry Kind = "relayEntry"
+	// RelayEntryTimeout is a fee of a relay entry timeout report
+	// transaction.
+	RelayEntryTimeout Kind = "relayEntryTimeout"
+	// DKGReimbursement is a reimbursement paid by the operator contract for
+	// a submitted DKG result.
+	DKGReimbursement Kind = "dkgReimbursement"
+	// GroupMemberReward is a group member reward withdrawn for the operator.
+	GroupMemberReward Kind = "groupMemberReward"
+)
+
+// Entry is a single cost or income of an operator. Costs are fees of
+// transactions submitted by the operator. Incomes are DKG reimbursements and
+// rewards. All amounts are in wei.
+type Entry struct {
+	Operator string `json:"operator"`
+	Kind     Kind   `json:"kind"`
+	// Reference identifies the entry among the entries of the same kind for
+	// the operator, like a transaction hash or a group index.
+	Reference   string `json:"reference"`
+	BlockNumber uint64 `json:"blockNumber"`
+
+	GasUsed  uint64   `json:"gasUsed,omitempty"`
+	GasPrice *big.Int `json:"gasPrice,omitempty"`
+	Fee      *big.Int `json:"fee,omitempty"`
+	// Reverted is set for transactions mined with a failed status. Their
+	// fee is paid nevertheless.
+	Reverted bool `json:"reverted,omitempty"`
+
+	Income *big.Int `json:"income,omitempty"`
+
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+// Totals are amounts summed over ledger entries.
+type Totals struct {
+	// Transactions is the number of transactions fees were recorded for.
+	Transactions uint64
+	// GasUsed is the gas used by all the transactions.
+	GasUsed uint64
+	// Fees is the sum of transaction fees.
+	Fees *big.Int
+	// Reimbursements is the sum of DKG reimbursements.
+	Reimbursements *big.Int
+	// Rewards is the sum of withdrawn group member rewards.
+	Rewards *big.Int
+}
+
+// Profit returns the total income reduced by the total fees. It is negative
+// when the fees exceed the income.
+func (t Totals) Profit() *big.Int {
+	profit := new(big.Int).Add(t.Reimbursements, t.Rewards)
+	return profit.Sub(profit, t.Fees)
+}
+
+func newTotals() Totals {
+	return Totals{
+		Fees:           new(big.Int),
+		Reimbursements: new(big.Int),
+		Rewards:        new(big.Int),
+	}
+}
+
+func (t *Totals) add(entry *Entry) {
+	if entry.Fee != nil {
+		t.Transactions++
+		t.GasUsed += entry.GasUsed
+		t.Fees.Add(t.Fees, entry.Fee)
+	}
+
+	if entry.Income != nil {
+		switch entry.Kind {
+		case DKGReimbursement:
+			t.Reimbursements.Add(t.Reimbursements, entry.Income)
+		default:
+			t.Rewards.Add(t.Rewards, entry.Income)
+		}
+	}
+}
+
+func (t Totals) copy() Totals {
+	return Totals{
+		Transactions:   t.Transactions,
+		GasUsed:        t.GasUsed,
+		Fees:           new(big.Int).Set(t.Fees),
+		Reimbursements: new(big.Int).Set(t.Reimbursements),
+		Rewards:        new(big.Int).Set(t.Rewards),
+	}
+}
+
+// Ledger is a persistent, append-only ledger of operator costs and incomes.
+// Each entry is stored as a single line of JSON. Entries are never modified
+// or removed once written.
+type Ledger struct {
+	mutex    sync.Mutex
+	path     string
+	file     *os.File
+	recorded map[string]bool
+	totals   Totals
+}
+
+// Open opens the ledger in the given data directory, creating the ledger
+// file if it does not exist yet.
+func Open(dataDir string) (*Ledger, error) {
+	path := filepath.Join(dataDir, FileName)
+
+	ledger := &Ledger{
+		path:     path,
+		recorded: make(map[string]bool),
+		totals:   newTotals(),
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		entries, err := ledger.read()
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			ledger.recorded[entryID(entry)] = true
+			ledger.totals.add(entry)
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("could not open ledger [%v]: [%v]", path, err)
+	}
+	ledger.file = file
+
+	return ledger, nil
+}
+
+func entryID(entry *Entry) string {
+	return entry.Operator + "/" + string(entry.Kind) + "/" + entry.Reference
+}
+
+// Append writes the given entry at the end of the ledger and flushes it to
+// the disk. The entry is not written if an entry of the same kind with the
+// same reference has been already recorded for the operator.
+func (l *Ledger) Append(entry *Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("could not marshal ledger entry: [%v]", err)
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	id := entryID(entry)
+	if l.recorded[id] {
+		return nil
+	}
+
+	if _, err := l.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("could not write ledger entry: [%v]", err)
+	}
+	if err := l.file.Sync(); err != nil {
+		return fmt.Errorf("could not flush ledger entry: [%v]", err)
+	}
+
+	l.recorded[id] = true
+	l.totals.add(entry)
+
+	return nil
+}
+
+// Entries reads all entries from the ledger, ordered from the oldest to the
+// newest one.
+func (l *Ledger) Entries() ([]*Entry, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.read()
+}
+
+func (l *Ledger) read() ([]*Entry, error) {
+	file, err := os.Open(l.path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open ledger [%v]: [%v]", l.path, err)
+	}
+	defer file.Close()
+
+	entries := make([]*Entry, 0)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		entry := &Entry{}
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			return nil, fmt.Errorf("could not unmarshal ledger entry: [%v]", err)
+		}
+
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read ledger [%v]: [%v]", l.path, err)
+	}
+
+	return entries, nil
+}
+
+// Totals returns amounts summed over all the entries in the ledger.
+func (l *Ledger) Totals() Totals {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.totals.copy()
+}
+
+// Close closes the ledger file. No entries can be appended to a closed
+// ledger.
+func (l *Ledger) Close() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.file.Close()
+}
+
+// ReportRow holds totals of entries of a single kind recorded for an
+// operator.
+type ReportRow struct {
+	Operator string
+	Kind     Kind
+	Count    int
+	Totals   Totals
+}
+
+// Report sums the given entries per operator and kind. Rows are ordered by
+// the operator and the kind.
+func Report(entries []*Entry) []*ReportRow {
+	rows := make(map[string]*ReportRow)
+
+	for _, entry := range entries {
+		key := entry.Operator + "/" + string(entry.Kind)
+
+		row, ok := rows[key]
+		if !ok {
+			row = &ReportRow{
+				Operator: entry.Operator,
+				Kind:     entry.Kind,
+				Totals:   newTotals(),
+			}
+			rows[key] = row
+		}
+
+		row.Count++
+		row.Totals.add(entry)
+	}
+
+	report := make([]*ReportRow, 0, len(rows))
+	for _, row := range rows {
+		report = append(report, row)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Operator != report[j].Operator {
+			return report[i].Operator < report[j].Operator
+		}
+		return report[i].Kind < report[j].Kind
+	})
+
+	return report
+}
@@ -0,0 +1,219 @@
+package ledger
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+)
+
+const (
+	operator1 = "0x65ea55c1f10491038425725dc00dffeab2a1e28a"
+	operator2 = "0x524f2e0176350d950fa630d9a5a59a0a190daf48"
+)
+
+func TestLedgerRecordsEntriesAcrossRestarts(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "ledger-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	ledger, err := Open(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries := []*Entry{
+		{
+			Operator:  operator1,
+			Kind:      Ticket,
+			Reference: "0x01",
+			GasUsed:   100000,
+			GasPrice:  big.NewInt(20),
+			Fee:       big.NewInt(2000000),
+		},
+		{
+			Operator:  operator1,
+			Kind:      DKGResult,
+			Reference: "0x02",
+			GasUsed:   1500000,
+			GasPrice:  big.NewInt(20),
+			Fee:       big.NewInt(30000000),
+		},
+		{
+			Operator:  operator1,
+			Kind:      DKGReimbursement,
+			Reference: "0x02",
+			Income:    big.NewInt(35000000),
+		},
+		{
+			Operator:  operator1,
+			Kind:      GroupMemberReward,
+			Reference: "3",
+			Income:    big.NewInt(1000000),
+		},
+	}
+	for _, entry := range entries {
+		if err := ledger.Append(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The same transaction mined again after a reorg should not be counted
+	// twice.
+	if err := ledger.Append(entries[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ledger.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	ledger, err = Open(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ledger.Close()
+
+	recorded, err := ledger.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recorded) != len(entries) {
+		t.Fatalf(
+			"unexpected number of entries\nexpected: [%v]\nactual:   [%v]",
+			len(entries),
+			len(recorded),
+		)
+	}
+
+	if err := ledger.Append(entries[1]); err != nil {
+		t.Fatal(err)
+	}
+
+	totals := ledger.Totals()
+	if totals.Transactions != 2 {
+		t.Errorf(
+			"unexpected number of transactions\nexpected: [%v]\nactual:   [%v]",
+			2,
+			totals.Transactions,
+		)
+	}
+	if totals.GasUsed != 1600000 {
+		t.Errorf(
+			"unexpected gas used\nexpected: [%v]\nactual:   [%v]",
+			1600000,
+			totals.GasUsed,
+		)
+	}
+	if totals.Fees.Cmp(big.NewInt(32000000)) != 0 {
+		t.Errorf(
+			"unexpected fees\nexpected: [%v]\nactual:   [%v]",
+			32000000,
+			totals.Fees,
+		)
+	}
+	if totals.Profit().Cmp(big.NewInt(4000000)) != 0 {
+		t.Errorf(
+			"unexpected profit\nexpected: [%v]\nactual:   [%v]",
+			4000000,
+			totals.Profit(),
+		)
+	}
+}
+
+func TestReport(t *testing.T) {
+	entries := []*Entry{
+		{
+			Operator:  operator1,
+			Kind:      RelayEntry,
+			Reference: "0x01",
+			GasUsed:   300000,
+			Fee:       big.NewInt(600),
+		},
+		{
+			Operator:  operator2,
+			Kind:      Ticket,
+			Reference: "0x02",
+			GasUsed:   100000,
+			Fee:       big.NewInt(200),
+		},
+		{
+			Operator:  operator1,
+			Kind:      RelayEntry,
+			Reference: "0x03",
+			GasUsed:   300000,
+			Fee:       big.NewInt(900),
+			Reverted:  true,
+		},
+		{
+			Operator:  operator1,
+			Kind:      GroupMemberReward,
+			Reference: "1",
+			Income:    big.NewInt(1000),
+		},
+	}
+
+	report := Report(entries)
+
+	if len(report) != 3 {
+		t.Fatalf(
+			"unexpected number of rows\nexpected: [%v]\nactual:   [%v]",
+			3,
+			len(report),
+		)
+	}
+
+	var tests = []struct {
+		operator        string
+		kind            Kind
+		expectedCount   int
+		expectedFees    int64
+		expectedRewards int64
+	}{
+		{operator2, Ticket, 1, 200, 0},
+		{operator1, GroupMemberReward, 1, 0, 1000},
+		{operator1, RelayEntry, 2, 1500, 0},
+	}
+
+	for i, test := range tests {
+		row := report[i]
+
+		if row.Operator != test.operator || row.Kind != test.kind {
+			t.Errorf(
+				"unexpected row [%v]\nexpected: [%v %v]\nactual:   [%v %v]",
+				i,
+				test.operator,
+				test.kind,
+				row.Operator,
+				row.Kind,
+			)
+			continue
+		}
+		if row.Count != test.expectedCount {
+			t.Errorf(
+				"unexpected count of [%v]\nexpected: [%v]\nactual:   [%v]",
+				test.kind,
+				test.expectedCount,
+				row.Count,
+			)
+		}
+		if row.Totals.Fees.Cmp(big.NewInt(test.expectedFees)) != 0 {
+			t.Errorf(
+				"unexpected fees of [%v]\nexpected: [%v]\nactual:   [%v]",
+				test.kind,
+				test.expectedFees,
+				row.Totals.Fees,
+			)
+		}
+		if row.Totals.Rewards.Cmp(big.NewInt(test.expectedRewards)) != 0 {
+			t.Errorf(
+				"unexpected rewards of [%v]\nexpected: [%v]\nactual:   [%v]",
+				test.kind,
+				test.expectedRewards,
+				row.Totals.Rewards,
+			)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package ethereum
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// localNonceTrustDuration is the time after the last transaction submission
+// during which the local nonce is trusted if it is higher than the pending
+// nonce reported by the chain. The Ethereum client may not report the last
+// submitted transactions in the pending nonce yet, for example when multiple
+// clients are deployed behind a load balancer. After that time, the local
+// nonce higher than the pending nonce means the submitted transactions have
+// been dropped and the nonces have to be reused not to leave a gap.
+var localNonceTrustDuration = 5 * time.Second
+
+// maxNonceAttempts is the maximum number of attempts to submit a transaction
+// when it is rejected because of its nonce.
+const maxNonceAttempts = 3
+
+// nonceErrors are fragments of errors returned by Ethereum clients when the
+// nonce of the submitted transaction has been already used.
+var nonceErrors = []string{
+	"nonce too low",
+	"replacement transaction underpriced",
+}
+
+// knownTransactionErrors are fragments of errors returned by Ethereum clients
+// when the submitted transaction has been already accepted, for example when
+// the previous submission reached the client but its response did not reach
+// the manager.
+var knownTransactionErrors = []string{
+	"already known",
+	"known transaction",
+}
+
+// nonceBackend is the part of the Ethereum client used by the nonce manager.
+type nonceBackend interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+}
+
+var (
+	nonceManagersMutex sync.Mutex
+	nonceManagers      = make(map[common.Address]*nonceManager)
+)
+
+// nonceManager allocates nonces for transactions submitted from one account.
+// Allocation is serialized so that transactions submitted concurrently never
+// get the same nonce. The nonce is evaluated as the higher of the pending
+// nonce reported by the chain and the nonce following the last transaction
+// submitted by the manager. If the submitted transaction is rejected because
+// its nonce has been already used, the nonce is synchronized with the chain
+// and the submission is retried.
+type nonceManager struct {
+	account common.Address
+	backend nonceBackend
+
+	// mutex serializes nonce allocation. It is shared with the generated
+	// contract bindings submitting transactions from the same account so
+	// that they never compete with the manager for the same nonce.
+	mutex          *sync.Mutex
+	localNonce     uint64
+	lastSubmission time.Time
+}
+
+// nonceManagerFor returns the nonce manager of the given account. The same
+// manager is returned for all connections submitting transactions from the
+// account.
+func nonceManagerFor(
+	account common.Address,
+	backend nonceBackend,
+) *nonceManager {
+	nonceManagersMutex.Lock()
+	defer nonceManagersMutex.Unlock()
+
+	manager, ok := nonceManagers[account]
+	if !ok {
+		manager = newNonceManager(account, backend)
+		nonceManagers[account] = manager
+	}
+
+	return manager
+}
+
+func newNonceManager(account common.Address, backend nonceBackend) *nonceManager {
+	return &nonceManager{
+		account: account,
+		backend: backend,
+		mutex:   &sync.Mutex{},
+	}
+}
+
+// transact submits a transaction using the provided function with the next
+// nonce of the account. The nonce is consumed only if the submission
+// succeeds. If the submission is rejected because the nonce has been already
+// used, the nonce is synchronized with the chain and the submission is
+// retried with the next available nonce. If the submission is rejected
+// because the client already knows the transaction, the transaction is
+// considered submitted, provided the function returned the signed
+// transaction along with the error.
+func (nm *nonceManager) transact(
+	submit func(nonce uint64) (*types.Transaction, error),
+) (*types.Transaction, error) {
+	nm.mutex.Lock()
+	defer nm.mutex.Unlock()
+
+	for attempt := 1; ; attempt++ {
+		nonce, err := nm.nextNonce()
+		if err != nil {
+			return nil, err
+		}
+
+		transaction, err := submit(nonce)
+		if err == nil {
+			nm.localNonce = nonce + 1
+			nm.lastSubmission = time.Now()
+			return transaction, nil
+		}
+
+		if transaction != nil && isKnownTransactionError(err) {
+			logger.Infof(
+				"transaction [%v] from account [%v] with nonce [%v] "+
+					"already known by the client",
+				transaction.Hash().TerminalString(),
+				nm.account.Hex(),
+				nonce,
+			)
+			nm.localNonce = nonce + 1
+			nm.lastSubmission = time.Now()
+			return transaction, nil
+		}
+
+		if !isNonceError(err) {
+			return nil, err
+		}
+
+		// The nonce has been used by a transaction not submitted by the
+		// manager so it can not be used anymore, even if the chain does
+		// not report it in the pending nonce yet.
+		nm.localNonce = nonce + 1
+		nm.lastSubmission = time.Now()
+
+		if attempt == maxNonceAttempts {
+			return nil, err
+		}
+
+		logger.Warningf(
+			"transaction from account [%v] with nonce [%v] rejected; "+
+				"retrying with the next nonce: [%v]",
+			nm.account.Hex(),
+			nonce,
+			err,
+		)
+	}
+}
+
+// nextNonce returns the nonce for the next transaction. It has to be called
+// with the mutex held.
+func (nm *nonceManager) nextNonce() (uint64, error) {
+	pendingNonce, err := nm.backend.PendingNonceAt(
+		context.Background(),
+		nm.account,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	if pendingNonce >= nm.localNonce {
+		return pendingNonce, nil
+	}
+
+	if time.Since(nm.lastSubmission) < localNonceTrustDuration {
+		return nm.localNonce, nil
+	}
+
+	logger.Warningf(
+		"local nonce [%v] of account [%v] is higher than pending nonce [%v] "+
+			"reported by the chain; transactions with nonces in between "+
+			"have been dropped and nonces are going to be reused",
+		nm.localNonce,
+		nm.account.Hex(),
+		pendingNonce,
+	)
+	nm.localNonce = pendingNonce
+
+	return pendingNonce, nil
+}
+
+func isNonceError(err error) bool {
+	return containsAny(err, nonceErrors)
+}
+
+func isKnownTransactionError(err error) bool {
+	return containsAny(err, knownTransactionErrors)
+}
+
+func containsAny(err error, fragments []string) bool {
+	message := strings.ToLower(err.Error())
+	for _, fragment := range fragments {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+
+	return false
+}
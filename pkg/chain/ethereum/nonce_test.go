@@ -0,0 +1,242 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+type testNonceBackend struct {
+	mutex        sync.Mutex
+	pendingNonce uint64
+}
+
+func (tnb *testNonceBackend) PendingNonceAt(
+	ctx context.Context,
+	account common.Address,
+) (uint64, error) {
+	tnb.mutex.Lock()
+	defer tnb.mutex.Unlock()
+
+	return tnb.pendingNonce, nil
+}
+
+func testTransaction(nonce uint64) *types.Transaction {
+	return types.NewTransaction(
+		nonce,
+		common.Address{},
+		big.NewInt(0),
+		21000,
+		big.NewInt(1),
+		nil,
+	)
+}
+
+func TestAllocateNoncesConcurrently(t *testing.T) {
+	// The chain does not report submitted transactions in the pending nonce
+	// so the manager has to rely on the local nonce.
+	manager := newNonceManager(common.Address{}, &testNonceBackend{})
+
+	transactions := 20
+	nonces := make(chan uint64, transactions)
+
+	var wg sync.WaitGroup
+	wg.Add(transactions)
+	for i := 0; i < transactions; i++ {
+		go func() {
+			defer wg.Done()
+
+			_, err := manager.transact(
+				func(nonce uint64) (*types.Transaction, error) {
+					nonces <- nonce
+					return testTransaction(nonce), nil
+				},
+			)
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	close(nonces)
+
+	used := make(map[uint64]bool)
+	for nonce := range nonces {
+		if used[nonce] {
+			t.Errorf("nonce [%v] allocated more than once", nonce)
+		}
+		used[nonce] = true
+	}
+	for nonce := uint64(0); nonce < uint64(transactions); nonce++ {
+		if !used[nonce] {
+			t.Errorf("nonce [%v] not allocated", nonce)
+		}
+	}
+}
+
+func TestRetryTransactionRejectedBecauseOfNonce(t *testing.T) {
+	manager := newNonceManager(common.Address{}, &testNonceBackend{pendingNonce: 3})
+
+	attempts := make([]uint64, 0)
+	transaction, err := manager.transact(
+		func(nonce uint64) (*types.Transaction, error) {
+			attempts = append(attempts, nonce)
+			if len(attempts) == 1 {
+				return nil, fmt.Errorf("nonce too low")
+			}
+			return testTransaction(nonce), nil
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedAttempts := []uint64{3, 4}
+	if fmt.Sprint(attempts) != fmt.Sprint(expectedAttempts) {
+		t.Errorf(
+			"unexpected attempts\nexpected: [%v]\nactual:   [%v]",
+			expectedAttempts,
+			attempts,
+		)
+	}
+	if transaction.Nonce() != 4 {
+		t.Errorf("unexpected nonce [%v]", transaction.Nonce())
+	}
+}
+
+func TestTrackAlreadyKnownTransaction(t *testing.T) {
+	manager := newNonceManager(common.Address{}, &testNonceBackend{pendingNonce: 3})
+
+	attempts := make([]uint64, 0)
+	transaction, err := manager.transact(
+		func(nonce uint64) (*types.Transaction, error) {
+			attempts = append(attempts, nonce)
+			return testTransaction(nonce), fmt.Errorf("already known")
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedAttempts := []uint64{3}
+	if fmt.Sprint(attempts) != fmt.Sprint(expectedAttempts) {
+		t.Errorf(
+			"unexpected attempts\nexpected: [%v]\nactual:   [%v]",
+			expectedAttempts,
+			attempts,
+		)
+	}
+	if transaction.Nonce() != 3 {
+		t.Errorf("unexpected nonce [%v]", transaction.Nonce())
+	}
+
+	nextNonce, err := manager.nextNonce()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nextNonce != 4 {
+		t.Errorf("unexpected next nonce [%v]", nextNonce)
+	}
+}
+
+func TestGiveUpAfterMaxNonceAttempts(t *testing.T) {
+	manager := newNonceManager(common.Address{}, &testNonceBackend{})
+
+	attempts := 0
+	_, err := manager.transact(
+		func(nonce uint64) (*types.Transaction, error) {
+			attempts++
+			return nil, fmt.Errorf("replacement transaction underpriced")
+		},
+	)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if attempts != maxNonceAttempts {
+		t.Errorf(
+			"unexpected number of attempts\nexpected: [%v]\nactual:   [%v]",
+			maxNonceAttempts,
+			attempts,
+		)
+	}
+}
+
+func TestDoNotConsumeNonceOfFailedTransaction(t *testing.T) {
+	manager := newNonceManager(common.Address{}, &testNonceBackend{})
+
+	_, err := manager.transact(
+		func(nonce uint64) (*types.Transaction, error) {
+			return nil, fmt.Errorf("connection refused")
+		},
+	)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	transaction, err := manager.transact(
+		func(nonce uint64) (*types.Transaction, error) {
+			return testTransaction(nonce), nil
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transaction.Nonce() != 0 {
+		t.Errorf("nonce of failed transaction should be reused")
+	}
+}
+
+func TestRecoverFromNonceGap(t *testing.T) {
+	defaultTrustDuration := localNonceTrustDuration
+	localNonceTrustDuration = 10 * time.Millisecond
+	defer func() { localNonceTrustDuration = defaultTrustDuration }()
+
+	backend := &testNonceBackend{pendingNonce: 3}
+	manager := newNonceManager(common.Address{}, backend)
+
+	submit := func(nonce uint64) (*types.Transaction, error) {
+		return testTransaction(nonce), nil
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := manager.transact(submit); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Transactions with nonces 3, 4 and 5 have been dropped.
+	time.Sleep(20 * time.Millisecond)
+
+	transaction, err := manager.transact(submit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transaction.Nonce() != 3 {
+		t.Errorf(
+			"unexpected nonce\nexpected: [%v]\nactual:   [%v]",
+			3,
+			transaction.Nonce(),
+		)
+	}
+}
+
+func TestNonceManagerSharedByAccount(t *testing.T) {
+	backend := &testNonceBackend{}
+
+	first := nonceManagerFor(common.HexToAddress("0x01"), backend)
+	second := nonceManagerFor(common.HexToAddress("0x01"), backend)
+	other := nonceManagerFor(common.HexToAddress("0x02"), backend)
+
+	if first != second {
+		t.Errorf("the same account should have the same nonce manager")
+	}
+	if first == other {
+		t.Errorf("different accounts should have different nonce managers")
+	}
+}
@@ -0,0 +1,168 @@
+package ethereum
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+)
+
+func (ec *ethereumChain) PastGroupSelectionStartedEvents(
+	startBlock uint64,
+	endBlock uint64,
+) ([]*event.GroupSelectionStart, error) {
+	iterator, err := ec.keepRandomBeaconOperatorFilterer.FilterGroupSelectionStarted(
+		filterOpts(startBlock, endBlock),
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not filter group selection started events: [%v]",
+			err,
+		)
+	}
+	defer iterator.Close()
+
+	events := make([]*event.GroupSelectionStart, 0)
+	for iterator.Next() {
+		events = append(events, &event.GroupSelectionStart{
+			NewEntry:    iterator.Event.NewEntry,
+			BlockNumber: iterator.Event.Raw.BlockNumber,
+		})
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, fmt.Errorf(
+			"could not iterate over group selection started events: [%v]",
+			err,
+		)
+	}
+
+	return events, nil
+}
+
+func (ec *ethereumChain) PastDKGResultSubmittedEvents(
+	startBlock uint64,
+	endBlock uint64,
+) ([]*event.DKGResultSubmission, error) {
+	iterator, err := ec.keepRandomBeaconOperatorFilterer.FilterDkgResultSubmittedEvent(
+		filterOpts(startBlock, endBlock),
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not filter DKG result submitted events: [%v]",
+			err,
+		)
+	}
+	defer iterator.Close()
+
+	events := make([]*event.DKGResultSubmission, 0)
+	for iterator.Next() {
+		events = append(events, &event.DKGResultSubmission{
+			MemberIndex:    uint32(iterator.Event.MemberIndex.Uint64()),
+			GroupPublicKey: iterator.Event.GroupPubKey,
+			Misbehaved:     iterator.Event.Misbehaved,
+			BlockNumber:    iterator.Event.Raw.BlockNumber,
+		})
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, fmt.Errorf(
+			"could not iterate over DKG result submitted events: [%v]",
+			err,
+		)
+	}
+
+	return events, nil
+}
+
+// PastGroupRegisteredEvents returns groups registered in the given range of
+// blocks. Just like OnGroupRegistered, it relies on DKG result submitted
+// events since a group is registered on-chain along with the DKG result.
+func (ec *ethereumChain) PastGroupRegisteredEvents(
+	startBlock uint64,
+	endBlock uint64,
+) ([]*event.GroupRegistration, error) {
+	submissions, err := ec.PastDKGResultSubmittedEvents(startBlock, endBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*event.GroupRegistration, len(submissions))
+	for i, submission := range submissions {
+		events[i] = &event.GroupRegistration{
+			GroupPublicKey: submission.GroupPublicKey,
+			BlockNumber:    submission.BlockNumber,
+		}
+	}
+
+	return events, nil
+}
+
+func (ec *ethereumChain) PastRelayEntryRequestedEvents(
+	startBlock uint64,
+	endBlock uint64,
+) ([]*event.Request, error) {
+	iterator, err := ec.keepRandomBeaconOperatorFilterer.FilterRelayEntryRequested(
+		filterOpts(startBlock, endBlock),
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not filter relay entry requested events: [%v]",
+			err,
+		)
+	}
+	defer iterator.Close()
+
+	events := make([]*event.Request, 0)
+	for iterator.Next() {
+		events = append(events, &event.Request{
+			PreviousEntry:  iterator.Event.PreviousEntry,
+			GroupPublicKey: iterator.Event.GroupPublicKey,
+			BlockNumber:    iterator.Event.Raw.BlockNumber,
+		})
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, fmt.Errorf(
+			"could not iterate over relay entry requested events: [%v]",
+			err,
+		)
+	}
+
+	return events, nil
+}
+
+func (ec *ethereumChain) PastRelayEntrySubmittedEvents(
+	startBlock uint64,
+	endBlock uint64,
+) ([]*event.EntrySubmitted, error) {
+	iterator, err := ec.keepRandomBeaconOperatorFilterer.FilterRelayEntrySubmitted(
+		filterOpts(startBlock, endBlock),
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not filter relay entry submitted events: [%v]",
+			err,
+		)
+	}
+	defer iterator.Close()
+
+	events := make([]*event.EntrySubmitted, 0)
+	for iterator.Next() {
+		events = append(events, &event.EntrySubmitted{
+			BlockNumber: iterator.Event.Raw.BlockNumber,
+		})
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, fmt.Errorf(
+			"could not iterate over relay entry submitted events: [%v]",
+			err,
+		)
+	}
+
+	return events, nil
+}
+
+func filterOpts(startBlock uint64, endBlock uint64) *bind.FilterOpts {
+	return &bind.FilterOpts{
+		Start: startBlock,
+		End:   &endBlock,
+	}
+}
@@ -0,0 +1,137 @@
+package ethereum
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"regexp"
+	"strings"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+)
+
+// revertReasonSelector is the selector of the `Error(string)` function used by
+// Solidity to encode revert reasons.
+var revertReasonSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+
+// revertReasonPatterns match revert reasons in error messages returned by
+// Ethereum clients and by the keep-common error resolver.
+var revertReasonPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`contract failed with: \[\[([^\]]*)\]\]`),
+	regexp.MustCompile(`execution reverted: ([^\]]*)`),
+	regexp.MustCompile(`VM Exception while processing transaction: revert ([^\]]*)`),
+}
+
+// revertDataPattern matches hex encoded `Error(string)` revert data in error
+// messages.
+var revertDataPattern = regexp.MustCompile(`0x08c379a0[0-9a-fA-F]*`)
+
+// outOfGasMessages are fragments of error messages returned by Ethereum
+// clients when a transaction runs out of gas or is sent with too low gas
+// limit.
+var outOfGasMessages = []string{
+	"out of gas",
+	"gas required exceeds allowance",
+	"intrinsic gas too low",
+}
+
+// revertReasonFailures maps revert reasons of the operator contract to
+// submission failures submitters can react on. Only DKG result submission
+// has an eligibility check; relay entry can be submitted by anyone as soon
+// as it is requested.
+//
+// Once a DKG result is accepted, the contract cleans up tickets of the group
+// selection, so another submission of the result fails on selecting group
+// members. If a new group selection completed in the meantime, the submitter
+// is no longer a member at its index.
+var revertReasonFailures = map[string]relaychain.SubmissionFailure{
+	"Entry was submitted":          relaychain.AlreadySubmitted,
+	"Entry timed out":              relaychain.TimedOut,
+	"Submitter not eligible":       relaychain.NotEligibleYet,
+	"Not enough tickets submitted": relaychain.AlreadySubmitted,
+	"Unexpected submitter index":   relaychain.AlreadySubmitted,
+	"Ticket submission is over":    relaychain.TimedOut,
+	"Duplicate ticket":             relaychain.AlreadySubmitted,
+}
+
+// decodeRevertReason decodes the reason from `Error(string)` revert data. It
+// returns false if the data does not hold a revert reason.
+func decodeRevertReason(data []byte) (string, bool) {
+	if len(data) < 4+64 || !bytes.Equal(data[:4], revertReasonSelector) {
+		return "", false
+	}
+	encoded := data[4:]
+
+	offset := new(big.Int).SetBytes(encoded[:32])
+	if !offset.IsUint64() || offset.Uint64()+32 > uint64(len(encoded)) {
+		return "", false
+	}
+	start := offset.Uint64() + 32
+
+	length := new(big.Int).SetBytes(encoded[offset.Uint64():start])
+	if !length.IsUint64() || start+length.Uint64() > uint64(len(encoded)) {
+		return "", false
+	}
+
+	return string(encoded[start : start+length.Uint64()]), true
+}
+
+// revertReason extracts the revert reason from the given error returned by a
+// contract call or transaction submission. It returns false if the error
+// does not hold a revert reason.
+func revertReason(err error) (string, bool) {
+	message := err.Error()
+
+	if data := revertDataPattern.FindString(message); data != "" {
+		decoded, decodeErr := hex.DecodeString(data[2:])
+		if decodeErr == nil {
+			if reason, ok := decodeRevertReason(decoded); ok {
+				return reason, true
+			}
+		}
+	}
+
+	for _, pattern := range revertReasonPatterns {
+		if match := pattern.FindStringSubmatch(message); match != nil {
+			return strings.TrimSpace(match[1]), true
+		}
+	}
+
+	return "", false
+}
+
+// newSubmissionError classifies the given error returned by a contract call or
+// transaction submission.
+func newSubmissionError(err error) *relaychain.SubmissionError {
+	if submissionError, ok := err.(*relaychain.SubmissionError); ok {
+		return submissionError
+	}
+
+	if reason, ok := revertReason(err); ok {
+		failure, known := revertReasonFailures[reason]
+		if !known {
+			failure = relaychain.Reverted
+		}
+
+		return &relaychain.SubmissionError{
+			Failure:      failure,
+			RevertReason: reason,
+			Cause:        err,
+		}
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, outOfGasMessage := range outOfGasMessages {
+		if strings.Contains(message, outOfGasMessage) {
+			return &relaychain.SubmissionError{
+				Failure: relaychain.OutOfGas,
+				Cause:   err,
+			}
+		}
+	}
+
+	return &relaychain.SubmissionError{
+		Failure: relaychain.UnknownFailure,
+		Cause:   err,
+	}
+}
@@ -0,0 +1,155 @@
+package ethereum
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+)
+
+// Revert data of `Error("Entry was submitted")`.
+const entrySubmittedRevertData = "0x08c379a0" +
+	"0000000000000000000000000000000000000000000000000000000000000020" +
+	"0000000000000000000000000000000000000000000000000000000000000013" +
+	"456e74727920776173207375626d697474656400000000000000000000000000"
+
+func TestDecodeRevertReason(t *testing.T) {
+	data, err := hex.DecodeString(entrySubmittedRevertData[2:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reason, ok := decodeRevertReason(data)
+	if !ok {
+		t.Fatal("revert reason should be decoded")
+	}
+	if reason != "Entry was submitted" {
+		t.Errorf(
+			"unexpected revert reason\nexpected: [%v]\nactual:   [%v]",
+			"Entry was submitted",
+			reason,
+		)
+	}
+
+	if _, ok := decodeRevertReason(data[:40]); ok {
+		t.Errorf("truncated revert data should not be decoded")
+	}
+	if _, ok := decodeRevertReason(append([]byte{0x01}, data[1:]...)); ok {
+		t.Errorf("data with unexpected selector should not be decoded")
+	}
+}
+
+func TestSubmissionErrorClassification(t *testing.T) {
+	var tests = map[string]struct {
+		err             error
+		expectedFailure relaychain.SubmissionFailure
+		expectedReason  string
+	}{
+		"reason resolved by the error resolver": {
+			err: fmt.Errorf(
+				"contract failed with: [[Entry was submitted]] " +
+					"(original error [abi: improperly formatted output])",
+			),
+			expectedFailure: relaychain.AlreadySubmitted,
+			expectedReason:  "Entry was submitted",
+		},
+		"reason returned by the client": {
+			err: fmt.Errorf(
+				"got error [execution reverted: Entry timed out] while " +
+					"resolving original error [gas required exceeds allowance]",
+			),
+			expectedFailure: relaychain.TimedOut,
+			expectedReason:  "Entry timed out",
+		},
+		"reason returned by ganache": {
+			err: fmt.Errorf(
+				"VM Exception while processing transaction: " +
+					"revert Submitter not eligible",
+			),
+			expectedFailure: relaychain.NotEligibleYet,
+			expectedReason:  "Submitter not eligible",
+		},
+		"entry already submitted": {
+			err:             fmt.Errorf("execution reverted: Entry was submitted"),
+			expectedFailure: relaychain.AlreadySubmitted,
+			expectedReason:  "Entry was submitted",
+		},
+		"entry timed out": {
+			err:             fmt.Errorf("execution reverted: Entry timed out"),
+			expectedFailure: relaychain.TimedOut,
+			expectedReason:  "Entry timed out",
+		},
+		"DKG result submitter not eligible": {
+			err:             fmt.Errorf("execution reverted: Submitter not eligible"),
+			expectedFailure: relaychain.NotEligibleYet,
+			expectedReason:  "Submitter not eligible",
+		},
+		"DKG result already submitted": {
+			err:             fmt.Errorf("execution reverted: Not enough tickets submitted"),
+			expectedFailure: relaychain.AlreadySubmitted,
+			expectedReason:  "Not enough tickets submitted",
+		},
+		"DKG result submitted before next group selection": {
+			err:             fmt.Errorf("execution reverted: Unexpected submitter index"),
+			expectedFailure: relaychain.AlreadySubmitted,
+			expectedReason:  "Unexpected submitter index",
+		},
+		"ticket submission is over": {
+			err:             fmt.Errorf("execution reverted: Ticket submission is over"),
+			expectedFailure: relaychain.TimedOut,
+			expectedReason:  "Ticket submission is over",
+		},
+		"duplicate ticket": {
+			err:             fmt.Errorf("execution reverted: Duplicate ticket"),
+			expectedFailure: relaychain.AlreadySubmitted,
+			expectedReason:  "Duplicate ticket",
+		},
+		"encoded revert data": {
+			err: fmt.Errorf(
+				"call reverted with data [%v]",
+				entrySubmittedRevertData,
+			),
+			expectedFailure: relaychain.AlreadySubmitted,
+			expectedReason:  "Entry was submitted",
+		},
+		"unrecognized reason": {
+			err:             fmt.Errorf("execution reverted: Invalid signature"),
+			expectedFailure: relaychain.Reverted,
+			expectedReason:  "Invalid signature",
+		},
+		"out of gas": {
+			err:             fmt.Errorf("intrinsic gas too low"),
+			expectedFailure: relaychain.OutOfGas,
+		},
+		"unknown error": {
+			err:             fmt.Errorf("connection refused"),
+			expectedFailure: relaychain.UnknownFailure,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			submissionError := newSubmissionError(test.err)
+
+			if submissionError.Failure != test.expectedFailure {
+				t.Errorf(
+					"unexpected failure\nexpected: [%v]\nactual:   [%v]",
+					test.expectedFailure,
+					submissionError.Failure,
+				)
+			}
+			if submissionError.RevertReason != test.expectedReason {
+				t.Errorf(
+					"unexpected revert reason\nexpected: [%v]\nactual:   [%v]",
+					test.expectedReason,
+					submissionError.RevertReason,
+				)
+			}
+			if relaychain.SubmissionFailureOf(submissionError) !=
+				test.expectedFailure {
+				t.Errorf("failure should be recognized from the error")
+			}
+		})
+	}
+}
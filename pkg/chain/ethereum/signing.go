@@ -22,7 +22,25 @@ type ethereumSigning struct {
 }
 
 func (ec *ethereumChain) Signing() chain.Signing {
-	return &ethereumSigning{ec.accountKey.PrivateKey}
+	if ec.externalSigner != nil {
+		return &externalSigning{ec.externalSigner}
+	}
+
+	return NewSigning(ec.accountKey.PrivateKey)
+}
+
+// NewSigning creates a Signing implementation producing signatures the same
+// way the Ethereum chain expects them, using the provided operator key.
+func NewSigning(operatorKey *ecdsa.PrivateKey) chain.Signing {
+	return &ethereumSigning{operatorKey}
+}
+
+// NewVerifyingSigning creates a Signing implementation verifying signatures
+// the same way the Ethereum chain does, for the operator with the provided
+// public key. It can not sign messages as the operator private key is not
+// available.
+func NewVerifyingSigning(operatorPublicKey *ecdsa.PublicKey) chain.Signing {
+	return &ethereumSigning{&ecdsa.PrivateKey{PublicKey: *operatorPublicKey}}
 }
 
 func (es *ethereumSigning) PublicKey() []byte {
@@ -31,10 +49,11 @@ func (es *ethereumSigning) PublicKey() []byte {
 }
 
 func (es *ethereumSigning) Sign(message []byte) ([]byte, error) {
-	prefixedHash := crypto.Keccak256(
-		[]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%v", len(message))),
-		message,
-	)
+	if es.operatorKey.D == nil {
+		return nil, fmt.Errorf("no operator private key to sign with")
+	}
+
+	prefixedHash := prefixedMessageHash(message)
 
 	signature, err := crypto.Sign(prefixedHash, es.operatorKey)
 	if err != nil {
@@ -95,10 +114,7 @@ func verifySignature(
 		)
 	}
 
-	prefixedHash := crypto.Keccak256(
-		[]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%v", len(message))),
-		message,
-	)
+	prefixedHash := prefixedMessageHash(message)
 
 	return crypto.VerifySignature(
 		uncompressedPubKey,
@@ -107,6 +123,15 @@ func verifySignature(
 	), nil
 }
 
+// prefixedMessageHash returns the hash of the given message prefixed the same
+// way as messages signed with the Ethereum `eth_sign` method.
+func prefixedMessageHash(message []byte) []byte {
+	return crypto.Keccak256(
+		[]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%v", len(message))),
+		message,
+	)
+}
+
 func unmarshalPublicKey(
 	bytes []byte,
 	curve elliptic.Curve,
@@ -155,6 +155,39 @@ func TestSignAndVerifyWithProvidedPublicKey(t *testing.T) {
 	}
 }
 
+func TestVerifyingSigning(t *testing.T) {
+	signing, err := newSigning()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := []byte("Whoever is careless with the truth in small matters " +
+		"cannot be trusted with important matters.")
+
+	signature, err := signing.Sign(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifyingSigning := NewVerifyingSigning(&signing.operatorKey.PublicKey)
+
+	ok, err := verifyingSigning.VerifyWithPublicKey(
+		message,
+		signature,
+		signing.PublicKey(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Errorf("expected valid signature but verification failed")
+	}
+
+	if _, err := verifyingSigning.Sign(message); err == nil {
+		t.Errorf("expected error when signing without the private key")
+	}
+}
+
 func newSigning() (*ethereumSigning, error) {
 	key, err := crypto.GenerateKey()
 	if err != nil {
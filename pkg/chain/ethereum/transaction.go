@@ -0,0 +1,383 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+// receiptCheckInterval is the interval in which receipts of submitted protocol
+// transactions are checked.
+var receiptCheckInterval = time.Second
+
+// TransactionStatus holds counters of protocol transactions, that is relay
+// entry, ticket and DKG result submissions.
+type TransactionStatus struct {
+	// Submitted is the number of submitted protocol transactions, not
+	// counting replacements.
+	Submitted uint64
+	// Replaced is the number of times a pending protocol transaction has
+	// been replaced with a transaction having a higher gas price.
+	Replaced uint64
+	// Mined is the number of protocol transactions mined.
+	Mined uint64
+	// DeadlineExceeded is the number of protocol transactions not mined
+	// before their protocol deadline.
+	DeadlineExceeded uint64
+	// Pending is the number of protocol transactions currently monitored.
+	Pending uint64
+}
+
+// TransactionStatusOf returns protocol transaction counters summed over all
+// the provided handles connected to Ethereum. Other handles are skipped.
+func TransactionStatusOf(handles []chain.Handle) TransactionStatus {
+	var total TransactionStatus
+
+	for _, handle := range handles {
+		ethereumHandle, ok := handle.(*ethereumChain)
+		if !ok {
+			continue
+		}
+
+		status := ethereumHandle.transactionManager.status()
+		total.Submitted += status.Submitted
+		total.Replaced += status.Replaced
+		total.Mined += status.Mined
+		total.DeadlineExceeded += status.DeadlineExceeded
+		total.Pending += status.Pending
+	}
+
+	return total
+}
+
+// transactionBackend is the part of the Ethereum client used by the
+// transaction manager.
+type transactionBackend interface {
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	TransactionReceipt(
+		ctx context.Context,
+		txHash common.Hash,
+	) (*types.Receipt, error)
+}
+
+// transactFn sends a transaction with the given options.
+type transactFn func(options *bind.TransactOpts) (*types.Transaction, error)
+
+// signedTransact sends a transaction with the provided function. If sending
+// fails, the transaction signed with the options signer is returned along
+// with the error, so that a transaction already known by the Ethereum client
+// can be tracked.
+func signedTransact(
+	transact transactFn,
+	options *bind.TransactOpts,
+) (*types.Transaction, error) {
+	if options.Signer == nil {
+		return transact(options)
+	}
+
+	var signed *types.Transaction
+	signer := options.Signer
+	options.Signer = func(
+		signerType types.Signer,
+		address common.Address,
+		transaction *types.Transaction,
+	) (*types.Transaction, error) {
+		transaction, err := signer(signerType, address, transaction)
+		signed = transaction
+		return transaction, err
+	}
+
+	transaction, err := transact(options)
+	if err != nil {
+		return signed, err
+	}
+
+	return transaction, nil
+}
+
+// transactionManager submits protocol transactions and makes sure they get
+// mined before the protocol deadline. If a transaction is not mined within
+// the replacement interval, it is replaced by a transaction with the same
+// nonce and a gas price increased by 20%. The gas price is never increased
+// above the gas price ceiling of the operator contract, since gas above the
+// ceiling is not reimbursed, nor above the configured maximum gas price.
+// Replacements stop when the transaction is mined or the deadline block is
+// reached.
+type transactionManager struct {
+	backend             transactionBackend
+	blockCounter        chain.BlockCounter
+	transactorOptions   *bind.TransactOpts
+	nonceManager        *nonceManager
+	replacementInterval time.Duration
+	maxGasPrice         *big.Int
+	gasPriceCeiling     func() (*big.Int, error)
+
+	// minedHandler, if set, is called with the version of the protocol
+	// transaction that got mined and its receipt.
+	minedHandler func(
+		name string,
+		transaction *types.Transaction,
+		receipt *types.Receipt,
+	)
+
+	statusMutex       sync.Mutex
+	transactionStatus TransactionStatus
+}
+
+// submit sends a protocol transaction with the provided function and monitors
+// it in the background until it is mined or the given deadline block is
+// reached. If the gas limit is zero, it is estimated.
+func (tm *transactionManager) submit(
+	name string,
+	gasLimit uint64,
+	deadline uint64,
+	transact transactFn,
+) (*types.Transaction, error) {
+	gasPrice, err := tm.initialGasPrice()
+	if err != nil {
+		return nil, fmt.Errorf("could not determine gas price: [%v]", err)
+	}
+
+	transaction, err := tm.nonceManager.transact(
+		func(nonce uint64) (*types.Transaction, error) {
+			return signedTransact(
+				transact,
+				tm.options(nonce, gasPrice, gasLimit),
+			)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof(
+		"submitted [%v] transaction [%v] with nonce [%v] and gas price "+
+			"[%v]; deadline is block [%v]",
+		name,
+		transaction.Hash().TerminalString(),
+		transaction.Nonce(),
+		transaction.GasPrice(),
+		deadline,
+	)
+
+	tm.updateStatus(func(status *TransactionStatus) {
+		status.Submitted++
+		status.Pending++
+	})
+
+	go tm.monitor(name, deadline, transaction, transact)
+
+	return transaction, nil
+}
+
+func (tm *transactionManager) options(
+	nonce uint64,
+	gasPrice *big.Int,
+	gasLimit uint64,
+) *bind.TransactOpts {
+	options := new(bind.TransactOpts)
+	*options = *tm.transactorOptions
+
+	options.Nonce = new(big.Int).SetUint64(nonce)
+	options.GasPrice = gasPrice
+	options.GasLimit = gasLimit
+
+	return options
+}
+
+// monitor waits until one of the submitted versions of the transaction is
+// mined and replaces the transaction with a higher gas price one each time
+// the replacement interval passes without the transaction being mined.
+func (tm *transactionManager) monitor(
+	name string,
+	deadline uint64,
+	transaction *types.Transaction,
+	transact transactFn,
+) {
+	defer tm.updateStatus(func(status *TransactionStatus) {
+		status.Pending--
+	})
+
+	// A replaced transaction may still get mined instead of its replacement
+	// so receipts of all submitted versions are checked.
+	submitted := []*types.Transaction{transaction}
+	lastSubmission := time.Now()
+
+	ticker := time.NewTicker(receiptCheckInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if mined, receipt := tm.findReceipt(submitted); receipt != nil {
+			logger.Infof(
+				"[%v] transaction [%v] mined with status [%v] at block [%v]",
+				name,
+				receipt.TxHash.TerminalString(),
+				receipt.Status,
+				receipt.BlockNumber,
+			)
+			tm.updateStatus(func(status *TransactionStatus) {
+				status.Mined++
+			})
+			if tm.minedHandler != nil {
+				tm.minedHandler(name, mined, receipt)
+			}
+			return
+		}
+
+		currentBlock, err := tm.blockCounter.CurrentBlock()
+		if err != nil {
+			logger.Warningf("could not determine current block: [%v]", err)
+			continue
+		}
+		if currentBlock >= deadline {
+			logger.Errorf(
+				"[%v] transaction [%v] not mined before the deadline "+
+					"block [%v]; stopping replacements",
+				name,
+				transaction.Hash().TerminalString(),
+				deadline,
+			)
+			tm.updateStatus(func(status *TransactionStatus) {
+				status.DeadlineExceeded++
+			})
+			return
+		}
+
+		if time.Since(lastSubmission) < tm.replacementInterval {
+			continue
+		}
+		lastSubmission = time.Now()
+
+		gasPrice, ok := tm.increasedGasPrice(transaction.GasPrice())
+		if !ok {
+			logger.Warningf(
+				"[%v] transaction [%v] not mined yet but gas price [%v] "+
+					"is already at the limit; waiting without replacement",
+				name,
+				transaction.Hash().TerminalString(),
+				transaction.GasPrice(),
+			)
+			continue
+		}
+
+		replacement, err := transact(
+			tm.options(transaction.Nonce(), gasPrice, transaction.Gas()),
+		)
+		if err != nil {
+			// The replacement fails also when one of the already submitted
+			// versions of the transaction has been just mined.
+			logger.Warningf(
+				"could not replace [%v] transaction [%v]: [%v]",
+				name,
+				transaction.Hash().TerminalString(),
+				err,
+			)
+			continue
+		}
+
+		logger.Infof(
+			"replaced [%v] transaction [%v] with transaction [%v] having "+
+				"gas price [%v]",
+			name,
+			transaction.Hash().TerminalString(),
+			replacement.Hash().TerminalString(),
+			gasPrice,
+		)
+		tm.updateStatus(func(status *TransactionStatus) {
+			status.Replaced++
+		})
+
+		transaction = replacement
+		submitted = append(submitted, replacement)
+	}
+}
+
+// findReceipt returns the mined version of the transaction, among the given
+// submitted versions, along with its receipt.
+func (tm *transactionManager) findReceipt(
+	transactions []*types.Transaction,
+) (*types.Transaction, *types.Receipt) {
+	for _, transaction := range transactions {
+		receipt, _ := tm.backend.TransactionReceipt(
+			context.Background(),
+			transaction.Hash(),
+		)
+		if receipt != nil {
+			return transaction, receipt
+		}
+	}
+
+	return nil, nil
+}
+
+// gasPriceLimit returns the maximum gas price protocol transactions can be
+// submitted with.
+func (tm *transactionManager) gasPriceLimit() *big.Int {
+	limit := tm.maxGasPrice
+
+	ceiling, err := tm.gasPriceCeiling()
+	if err != nil {
+		logger.Warningf("could not get gas price ceiling: [%v]", err)
+	} else if ceiling.Cmp(limit) < 0 {
+		limit = ceiling
+	}
+
+	return limit
+}
+
+func (tm *transactionManager) initialGasPrice() (*big.Int, error) {
+	gasPrice, err := tm.backend.SuggestGasPrice(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	if limit := tm.gasPriceLimit(); gasPrice.Cmp(limit) > 0 {
+		return limit, nil
+	}
+
+	return gasPrice, nil
+}
+
+// increasedGasPrice returns the given gas price increased by 20% but not more
+// than the gas price limit. It returns false if the given gas price is
+// already at the limit.
+func (tm *transactionManager) increasedGasPrice(
+	gasPrice *big.Int,
+) (*big.Int, bool) {
+	limit := tm.gasPriceLimit()
+	if gasPrice.Cmp(limit) >= 0 {
+		return nil, false
+	}
+
+	twentyPercent := new(big.Int).Div(gasPrice, big.NewInt(5))
+	if twentyPercent.Sign() == 0 {
+		twentyPercent = big.NewInt(1)
+	}
+	increased := new(big.Int).Add(gasPrice, twentyPercent)
+	if increased.Cmp(limit) > 0 {
+		increased = limit
+	}
+
+	return increased, true
+}
+
+func (tm *transactionManager) updateStatus(update func(*TransactionStatus)) {
+	tm.statusMutex.Lock()
+	defer tm.statusMutex.Unlock()
+
+	update(&tm.transactionStatus)
+}
+
+func (tm *transactionManager) status() TransactionStatus {
+	tm.statusMutex.Lock()
+	defer tm.statusMutex.Unlock()
+
+	return tm.transactionStatus
+}
@@ -0,0 +1,331 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+type testTransactionBackend struct {
+	mutex       sync.Mutex
+	gasPrice    *big.Int
+	minedPrices map[uint64]bool
+	submitted   map[common.Hash]*types.Transaction
+}
+
+func newTestTransactionBackend(gasPrice int64) *testTransactionBackend {
+	return &testTransactionBackend{
+		gasPrice:    big.NewInt(gasPrice),
+		minedPrices: make(map[uint64]bool),
+		submitted:   make(map[common.Hash]*types.Transaction),
+	}
+}
+
+// mineWithGasPrice makes transactions with the given gas price mined.
+func (ttb *testTransactionBackend) mineWithGasPrice(gasPrice uint64) {
+	ttb.mutex.Lock()
+	defer ttb.mutex.Unlock()
+
+	ttb.minedPrices[gasPrice] = true
+}
+
+func (ttb *testTransactionBackend) SuggestGasPrice(
+	ctx context.Context,
+) (*big.Int, error) {
+	return ttb.gasPrice, nil
+}
+
+func (ttb *testTransactionBackend) TransactionReceipt(
+	ctx context.Context,
+	txHash common.Hash,
+) (*types.Receipt, error) {
+	ttb.mutex.Lock()
+	defer ttb.mutex.Unlock()
+
+	transaction, ok := ttb.submitted[txHash]
+	if !ok || !ttb.minedPrices[transaction.GasPrice().Uint64()] {
+		return nil, ethereum.NotFound
+	}
+
+	return &types.Receipt{TxHash: txHash, BlockNumber: big.NewInt(1)}, nil
+}
+
+func (ttb *testTransactionBackend) PendingCodeAt(
+	ctx context.Context,
+	account common.Address,
+) ([]byte, error) {
+	return nil, nil
+}
+
+func (ttb *testTransactionBackend) PendingNonceAt(
+	ctx context.Context,
+	account common.Address,
+) (uint64, error) {
+	return 7, nil
+}
+
+func (ttb *testTransactionBackend) EstimateGas(
+	ctx context.Context,
+	call ethereum.CallMsg,
+) (uint64, error) {
+	return 0, nil
+}
+
+func (ttb *testTransactionBackend) SendTransaction(
+	ctx context.Context,
+	tx *types.Transaction,
+) error {
+	return nil
+}
+
+// transact creates a transaction with the given options.
+func (ttb *testTransactionBackend) transact(
+	options *bind.TransactOpts,
+) (*types.Transaction, error) {
+	ttb.mutex.Lock()
+	defer ttb.mutex.Unlock()
+
+	transaction := types.NewTransaction(
+		options.Nonce.Uint64(),
+		common.Address{},
+		big.NewInt(0),
+		options.GasLimit,
+		options.GasPrice,
+		nil,
+	)
+	ttb.submitted[transaction.Hash()] = transaction
+
+	return transaction, nil
+}
+
+func (ttb *testTransactionBackend) submittedGasPrices() map[uint64]uint64 {
+	ttb.mutex.Lock()
+	defer ttb.mutex.Unlock()
+
+	gasPrices := make(map[uint64]uint64)
+	for _, transaction := range ttb.submitted {
+		gasPrices[transaction.GasPrice().Uint64()] = transaction.Nonce()
+	}
+	return gasPrices
+}
+
+type testCurrentBlockCounter struct {
+	chain.BlockCounter
+
+	mutex           sync.Mutex
+	currentBlock    uint64
+	currentBlockErr error
+}
+
+func (tcbc *testCurrentBlockCounter) CurrentBlock() (uint64, error) {
+	tcbc.mutex.Lock()
+	defer tcbc.mutex.Unlock()
+
+	if tcbc.currentBlockErr != nil {
+		return 0, tcbc.currentBlockErr
+	}
+
+	return tcbc.currentBlock, nil
+}
+
+func (tcbc *testCurrentBlockCounter) setCurrentBlock(block uint64) {
+	tcbc.mutex.Lock()
+	defer tcbc.mutex.Unlock()
+
+	tcbc.currentBlock = block
+	tcbc.currentBlockErr = nil
+}
+
+func (tcbc *testCurrentBlockCounter) setCurrentBlockErr(err error) {
+	tcbc.mutex.Lock()
+	defer tcbc.mutex.Unlock()
+
+	tcbc.currentBlockErr = err
+}
+
+func newTestTransactionManager(
+	backend *testTransactionBackend,
+	blockCounter chain.BlockCounter,
+	gasPriceCeiling int64,
+) *transactionManager {
+	receiptCheckInterval = 5 * time.Millisecond
+
+	return &transactionManager{
+		backend:             backend,
+		blockCounter:        blockCounter,
+		transactorOptions:   &bind.TransactOpts{},
+		nonceManager:        newNonceManager(common.Address{}, backend),
+		replacementInterval: 10 * time.Millisecond,
+		maxGasPrice:         big.NewInt(1000),
+		gasPriceCeiling: func() (*big.Int, error) {
+			return big.NewInt(gasPriceCeiling), nil
+		},
+	}
+}
+
+func waitForTransactions(
+	t *testing.T,
+	manager *transactionManager,
+) TransactionStatus {
+	for i := 0; i < 200; i++ {
+		status := manager.status()
+		if status.Pending == 0 {
+			return status
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	t.Fatal("transaction still monitored")
+	return TransactionStatus{}
+}
+
+func TestReplaceTransactionUpToGasPriceCeiling(t *testing.T) {
+	backend := newTestTransactionBackend(100)
+	manager := newTestTransactionManager(
+		backend,
+		&testCurrentBlockCounter{currentBlock: 1},
+		150,
+	)
+
+	_, err := manager.submit("test", 21000, 100, backend.transact)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Wait for the gas price to reach the ceiling and some more intervals
+	// to make sure it is not increased above it.
+	time.Sleep(100 * time.Millisecond)
+	backend.mineWithGasPrice(150)
+
+	status := waitForTransactions(t, manager)
+
+	expectedGasPrices := map[uint64]uint64{100: 7, 120: 7, 144: 7, 150: 7}
+	gasPrices := backend.submittedGasPrices()
+	if len(gasPrices) != len(expectedGasPrices) {
+		t.Errorf(
+			"unexpected transactions\nexpected: [%v]\nactual:   [%v]",
+			expectedGasPrices,
+			gasPrices,
+		)
+	}
+	for gasPrice, nonce := range expectedGasPrices {
+		if actualNonce, ok := gasPrices[gasPrice]; !ok || actualNonce != nonce {
+			t.Errorf(
+				"expected transaction with gas price [%v] and nonce [%v]",
+				gasPrice,
+				nonce,
+			)
+		}
+	}
+
+	expectedStatus := TransactionStatus{Submitted: 1, Replaced: 3, Mined: 1}
+	if status != expectedStatus {
+		t.Errorf(
+			"unexpected status\nexpected: [%+v]\nactual:   [%+v]",
+			expectedStatus,
+			status,
+		)
+	}
+}
+
+func TestReplacedTransactionMined(t *testing.T) {
+	backend := newTestTransactionBackend(100)
+	manager := newTestTransactionManager(
+		backend,
+		&testCurrentBlockCounter{currentBlock: 1},
+		1000,
+	)
+
+	_, err := manager.submit("test", 21000, 100, backend.transact)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The original transaction gets mined after it has been replaced.
+	time.Sleep(30 * time.Millisecond)
+	backend.mineWithGasPrice(100)
+
+	status := waitForTransactions(t, manager)
+
+	if status.Replaced == 0 {
+		t.Errorf("transaction should be replaced")
+	}
+	if status.Mined != 1 {
+		t.Errorf("replaced transaction should be recognized as mined")
+	}
+}
+
+func TestStopReplacingTransactionAfterDeadline(t *testing.T) {
+	backend := newTestTransactionBackend(100)
+	blockCounter := &testCurrentBlockCounter{currentBlock: 1}
+	manager := newTestTransactionManager(backend, blockCounter, 1000)
+
+	_, err := manager.submit("test", 21000, 10, backend.transact)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	blockCounter.setCurrentBlock(10)
+
+	status := waitForTransactions(t, manager)
+
+	if status.DeadlineExceeded != 1 || status.Mined != 0 {
+		t.Errorf(
+			"transaction should exceed the deadline\nactual status: [%+v]",
+			status,
+		)
+	}
+
+	replacements := len(backend.submittedGasPrices())
+	time.Sleep(30 * time.Millisecond)
+	if len(backend.submittedGasPrices()) != replacements {
+		t.Errorf("transaction should not be replaced after the deadline")
+	}
+}
+
+func TestSignedTransactReturnsTransactionRejectedByClient(t *testing.T) {
+	signed := testTransaction(7)
+	options := &bind.TransactOpts{
+		Signer: func(
+			signer types.Signer,
+			address common.Address,
+			transaction *types.Transaction,
+		) (*types.Transaction, error) {
+			return signed, nil
+		},
+	}
+
+	transaction, err := signedTransact(
+		func(options *bind.TransactOpts) (*types.Transaction, error) {
+			if _, err := options.Signer(
+				types.HomesteadSigner{},
+				options.From,
+				testTransaction(7),
+			); err != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf("already known")
+		},
+		options,
+	)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if transaction != signed {
+		t.Errorf(
+			"unexpected transaction\nexpected: [%v]\nactual:   [%v]",
+			signed.Hash().Hex(),
+			transaction,
+		)
+	}
+}
@@ -31,6 +31,9 @@ var seedGroupPublicKey = []byte("seed to group public key")
 var seedRelayEntry = big.NewInt(123456789)
 var groupActiveTime = uint64(10)
 var relayRequestTimeout = uint64(8)
+var groupMemberRewards = big.NewInt(1000)
+var gasPrice = big.NewInt(20000000000)
+var ticketSubmissionGas = uint64(250000)
 
 // Chain is an extention of chain.Handle interface which exposes
 // additional functions useful for testing.
@@ -47,6 +50,21 @@ type Chain interface {
 	// GetRelayEntryTimeoutReports returns an array of blocks which denote at what
 	// block a relay entry timeout occured.
 	GetRelayEntryTimeoutReports() []uint64
+
+	// GetUnauthorizedSigningReports returns public keys of all groups
+	// reported for unauthorized signing.
+	GetUnauthorizedSigningReports() [][]byte
+
+	// RequestRelayEntry emits a relay entry request for the group with the
+	// given public key, using the given previous entry, at the current block.
+	RequestRelayEntry(previousEntry []byte, groupPublicKey []byte) *event.Request
+
+	// SimulateReorg simulates a chain reorganization replacing the given
+	// number of most recent blocks with blocks having no events. Events
+	// emitted in the replaced blocks are removed, groups registered in them
+	// are unregistered and handlers registered with OnEventRemoved are
+	// notified about each removed event.
+	SimulateReorg(depth uint64) error
 }
 
 type localGroup struct {
@@ -69,6 +87,13 @@ type localChain struct {
 	groupSelectionStartedHandlers map[int]func(groupSelectionStart *event.GroupSelectionStart)
 	groupRegisteredHandlers       map[int]func(groupRegistration *event.GroupRegistration)
 	resultSubmissionHandlers      map[int]func(submission *event.DKGResultSubmission)
+	rewardsWithdrawnHandlers      map[int]func(withdrawal *event.GroupMemberRewardsWithdrawn)
+	eventRemovedHandlers          map[int]func(removal *event.Removed)
+
+	pastRelayEntryRequestedEvents []*event.Request
+	pastRelayEntrySubmittedEvents []*event.EntrySubmitted
+	pastDKGResultSubmittedEvents  []*event.DKGResultSubmission
+	pastGroupRegisteredEvents     []*event.GroupRegistration
 
 	simulatedHeight uint64
 	stakeMonitor    chain.StakeMonitor
@@ -80,6 +105,13 @@ type localChain struct {
 	relayEntryTimeoutReportsMutex sync.Mutex
 	relayEntryTimeoutReports      []uint64
 
+	unauthorizedSigningReportsMutex sync.Mutex
+	unauthorizedSigningReports      [][]byte
+
+	withdrawnRewardsMutex sync.Mutex
+	// key is group public key and operator address, both in hexadecimal form
+	withdrawnRewards map[string]bool
+
 	operatorKey *ecdsa.PrivateKey
 }
 
@@ -126,6 +158,12 @@ func (c *localChain) SubmitTicket(ticket *relaychain.Ticket) *async.EventGroupTi
 	return promise
 }
 
+func (c *localChain) SubmitTicketGasEstimate(
+	ticket *relaychain.Ticket,
+) (uint64, error) {
+	return ticketSubmissionGas, nil
+}
+
 func (c *localChain) GetSubmittedTickets() ([]uint64, error) {
 	tickets := make([]uint64, len(c.tickets))
 
@@ -174,10 +212,15 @@ func (c *localChain) SubmitRelayEntry(newEntry []byte) *async.EventEntrySubmitte
 	}
 
 	entry := &event.EntrySubmitted{
+		Entry:       newEntry,
 		BlockNumber: currentBlock,
 	}
 
 	c.handlerMutex.Lock()
+	c.pastRelayEntrySubmittedEvents = append(
+		c.pastRelayEntrySubmittedEvents,
+		entry,
+	)
 	for _, handler := range c.relayEntryHandlers {
 		go func(handler func(entry *event.EntrySubmitted), entry *event.EntrySubmitted) {
 			handler(entry)
@@ -230,6 +273,33 @@ func (c *localChain) OnRelayEntryRequested(
 	}), nil
 }
 
+func (c *localChain) RequestRelayEntry(
+	previousEntry []byte,
+	groupPublicKey []byte,
+) *event.Request {
+	currentBlock, _ := c.blockCounter.CurrentBlock()
+
+	request := &event.Request{
+		PreviousEntry:  previousEntry,
+		GroupPublicKey: groupPublicKey,
+		BlockNumber:    currentBlock,
+	}
+
+	c.handlerMutex.Lock()
+	c.pastRelayEntryRequestedEvents = append(
+		c.pastRelayEntryRequestedEvents,
+		request,
+	)
+	for _, handler := range c.relayRequestHandlers {
+		go func(handler func(*event.Request), request *event.Request) {
+			handler(request)
+		}(handler, request)
+	}
+	c.handlerMutex.Unlock()
+
+	return request
+}
+
 func (c *localChain) OnGroupSelectionStarted(
 	handler func(entry *event.GroupSelectionStart),
 ) (subscription.EventSubscription, error) {
@@ -311,15 +381,19 @@ func ConnectWithKey(
 			MinimumStake:               minimumStake,
 			RelayEntryTimeout:          resultPublicationBlockStep * uint64(groupSize),
 		},
-		relayEntryHandlers:       make(map[int]func(request *event.EntrySubmitted)),
-		relayRequestHandlers:     make(map[int]func(request *event.Request)),
-		groupRegisteredHandlers:  make(map[int]func(groupRegistration *event.GroupRegistration)),
-		resultSubmissionHandlers: make(map[int]func(submission *event.DKGResultSubmission)),
-		blockCounter:             bc,
-		stakeMonitor:             NewStakeMonitor(minimumStake),
-		tickets:                  make([]*relaychain.Ticket, 0),
-		groups:                   []localGroup{group},
-		operatorKey:              operatorKey,
+		relayEntryHandlers:            make(map[int]func(request *event.EntrySubmitted)),
+		relayRequestHandlers:          make(map[int]func(request *event.Request)),
+		groupSelectionStartedHandlers: make(map[int]func(groupSelectionStart *event.GroupSelectionStart)),
+		groupRegisteredHandlers:       make(map[int]func(groupRegistration *event.GroupRegistration)),
+		resultSubmissionHandlers:      make(map[int]func(submission *event.DKGResultSubmission)),
+		rewardsWithdrawnHandlers:      make(map[int]func(withdrawal *event.GroupMemberRewardsWithdrawn)),
+		eventRemovedHandlers:          make(map[int]func(removal *event.Removed)),
+		withdrawnRewards:              make(map[string]bool),
+		blockCounter:                  bc,
+		stakeMonitor:                  NewStakeMonitor(minimumStake),
+		tickets:                       make([]*relaychain.Ticket, 0),
+		groups:                        []localGroup{group},
+		operatorKey:                   operatorKey,
 	}
 }
 
@@ -412,6 +486,14 @@ func (c *localChain) SubmitDKGResult(
 	}
 
 	c.handlerMutex.Lock()
+	c.pastDKGResultSubmittedEvents = append(
+		c.pastDKGResultSubmittedEvents,
+		dkgResultPublicationEvent,
+	)
+	c.pastGroupRegisteredEvents = append(
+		c.pastGroupRegisteredEvents,
+		groupRegistrationEvent,
+	)
 	for _, handler := range c.resultSubmissionHandlers {
 		go func(handler func(*event.DKGResultSubmission), dkgResultPublication *event.DKGResultSubmission) {
 			handler(dkgResultPublicationEvent)
@@ -470,6 +552,35 @@ func (c *localChain) ReportRelayEntryTimeout() error {
 	return nil
 }
 
+func (c *localChain) ReportUnauthorizedSigning(
+	groupPublicKey []byte,
+	signedOperatorAddress []byte,
+) error {
+	isRegistered, err := c.IsGroupRegistered(groupPublicKey)
+	if err != nil {
+		return err
+	}
+	if !isRegistered {
+		return fmt.Errorf("group does not exist")
+	}
+
+	c.unauthorizedSigningReportsMutex.Lock()
+	defer c.unauthorizedSigningReportsMutex.Unlock()
+
+	c.unauthorizedSigningReports = append(
+		c.unauthorizedSigningReports,
+		groupPublicKey,
+	)
+	return nil
+}
+
+func (c *localChain) GetUnauthorizedSigningReports() [][]byte {
+	c.unauthorizedSigningReportsMutex.Lock()
+	defer c.unauthorizedSigningReportsMutex.Unlock()
+
+	return c.unauthorizedSigningReports
+}
+
 func (c *localChain) IsEntryInProgress() (bool, error) {
 	panic("not implemented")
 }
@@ -501,3 +612,300 @@ func (c *localChain) CalculateDKGResultHash(
 
 	return dkgResultHash, nil
 }
+
+func (c *localChain) GetGroupMemberRewards(groupPublicKey []byte) (*big.Int, error) {
+	return groupMemberRewards, nil
+}
+
+func (c *localChain) HasWithdrawnRewards(
+	operator relaychain.StakerAddress,
+	groupPublicKey []byte,
+) (bool, error) {
+	c.withdrawnRewardsMutex.Lock()
+	defer c.withdrawnRewardsMutex.Unlock()
+
+	return c.withdrawnRewards[withdrawalKey(operator, groupPublicKey)], nil
+}
+
+func (c *localChain) WithdrawGroupMemberRewards(
+	operator relaychain.StakerAddress,
+	groupPublicKey []byte,
+) *async.EventGroupMemberRewardsWithdrawnPromise {
+	withdrawalPromise := &async.EventGroupMemberRewardsWithdrawnPromise{}
+
+	isStale, err := c.IsStaleGroup(groupPublicKey)
+	if err != nil {
+		withdrawalPromise.Fail(err)
+		return withdrawalPromise
+	}
+	if !isStale {
+		withdrawalPromise.Fail(fmt.Errorf("group must be expired and stale"))
+		return withdrawalPromise
+	}
+
+	groupIndex := -1
+	for index, group := range c.groups {
+		if bytes.Compare(group.groupPublicKey, groupPublicKey) == 0 {
+			groupIndex = index
+		}
+	}
+	if groupIndex < 0 {
+		withdrawalPromise.Fail(fmt.Errorf("group does not exist"))
+		return withdrawalPromise
+	}
+
+	c.withdrawnRewardsMutex.Lock()
+	key := withdrawalKey(operator, groupPublicKey)
+	if c.withdrawnRewards[key] {
+		c.withdrawnRewardsMutex.Unlock()
+		withdrawalPromise.Fail(fmt.Errorf("rewards already withdrawn"))
+		return withdrawalPromise
+	}
+	c.withdrawnRewards[key] = true
+	c.withdrawnRewardsMutex.Unlock()
+
+	currentBlock, err := c.blockCounter.CurrentBlock()
+	if err != nil {
+		withdrawalPromise.Fail(fmt.Errorf("cannot read current block"))
+		return withdrawalPromise
+	}
+
+	withdrawal := &event.GroupMemberRewardsWithdrawn{
+		Beneficiary: operator,
+		Operator:    operator,
+		Amount:      groupMemberRewards,
+		GroupIndex:  big.NewInt(int64(groupIndex)),
+		BlockNumber: currentBlock,
+	}
+
+	c.handlerMutex.Lock()
+	for _, handler := range c.rewardsWithdrawnHandlers {
+		go func(
+			handler func(*event.GroupMemberRewardsWithdrawn),
+			withdrawal *event.GroupMemberRewardsWithdrawn,
+		) {
+			handler(withdrawal)
+		}(handler, withdrawal)
+	}
+	c.handlerMutex.Unlock()
+
+	err = withdrawalPromise.Fulfill(withdrawal)
+	if err != nil {
+		logger.Errorf("failed to fulfill promise: [%v].", err)
+	}
+
+	return withdrawalPromise
+}
+
+func (c *localChain) OnGroupMemberRewardsWithdrawn(
+	handler func(withdrawal *event.GroupMemberRewardsWithdrawn),
+) (subscription.EventSubscription, error) {
+	c.handlerMutex.Lock()
+	defer c.handlerMutex.Unlock()
+
+	handlerID := rand.Int()
+	c.rewardsWithdrawnHandlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		c.handlerMutex.Lock()
+		defer c.handlerMutex.Unlock()
+
+		delete(c.rewardsWithdrawnHandlers, handlerID)
+	}), nil
+}
+
+func (c *localChain) CurrentGasPrice() (*big.Int, error) {
+	return gasPrice, nil
+}
+
+// PastGroupSelectionStartedEvents returns no events since the local chain
+// never starts group selection.
+func (c *localChain) PastGroupSelectionStartedEvents(
+	startBlock uint64,
+	endBlock uint64,
+) ([]*event.GroupSelectionStart, error) {
+	return make([]*event.GroupSelectionStart, 0), nil
+}
+
+func (c *localChain) PastDKGResultSubmittedEvents(
+	startBlock uint64,
+	endBlock uint64,
+) ([]*event.DKGResultSubmission, error) {
+	c.handlerMutex.Lock()
+	defer c.handlerMutex.Unlock()
+
+	events := make([]*event.DKGResultSubmission, 0)
+	for _, submission := range c.pastDKGResultSubmittedEvents {
+		if isInBlockRange(submission.BlockNumber, startBlock, endBlock) {
+			events = append(events, submission)
+		}
+	}
+
+	return events, nil
+}
+
+func (c *localChain) PastGroupRegisteredEvents(
+	startBlock uint64,
+	endBlock uint64,
+) ([]*event.GroupRegistration, error) {
+	c.handlerMutex.Lock()
+	defer c.handlerMutex.Unlock()
+
+	events := make([]*event.GroupRegistration, 0)
+	for _, registration := range c.pastGroupRegisteredEvents {
+		if isInBlockRange(registration.BlockNumber, startBlock, endBlock) {
+			events = append(events, registration)
+		}
+	}
+
+	return events, nil
+}
+
+func (c *localChain) PastRelayEntryRequestedEvents(
+	startBlock uint64,
+	endBlock uint64,
+) ([]*event.Request, error) {
+	c.handlerMutex.Lock()
+	defer c.handlerMutex.Unlock()
+
+	events := make([]*event.Request, 0)
+	for _, request := range c.pastRelayEntryRequestedEvents {
+		if isInBlockRange(request.BlockNumber, startBlock, endBlock) {
+			events = append(events, request)
+		}
+	}
+
+	return events, nil
+}
+
+func (c *localChain) PastRelayEntrySubmittedEvents(
+	startBlock uint64,
+	endBlock uint64,
+) ([]*event.EntrySubmitted, error) {
+	c.handlerMutex.Lock()
+	defer c.handlerMutex.Unlock()
+
+	events := make([]*event.EntrySubmitted, 0)
+	for _, entry := range c.pastRelayEntrySubmittedEvents {
+		if isInBlockRange(entry.BlockNumber, startBlock, endBlock) {
+			events = append(events, entry)
+		}
+	}
+
+	return events, nil
+}
+
+func (c *localChain) OnEventRemoved(
+	handler func(removal *event.Removed),
+) (subscription.EventSubscription, error) {
+	c.handlerMutex.Lock()
+	defer c.handlerMutex.Unlock()
+
+	handlerID := rand.Int()
+	c.eventRemovedHandlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		c.handlerMutex.Lock()
+		defer c.handlerMutex.Unlock()
+
+		delete(c.eventRemovedHandlers, handlerID)
+	}), nil
+}
+
+func (c *localChain) SimulateReorg(depth uint64) error {
+	currentBlock, err := c.blockCounter.CurrentBlock()
+	if err != nil {
+		return fmt.Errorf("could not determine current block: [%v]", err)
+	}
+
+	isRemoved := func(blockNumber uint64) bool {
+		return blockNumber+depth > currentBlock
+	}
+
+	c.handlerMutex.Lock()
+	defer c.handlerMutex.Unlock()
+
+	removed := make([]*event.Removed, 0)
+	remove := func(removedEvent interface{}, blockNumber uint64) {
+		removed = append(removed, &event.Removed{
+			Event:       removedEvent,
+			BlockNumber: blockNumber,
+		})
+	}
+
+	requests := make([]*event.Request, 0)
+	for _, request := range c.pastRelayEntryRequestedEvents {
+		if isRemoved(request.BlockNumber) {
+			remove(request, request.BlockNumber)
+		} else {
+			requests = append(requests, request)
+		}
+	}
+	c.pastRelayEntryRequestedEvents = requests
+
+	entries := make([]*event.EntrySubmitted, 0)
+	for _, entry := range c.pastRelayEntrySubmittedEvents {
+		if isRemoved(entry.BlockNumber) {
+			remove(entry, entry.BlockNumber)
+		} else {
+			entries = append(entries, entry)
+		}
+	}
+	c.pastRelayEntrySubmittedEvents = entries
+
+	submissions := make([]*event.DKGResultSubmission, 0)
+	for _, submission := range c.pastDKGResultSubmittedEvents {
+		if isRemoved(submission.BlockNumber) {
+			remove(submission, submission.BlockNumber)
+		} else {
+			submissions = append(submissions, submission)
+		}
+	}
+	c.pastDKGResultSubmittedEvents = submissions
+
+	registrations := make([]*event.GroupRegistration, 0)
+	for _, registration := range c.pastGroupRegisteredEvents {
+		if isRemoved(registration.BlockNumber) {
+			remove(registration, registration.BlockNumber)
+		} else {
+			registrations = append(registrations, registration)
+		}
+	}
+	c.pastGroupRegisteredEvents = registrations
+
+	groups := make([]localGroup, 0)
+	for _, group := range c.groups {
+		// The seed group is registered at genesis and is never removed.
+		if bytes.Equal(group.groupPublicKey, seedGroupPublicKey) ||
+			!isRemoved(group.registrationBlockHeight) {
+			groups = append(groups, group)
+		}
+	}
+	c.groups = groups
+
+	logger.Infof(
+		"simulated chain reorganization of [%v] blocks at block [%v] "+
+			"removed [%v] events",
+		depth,
+		currentBlock,
+		len(removed),
+	)
+
+	for _, removal := range removed {
+		for _, handler := range c.eventRemovedHandlers {
+			go func(handler func(*event.Removed), removal *event.Removed) {
+				handler(removal)
+			}(handler, removal)
+		}
+	}
+
+	return nil
+}
+
+func isInBlockRange(blockNumber, startBlock, endBlock uint64) bool {
+	return blockNumber >= startBlock && blockNumber <= endBlock
+}
+
+func withdrawalKey(operator relaychain.StakerAddress, groupPublicKey []byte) string {
+	return fmt.Sprintf("%x-%x", groupPublicKey, operator)
+}
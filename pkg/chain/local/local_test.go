@@ -1,6 +1,7 @@
 package local
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math/big"
@@ -369,6 +370,125 @@ func TestLocalOnDKGResultSubmittedUnsubscribed(t *testing.T) {
 	}
 }
 
+func TestLocalPastGroupRegisteredEvents(t *testing.T) {
+	chainHandle := Connect(10, 4, big.NewInt(200))
+	relayChain := chainHandle.ThresholdRelay()
+
+	blockCounter, err := chainHandle.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signatures := map[relaychain.GroupMemberIndex][]byte{
+		1: []byte{101},
+		2: []byte{102},
+		3: []byte{103},
+		4: []byte{104},
+	}
+
+	submitResult := func(groupPublicKey []byte) uint64 {
+		currentBlock, err := blockCounter.CurrentBlock()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		relayChain.SubmitDKGResult(
+			relaychain.GroupMemberIndex(1),
+			&relaychain.DKGResult{GroupPublicKey: groupPublicKey},
+			signatures,
+		)
+
+		if err := blockCounter.WaitForBlockHeight(currentBlock + 1); err != nil {
+			t.Fatal(err)
+		}
+
+		return currentBlock
+	}
+
+	firstBlock := submitResult([]byte("1"))
+	secondBlock := submitResult([]byte("2"))
+
+	var tests = map[string]struct {
+		startBlock             uint64
+		endBlock               uint64
+		expectedGroupPublicKey [][]byte
+	}{
+		"all events": {
+			startBlock:             firstBlock,
+			endBlock:               secondBlock,
+			expectedGroupPublicKey: [][]byte{[]byte("1"), []byte("2")},
+		},
+		"only the second event": {
+			startBlock:             secondBlock,
+			endBlock:               secondBlock + 10,
+			expectedGroupPublicKey: [][]byte{[]byte("2")},
+		},
+		"no events": {
+			startBlock:             secondBlock + 1,
+			endBlock:               secondBlock + 10,
+			expectedGroupPublicKey: [][]byte{},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			registrations, err := relayChain.PastGroupRegisteredEvents(
+				test.startBlock,
+				test.endBlock,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			submissions, err := relayChain.PastDKGResultSubmittedEvents(
+				test.startBlock,
+				test.endBlock,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(registrations) != len(test.expectedGroupPublicKey) {
+				t.Fatalf(
+					"unexpected number of group registrations\n"+
+						"expected: [%v]\nactual:   [%v]",
+					len(test.expectedGroupPublicKey),
+					len(registrations),
+				)
+			}
+			if len(submissions) != len(test.expectedGroupPublicKey) {
+				t.Fatalf(
+					"unexpected number of DKG result submissions\n"+
+						"expected: [%v]\nactual:   [%v]",
+					len(test.expectedGroupPublicKey),
+					len(submissions),
+				)
+			}
+
+			for i, groupPublicKey := range test.expectedGroupPublicKey {
+				if !bytes.Equal(registrations[i].GroupPublicKey, groupPublicKey) {
+					t.Errorf(
+						"unexpected group registration [%v]\n"+
+							"expected: [%s]\nactual:   [%s]",
+						i,
+						groupPublicKey,
+						registrations[i].GroupPublicKey,
+					)
+				}
+				if !bytes.Equal(submissions[i].GroupPublicKey, groupPublicKey) {
+					t.Errorf(
+						"unexpected DKG result submission [%v]\n"+
+							"expected: [%s]\nactual:   [%s]",
+						i,
+						groupPublicKey,
+						submissions[i].GroupPublicKey,
+					)
+				}
+			}
+		})
+	}
+}
+
 func TestWatchBlocks(t *testing.T) {
 	c := Connect(10, 4, big.NewInt(100))
 	blockCounter, err := c.BlockCounter()
@@ -590,6 +710,118 @@ func TestLocalIsGroupStale(t *testing.T) {
 	}
 }
 
+func TestLocalWithdrawGroupMemberRewards(t *testing.T) {
+	ctx, cancel := newTestContext(15 * time.Second)
+	defer cancel()
+
+	operator := relaychain.StakerAddress([]byte{0x01})
+	staleGroup := localGroup{
+		groupPublicKey:          []byte{'s'},
+		registrationBlockHeight: 0,
+	}
+
+	chain := Connect(10, 4, big.NewInt(200)).(*localChain)
+	chain.groups = []localGroup{staleGroup}
+	chain.simulatedHeight = staleGroup.registrationBlockHeight +
+		groupActiveTime +
+		relayRequestTimeout +
+		1
+	chainHandle := chain.ThresholdRelay()
+
+	withdrawals := make(chan *event.GroupMemberRewardsWithdrawn)
+	subscription, err := chainHandle.OnGroupMemberRewardsWithdrawn(
+		func(withdrawal *event.GroupMemberRewardsWithdrawn) {
+			withdrawals <- withdrawal
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer subscription.Unsubscribe()
+
+	chainHandle.WithdrawGroupMemberRewards(
+		operator,
+		staleGroup.groupPublicKey,
+	)
+
+	select {
+	case withdrawal := <-withdrawals:
+		if !reflect.DeepEqual(withdrawal.Operator, []byte(operator)) {
+			t.Errorf(
+				"unexpected operator\nexpected: [%x]\nactual:   [%x]",
+				operator,
+				withdrawal.Operator,
+			)
+		}
+		if withdrawal.Amount.Cmp(groupMemberRewards) != 0 {
+			t.Errorf(
+				"unexpected amount\nexpected: [%v]\nactual:   [%v]",
+				groupMemberRewards,
+				withdrawal.Amount,
+			)
+		}
+		if withdrawal.GroupIndex.Cmp(big.NewInt(0)) != 0 {
+			t.Errorf(
+				"unexpected group index\nexpected: [0]\nactual:   [%v]",
+				withdrawal.GroupIndex,
+			)
+		}
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+
+	hasWithdrawn, err := chainHandle.HasWithdrawnRewards(
+		operator,
+		staleGroup.groupPublicKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasWithdrawn {
+		t.Errorf("rewards should be marked as withdrawn")
+	}
+}
+
+func TestLocalWithdrawGroupMemberRewardsFromActiveGroup(t *testing.T) {
+	operator := relaychain.StakerAddress([]byte{0x01})
+	activeGroup := localGroup{
+		groupPublicKey:          []byte{'a'},
+		registrationBlockHeight: 1,
+	}
+
+	chain := Connect(10, 4, big.NewInt(200)).(*localChain)
+	chain.groups = []localGroup{activeGroup}
+	chain.simulatedHeight = 1
+	chainHandle := chain.ThresholdRelay()
+
+	promise := chainHandle.WithdrawGroupMemberRewards(
+		operator,
+		activeGroup.groupPublicKey,
+	)
+
+	failed := make(chan error, 1)
+	promise.OnFailure(func(err error) {
+		failed <- err
+	})
+
+	select {
+	case <-failed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("withdrawal from an active group should fail")
+	}
+
+	hasWithdrawn, err := chainHandle.HasWithdrawnRewards(
+		operator,
+		activeGroup.groupPublicKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hasWithdrawn {
+		t.Errorf("rewards should not be marked as withdrawn")
+	}
+}
+
 func TestLocalSubmitDKGResult(t *testing.T) {
 	localChain := Connect(10, 4, big.NewInt(200))
 
@@ -790,3 +1022,85 @@ func TestNextGroupIndex(t *testing.T) {
 		})
 	}
 }
+
+func TestLocalSimulateReorg(t *testing.T) {
+	localChain := Connect(10, 4, big.NewInt(200))
+	chainHandle := localChain.ThresholdRelay()
+
+	removals := make(chan *event.Removed, 10)
+	subscription, err := chainHandle.OnEventRemoved(
+		func(removal *event.Removed) {
+			removals <- removal
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer subscription.Unsubscribe()
+
+	result := &relaychain.DKGResult{
+		GroupPublicKey: []byte{11},
+	}
+	signatures := map[relaychain.GroupMemberIndex][]byte{
+		1: []byte{101},
+		2: []byte{102},
+		3: []byte{103},
+		4: []byte{104},
+	}
+	chainHandle.SubmitDKGResult(1, result, signatures)
+	request := localChain.RequestRelayEntry([]byte{1}, result.GroupPublicKey)
+
+	if err := localChain.SimulateReorg(5); err != nil {
+		t.Fatal(err)
+	}
+
+	groupRegistered, err := chainHandle.IsGroupRegistered(result.GroupPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if groupRegistered {
+		t.Errorf("group registered in removed block should be unregistered")
+	}
+
+	seedGroupRegistered, err := chainHandle.IsGroupRegistered(seedGroupPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !seedGroupRegistered {
+		t.Errorf("seed group should stay registered")
+	}
+
+	pastRequests, err := chainHandle.PastRelayEntryRequestedEvents(
+		0,
+		request.BlockNumber,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pastRequests) != 0 {
+		t.Errorf("removed relay entry request should not be returned")
+	}
+
+	removedTypes := make(map[string]bool)
+	for i := 0; i < 3; i++ {
+		select {
+		case removal := <-removals:
+			removedTypes[fmt.Sprintf("%T", removal.Event)] = true
+		case <-time.After(time.Second):
+			t.Fatal("removal notification not received")
+		}
+	}
+
+	expectedTypes := map[string]bool{
+		"*event.Request":             true,
+		"*event.DKGResultSubmission": true,
+		"*event.GroupRegistration":   true,
+	}
+	if !reflect.DeepEqual(expectedTypes, removedTypes) {
+		t.Errorf(
+			"unexpected removed events\nexpected: [%v]\nactual:   [%v]",
+			expectedTypes,
+			removedTypes,
+		)
+	}
+}
@@ -0,0 +1,118 @@
+package localchain
+
+import (
+	"context"
+	"sync"
+)
+
+// blockCounter tracks blocks of the local chain as they are seen by the
+// client polling the server.
+type blockCounter struct {
+	structMutex sync.Mutex
+	blockHeight uint64
+	waiters     map[uint64][]chan uint64
+	watchers    []*watcher
+}
+
+type watcher struct {
+	ctx     context.Context
+	channel chan uint64
+}
+
+func newBlockCounter(blockHeight uint64) *blockCounter {
+	return &blockCounter{
+		blockHeight: blockHeight,
+		waiters:     make(map[uint64][]chan uint64),
+	}
+}
+
+func (bc *blockCounter) WaitForBlockHeight(blockNumber uint64) error {
+	waiter, err := bc.BlockHeightWaiter(blockNumber)
+	if err != nil {
+		return err
+	}
+	<-waiter
+	return nil
+}
+
+func (bc *blockCounter) BlockHeightWaiter(
+	blockNumber uint64,
+) (<-chan uint64, error) {
+	newWaiter := make(chan uint64)
+
+	bc.structMutex.Lock()
+	defer bc.structMutex.Unlock()
+
+	if blockNumber <= bc.blockHeight {
+		go func() { newWaiter <- blockNumber }()
+	} else {
+		bc.waiters[blockNumber] = append(bc.waiters[blockNumber], newWaiter)
+	}
+
+	return newWaiter, nil
+}
+
+func (bc *blockCounter) CurrentBlock() (uint64, error) {
+	bc.structMutex.Lock()
+	defer bc.structMutex.Unlock()
+
+	return bc.blockHeight, nil
+}
+
+func (bc *blockCounter) WatchBlocks(ctx context.Context) <-chan uint64 {
+	watcher := &watcher{
+		ctx:     ctx,
+		channel: make(chan uint64, 1),
+	}
+
+	bc.structMutex.Lock()
+	bc.watchers = append(bc.watchers, watcher)
+	bc.structMutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		bc.structMutex.Lock()
+		for i, w := range bc.watchers {
+			if w == watcher {
+				bc.watchers[i] = bc.watchers[len(bc.watchers)-1]
+				bc.watchers = bc.watchers[:len(bc.watchers)-1]
+				break
+			}
+		}
+		bc.structMutex.Unlock()
+
+		close(watcher.channel)
+	}()
+
+	return watcher.channel
+}
+
+// update advances the block height to the given block, notifying waiters and
+// watchers of all blocks in between. Blocks lower than the current height are
+// ignored.
+func (bc *blockCounter) update(blockHeight uint64) {
+	bc.structMutex.Lock()
+	defer bc.structMutex.Unlock()
+
+	for bc.blockHeight < blockHeight {
+		bc.blockHeight++
+		height := bc.blockHeight
+
+		for _, waiter := range bc.waiters[height] {
+			go func(w chan uint64) { w <- height }(waiter)
+		}
+		delete(bc.waiters, height)
+
+		for _, watcher := range bc.watchers {
+			if watcher.ctx.Err() != nil {
+				continue
+			}
+
+			select {
+			case watcher.channel <- height: // perfect
+			default: // we don't care, let's drop it
+			}
+		}
+	}
+}
@@ -0,0 +1,851 @@
+package localchain
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"math/rand"
+	"net/rpc"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	relayconfig "github.com/keep-network/keep-core/pkg/beacon/relay/config"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/gen/async"
+	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/keep-network/keep-core/pkg/subscription"
+)
+
+// pollInterval is the time between subsequent requests for new events and
+// blocks sent by the client to the server.
+const pollInterval = 250 * time.Millisecond
+
+// connection is a connection to the local chain server shared by all
+// operators hosted by the client.
+type connection struct {
+	address string
+
+	clientMutex sync.Mutex
+	client      *rpc.Client
+
+	parameters   *Parameters
+	blockCounter *blockCounter
+
+	handlerMutex sync.Mutex
+	handlers     map[EventType]map[int]func(*Event)
+	lastSequence uint64
+}
+
+// Connect connects to the local chain server on behalf of the operator with
+// the given key.
+func Connect(config Config, operatorKey *keystore.Key) (chain.Handle, error) {
+	handles, err := ConnectOperators(config, []*keystore.Key{operatorKey})
+	if err != nil {
+		return nil, err
+	}
+
+	return handles[0], nil
+}
+
+// ConnectOperators connects to the local chain server on behalf of all the
+// operators with the given keys. All returned handles share the same
+// connection. Operators seen by the server for the first time are granted
+// the initial stake.
+func ConnectOperators(
+	config Config,
+	operatorKeys []*keystore.Key,
+) ([]chain.Handle, error) {
+	c := &connection{
+		address:  config.Address,
+		handlers: make(map[EventType]map[int]func(*Event)),
+	}
+
+	status := &StatusReply{}
+	if err := c.call("Status", &OperatorArgs{}, status); err != nil {
+		return nil, fmt.Errorf(
+			"could not connect to local chain server [%v]: [%v]",
+			config.Address,
+			err,
+		)
+	}
+
+	parameters := &Parameters{}
+	if err := c.call("GetConfig", &OperatorArgs{}, parameters); err != nil {
+		return nil, fmt.Errorf("could not get chain parameters: [%v]", err)
+	}
+
+	c.parameters = parameters
+	c.blockCounter = newBlockCounter(status.CurrentBlock)
+	c.lastSequence = status.LastSequence
+
+	handles := make([]chain.Handle, len(operatorKeys))
+	for i, operatorKey := range operatorKeys {
+		address := operatorKey.Address.Bytes()
+
+		stake := &StakeReply{}
+		err := c.call("RegisterOperator", &OperatorArgs{address}, stake)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not register operator [%v]: [%v]",
+				operatorKey.Address.Hex(),
+				err,
+			)
+		}
+
+		logger.Infof(
+			"connected operator [%v] with stake [%v] to local chain at [%v]",
+			operatorKey.Address.Hex(),
+			stake.Stake,
+			config.Address,
+		)
+
+		handles[i] = &localChainClient{
+			connection:  c,
+			operatorKey: operatorKey,
+			address:     address,
+		}
+	}
+
+	go c.pollEvents()
+
+	return handles, nil
+}
+
+// RequestRelayEntry requests a new relay entry from the local chain server
+// with the given address and returns the block at which the entry has been
+// requested.
+func RequestRelayEntry(config Config) (uint64, error) {
+	c := &connection{address: config.Address}
+	defer c.close()
+
+	reply := &BlockReply{}
+	if err := c.call("RequestRelayEntry", &OperatorArgs{}, reply); err != nil {
+		return 0, err
+	}
+
+	return reply.BlockNumber, nil
+}
+
+func (c *connection) rpcClient() (*rpc.Client, error) {
+	c.clientMutex.Lock()
+	defer c.clientMutex.Unlock()
+
+	if c.client == nil {
+		client, err := rpc.Dial("tcp", c.address)
+		if err != nil {
+			return nil, err
+		}
+		c.client = client
+	}
+
+	return c.client, nil
+}
+
+// call calls the remote procedure of the server. If the connection to the
+// server has been lost, it is dialed again on the next call.
+func (c *connection) call(
+	procedure string,
+	args interface{},
+	reply interface{},
+) error {
+	client, err := c.rpcClient()
+	if err != nil {
+		return err
+	}
+
+	err = client.Call(serviceName+"."+procedure, args, reply)
+	if err == rpc.ErrShutdown || err == io.EOF || err == io.ErrUnexpectedEOF {
+		c.clientMutex.Lock()
+		if c.client == client {
+			c.client.Close()
+			c.client = nil
+		}
+		c.clientMutex.Unlock()
+	}
+
+	return err
+}
+
+func (c *connection) close() {
+	c.clientMutex.Lock()
+	defer c.clientMutex.Unlock()
+
+	if c.client != nil {
+		c.client.Close()
+		c.client = nil
+	}
+}
+
+// pollEvents polls the server for new events and blocks, passes events to
+// their handlers and advances the block counter.
+func (c *connection) pollEvents() {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		reply := &EventsReply{}
+		err := c.call("Events", &EventsArgs{c.lastSequence}, reply)
+		if err != nil {
+			logger.Warningf("could not poll local chain events: [%v]", err)
+			continue
+		}
+
+		for _, event := range reply.Events {
+			c.dispatch(event)
+			c.lastSequence = event.Sequence
+		}
+
+		c.blockCounter.update(reply.CurrentBlock)
+	}
+}
+
+func (c *connection) dispatch(event *Event) {
+	c.handlerMutex.Lock()
+	defer c.handlerMutex.Unlock()
+
+	for _, handler := range c.handlers[event.Type] {
+		go handler(event)
+	}
+}
+
+func (c *connection) subscribe(
+	eventType EventType,
+	handler func(*Event),
+) subscription.EventSubscription {
+	c.handlerMutex.Lock()
+	defer c.handlerMutex.Unlock()
+
+	handlers, ok := c.handlers[eventType]
+	if !ok {
+		handlers = make(map[int]func(*Event))
+		c.handlers[eventType] = handlers
+	}
+
+	handlerID := rand.Int()
+	handlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		c.handlerMutex.Lock()
+		defer c.handlerMutex.Unlock()
+
+		delete(handlers, handlerID)
+	})
+}
+
+func (c *connection) pastEvents(
+	eventType EventType,
+	startBlock uint64,
+	endBlock uint64,
+) ([]*Event, error) {
+	reply := &EventsReply{}
+	err := c.call(
+		"PastEvents",
+		&PastEventsArgs{eventType, startBlock, endBlock},
+		reply,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return reply.Events, nil
+}
+
+// localChainClient implements the chain interfaces for a single operator
+// connected to the local chain server.
+type localChainClient struct {
+	connection  *connection
+	operatorKey *keystore.Key
+	address     []byte
+}
+
+func (lcc *localChainClient) BlockCounter() (chain.BlockCounter, error) {
+	return lcc.connection.blockCounter, nil
+}
+
+func (lcc *localChainClient) StakeMonitor() (chain.StakeMonitor, error) {
+	return &stakeMonitor{lcc.connection}, nil
+}
+
+func (lcc *localChainClient) ThresholdRelay() relaychain.Interface {
+	return lcc
+}
+
+func (lcc *localChainClient) Signing() chain.Signing {
+	return ethereum.NewSigning(lcc.operatorKey.PrivateKey)
+}
+
+func (lcc *localChainClient) GetConfig() (*relayconfig.Chain, error) {
+	return lcc.connection.parameters.relayConfig(), nil
+}
+
+func (lcc *localChainClient) GetKeys() (*operator.PrivateKey, *operator.PublicKey) {
+	return operator.EthereumKeyToOperatorKey(lcc.operatorKey)
+}
+
+func (lcc *localChainClient) OnGroupSelectionStarted(
+	handler func(groupSelectionStarted *event.GroupSelectionStart),
+) (subscription.EventSubscription, error) {
+	return lcc.connection.subscribe(
+		GroupSelectionStartedEvent,
+		func(e *Event) { handler(toGroupSelectionStart(e)) },
+	), nil
+}
+
+func (lcc *localChainClient) SubmitTicket(
+	ticket *relaychain.Ticket,
+) *async.EventGroupTicketSubmissionPromise {
+	promise := &async.EventGroupTicketSubmissionPromise{}
+
+	reply := &BlockReply{}
+	err := lcc.connection.call(
+		"SubmitTicket",
+		&TicketArgs{lcc.address, ticket},
+		reply,
+	)
+	if err != nil {
+		promise.Fail(fmt.Errorf("could not submit ticket: [%v]", err))
+		return promise
+	}
+
+	promise.Fulfill(&event.GroupTicketSubmission{
+		TicketValue: new(big.Int).SetBytes(ticket.Value[:]),
+		BlockNumber: reply.BlockNumber,
+	})
+
+	return promise
+}
+
+// SubmitTicketGasEstimate returns zero since the local chain does not charge
+// for transactions.
+func (lcc *localChainClient) SubmitTicketGasEstimate(
+	ticket *relaychain.Ticket,
+) (uint64, error) {
+	return 0, nil
+}
+
+func (lcc *localChainClient) GetSubmittedTickets() ([]uint64, error) {
+	reply := &TicketsReply{}
+	err := lcc.connection.call(
+		"SubmittedTickets",
+		&OperatorArgs{lcc.address},
+		reply,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return reply.Tickets, nil
+}
+
+func (lcc *localChainClient) GetSelectedParticipants() (
+	[]relaychain.StakerAddress,
+	error,
+) {
+	reply := &ParticipantsReply{}
+	err := lcc.connection.call(
+		"SelectedParticipants",
+		&OperatorArgs{lcc.address},
+		reply,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return toStakerAddresses(reply.Participants), nil
+}
+
+// OnGroupRegistered is invoked on DKG result submission since the group is
+// registered by the chain when the result is accepted.
+func (lcc *localChainClient) OnGroupRegistered(
+	handler func(groupRegistration *event.GroupRegistration),
+) (subscription.EventSubscription, error) {
+	return lcc.connection.subscribe(
+		DKGResultSubmittedEvent,
+		func(e *Event) { handler(toGroupRegistration(e)) },
+	), nil
+}
+
+func (lcc *localChainClient) IsStaleGroup(groupPublicKey []byte) (bool, error) {
+	reply := &BoolReply{}
+	err := lcc.connection.call(
+		"IsStaleGroup",
+		&GroupArgs{lcc.address, groupPublicKey},
+		reply,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return reply.Value, nil
+}
+
+func (lcc *localChainClient) GetGroupMembers(groupPublicKey []byte) (
+	[]relaychain.StakerAddress,
+	error,
+) {
+	reply := &ParticipantsReply{}
+	err := lcc.connection.call(
+		"GroupMembers",
+		&GroupArgs{lcc.address, groupPublicKey},
+		reply,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return toStakerAddresses(reply.Participants), nil
+}
+
+func (lcc *localChainClient) ReportUnauthorizedSigning(
+	groupPublicKey []byte,
+	signedOperatorAddress []byte,
+) error {
+	return lcc.connection.call(
+		"ReportUnauthorizedSigning",
+		&UnauthorizedSigningArgs{
+			Operator:              lcc.address,
+			GroupPublicKey:        groupPublicKey,
+			SignedOperatorAddress: signedOperatorAddress,
+		},
+		&BlockReply{},
+	)
+}
+
+func (lcc *localChainClient) SubmitRelayEntry(
+	entry []byte,
+) *async.EventEntrySubmittedPromise {
+	promise := &async.EventEntrySubmittedPromise{}
+
+	reply := &EventReply{}
+	err := lcc.connection.call(
+		"SubmitRelayEntry",
+		&EntryArgs{lcc.address, entry},
+		reply,
+	)
+	if err != nil {
+		promise.Fail(submissionError(
+			fmt.Errorf("could not submit relay entry: [%v]", err),
+		))
+		return promise
+	}
+
+	promise.Fulfill(toEntrySubmitted(reply.Event))
+
+	return promise
+}
+
+func (lcc *localChainClient) OnRelayEntrySubmitted(
+	handler func(entry *event.EntrySubmitted),
+) (subscription.EventSubscription, error) {
+	return lcc.connection.subscribe(
+		RelayEntrySubmittedEvent,
+		func(e *Event) { handler(toEntrySubmitted(e)) },
+	), nil
+}
+
+func (lcc *localChainClient) OnRelayEntryRequested(
+	handler func(request *event.Request),
+) (subscription.EventSubscription, error) {
+	return lcc.connection.subscribe(
+		RelayEntryRequestedEvent,
+		func(e *Event) { handler(toRequest(e)) },
+	), nil
+}
+
+func (lcc *localChainClient) ReportRelayEntryTimeout() error {
+	return lcc.connection.call(
+		"ReportRelayEntryTimeout",
+		&OperatorArgs{lcc.address},
+		&BlockReply{},
+	)
+}
+
+func (lcc *localChainClient) currentRequest() (*CurrentRequestReply, error) {
+	reply := &CurrentRequestReply{}
+	err := lcc.connection.call(
+		"CurrentRequest",
+		&OperatorArgs{lcc.address},
+		reply,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+func (lcc *localChainClient) IsEntryInProgress() (bool, error) {
+	request, err := lcc.currentRequest()
+	if err != nil {
+		return false, err
+	}
+
+	return request.InProgress, nil
+}
+
+func (lcc *localChainClient) CurrentRequestStartBlock() (*big.Int, error) {
+	request, err := lcc.currentRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetUint64(request.StartBlock), nil
+}
+
+func (lcc *localChainClient) CurrentRequestPreviousEntry() ([]byte, error) {
+	request, err := lcc.currentRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	return request.PreviousEntry, nil
+}
+
+func (lcc *localChainClient) CurrentRequestGroupPublicKey() ([]byte, error) {
+	request, err := lcc.currentRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	return request.GroupPublicKey, nil
+}
+
+func (lcc *localChainClient) SubmitDKGResult(
+	participantIndex relaychain.GroupMemberIndex,
+	dkgResult *relaychain.DKGResult,
+	signatures map[relaychain.GroupMemberIndex][]byte,
+) *async.EventDKGResultSubmissionPromise {
+	promise := &async.EventDKGResultSubmissionPromise{}
+
+	reply := &EventReply{}
+	err := lcc.connection.call(
+		"SubmitDKGResult",
+		&DKGResultArgs{
+			Operator:         lcc.address,
+			ParticipantIndex: participantIndex,
+			Result:           dkgResult,
+			Signatures:       signatures,
+		},
+		reply,
+	)
+	if err != nil {
+		submissionErr := submissionError(
+			fmt.Errorf("could not submit DKG result: [%v]", err),
+		)
+		// Once the result is accepted, the group selection is over so the
+		// result submitted again is rejected for that reason.
+		registered, registeredErr := lcc.IsGroupRegistered(
+			dkgResult.GroupPublicKey,
+		)
+		if registeredErr == nil && registered {
+			submissionErr.Failure = relaychain.AlreadySubmitted
+		}
+		promise.Fail(submissionErr)
+		return promise
+	}
+
+	promise.Fulfill(toDKGResultSubmission(reply.Event))
+
+	return promise
+}
+
+// submissionError reports the given error of a relay entry or DKG result
+// submission as a typed submission error based on the rejection reason
+// returned by the server.
+func submissionError(err error) *relaychain.SubmissionError {
+	failures := map[string]relaychain.SubmissionFailure{
+		entryNotInProgressReason:    relaychain.AlreadySubmitted,
+		entryTimedOutReason:         relaychain.TimedOut,
+		groupRegisteredReason:       relaychain.AlreadySubmitted,
+		resultSubmissionEndedReason: relaychain.TimedOut,
+		submitterNotEligibleReason:  relaychain.NotEligibleYet,
+	}
+
+	for reason, failure := range failures {
+		if strings.Contains(err.Error(), reason) {
+			return &relaychain.SubmissionError{
+				Failure:      failure,
+				RevertReason: reason,
+				Cause:        err,
+			}
+		}
+	}
+
+	return &relaychain.SubmissionError{
+		Failure: relaychain.UnknownFailure,
+		Cause:   err,
+	}
+}
+
+func (lcc *localChainClient) OnDKGResultSubmitted(
+	handler func(event *event.DKGResultSubmission),
+) (subscription.EventSubscription, error) {
+	return lcc.connection.subscribe(
+		DKGResultSubmittedEvent,
+		func(e *Event) { handler(toDKGResultSubmission(e)) },
+	), nil
+}
+
+func (lcc *localChainClient) IsGroupRegistered(groupPublicKey []byte) (bool, error) {
+	reply := &BoolReply{}
+	err := lcc.connection.call(
+		"IsGroupRegistered",
+		&GroupArgs{lcc.address, groupPublicKey},
+		reply,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return reply.Value, nil
+}
+
+// CalculateDKGResultHash calculates the hash of the DKG result the same way
+// the server does when verifying signatures supporting the result.
+func (lcc *localChainClient) CalculateDKGResultHash(
+	dkgResult *relaychain.DKGResult,
+) (relaychain.DKGResultHash, error) {
+	hash := crypto.Keccak256(dkgResult.GroupPublicKey, dkgResult.Misbehaved)
+
+	return relaychain.DKGResultHashFromBytes(hash)
+}
+
+func (lcc *localChainClient) GetGroupMemberRewards(
+	groupPublicKey []byte,
+) (*big.Int, error) {
+	reply := &AmountReply{}
+	err := lcc.connection.call(
+		"GroupMemberRewards",
+		&GroupArgs{lcc.address, groupPublicKey},
+		reply,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return reply.Amount, nil
+}
+
+func (lcc *localChainClient) HasWithdrawnRewards(
+	operator relaychain.StakerAddress,
+	groupPublicKey []byte,
+) (bool, error) {
+	reply := &BoolReply{}
+	err := lcc.connection.call(
+		"HasWithdrawnRewards",
+		&GroupArgs{operator, groupPublicKey},
+		reply,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return reply.Value, nil
+}
+
+func (lcc *localChainClient) WithdrawGroupMemberRewards(
+	operator relaychain.StakerAddress,
+	groupPublicKey []byte,
+) *async.EventGroupMemberRewardsWithdrawnPromise {
+	promise := &async.EventGroupMemberRewardsWithdrawnPromise{}
+
+	reply := &EventReply{}
+	err := lcc.connection.call(
+		"WithdrawGroupMemberRewards",
+		&GroupArgs{operator, groupPublicKey},
+		reply,
+	)
+	if err != nil {
+		promise.Fail(fmt.Errorf("could not withdraw rewards: [%v]", err))
+		return promise
+	}
+
+	promise.Fulfill(toGroupMemberRewardsWithdrawn(reply.Event))
+
+	return promise
+}
+
+func (lcc *localChainClient) OnGroupMemberRewardsWithdrawn(
+	handler func(withdrawal *event.GroupMemberRewardsWithdrawn),
+) (subscription.EventSubscription, error) {
+	return lcc.connection.subscribe(
+		GroupMemberRewardsWithdrawnEvent,
+		func(e *Event) { handler(toGroupMemberRewardsWithdrawn(e)) },
+	), nil
+}
+
+// OnEventRemoved never calls the handler since the local chain server has
+// a single, final chain of blocks and never reorganizes it.
+func (lcc *localChainClient) OnEventRemoved(
+	handler func(removal *event.Removed),
+) (subscription.EventSubscription, error) {
+	return subscription.NewEventSubscription(func() {}), nil
+}
+
+// CurrentGasPrice returns zero since the local chain does not charge for
+// transactions.
+func (lcc *localChainClient) CurrentGasPrice() (*big.Int, error) {
+	return big.NewInt(0), nil
+}
+
+func (lcc *localChainClient) PastGroupSelectionStartedEvents(
+	startBlock uint64,
+	endBlock uint64,
+) ([]*event.GroupSelectionStart, error) {
+	events, err := lcc.connection.pastEvents(
+		GroupSelectionStartedEvent,
+		startBlock,
+		endBlock,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*event.GroupSelectionStart, len(events))
+	for i, e := range events {
+		result[i] = toGroupSelectionStart(e)
+	}
+	return result, nil
+}
+
+func (lcc *localChainClient) PastDKGResultSubmittedEvents(
+	startBlock uint64,
+	endBlock uint64,
+) ([]*event.DKGResultSubmission, error) {
+	events, err := lcc.connection.pastEvents(
+		DKGResultSubmittedEvent,
+		startBlock,
+		endBlock,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*event.DKGResultSubmission, len(events))
+	for i, e := range events {
+		result[i] = toDKGResultSubmission(e)
+	}
+	return result, nil
+}
+
+func (lcc *localChainClient) PastGroupRegisteredEvents(
+	startBlock uint64,
+	endBlock uint64,
+) ([]*event.GroupRegistration, error) {
+	events, err := lcc.connection.pastEvents(
+		DKGResultSubmittedEvent,
+		startBlock,
+		endBlock,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*event.GroupRegistration, len(events))
+	for i, e := range events {
+		result[i] = toGroupRegistration(e)
+	}
+	return result, nil
+}
+
+func (lcc *localChainClient) PastRelayEntryRequestedEvents(
+	startBlock uint64,
+	endBlock uint64,
+) ([]*event.Request, error) {
+	events, err := lcc.connection.pastEvents(
+		RelayEntryRequestedEvent,
+		startBlock,
+		endBlock,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*event.Request, len(events))
+	for i, e := range events {
+		result[i] = toRequest(e)
+	}
+	return result, nil
+}
+
+func (lcc *localChainClient) PastRelayEntrySubmittedEvents(
+	startBlock uint64,
+	endBlock uint64,
+) ([]*event.EntrySubmitted, error) {
+	events, err := lcc.connection.pastEvents(
+		RelayEntrySubmittedEvent,
+		startBlock,
+		endBlock,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*event.EntrySubmitted, len(events))
+	for i, e := range events {
+		result[i] = toEntrySubmitted(e)
+	}
+	return result, nil
+}
+
+func toStakerAddresses(addresses [][]byte) []relaychain.StakerAddress {
+	stakerAddresses := make([]relaychain.StakerAddress, len(addresses))
+	for i, address := range addresses {
+		stakerAddresses[i] = address
+	}
+	return stakerAddresses
+}
+
+func toGroupSelectionStart(e *Event) *event.GroupSelectionStart {
+	return &event.GroupSelectionStart{
+		NewEntry:    e.NewEntry,
+		BlockNumber: e.BlockNumber,
+	}
+}
+
+func toDKGResultSubmission(e *Event) *event.DKGResultSubmission {
+	return &event.DKGResultSubmission{
+		MemberIndex:    e.MemberIndex,
+		GroupPublicKey: e.GroupPublicKey,
+		Misbehaved:     e.Misbehaved,
+		BlockNumber:    e.BlockNumber,
+	}
+}
+
+func toGroupRegistration(e *Event) *event.GroupRegistration {
+	return &event.GroupRegistration{
+		GroupPublicKey: e.GroupPublicKey,
+		BlockNumber:    e.BlockNumber,
+	}
+}
+
+func toRequest(e *Event) *event.Request {
+	return &event.Request{
+		PreviousEntry:  e.PreviousEntry,
+		GroupPublicKey: e.GroupPublicKey,
+		BlockNumber:    e.BlockNumber,
+	}
+}
+
+func toEntrySubmitted(e *Event) *event.EntrySubmitted {
+	return &event.EntrySubmitted{
+		Entry:       e.Entry,
+		BlockNumber: e.BlockNumber,
+	}
+}
+
+func toGroupMemberRewardsWithdrawn(e *Event) *event.GroupMemberRewardsWithdrawn {
+	return &event.GroupMemberRewardsWithdrawn{
+		Beneficiary: e.Operator,
+		Operator:    e.Operator,
+		Amount:      e.Amount,
+		GroupIndex:  e.GroupIndex,
+		BlockNumber: e.BlockNumber,
+	}
+}
@@ -0,0 +1,280 @@
+package localchain
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/gen/async"
+)
+
+func startTestServer(
+	ctx context.Context,
+	t *testing.T,
+	dataDir string,
+) (*Server, string) {
+	server, err := NewServer(
+		ServerConfig{DataDir: dataDir, BlockTime: 20 * time.Millisecond},
+		testParameters(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		if err := server.Serve(ctx, listener); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	return server, listener.Addr().String()
+}
+
+func newTestKeys(t *testing.T, count int) []*keystore.Key {
+	keys := make([]*keystore.Key, count)
+	for i := range keys {
+		privateKey, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[i] = &keystore.Key{
+			Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+			PrivateKey: privateKey,
+		}
+	}
+	return keys
+}
+
+func TestGroupRegistrationOverConnection(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "localchain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	_, address := startTestServer(ctx, t, dataDir)
+
+	keys := newTestKeys(t, 3)
+	handles, err := ConnectOperators(Config{Address: address}, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stakeMonitor, err := handles[0].StakeMonitor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hasMinimumStake, err := stakeMonitor.HasMinimumStake(keys[0].Address.Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasMinimumStake {
+		t.Fatal("connected operator should have the minimum stake")
+	}
+
+	relay := handles[0].ThresholdRelay()
+
+	blockCounter, err := handles[0].BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := blockCounter.WaitForBlockHeight(2); err != nil {
+		t.Fatal(err)
+	}
+
+	selections, err := relay.PastGroupSelectionStartedEvents(0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(selections) != 1 {
+		t.Fatalf("expected one group selection; has [%v]", len(selections))
+	}
+
+	for i, handle := range handles {
+		ticket := newTicket(
+			t,
+			selections[0].NewEntry,
+			keys[i].Address.Bytes(),
+			1,
+		)
+		err := waitForTicket(handle.ThresholdRelay().SubmitTicket(ticket))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tickets, err := relay.GetSubmittedTickets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tickets) != 3 {
+		t.Fatalf("expected three tickets; has [%v]", len(tickets))
+	}
+
+	config, err := relay.GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = blockCounter.WaitForBlockHeight(
+		selections[0].BlockNumber +
+			config.TicketSubmissionTimeout +
+			dkgDurationBlocks,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	participants, err := relay.GetSelectedParticipants()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := &relaychain.DKGResult{
+		GroupPublicKey: new(bn256.G2).ScalarBaseMult(big.NewInt(99)).Marshal(),
+		Misbehaved:     []byte{},
+	}
+	resultHash, err := relay.CalculateDKGResultHash(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var submitter chain.Handle
+	signatures := make(map[relaychain.GroupMemberIndex][]byte)
+	for i, participant := range participants {
+		for j, key := range keys {
+			if string(key.Address.Bytes()) != string(participant) {
+				continue
+			}
+			if i == 0 {
+				submitter = handles[j]
+			}
+			signatures[uint8(i+1)], err = handles[j].Signing().Sign(
+				resultHash[:],
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	registrations := make(chan *event.GroupRegistration, 1)
+	subscription, err := relay.OnGroupRegistered(
+		func(registration *event.GroupRegistration) {
+			registrations <- registration
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer subscription.Unsubscribe()
+
+	promise := submitter.ThresholdRelay().SubmitDKGResult(1, result, signatures)
+	if err := waitForResult(promise); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case registration := <-registrations:
+		if string(registration.GroupPublicKey) != string(result.GroupPublicKey) {
+			t.Errorf("unexpected group public key of registered group")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("group registration event not received")
+	}
+
+	isRegistered, err := relay.IsGroupRegistered(result.GroupPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isRegistered {
+		t.Errorf("group should be registered")
+	}
+
+	promise = submitter.ThresholdRelay().SubmitDKGResult(1, result, signatures)
+	err = waitForResult(promise)
+	if relaychain.SubmissionFailureOf(err) != relaychain.AlreadySubmitted {
+		t.Errorf("unexpected error of submitting the result again: [%v]", err)
+	}
+}
+
+func TestServerRestoresStoredChain(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "localchain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	server, _ := startTestServer(ctx, t, dataDir)
+	time.Sleep(200 * time.Millisecond)
+	cancelCtx()
+
+	var lastBlock uint64
+	server.view(func(state *chainState) error {
+		lastBlock = state.CurrentBlock
+		return nil
+	})
+	if lastBlock == 0 {
+		t.Fatal("expected blocks to be mined")
+	}
+
+	parameters := testParameters()
+	parameters.GroupSize = 10
+	restored, err := NewServer(ServerConfig{DataDir: dataDir}, parameters)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored.view(func(state *chainState) error {
+		if state.CurrentBlock < lastBlock {
+			t.Errorf(
+				"restored chain is behind\nexpected at least: [%v]\nactual: [%v]",
+				lastBlock,
+				state.CurrentBlock,
+			)
+		}
+		if len(state.Events) == 0 {
+			t.Errorf("restored chain has no events")
+		}
+		return nil
+	})
+
+	if restored.Parameters().GroupSize != testParameters().GroupSize {
+		t.Errorf("parameters of a stored chain should not change")
+	}
+}
+
+func waitForTicket(promise *async.EventGroupTicketSubmissionPromise) error {
+	errors := make(chan error, 1)
+	promise.OnComplete(func(_ *event.GroupTicketSubmission, err error) {
+		errors <- err
+	})
+	return <-errors
+}
+
+func waitForResult(promise *async.EventDKGResultSubmissionPromise) error {
+	errors := make(chan error, 1)
+	promise.OnComplete(func(_ *event.DKGResultSubmission, err error) {
+		errors <- err
+	})
+	return <-errors
+}
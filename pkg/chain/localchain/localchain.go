@@ -0,0 +1,27 @@
+// Package localchain contains a local chain server and a client implementing
+// the chain interfaces on top of it. The server keeps the chain state on disk
+// and runs the on-chain part of the random beacon protocol: ticket-based group
+// selection, DKG result acceptance, relay entry requests with timeouts, stake
+// accounting and group member rewards. Several keep-client processes can
+// connect to the same server to form a development network without an
+// Ethereum node.
+//
+// The server trusts the operator addresses declared by the clients and must
+// never be used outside of development networks.
+package localchain
+
+import (
+	"github.com/ipfs/go-log"
+)
+
+var logger = log.Logger("keep-chain-localchain")
+
+// serviceName is the name under which the local chain procedures are
+// registered on the server.
+const serviceName = "LocalChain"
+
+// Config is the configuration of the connection to the local chain server.
+type Config struct {
+	// Address of the local chain server in the host:port form.
+	Address string
+}
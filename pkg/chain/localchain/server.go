@@ -0,0 +1,390 @@
+package localchain
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/rpc"
+	"sync"
+	"time"
+)
+
+// ServerConfig is the configuration of the local chain server.
+type ServerConfig struct {
+	// DataDir is the directory in which the chain state is stored.
+	DataDir string
+	// BlockTime is the time between subsequent blocks.
+	BlockTime time.Duration
+	// RequestInterval is the number of blocks between relay entry requests
+	// made automatically by the server. Relay entries are not requested
+	// automatically if not set.
+	RequestInterval uint64
+}
+
+// Server is the local chain server. It mines blocks, executes procedures
+// called by clients and stores the chain state after every change.
+type Server struct {
+	config ServerConfig
+	store  *store
+
+	mutex sync.Mutex
+	state *chainState
+}
+
+// NewServer creates a server of the chain stored in the data directory. If
+// there is no chain stored yet, a new one with the given parameters is
+// created. Parameters of an existing chain never change; the stored ones are
+// used.
+func NewServer(config ServerConfig, parameters *Parameters) (*Server, error) {
+	store, err := newStore(config.DataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := store.load()
+	if err != nil {
+		return nil, err
+	}
+
+	if state == nil {
+		logger.Infof("creating new local chain in [%v]", config.DataDir)
+
+		state = newChainState(parameters)
+		if err := store.save(state); err != nil {
+			return nil, err
+		}
+	} else {
+		logger.Infof(
+			"loaded local chain from [%v] at block [%v]",
+			config.DataDir,
+			state.CurrentBlock,
+		)
+	}
+
+	return &Server{
+		config: config,
+		store:  store,
+		state:  state,
+	}, nil
+}
+
+// Parameters returns on-chain parameters of the served chain.
+func (s *Server) Parameters() *Parameters {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.state.Parameters
+}
+
+// Serve mines blocks and serves connections accepted by the listener until
+// the context is done.
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	rpcServer := rpc.NewServer()
+	if err := rpcServer.RegisterName(serviceName, &service{s}); err != nil {
+		return fmt.Errorf("could not register local chain service: [%v]", err)
+	}
+
+	go s.mine(ctx)
+
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	for {
+		connection, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("could not accept connection: [%v]", err)
+		}
+
+		go rpcServer.ServeConn(connection)
+	}
+}
+
+func (s *Server) mine(ctx context.Context) {
+	ticker := time.NewTicker(s.config.BlockTime)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			err := s.transact(func(state *chainState) error {
+				state.mineBlock()
+
+				if s.config.RequestInterval != 0 &&
+					state.CurrentBlock%s.config.RequestInterval == 0 &&
+					state.Request == nil {
+					if err := state.requestRelayEntry(); err != nil {
+						logger.Debugf(
+							"could not request relay entry: [%v]",
+							err,
+						)
+					}
+				}
+
+				return nil
+			})
+			if err != nil {
+				logger.Errorf("could not mine block: [%v]", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// transact executes the function changing the chain state and stores the
+// changed state. If the function returns an error, the state is not stored.
+func (s *Server) transact(change func(state *chainState) error) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := change(s.state); err != nil {
+		return err
+	}
+
+	return s.store.save(s.state)
+}
+
+// view executes the function reading the chain state.
+func (s *Server) view(read func(state *chainState) error) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return read(s.state)
+}
+
+// service exposes procedures of the server to the clients. All exported
+// functions of the service are remote procedures.
+type service struct {
+	server *Server
+}
+
+func (cs *service) Status(args *OperatorArgs, reply *StatusReply) error {
+	return cs.server.view(func(state *chainState) error {
+		reply.CurrentBlock = state.CurrentBlock
+		reply.LastSequence = uint64(len(state.Events))
+		return nil
+	})
+}
+
+func (cs *service) GetConfig(args *OperatorArgs, reply *Parameters) error {
+	return cs.server.view(func(state *chainState) error {
+		*reply = *state.Parameters
+		return nil
+	})
+}
+
+func (cs *service) RegisterOperator(args *OperatorArgs, reply *StakeReply) error {
+	return cs.server.transact(func(state *chainState) error {
+		state.registerOperator(args.Operator)
+		reply.Stake = state.stakeOf(args.Operator)
+		return nil
+	})
+}
+
+func (cs *service) Stake(args *OperatorArgs, reply *StakeReply) error {
+	return cs.server.view(func(state *chainState) error {
+		reply.Stake = state.stakeOf(args.Operator)
+		return nil
+	})
+}
+
+func (cs *service) SubmitTicket(args *TicketArgs, reply *BlockReply) error {
+	return cs.server.transact(func(state *chainState) error {
+		if err := state.submitTicket(args.Operator, args.Ticket); err != nil {
+			return err
+		}
+		reply.BlockNumber = state.CurrentBlock
+		return nil
+	})
+}
+
+func (cs *service) SubmittedTickets(args *OperatorArgs, reply *TicketsReply) error {
+	return cs.server.view(func(state *chainState) error {
+		reply.Tickets = state.submittedTickets()
+		return nil
+	})
+}
+
+func (cs *service) SelectedParticipants(
+	args *OperatorArgs,
+	reply *ParticipantsReply,
+) error {
+	return cs.server.view(func(state *chainState) error {
+		participants, err := state.selectedParticipants()
+		if err != nil {
+			return err
+		}
+		reply.Participants = participants
+		return nil
+	})
+}
+
+func (cs *service) SubmitDKGResult(args *DKGResultArgs, reply *EventReply) error {
+	return cs.server.transact(func(state *chainState) error {
+		event, err := state.submitDKGResult(
+			args.Operator,
+			args.ParticipantIndex,
+			args.Result,
+			args.Signatures,
+		)
+		if err != nil {
+			return err
+		}
+		reply.Event = event
+		return nil
+	})
+}
+
+func (cs *service) IsGroupRegistered(args *GroupArgs, reply *BoolReply) error {
+	return cs.server.view(func(state *chainState) error {
+		reply.Value = state.findGroup(args.GroupPublicKey) != nil
+		return nil
+	})
+}
+
+func (cs *service) IsStaleGroup(args *GroupArgs, reply *BoolReply) error {
+	return cs.server.view(func(state *chainState) error {
+		reply.Value = state.isStaleGroup(args.GroupPublicKey)
+		return nil
+	})
+}
+
+func (cs *service) GroupMembers(args *GroupArgs, reply *ParticipantsReply) error {
+	return cs.server.view(func(state *chainState) error {
+		members, err := state.groupMembers(args.GroupPublicKey)
+		if err != nil {
+			return err
+		}
+		reply.Participants = members
+		return nil
+	})
+}
+
+func (cs *service) SubmitRelayEntry(args *EntryArgs, reply *EventReply) error {
+	return cs.server.transact(func(state *chainState) error {
+		event, err := state.submitRelayEntry(args.Entry)
+		if err != nil {
+			return err
+		}
+		reply.Event = event
+		return nil
+	})
+}
+
+func (cs *service) ReportRelayEntryTimeout(
+	args *OperatorArgs,
+	reply *BlockReply,
+) error {
+	return cs.server.transact(func(state *chainState) error {
+		if err := state.reportRelayEntryTimeout(args.Operator); err != nil {
+			return err
+		}
+		reply.BlockNumber = state.CurrentBlock
+		return nil
+	})
+}
+
+func (cs *service) ReportUnauthorizedSigning(
+	args *UnauthorizedSigningArgs,
+	reply *BlockReply,
+) error {
+	return cs.server.transact(func(state *chainState) error {
+		err := state.reportUnauthorizedSigning(
+			args.Operator,
+			args.GroupPublicKey,
+			args.SignedOperatorAddress,
+		)
+		if err != nil {
+			return err
+		}
+		reply.BlockNumber = state.CurrentBlock
+		return nil
+	})
+}
+
+func (cs *service) CurrentRequest(
+	args *OperatorArgs,
+	reply *CurrentRequestReply,
+) error {
+	return cs.server.view(func(state *chainState) error {
+		*reply = *state.currentRequest()
+		return nil
+	})
+}
+
+func (cs *service) GroupMemberRewards(args *GroupArgs, reply *AmountReply) error {
+	return cs.server.view(func(state *chainState) error {
+		amount, err := state.groupMemberRewards(args.GroupPublicKey)
+		if err != nil {
+			return err
+		}
+		reply.Amount = amount
+		return nil
+	})
+}
+
+func (cs *service) HasWithdrawnRewards(args *GroupArgs, reply *BoolReply) error {
+	return cs.server.view(func(state *chainState) error {
+		hasWithdrawn, err := state.hasWithdrawnRewards(
+			args.Operator,
+			args.GroupPublicKey,
+		)
+		if err != nil {
+			return err
+		}
+		reply.Value = hasWithdrawn
+		return nil
+	})
+}
+
+func (cs *service) WithdrawGroupMemberRewards(
+	args *GroupArgs,
+	reply *EventReply,
+) error {
+	return cs.server.transact(func(state *chainState) error {
+		event, err := state.withdrawGroupMemberRewards(
+			args.Operator,
+			args.GroupPublicKey,
+		)
+		if err != nil {
+			return err
+		}
+		reply.Event = event
+		return nil
+	})
+}
+
+func (cs *service) RequestRelayEntry(args *OperatorArgs, reply *BlockReply) error {
+	return cs.server.transact(func(state *chainState) error {
+		if err := state.requestRelayEntry(); err != nil {
+			return err
+		}
+		reply.BlockNumber = state.CurrentBlock
+		return nil
+	})
+}
+
+func (cs *service) Events(args *EventsArgs, reply *EventsReply) error {
+	return cs.server.view(func(state *chainState) error {
+		reply.CurrentBlock = state.CurrentBlock
+		reply.Events = state.eventsAfter(args.AfterSequence)
+		return nil
+	})
+}
+
+func (cs *service) PastEvents(args *PastEventsArgs, reply *EventsReply) error {
+	return cs.server.view(func(state *chainState) error {
+		reply.CurrentBlock = state.CurrentBlock
+		reply.Events = state.pastEvents(
+			args.Type,
+			args.StartBlock,
+			args.EndBlock,
+		)
+		return nil
+	})
+}
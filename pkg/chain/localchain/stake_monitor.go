@@ -0,0 +1,57 @@
+package localchain
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+type stakeMonitor struct {
+	connection *connection
+}
+
+func (sm *stakeMonitor) HasMinimumStake(address string) (bool, error) {
+	staker, err := sm.StakerFor(address)
+	if err != nil {
+		return false, err
+	}
+
+	stake, err := staker.Stake()
+	if err != nil {
+		return false, err
+	}
+
+	return stake.Cmp(sm.connection.parameters.MinimumStake) >= 0, nil
+}
+
+func (sm *stakeMonitor) StakerFor(address string) (chain.Staker, error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("not a valid ethereum address: %v", address)
+	}
+
+	return &staker{
+		address:    common.HexToAddress(address).Bytes(),
+		connection: sm.connection,
+	}, nil
+}
+
+type staker struct {
+	address    []byte
+	connection *connection
+}
+
+func (s *staker) Address() relaychain.StakerAddress {
+	return s.address
+}
+
+func (s *staker) Stake() (*big.Int, error) {
+	reply := &StakeReply{}
+	if err := s.connection.call("Stake", &OperatorArgs{s.address}, reply); err != nil {
+		return nil, err
+	}
+
+	return reply.Stake, nil
+}
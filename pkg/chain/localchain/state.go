@@ -0,0 +1,817 @@
+package localchain
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/audit"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	relayconfig "github.com/keep-network/keep-core/pkg/beacon/relay/config"
+	"github.com/keep-network/keep-core/pkg/bls"
+	"github.com/keep-network/keep-core/pkg/internal/byteutils"
+)
+
+const (
+	// dkgDurationBlocks is the number of blocks the off-chain part of the
+	// distributed key generation takes, before the result can be published.
+	// It is the same as the DKG time of the operator contract and matches
+	// the duration of the GJKR protocol and result signing in the client.
+	dkgDurationBlocks = uint64(5*(1+5) + 2*(1+10) + 20)
+
+	// tattletaleRewardPercent is the percentage of the seized stakes the
+	// reporter of a misbehaving group is rewarded with.
+	tattletaleRewardPercent = 5
+)
+
+// Reasons of rejected relay entry and DKG result submissions. They are
+// recognized by the client to report typed submission errors.
+const (
+	entryNotInProgressReason    = "relay entry is not in progress"
+	entryTimedOutReason         = "relay entry timed out"
+	groupRegisteredReason       = "group has been already registered"
+	resultSubmissionEndedReason = "DKG result submission has ended"
+	submitterNotEligibleReason  = "Submitter not eligible"
+)
+
+// genesisEntry is the relay entry used as the seed of the first group
+// selection and as the previous entry of the first relay request.
+var genesisEntry = new(bn256.G1).ScalarBaseMult(big.NewInt(31415926535)).Marshal()
+
+// Parameters are on-chain parameters of the local chain. They are set when
+// the chain is created and do not change for its whole lifetime.
+type Parameters struct {
+	GroupSize                  int      `json:"groupSize"`
+	HonestThreshold            int      `json:"honestThreshold"`
+	TicketSubmissionTimeout    uint64   `json:"ticketSubmissionTimeout"`
+	ResultPublicationBlockStep uint64   `json:"resultPublicationBlockStep"`
+	RelayEntryTimeout          uint64   `json:"relayEntryTimeout"`
+	GroupActiveTime            uint64   `json:"groupActiveTime"`
+	MinimumStake               *big.Int `json:"minimumStake"`
+	InitialStake               *big.Int `json:"initialStake"`
+	EntryReward                *big.Int `json:"entryReward"`
+}
+
+// DefaultParameters returns parameters suitable for a small development
+// network.
+func DefaultParameters() *Parameters {
+	return &Parameters{
+		GroupSize:                  5,
+		HonestThreshold:            3,
+		TicketSubmissionTimeout:    12,
+		ResultPublicationBlockStep: 3,
+		RelayEntryTimeout:          15,
+		GroupActiveTime:            300,
+		MinimumStake:               big.NewInt(2000000),
+		InitialStake:               big.NewInt(10000000),
+		EntryReward:                big.NewInt(1000),
+	}
+}
+
+func (p *Parameters) relayConfig() *relayconfig.Chain {
+	return &relayconfig.Chain{
+		GroupSize:                  p.GroupSize,
+		HonestThreshold:            p.HonestThreshold,
+		TicketSubmissionTimeout:    p.TicketSubmissionTimeout,
+		ResultPublicationBlockStep: p.ResultPublicationBlockStep,
+		MinimumStake:               p.MinimumStake,
+		RelayEntryTimeout:          p.RelayEntryTimeout,
+	}
+}
+
+func (p *Parameters) dkgResultSubmissionTimeout() uint64 {
+	return dkgDurationBlocks +
+		uint64(p.GroupSize)*p.ResultPublicationBlockStep
+}
+
+type group struct {
+	PublicKey         []byte          `json:"publicKey"`
+	Members           [][]byte        `json:"members"`
+	RegistrationBlock uint64          `json:"registrationBlock"`
+	Terminated        bool            `json:"terminated"`
+	MemberRewards     *big.Int        `json:"memberRewards"`
+	Withdrawn         map[string]bool `json:"withdrawn"`
+}
+
+type relayRequest struct {
+	PreviousEntry []byte `json:"previousEntry"`
+	GroupIndex    int    `json:"groupIndex"`
+	StartBlock    uint64 `json:"startBlock"`
+}
+
+type ticket struct {
+	Value              uint64 `json:"value"`
+	Staker             []byte `json:"staker"`
+	VirtualStakerIndex uint64 `json:"virtualStakerIndex"`
+}
+
+type groupSelection struct {
+	Seed       *big.Int  `json:"seed"`
+	StartBlock uint64    `json:"startBlock"`
+	Tickets    []*ticket `json:"tickets"`
+}
+
+// chainState is the complete state of the local chain. All the functions
+// of the state have to be called with the server lock held. Functions
+// changing the state return an error if the change is rejected; the state is
+// left unchanged in such case, just like a reverted transaction leaves the
+// state of a contract unchanged.
+type chainState struct {
+	Parameters   *Parameters         `json:"parameters"`
+	CurrentBlock uint64              `json:"currentBlock"`
+	Stakes       map[string]*big.Int `json:"stakes"`
+	Balances     map[string]*big.Int `json:"balances"`
+	Groups       []*group            `json:"groups"`
+	LastEntry    []byte              `json:"lastEntry"`
+	Request      *relayRequest       `json:"request,omitempty"`
+	Selection    *groupSelection     `json:"selection,omitempty"`
+	Events       []*Event            `json:"events"`
+}
+
+func newChainState(parameters *Parameters) *chainState {
+	return &chainState{
+		Parameters: parameters,
+		Stakes:     make(map[string]*big.Int),
+		Balances:   make(map[string]*big.Int),
+		Groups:     make([]*group, 0),
+		LastEntry:  genesisEntry,
+		Events:     make([]*Event, 0),
+	}
+}
+
+func (cs *chainState) emit(event *Event) *Event {
+	event.Sequence = uint64(len(cs.Events)) + 1
+	event.BlockNumber = cs.CurrentBlock
+	cs.Events = append(cs.Events, event)
+	return event
+}
+
+// mineBlock advances the chain by one block. When there are no active groups
+// and no group selection is in progress, a new group selection is started so
+// that the network can recover, just like with the genesis.
+func (cs *chainState) mineBlock() {
+	cs.CurrentBlock++
+
+	if cs.Selection != nil && cs.CurrentBlock > cs.dkgResultSubmissionEndBlock() {
+		logger.Warningf(
+			"group selection started at block [%v] expired; "+
+				"no DKG result has been submitted",
+			cs.Selection.StartBlock,
+		)
+		cs.Selection = nil
+	}
+
+	if cs.Selection == nil && len(cs.activeGroupIndexes()) == 0 {
+		cs.startGroupSelection(cs.LastEntry)
+	}
+}
+
+func (cs *chainState) startGroupSelection(entry []byte) {
+	cs.Selection = &groupSelection{
+		Seed:       new(big.Int).SetBytes(crypto.Keccak256(entry)),
+		StartBlock: cs.CurrentBlock,
+		Tickets:    make([]*ticket, 0),
+	}
+
+	cs.emit(&Event{
+		Type:     GroupSelectionStartedEvent,
+		NewEntry: cs.Selection.Seed,
+	})
+}
+
+func (cs *chainState) ticketSubmissionEndBlock() uint64 {
+	return cs.Selection.StartBlock + cs.Parameters.TicketSubmissionTimeout
+}
+
+func (cs *chainState) dkgResultSubmissionEndBlock() uint64 {
+	return cs.ticketSubmissionEndBlock() +
+		cs.Parameters.dkgResultSubmissionTimeout()
+}
+
+// registerOperator grants the initial stake to operators seen for the first
+// time.
+func (cs *chainState) registerOperator(operator []byte) {
+	key := addressKey(operator)
+	if _, ok := cs.Stakes[key]; !ok {
+		cs.Stakes[key] = new(big.Int).Set(cs.Parameters.InitialStake)
+	}
+}
+
+func (cs *chainState) stakeOf(operator []byte) *big.Int {
+	if stake, ok := cs.Stakes[addressKey(operator)]; ok {
+		return new(big.Int).Set(stake)
+	}
+	return big.NewInt(0)
+}
+
+func (cs *chainState) submitTicket(
+	operator []byte,
+	submittedTicket *relaychain.Ticket,
+) error {
+	if cs.Selection == nil {
+		return fmt.Errorf("group selection is not in progress")
+	}
+	if cs.CurrentBlock > cs.ticketSubmissionEndBlock() {
+		return fmt.Errorf("ticket submission has ended")
+	}
+	if submittedTicket.Proof == nil ||
+		submittedTicket.Proof.StakerValue == nil ||
+		submittedTicket.Proof.VirtualStakerIndex == nil {
+		return fmt.Errorf("ticket proof is incomplete")
+	}
+
+	staker := common.BigToAddress(submittedTicket.Proof.StakerValue).Bytes()
+	if !bytes.Equal(staker, operator) {
+		return fmt.Errorf("ticket can be submitted only by the staker")
+	}
+
+	virtualStakers := new(big.Int).Quo(
+		cs.stakeOf(staker),
+		cs.Parameters.MinimumStake,
+	)
+	index := submittedTicket.Proof.VirtualStakerIndex
+	if index.Sign() <= 0 || index.Cmp(virtualStakers) > 0 {
+		return fmt.Errorf(
+			"invalid virtual staker index [%v]; staker has [%v] virtual stakers",
+			index,
+			virtualStakers,
+		)
+	}
+
+	expectedValue, err := ticketValue(cs.Selection.Seed, staker, index)
+	if err != nil {
+		return err
+	}
+	if expectedValue != submittedTicket.Value {
+		return fmt.Errorf("invalid ticket value")
+	}
+
+	newTicket := &ticket{
+		Value:              new(big.Int).SetBytes(expectedValue[:]).Uint64(),
+		Staker:             staker,
+		VirtualStakerIndex: index.Uint64(),
+	}
+
+	for _, t := range cs.Selection.Tickets {
+		if bytes.Equal(t.Staker, newTicket.Staker) &&
+			t.VirtualStakerIndex == newTicket.VirtualStakerIndex {
+			return fmt.Errorf("ticket has been already submitted")
+		}
+	}
+
+	tickets := append(cs.Selection.Tickets, newTicket)
+	sort.SliceStable(tickets, func(i, j int) bool {
+		return tickets[i].Value < tickets[j].Value
+	})
+	if len(tickets) > cs.Parameters.GroupSize {
+		tickets = tickets[:cs.Parameters.GroupSize]
+	}
+	cs.Selection.Tickets = tickets
+
+	return nil
+}
+
+func (cs *chainState) submittedTickets() []uint64 {
+	tickets := make([]uint64, 0)
+	if cs.Selection != nil {
+		for _, t := range cs.Selection.Tickets {
+			tickets = append(tickets, t.Value)
+		}
+	}
+	return tickets
+}
+
+func (cs *chainState) selectedParticipants() ([][]byte, error) {
+	if cs.Selection == nil {
+		return nil, fmt.Errorf("group selection is not in progress")
+	}
+	if cs.CurrentBlock <= cs.ticketSubmissionEndBlock() {
+		return nil, fmt.Errorf("ticket submission is still in progress")
+	}
+
+	participants := make([][]byte, len(cs.Selection.Tickets))
+	for i, t := range cs.Selection.Tickets {
+		participants[i] = t.Staker
+	}
+	return participants, nil
+}
+
+func (cs *chainState) submitDKGResult(
+	operator []byte,
+	participantIndex uint8,
+	result *relaychain.DKGResult,
+	signatures map[uint8][]byte,
+) (*Event, error) {
+	participants, err := cs.selectedParticipants()
+	if err != nil {
+		return nil, err
+	}
+	if cs.CurrentBlock > cs.dkgResultSubmissionEndBlock() {
+		return nil, fmt.Errorf(resultSubmissionEndedReason)
+	}
+	if len(participants) < cs.Parameters.GroupSize {
+		return nil, fmt.Errorf(
+			"not enough tickets submitted; has [%v], group size is [%v]",
+			len(participants),
+			cs.Parameters.GroupSize,
+		)
+	}
+	if !cs.isParticipant(participants, participantIndex, operator) {
+		return nil, fmt.Errorf(
+			"operator is not the participant with index [%v]",
+			participantIndex,
+		)
+	}
+	if cs.CurrentBlock < cs.dkgResultSubmissionEligibleBlock(participantIndex) {
+		return nil, fmt.Errorf(submitterNotEligibleReason)
+	}
+	if cs.findGroup(result.GroupPublicKey) != nil {
+		return nil, fmt.Errorf(groupRegisteredReason)
+	}
+	if _, err := new(bn256.G2).Unmarshal(result.GroupPublicKey); err != nil {
+		return nil, fmt.Errorf("invalid group public key: [%v]", err)
+	}
+	if err := cs.validateMisbehaved(result.Misbehaved); err != nil {
+		return nil, fmt.Errorf("invalid misbehaved members: [%v]", err)
+	}
+	if len(signatures) < cs.Parameters.HonestThreshold {
+		return nil, fmt.Errorf(
+			"[%v] signatures supporting the result, at least [%v] required",
+			len(signatures),
+			cs.Parameters.HonestThreshold,
+		)
+	}
+
+	resultHash := crypto.Keccak256(result.GroupPublicKey, result.Misbehaved)
+	for index, signature := range signatures {
+		signer, err := recoverSigner(resultHash, signature)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"invalid signature of member [%v]: [%v]",
+				index,
+				err,
+			)
+		}
+		if !cs.isParticipant(participants, index, signer) {
+			return nil, fmt.Errorf(
+				"signature of member [%v] not made by the member",
+				index,
+			)
+		}
+	}
+
+	cs.Groups = append(cs.Groups, &group{
+		PublicKey:         result.GroupPublicKey,
+		Members:           withoutMisbehaved(participants, result.Misbehaved),
+		RegistrationBlock: cs.CurrentBlock,
+		MemberRewards:     big.NewInt(0),
+		Withdrawn:         make(map[string]bool),
+	})
+	cs.Selection = nil
+
+	return cs.emit(&Event{
+		Type:           DKGResultSubmittedEvent,
+		MemberIndex:    uint32(participantIndex),
+		GroupPublicKey: result.GroupPublicKey,
+		Misbehaved:     result.Misbehaved,
+	}), nil
+}
+
+// dkgResultSubmissionEligibleBlock returns the block from which the
+// participant with the given index is eligible to submit the DKG result.
+// The first participant is eligible once the key generation completes and
+// each following one after the result publication block step.
+func (cs *chainState) dkgResultSubmissionEligibleBlock(
+	participantIndex uint8,
+) uint64 {
+	return cs.ticketSubmissionEndBlock() +
+		dkgDurationBlocks +
+		uint64(participantIndex-1)*cs.Parameters.ResultPublicationBlockStep
+}
+
+// validateMisbehaved checks if misbehaved member indexes of a DKG result are
+// indexes of group members in an ascending order and if there are not too
+// many of them for the group to be able to sign.
+func (cs *chainState) validateMisbehaved(misbehaved []byte) error {
+	if len(misbehaved) > cs.Parameters.GroupSize-cs.Parameters.HonestThreshold {
+		return fmt.Errorf(
+			"[%v] misbehaved members, at most [%v] allowed",
+			len(misbehaved),
+			cs.Parameters.GroupSize-cs.Parameters.HonestThreshold,
+		)
+	}
+
+	for i, index := range misbehaved {
+		if index < 1 || int(index) > cs.Parameters.GroupSize {
+			return fmt.Errorf("member index [%v] out of range", index)
+		}
+		if i > 0 && index <= misbehaved[i-1] {
+			return fmt.Errorf("member indexes not in an ascending order")
+		}
+	}
+
+	return nil
+}
+
+// withoutMisbehaved returns group members with misbehaved members eliminated
+// the same way the operator contract does: each misbehaved member, starting
+// from the last one, is replaced with the last member of the group.
+func withoutMisbehaved(participants [][]byte, misbehaved []byte) [][]byte {
+	members := make([][]byte, len(participants))
+	copy(members, participants)
+
+	for i := len(misbehaved) - 1; i >= 0; i-- {
+		position := int(misbehaved[i]) - 1
+		members[position] = members[len(members)-1]
+		members = members[:len(members)-1]
+	}
+
+	return members
+}
+
+func (cs *chainState) isParticipant(
+	participants [][]byte,
+	index uint8,
+	address []byte,
+) bool {
+	return index >= 1 &&
+		int(index) <= len(participants) &&
+		bytes.Equal(participants[index-1], address)
+}
+
+func (cs *chainState) findGroup(groupPublicKey []byte) *group {
+	for _, g := range cs.Groups {
+		if bytes.Equal(g.PublicKey, groupPublicKey) {
+			return g
+		}
+	}
+	return nil
+}
+
+func (cs *chainState) isActiveGroup(g *group) bool {
+	return !g.Terminated &&
+		cs.CurrentBlock <= g.RegistrationBlock+cs.Parameters.GroupActiveTime
+}
+
+// isStaleGroup checks if the group is expired or terminated and if the
+// timeout of any operation it could have been selected for has passed.
+// Unknown groups are considered stale.
+func (cs *chainState) isStaleGroup(groupPublicKey []byte) bool {
+	g := cs.findGroup(groupPublicKey)
+	if g == nil || g.Terminated {
+		return true
+	}
+
+	return cs.CurrentBlock > g.RegistrationBlock+
+		cs.Parameters.GroupActiveTime+
+		cs.Parameters.RelayEntryTimeout
+}
+
+func (cs *chainState) activeGroupIndexes() []int {
+	indexes := make([]int, 0)
+	for i, g := range cs.Groups {
+		if cs.isActiveGroup(g) {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+func (cs *chainState) groupMembers(groupPublicKey []byte) ([][]byte, error) {
+	g := cs.findGroup(groupPublicKey)
+	if g == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	return g.Members, nil
+}
+
+// requestRelayEntry requests a new relay entry from one of the active groups,
+// selected based on the last entry.
+func (cs *chainState) requestRelayEntry() error {
+	if cs.Request != nil {
+		return fmt.Errorf("relay entry is already in progress")
+	}
+
+	return cs.assignRequest(cs.LastEntry)
+}
+
+func (cs *chainState) assignRequest(previousEntry []byte) error {
+	activeGroups := cs.activeGroupIndexes()
+	if len(activeGroups) == 0 {
+		cs.Request = nil
+		return fmt.Errorf("no active groups")
+	}
+
+	selected := new(big.Int).Mod(
+		new(big.Int).SetBytes(previousEntry),
+		big.NewInt(int64(len(activeGroups))),
+	)
+	groupIndex := activeGroups[selected.Int64()]
+
+	cs.Request = &relayRequest{
+		PreviousEntry: previousEntry,
+		GroupIndex:    groupIndex,
+		StartBlock:    cs.CurrentBlock,
+	}
+
+	cs.emit(&Event{
+		Type:           RelayEntryRequestedEvent,
+		PreviousEntry:  previousEntry,
+		GroupPublicKey: cs.Groups[groupIndex].PublicKey,
+	})
+
+	return nil
+}
+
+func (cs *chainState) submitRelayEntry(entry []byte) (*Event, error) {
+	if cs.Request == nil {
+		return nil, fmt.Errorf(entryNotInProgressReason)
+	}
+	if cs.CurrentBlock > cs.Request.StartBlock+cs.Parameters.RelayEntryTimeout {
+		return nil, fmt.Errorf(entryTimedOutReason)
+	}
+
+	selectedGroup := cs.Groups[cs.Request.GroupIndex]
+	err := audit.VerifyEntry(
+		selectedGroup.PublicKey,
+		cs.Request.PreviousEntry,
+		entry,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid relay entry: [%v]", err)
+	}
+
+	selectedGroup.MemberRewards = new(big.Int).Add(
+		selectedGroup.MemberRewards,
+		cs.Parameters.EntryReward,
+	)
+	cs.LastEntry = entry
+	cs.Request = nil
+
+	submitted := cs.emit(&Event{
+		Type:  RelayEntrySubmittedEvent,
+		Entry: entry,
+	})
+
+	if cs.Selection == nil {
+		cs.startGroupSelection(entry)
+	}
+
+	return submitted, nil
+}
+
+// reportRelayEntryTimeout terminates the group which did not deliver the
+// relay entry in time, seizes stakes of its members and assigns the request
+// to another active group, if there is any.
+func (cs *chainState) reportRelayEntryTimeout(reporter []byte) error {
+	if cs.Request == nil {
+		return fmt.Errorf(entryNotInProgressReason)
+	}
+	if cs.CurrentBlock <= cs.Request.StartBlock+cs.Parameters.RelayEntryTimeout {
+		return fmt.Errorf("relay entry did not time out yet")
+	}
+
+	cs.terminateGroup(cs.Groups[cs.Request.GroupIndex], reporter)
+
+	if err := cs.assignRequest(cs.Request.PreviousEntry); err != nil {
+		logger.Warningf("relay request dropped: [%v]", err)
+	}
+
+	return nil
+}
+
+// reportUnauthorizedSigning terminates the group whose private key has been
+// used to sign the reporter address and seizes stakes of its members.
+func (cs *chainState) reportUnauthorizedSigning(
+	reporter []byte,
+	groupPublicKey []byte,
+	signedReporterAddress []byte,
+) error {
+	g := cs.findGroup(groupPublicKey)
+	if g == nil || g.Terminated {
+		return fmt.Errorf("group not found or already terminated")
+	}
+
+	publicKey := new(bn256.G2)
+	if _, err := publicKey.Unmarshal(g.PublicKey); err != nil {
+		return fmt.Errorf("invalid group public key: [%v]", err)
+	}
+	signature := new(bn256.G1)
+	if _, err := signature.Unmarshal(signedReporterAddress); err != nil {
+		return fmt.Errorf("invalid signature: [%v]", err)
+	}
+	if !bls.Verify(publicKey, reporter, signature) {
+		return fmt.Errorf("signature is not a signature over reporter address")
+	}
+
+	cs.terminateGroup(g, reporter)
+
+	return nil
+}
+
+func (cs *chainState) terminateGroup(g *group, reporter []byte) {
+	g.Terminated = true
+
+	seized := big.NewInt(0)
+	for _, member := range g.Members {
+		stake := cs.stakeOf(member)
+		penalty := cs.Parameters.MinimumStake
+		if stake.Cmp(penalty) < 0 {
+			penalty = stake
+		}
+
+		cs.Stakes[addressKey(member)] = new(big.Int).Sub(stake, penalty)
+		seized.Add(seized, penalty)
+	}
+
+	reward := new(big.Int).Div(
+		new(big.Int).Mul(seized, big.NewInt(tattletaleRewardPercent)),
+		big.NewInt(100),
+	)
+	cs.credit(reporter, reward)
+
+	logger.Infof(
+		"group [0x%x] terminated; seized [%v] from its members, "+
+			"rewarded [0x%x] with [%v]",
+		g.PublicKey,
+		seized,
+		reporter,
+		reward,
+	)
+}
+
+func (cs *chainState) credit(address []byte, amount *big.Int) {
+	balance, ok := cs.Balances[addressKey(address)]
+	if !ok {
+		balance = big.NewInt(0)
+	}
+	cs.Balances[addressKey(address)] = new(big.Int).Add(balance, amount)
+}
+
+func (cs *chainState) groupMemberRewards(groupPublicKey []byte) (*big.Int, error) {
+	g := cs.findGroup(groupPublicKey)
+	if g == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	return new(big.Int).Set(g.MemberRewards), nil
+}
+
+func (cs *chainState) hasWithdrawnRewards(
+	operator []byte,
+	groupPublicKey []byte,
+) (bool, error) {
+	g := cs.findGroup(groupPublicKey)
+	if g == nil {
+		return false, fmt.Errorf("group not found")
+	}
+	return g.Withdrawn[addressKey(operator)], nil
+}
+
+// withdrawGroupMemberRewards transfers rewards accumulated by the operator for
+// all the seats it had in the stale group to the operator balance.
+func (cs *chainState) withdrawGroupMemberRewards(
+	operator []byte,
+	groupPublicKey []byte,
+) (*Event, error) {
+	g := cs.findGroup(groupPublicKey)
+	if g == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if !cs.isStaleGroup(groupPublicKey) {
+		return nil, fmt.Errorf("group is not stale")
+	}
+	if g.Withdrawn[addressKey(operator)] {
+		return nil, fmt.Errorf("rewards have been already withdrawn")
+	}
+
+	seats := int64(0)
+	for _, member := range g.Members {
+		if bytes.Equal(member, operator) {
+			seats++
+		}
+	}
+	if seats == 0 {
+		return nil, fmt.Errorf("operator is not a member of the group")
+	}
+
+	amount := new(big.Int).Mul(g.MemberRewards, big.NewInt(seats))
+	cs.credit(operator, amount)
+	g.Withdrawn[addressKey(operator)] = true
+
+	groupIndex := 0
+	for i := range cs.Groups {
+		if cs.Groups[i] == g {
+			groupIndex = i
+		}
+	}
+
+	return cs.emit(&Event{
+		Type:       GroupMemberRewardsWithdrawnEvent,
+		Operator:   operator,
+		Amount:     amount,
+		GroupIndex: big.NewInt(int64(groupIndex)),
+	}), nil
+}
+
+func (cs *chainState) currentRequest() *CurrentRequestReply {
+	if cs.Request == nil {
+		return &CurrentRequestReply{}
+	}
+
+	return &CurrentRequestReply{
+		InProgress:     true,
+		StartBlock:     cs.Request.StartBlock,
+		PreviousEntry:  cs.Request.PreviousEntry,
+		GroupPublicKey: cs.Groups[cs.Request.GroupIndex].PublicKey,
+	}
+}
+
+func (cs *chainState) eventsAfter(sequence uint64) []*Event {
+	if sequence >= uint64(len(cs.Events)) {
+		return []*Event{}
+	}
+	events := make([]*Event, uint64(len(cs.Events))-sequence)
+	copy(events, cs.Events[sequence:])
+	return events
+}
+
+func (cs *chainState) pastEvents(
+	eventType EventType,
+	startBlock uint64,
+	endBlock uint64,
+) []*Event {
+	events := make([]*Event, 0)
+	for _, event := range cs.Events {
+		if event.Type == eventType &&
+			event.BlockNumber >= startBlock &&
+			event.BlockNumber <= endBlock {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func addressKey(address []byte) string {
+	return common.BytesToAddress(address).Hex()
+}
+
+// ticketValue calculates the ticket value the same way the group selection
+// protocol does.
+func ticketValue(
+	seed *big.Int,
+	staker []byte,
+	virtualStakerIndex *big.Int,
+) ([8]byte, error) {
+	var value [8]byte
+
+	seedPadded, err := byteutils.LeftPadTo32Bytes(seed.Bytes())
+	if err != nil {
+		return value, err
+	}
+	stakerPadded, err := byteutils.LeftPadTo32Bytes(staker)
+	if err != nil {
+		return value, err
+	}
+	indexPadded, err := byteutils.LeftPadTo32Bytes(virtualStakerIndex.Bytes())
+	if err != nil {
+		return value, err
+	}
+
+	copy(
+		value[:],
+		crypto.Keccak256(seedPadded, stakerPadded, indexPadded)[:8],
+	)
+
+	return value, nil
+}
+
+// recoverSigner returns the address of the operator who signed the message
+// using the Ethereum signed message scheme.
+func recoverSigner(message []byte, signature []byte) ([]byte, error) {
+	if len(signature) != 65 {
+		return nil, fmt.Errorf("invalid signature length [%v]", len(signature))
+	}
+
+	recoverable := make([]byte, len(signature))
+	copy(recoverable, signature)
+	if recoverable[64] >= 27 {
+		recoverable[64] -= 27
+	}
+
+	prefixedHash := crypto.Keccak256(
+		[]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%v", len(message))),
+		message,
+	)
+
+	publicKey, err := crypto.SigToPub(prefixedHash, recoverable)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.PubkeyToAddress(*publicKey).Bytes(), nil
+}
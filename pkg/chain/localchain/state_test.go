@@ -0,0 +1,596 @@
+package localchain
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"reflect"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/bls"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+)
+
+func testParameters() *Parameters {
+	parameters := DefaultParameters()
+	parameters.GroupSize = 3
+	parameters.HonestThreshold = 2
+	return parameters
+}
+
+type testOperator struct {
+	key     *ecdsa.PrivateKey
+	address []byte
+}
+
+func newTestOperators(t *testing.T, count int) []*testOperator {
+	operators := make([]*testOperator, count)
+	for i := range operators {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		operators[i] = &testOperator{
+			key:     key,
+			address: crypto.PubkeyToAddress(key.PublicKey).Bytes(),
+		}
+	}
+	return operators
+}
+
+func newTicket(
+	t *testing.T,
+	seed *big.Int,
+	staker []byte,
+	index int64,
+) *relaychain.Ticket {
+	value, err := ticketValue(seed, staker, big.NewInt(index))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &relaychain.Ticket{
+		Value: value,
+		Proof: &relaychain.TicketProof{
+			StakerValue:        new(big.Int).SetBytes(staker),
+			VirtualStakerIndex: big.NewInt(index),
+		},
+	}
+}
+
+func mineBlocks(state *chainState, count uint64) {
+	for i := uint64(0); i < count; i++ {
+		state.mineBlock()
+	}
+}
+
+// registerTestGroup runs the group selection and registers a group with the
+// given secret key, returning members of the group.
+func registerTestGroup(
+	t *testing.T,
+	state *chainState,
+	operators []*testOperator,
+	secretKey *big.Int,
+) [][]byte {
+	for _, operator := range operators {
+		state.registerOperator(operator.address)
+		err := state.submitTicket(
+			operator.address,
+			newTicket(t, state.Selection.Seed, operator.address, 1),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mineBlocks(state, state.Parameters.TicketSubmissionTimeout+dkgDurationBlocks)
+
+	participants, err := state.selectedParticipants()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := &relaychain.DKGResult{
+		GroupPublicKey: new(bn256.G2).ScalarBaseMult(secretKey).Marshal(),
+		Misbehaved:     []byte{},
+	}
+	signatures := signResult(t, result, operators, participants)
+
+	submitter := findOperator(operators, participants[0])
+	_, err = state.submitDKGResult(submitter.address, 1, result, signatures)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return participants
+}
+
+func signResult(
+	t *testing.T,
+	result *relaychain.DKGResult,
+	operators []*testOperator,
+	participants [][]byte,
+) map[uint8][]byte {
+	hash := crypto.Keccak256(result.GroupPublicKey, result.Misbehaved)
+
+	signatures := make(map[uint8][]byte)
+	for i, participant := range participants {
+		signature, err := ethereum.NewSigning(
+			findOperator(operators, participant).key,
+		).Sign(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signatures[uint8(i+1)] = signature
+	}
+
+	return signatures
+}
+
+func findOperator(operators []*testOperator, address []byte) *testOperator {
+	for _, operator := range operators {
+		if string(operator.address) == string(address) {
+			return operator
+		}
+	}
+	return nil
+}
+
+func TestGenesisGroupSelection(t *testing.T) {
+	state := newChainState(testParameters())
+
+	state.mineBlock()
+
+	if state.Selection == nil {
+		t.Fatal("expected group selection to start")
+	}
+	if len(state.Events) != 1 ||
+		state.Events[0].Type != GroupSelectionStartedEvent {
+		t.Fatalf("unexpected events: [%v]", state.Events)
+	}
+}
+
+func TestSubmitTicket(t *testing.T) {
+	operators := newTestOperators(t, 2)
+
+	state := newChainState(testParameters())
+	state.mineBlock()
+	state.registerOperator(operators[0].address)
+
+	seed := state.Selection.Seed
+	validTicket := newTicket(t, seed, operators[0].address, 1)
+	invalidValueTicket := newTicket(t, seed, operators[0].address, 2)
+	invalidValueTicket.Value = validTicket.Value
+
+	var tests = map[string]struct {
+		operator      []byte
+		ticket        *relaychain.Ticket
+		expectedError bool
+	}{
+		"valid ticket": {
+			operator: operators[0].address,
+			ticket:   validTicket,
+		},
+		"submitted by someone else than the staker": {
+			operator:      operators[1].address,
+			ticket:        validTicket,
+			expectedError: true,
+		},
+		"virtual staker index out of stake": {
+			operator:      operators[0].address,
+			ticket:        newTicket(t, seed, operators[0].address, 6),
+			expectedError: true,
+		},
+		"invalid ticket value": {
+			operator:      operators[0].address,
+			ticket:        invalidValueTicket,
+			expectedError: true,
+		},
+		"staker with no stake": {
+			operator:      operators[1].address,
+			ticket:        newTicket(t, seed, operators[1].address, 1),
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			state.Selection.Tickets = make([]*ticket, 0)
+
+			err := state.submitTicket(test.operator, test.ticket)
+			if test.expectedError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if len(state.submittedTickets()) != 0 {
+					t.Errorf("ticket should not be accepted")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(state.submittedTickets()) != 1 {
+				t.Errorf("ticket should be accepted")
+			}
+		})
+	}
+}
+
+func TestSubmitDKGResult(t *testing.T) {
+	operators := newTestOperators(t, 3)
+
+	state := newChainState(testParameters())
+	state.mineBlock()
+
+	for _, operator := range operators {
+		state.registerOperator(operator.address)
+		err := state.submitTicket(
+			operator.address,
+			newTicket(t, state.Selection.Seed, operator.address, 1),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result := &relaychain.DKGResult{
+		GroupPublicKey: new(bn256.G2).ScalarBaseMult(big.NewInt(7)).Marshal(),
+		Misbehaved:     []byte{},
+	}
+
+	if _, err := state.submitDKGResult(
+		operators[0].address,
+		1,
+		result,
+		map[uint8][]byte{},
+	); err == nil {
+		t.Fatal("expected result to be rejected during ticket submission")
+	}
+
+	mineBlocks(state, state.Parameters.TicketSubmissionTimeout+1)
+
+	participants, err := state.selectedParticipants()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signatures := signResult(t, result, operators, participants)
+	submitter := findOperator(operators, participants[0])
+
+	if _, err := state.submitDKGResult(
+		submitter.address,
+		1,
+		result,
+		signatures,
+	); err == nil || err.Error() != submitterNotEligibleReason {
+		t.Fatalf(
+			"expected result to be rejected before the key generation "+
+				"completes; has: [%v]",
+			err,
+		)
+	}
+
+	mineBlocks(state, dkgDurationBlocks-1)
+
+	notSubmitter := findOperator(operators, participants[1])
+	if _, err := state.submitDKGResult(
+		notSubmitter.address,
+		2,
+		result,
+		signatures,
+	); err == nil || err.Error() != submitterNotEligibleReason {
+		t.Fatalf(
+			"expected result to be rejected before the second member "+
+				"is eligible; has: [%v]",
+			err,
+		)
+	}
+
+	if _, err := state.submitDKGResult(
+		notSubmitter.address,
+		1,
+		result,
+		signatures,
+	); err == nil {
+		t.Fatal("expected result submitted by another member to be rejected")
+	}
+
+	delete(signatures, 2)
+	delete(signatures, 3)
+	if _, err := state.submitDKGResult(
+		submitter.address,
+		1,
+		result,
+		signatures,
+	); err == nil {
+		t.Fatal("expected result with not enough signatures to be rejected")
+	}
+
+	signatures = signResult(t, result, operators, participants)
+	signatures[2], signatures[3] = signatures[3], signatures[2]
+	if _, err := state.submitDKGResult(
+		submitter.address,
+		1,
+		result,
+		signatures,
+	); err == nil {
+		t.Fatal("expected result with signatures of wrong members to be rejected")
+	}
+
+	signatures = signResult(t, result, operators, participants)
+	event, err := state.submitDKGResult(submitter.address, 1, result, signatures)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if event.Type != DKGResultSubmittedEvent {
+		t.Errorf("unexpected event type [%v]", event.Type)
+	}
+	if state.findGroup(result.GroupPublicKey) == nil {
+		t.Errorf("group should be registered")
+	}
+	if state.Selection != nil {
+		t.Errorf("group selection should be completed")
+	}
+}
+
+func TestSubmitDKGResultWithMisbehavedMembers(t *testing.T) {
+	operators := newTestOperators(t, 3)
+
+	state := newChainState(testParameters())
+	state.mineBlock()
+
+	for _, operator := range operators {
+		state.registerOperator(operator.address)
+		err := state.submitTicket(
+			operator.address,
+			newTicket(t, state.Selection.Seed, operator.address, 1),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mineBlocks(state, state.Parameters.TicketSubmissionTimeout+dkgDurationBlocks)
+
+	participants, err := state.selectedParticipants()
+	if err != nil {
+		t.Fatal(err)
+	}
+	submitter := findOperator(operators, participants[0])
+
+	groupPublicKey := new(bn256.G2).ScalarBaseMult(big.NewInt(7)).Marshal()
+
+	for _, misbehaved := range [][]byte{{0}, {4}, {1, 2}} {
+		result := &relaychain.DKGResult{
+			GroupPublicKey: groupPublicKey,
+			Misbehaved:     misbehaved,
+		}
+		signatures := signResult(t, result, operators, participants)
+		if _, err := state.submitDKGResult(
+			submitter.address,
+			1,
+			result,
+			signatures,
+		); err == nil {
+			t.Fatalf(
+				"expected result with misbehaved members [%v] to be rejected",
+				misbehaved,
+			)
+		}
+	}
+
+	result := &relaychain.DKGResult{
+		GroupPublicKey: groupPublicKey,
+		Misbehaved:     []byte{2},
+	}
+	signatures := signResult(t, result, operators, participants)
+	if _, err := state.submitDKGResult(
+		submitter.address,
+		1,
+		result,
+		signatures,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	members, err := state.groupMembers(groupPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedMembers := [][]byte{participants[0], participants[2]}
+	if !reflect.DeepEqual(expectedMembers, members) {
+		t.Errorf(
+			"unexpected group members\nexpected: [%x]\nactual:   [%x]",
+			expectedMembers,
+			members,
+		)
+	}
+}
+
+func TestRelayEntry(t *testing.T) {
+	operators := newTestOperators(t, 3)
+	secretKey := big.NewInt(1234)
+
+	state := newChainState(testParameters())
+	state.mineBlock()
+	registerTestGroup(t, state, operators, secretKey)
+
+	if err := state.requestRelayEntry(); err != nil {
+		t.Fatal(err)
+	}
+
+	previousEntry := new(bn256.G1)
+	if _, err := previousEntry.Unmarshal(state.Request.PreviousEntry); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := state.submitRelayEntry(
+		bls.SignG1(big.NewInt(1), previousEntry).Marshal(),
+	); err == nil {
+		t.Fatal("expected entry signed with another key to be rejected")
+	}
+
+	entry := bls.SignG1(secretKey, previousEntry).Marshal()
+	if _, err := state.submitRelayEntry(entry); err != nil {
+		t.Fatal(err)
+	}
+
+	if state.Request != nil {
+		t.Errorf("relay request should be completed")
+	}
+	if string(state.LastEntry) != string(entry) {
+		t.Errorf("last entry should be updated")
+	}
+	if state.Selection == nil {
+		t.Errorf("new group selection should be started")
+	}
+
+	rewards, err := state.groupMemberRewards(state.Groups[0].PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rewards.Cmp(state.Parameters.EntryReward) != 0 {
+		t.Errorf(
+			"unexpected group member rewards\nexpected: [%v]\nactual:   [%v]",
+			state.Parameters.EntryReward,
+			rewards,
+		)
+	}
+}
+
+func TestReportRelayEntryTimeout(t *testing.T) {
+	operators := newTestOperators(t, 4)
+
+	state := newChainState(testParameters())
+	state.mineBlock()
+	members := registerTestGroup(t, state, operators[:3], big.NewInt(1234))
+
+	if err := state.requestRelayEntry(); err != nil {
+		t.Fatal(err)
+	}
+
+	reporter := operators[3].address
+	if err := state.reportRelayEntryTimeout(reporter); err == nil {
+		t.Fatal("expected report before the timeout to be rejected")
+	}
+
+	mineBlocks(state, state.Parameters.RelayEntryTimeout+1)
+
+	if err := state.reportRelayEntryTimeout(reporter); err != nil {
+		t.Fatal(err)
+	}
+
+	if !state.Groups[0].Terminated {
+		t.Errorf("group should be terminated")
+	}
+	if state.Request != nil {
+		t.Errorf("request should be dropped with no other active group")
+	}
+
+	expectedStake := new(big.Int).Sub(
+		state.Parameters.InitialStake,
+		state.Parameters.MinimumStake,
+	)
+	for _, member := range members {
+		if state.stakeOf(member).Cmp(expectedStake) != 0 {
+			t.Errorf(
+				"unexpected stake of member [%x]\nexpected: [%v]\nactual:   [%v]",
+				member,
+				expectedStake,
+				state.stakeOf(member),
+			)
+		}
+	}
+
+	expectedReward := big.NewInt(300000) // 5% of 3 * 2000000
+	if state.Balances[addressKey(reporter)].Cmp(expectedReward) != 0 {
+		t.Errorf(
+			"unexpected reporter reward\nexpected: [%v]\nactual:   [%v]",
+			expectedReward,
+			state.Balances[addressKey(reporter)],
+		)
+	}
+}
+
+func TestReportUnauthorizedSigning(t *testing.T) {
+	operators := newTestOperators(t, 4)
+	secretKey := big.NewInt(1234)
+
+	state := newChainState(testParameters())
+	state.mineBlock()
+	registerTestGroup(t, state, operators[:3], secretKey)
+
+	groupPublicKey := state.Groups[0].PublicKey
+	reporter := operators[3].address
+
+	if err := state.reportUnauthorizedSigning(
+		reporter,
+		groupPublicKey,
+		bls.Sign(secretKey, operators[0].address).Marshal(),
+	); err == nil {
+		t.Fatal("expected signature over another address to be rejected")
+	}
+
+	if err := state.reportUnauthorizedSigning(
+		reporter,
+		groupPublicKey,
+		bls.Sign(secretKey, reporter).Marshal(),
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	if !state.Groups[0].Terminated {
+		t.Errorf("group should be terminated")
+	}
+}
+
+func TestWithdrawGroupMemberRewards(t *testing.T) {
+	operators := newTestOperators(t, 3)
+
+	state := newChainState(testParameters())
+	state.mineBlock()
+	members := registerTestGroup(t, state, operators, big.NewInt(1234))
+	groupPublicKey := state.Groups[0].PublicKey
+	state.Groups[0].MemberRewards = big.NewInt(500)
+
+	if _, err := state.withdrawGroupMemberRewards(
+		members[0],
+		groupPublicKey,
+	); err == nil {
+		t.Fatal("expected withdrawal from active group to be rejected")
+	}
+
+	mineBlocks(
+		state,
+		state.Parameters.GroupActiveTime+state.Parameters.RelayEntryTimeout+1,
+	)
+
+	event, err := state.withdrawGroupMemberRewards(members[0], groupPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.Amount.Cmp(big.NewInt(500)) != 0 {
+		t.Errorf("unexpected withdrawn amount [%v]", event.Amount)
+	}
+
+	hasWithdrawn, err := state.hasWithdrawnRewards(members[0], groupPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasWithdrawn {
+		t.Errorf("rewards should be withdrawn")
+	}
+
+	if _, err := state.withdrawGroupMemberRewards(
+		members[0],
+		groupPublicKey,
+	); err == nil {
+		t.Fatal("expected second withdrawal to be rejected")
+	}
+}
@@ -0,0 +1,67 @@
+package localchain
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// stateFileName is the name of the file in the data directory in which the
+// state of the local chain is stored.
+const stateFileName = "chain.json"
+
+// store keeps the state of the local chain on disk so that the chain survives
+// restarts of the server.
+type store struct {
+	path string
+}
+
+func newStore(dataDir string) (*store, error) {
+	if err := os.MkdirAll(dataDir, 0700); err != nil {
+		return nil, fmt.Errorf("could not create data directory: [%v]", err)
+	}
+
+	return &store{path: filepath.Join(dataDir, stateFileName)}, nil
+}
+
+// load reads the stored state of the chain. If no state has been stored yet,
+// nil is returned.
+func (s *store) load() (*chainState, error) {
+	content, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read chain state: [%v]", err)
+	}
+
+	state := &chainState{}
+	if err := json.Unmarshal(content, state); err != nil {
+		return nil, fmt.Errorf("could not decode chain state: [%v]", err)
+	}
+
+	return state, nil
+}
+
+// save stores the state of the chain. The state is first written to
+// a temporary file which then replaces the previous state so that the stored
+// state is never partially written.
+func (s *store) save(state *chainState) error {
+	content, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("could not encode chain state: [%v]", err)
+	}
+
+	tmpPath := s.path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, content, 0600); err != nil {
+		return fmt.Errorf("could not write chain state: [%v]", err)
+	}
+
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		return fmt.Errorf("could not replace chain state: [%v]", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package localchain
+
+import (
+	"math/big"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+)
+
+// EventType is a type of an event emitted by the local chain server.
+type EventType string
+
+// Types of events emitted by the local chain server.
+const (
+	GroupSelectionStartedEvent       EventType = "GroupSelectionStarted"
+	DKGResultSubmittedEvent          EventType = "DKGResultSubmitted"
+	RelayEntryRequestedEvent         EventType = "RelayEntryRequested"
+	RelayEntrySubmittedEvent         EventType = "RelayEntrySubmitted"
+	GroupMemberRewardsWithdrawnEvent EventType = "GroupMemberRewardsWithdrawn"
+)
+
+// Event is an event emitted by the local chain server. Events are numbered
+// with consecutive sequence numbers, starting from one. Only the fields
+// relevant for the given event type are set.
+type Event struct {
+	Sequence    uint64    `json:"sequence"`
+	Type        EventType `json:"type"`
+	BlockNumber uint64    `json:"blockNumber"`
+
+	NewEntry       *big.Int `json:"newEntry,omitempty"`
+	PreviousEntry  []byte   `json:"previousEntry,omitempty"`
+	Entry          []byte   `json:"entry,omitempty"`
+	GroupPublicKey []byte   `json:"groupPublicKey,omitempty"`
+	MemberIndex    uint32   `json:"memberIndex,omitempty"`
+	Misbehaved     []byte   `json:"misbehaved,omitempty"`
+	Operator       []byte   `json:"operator,omitempty"`
+	Amount         *big.Int `json:"amount,omitempty"`
+	GroupIndex     *big.Int `json:"groupIndex,omitempty"`
+}
+
+// The types below are arguments and replies of the local chain server remote
+// procedures. Operator is the address of the operator on whose behalf the
+// procedure is called.
+
+// BlockReply carries the block at which the procedure has been executed by
+// the chain.
+type BlockReply struct {
+	BlockNumber uint64
+}
+
+// StatusReply describes the current state of the local chain.
+type StatusReply struct {
+	CurrentBlock uint64
+	LastSequence uint64
+}
+
+// OperatorArgs are arguments of procedures related to a single operator.
+type OperatorArgs struct {
+	Operator []byte
+}
+
+// StakeReply carries the stake of an operator.
+type StakeReply struct {
+	Stake *big.Int
+}
+
+// TicketArgs are arguments of a ticket submission.
+type TicketArgs struct {
+	Operator []byte
+	Ticket   *relaychain.Ticket
+}
+
+// TicketsReply carries values of tickets submitted in the current group
+// selection.
+type TicketsReply struct {
+	Tickets []uint64
+}
+
+// ParticipantsReply carries addresses of group members or candidates.
+type ParticipantsReply struct {
+	Participants [][]byte
+}
+
+// DKGResultArgs are arguments of a DKG result submission.
+type DKGResultArgs struct {
+	Operator         []byte
+	ParticipantIndex uint8
+	Result           *relaychain.DKGResult
+	Signatures       map[uint8][]byte
+}
+
+// GroupArgs are arguments of procedures related to a single group.
+type GroupArgs struct {
+	Operator       []byte
+	GroupPublicKey []byte
+}
+
+// BoolReply carries a single flag.
+type BoolReply struct {
+	Value bool
+}
+
+// AmountReply carries a single amount.
+type AmountReply struct {
+	Amount *big.Int
+}
+
+// EntryArgs are arguments of a relay entry submission.
+type EntryArgs struct {
+	Operator []byte
+	Entry    []byte
+}
+
+// UnauthorizedSigningArgs are arguments of an unauthorized signing report.
+type UnauthorizedSigningArgs struct {
+	Operator              []byte
+	GroupPublicKey        []byte
+	SignedOperatorAddress []byte
+}
+
+// CurrentRequestReply describes the relay request currently in progress.
+type CurrentRequestReply struct {
+	InProgress     bool
+	StartBlock     uint64
+	PreviousEntry  []byte
+	GroupPublicKey []byte
+}
+
+// EventReply carries a single event.
+type EventReply struct {
+	Event *Event
+}
+
+// EventsArgs are arguments of a request for events emitted after the event
+// with the given sequence number.
+type EventsArgs struct {
+	AfterSequence uint64
+}
+
+// PastEventsArgs are arguments of a request for events of the given type
+// emitted between the start and the end block, both inclusive.
+type PastEventsArgs struct {
+	Type       EventType
+	StartBlock uint64
+	EndBlock   uint64
+}
+
+// EventsReply carries events along with the current block of the chain.
+type EventsReply struct {
+	CurrentBlock uint64
+	Events       []*Event
+}
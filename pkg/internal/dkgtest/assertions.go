@@ -5,6 +5,7 @@ import (
 
 	"github.com/keep-network/keep-core/pkg/altbn128"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/bls"
 	"github.com/keep-network/keep-core/pkg/internal/testutils"
 )
 
@@ -171,6 +172,33 @@ func AssertValidGroupPublicKey(t *testing.T, testResult *Result) {
 	}
 }
 
+// AssertValidGroupPublicKeyShares checks if signature shares calculated by
+// each signer are valid against the group public key share of that signer
+// computed by all other signers.
+func AssertValidGroupPublicKeyShares(t *testing.T, testResult *Result) {
+	message := altbn128.G1HashToPoint([]byte("group public key shares"))
+
+	for _, signer := range testResult.signers {
+		signatureShare := signer.CalculateSignatureShare(message)
+
+		for _, otherSigner := range testResult.signers {
+			if otherSigner.MemberID() == signer.MemberID() {
+				continue
+			}
+
+			publicKeyShare, ok := otherSigner.GroupPublicKeyShares()[signer.MemberID()]
+			if !ok || !bls.VerifyG1(publicKeyShare, message, signatureShare) {
+				t.Errorf(
+					"invalid group public key share of member [%v] "+
+						"computed by member [%v]",
+					signer.MemberID(),
+					otherSigner.MemberID(),
+				)
+			}
+		}
+	}
+}
+
 // AssertResultSupportingMembers checks which particular members
 // actually support the final result with their signature.
 func AssertResultSupportingMembers(
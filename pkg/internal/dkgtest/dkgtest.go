@@ -33,6 +33,7 @@ type Result struct {
 	dkgResultSignatures map[group.MemberIndex][]byte
 	signers             []*dkg.ThresholdSigner
 	memberFailures      []error
+	transcripts         map[group.MemberIndex]*gjkr.Transcript
 }
 
 // GetSigners returns all signers created from DKG protocol execution.
@@ -42,6 +43,12 @@ func (r *Result) GetSigners() []*dkg.ThresholdSigner {
 	return r.signers
 }
 
+// GetTranscript returns the GJKR protocol transcript recorded by the member
+// with the given index.
+func (r *Result) GetTranscript(memberIndex group.MemberIndex) *gjkr.Transcript {
+	return r.transcripts[memberIndex]
+}
+
 // RandomSeed generates a random DKG seed value. It is important to do not
 // reuse the same seed value between integration tests run in parallel.
 // Broadcast channel name contains a seed to avoid mixing up channel messages
@@ -62,6 +69,20 @@ func RunTest(
 	honestThreshold int,
 	seed *big.Int,
 	rules interception.Rules,
+) (*Result, error) {
+	return RunTestWithSeats(groupSize, honestThreshold, seed, rules, nil)
+}
+
+// RunTestWithSeats executes the full DKG roundtrip test the same way as
+// RunTest does but members with the given indexes execute the GJKR protocol
+// as seats of one operator, see gjkr.Seats. Other members execute the
+// protocol separately.
+func RunTestWithSeats(
+	groupSize int,
+	honestThreshold int,
+	seed *big.Int,
+	rules interception.Rules,
+	seatIndexes []group.MemberIndex,
 ) (*Result, error) {
 	privateKey, publicKey, err := operator.GenerateKeyPair()
 	if err != nil {
@@ -91,7 +112,7 @@ func RunTest(
 		selectedStakers[i] = address
 	}
 
-	return executeDKG(seed, chain, network, selectedStakers)
+	return executeDKG(seed, chain, network, selectedStakers, seatIndexes)
 }
 
 func executeDKG(
@@ -99,6 +120,7 @@ func executeDKG(
 	chain chainLocal.Chain,
 	network interception.Network,
 	selectedStakers []relaychain.StakerAddress,
+	seatIndexes []group.MemberIndex,
 ) (*Result, error) {
 	relayConfig, err := chain.ThresholdRelay().GetConfig()
 	if err != nil {
@@ -127,6 +149,9 @@ func executeDKG(
 
 	var memberFailures []error
 
+	transcripts := make(map[group.MemberIndex]*gjkr.Transcript)
+	var transcriptsMutex sync.Mutex
+
 	var wg sync.WaitGroup
 	wg.Add(relayConfig.GroupSize)
 
@@ -147,10 +172,31 @@ func executeDKG(
 		chain.Signing(),
 	)
 
+	var seats *gjkr.Seats
+	if len(seatIndexes) > 0 {
+		seats = gjkr.NewSeats(seatIndexes)
+	}
+	isSeat := func(memberIndex group.MemberIndex) bool {
+		for _, seatIndex := range seatIndexes {
+			if seatIndex == memberIndex {
+				return true
+			}
+		}
+		return false
+	}
+
 	for i := 0; i < relayConfig.GroupSize; i++ {
 		i := i // capture for goroutine
 		go func() {
+			transcriptRecorder := gjkr.NewTranscriptRecorder(selectedStakers)
+
+			var memberSeats *gjkr.Seats
+			if isSeat(group.MemberIndex(i + 1)) {
+				memberSeats = seats
+			}
+
 			signer, err := dkg.ExecuteDKG(
+				context.Background(),
 				seed,
 				uint8(i),
 				relayConfig.GroupSize,
@@ -161,7 +207,14 @@ func executeDKG(
 				chain.ThresholdRelay(),
 				chain.Signing(),
 				broadcastChannel,
+				transcriptRecorder,
+				memberSeats,
 			)
+
+			transcriptsMutex.Lock()
+			transcripts[group.MemberIndex(i+1)] = transcriptRecorder.Transcript()
+			transcriptsMutex.Unlock()
+
 			if signer != nil {
 				signersMutex.Lock()
 				signers = append(signers, signer)
@@ -191,6 +244,7 @@ func executeDKG(
 			dkgResultSignatures,
 			signers,
 			memberFailures,
+			transcripts,
 		}, nil
 
 	case <-ctx.Done():
@@ -200,6 +254,7 @@ func executeDKG(
 			nil,
 			signers,
 			memberFailures,
+			transcripts,
 		}, nil
 	}
 }
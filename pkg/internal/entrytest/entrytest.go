@@ -16,9 +16,11 @@ import (
 	"github.com/keep-network/keep-core/pkg/net/key"
 	"github.com/keep-network/keep-core/pkg/operator"
 
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/entry"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 
 	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
 	netLocal "github.com/keep-network/keep-core/pkg/net/local"
@@ -62,6 +64,20 @@ func RunTest(
 	threshold int,
 	rules interception.Rules,
 	previousEntry []byte,
+) (*Result, error) {
+	return RunTestWithSeats(signers, threshold, rules, previousEntry, nil)
+}
+
+// RunTestWithSeats executes the full relay entry signing roundtrip test the
+// same way as RunTest does but signers of members with the given indexes sign
+// together as seats of one node, in a single signing session. Other signers
+// sign separately.
+func RunTestWithSeats(
+	signers []*dkg.ThresholdSigner,
+	threshold int,
+	rules interception.Rules,
+	previousEntry []byte,
+	seatIndexes []group.MemberIndex,
 ) (*Result, error) {
 	privateKey, publicKey, err := operator.GenerateKeyPair()
 	if err != nil {
@@ -77,14 +93,84 @@ func RunTest(
 
 	chain := chainLocal.ConnectWithKey(len(signers), threshold, minimumStake, privateKey)
 
-	return executeSigning(signers, threshold, chain, network, previousEntry)
+	address := chain.Signing().PublicKeyBytesToAddress(
+		key.Marshal(networkPublicKey),
+	)
+
+	selectedStakers := make([]relaychain.StakerAddress, groupSize(signers))
+	for i := range selectedStakers {
+		selectedStakers[i] = address
+	}
+
+	membershipValidator := group.NewStakersMembershipValidator(
+		selectedStakers,
+		chain.Signing(),
+	)
+
+	return executeSigning(
+		groupSigners(signers, seatIndexes),
+		threshold,
+		chain,
+		network,
+		membershipValidator,
+		previousEntry,
+	)
 }
 
-func executeSigning(
+// groupSize returns the highest index of a member having a group public key
+// share, so that all the members are covered by the membership validator.
+func groupSize(signers []*dkg.ThresholdSigner) int {
+	size := 0
+	for _, signer := range signers {
+		for memberIndex := range signer.GroupPublicKeyShares() {
+			if int(memberIndex) > size {
+				size = int(memberIndex)
+			}
+		}
+	}
+
+	return size
+}
+
+// groupSigners groups signers of members with the given indexes together.
+// Each of other signers is in a separate group.
+func groupSigners(
 	signers []*dkg.ThresholdSigner,
+	seatIndexes []group.MemberIndex,
+) [][]*dkg.ThresholdSigner {
+	isSeat := func(memberIndex group.MemberIndex) bool {
+		for _, seatIndex := range seatIndexes {
+			if seatIndex == memberIndex {
+				return true
+			}
+		}
+		return false
+	}
+
+	var grouped [][]*dkg.ThresholdSigner
+	var seats []*dkg.ThresholdSigner
+	for _, signer := range signers {
+		if isSeat(signer.MemberID()) {
+			seats = append(seats, signer)
+			continue
+		}
+
+		grouped = append(grouped, []*dkg.ThresholdSigner{signer})
+	}
+
+	if len(seats) > 0 {
+		grouped = append(grouped, seats)
+	}
+
+	return grouped
+}
+
+func executeSigning(
+	signers [][]*dkg.ThresholdSigner,
 	threshold int,
 	chain chainLocal.Chain,
 	network interception.Network,
+	membershipValidator group.MembershipValidator,
 	previousEntry []byte,
 ) (*Result, error) {
 	blockCounter, err := chain.BlockCounter()
@@ -130,25 +216,29 @@ func executeSigning(
 
 	entry.RegisterUnmarshallers(broadcastChannel)
 
-	for _, signer := range signers {
-		go func(signer *dkg.ThresholdSigner) {
+	for _, seats := range signers {
+		go func(seats []*dkg.ThresholdSigner) {
 			err := entry.SignAndSubmit(
+				context.Background(),
 				blockCounter,
 				broadcastChannel,
 				chain.ThresholdRelay(),
 				previousEntry,
 				threshold,
-				signer,
+				seats,
+				membershipValidator,
 				startBlockHeight,
+				nil,
+				nil,
 			)
 			if err != nil {
-				fmt.Printf("[signer:%v %v] failed with: [%v]\n", signer.MemberID(), previousEntry, err)
+				fmt.Printf("[signer:%v %v] failed with: [%v]\n", seats[0].MemberID(), previousEntry, err)
 				signerFailuresMutex.Lock()
 				signerFailures = append(signerFailures, err)
 				signerFailuresMutex.Unlock()
 			}
 			wg.Done()
-		}(signer)
+		}(seats)
 	}
 	wg.Wait()
 
@@ -0,0 +1,95 @@
+// Package persistenceutils provides helper utilities for reading data stored
+// with the persistence handle.
+package persistenceutils
+
+import (
+	"strings"
+
+	"github.com/ipfs/go-log"
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+var logger = log.Logger("keep-persistence-utils")
+
+// ReadLatest reads the latest file of each persistence directory whose name
+// starts with the given prefix. Files of a directory are ordered by their
+// names and content of each read file is passed to the unmarshal function.
+// Files which could not be read or unmarshalled, for example because the
+// client crashed while saving them, are skipped so that the previous file of
+// the directory is used. Unmarshalled files are returned by names of their
+// directories.
+func ReadLatest(
+	handle persistence.Handle,
+	directoryPrefix string,
+	unmarshal func(content []byte) (interface{}, error),
+) map[string]interface{} {
+	type latestFile struct {
+		name string
+		data interface{}
+	}
+	latestFiles := make(map[string]*latestFile)
+
+	dataChannel, errorChannel := handle.ReadAll()
+
+	// Both channels have to be drained till they are closed so that the
+	// persistence producer is not blocked.
+	for dataChannel != nil || errorChannel != nil {
+		select {
+		case descriptor, ok := <-dataChannel:
+			if !ok {
+				dataChannel = nil
+				continue
+			}
+
+			if !strings.HasPrefix(descriptor.Directory(), directoryPrefix) {
+				continue
+			}
+
+			latest, ok := latestFiles[descriptor.Directory()]
+			if ok && latest.name > descriptor.Name() {
+				continue
+			}
+
+			content, err := descriptor.Content()
+			if err != nil {
+				logger.Warningf(
+					"could not read file [%v/%v]: [%v]",
+					descriptor.Directory(),
+					descriptor.Name(),
+					err,
+				)
+				continue
+			}
+
+			data, err := unmarshal(content)
+			if err != nil {
+				logger.Warningf(
+					"could not unmarshal file [%v/%v]: [%v]",
+					descriptor.Directory(),
+					descriptor.Name(),
+					err,
+				)
+				continue
+			}
+
+			latestFiles[descriptor.Directory()] = &latestFile{
+				name: descriptor.Name(),
+				data: data,
+			}
+		case err, ok := <-errorChannel:
+			if !ok {
+				errorChannel = nil
+				continue
+			}
+
+			logger.Debugf("could not read persisted data: [%v]", err)
+		}
+	}
+
+	latestData := make(map[string]interface{}, len(latestFiles))
+	for directory, latest := range latestFiles {
+		latestData[directory] = latest.data
+	}
+
+	return latestData
+}
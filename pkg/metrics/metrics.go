@@ -2,11 +2,16 @@ package metrics
 
 import (
 	"context"
+	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/ipfs/go-log"
 	"github.com/keep-network/keep-common/pkg/metrics"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/audit"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/ledger"
 	"github.com/keep-network/keep-core/pkg/net"
 )
 
@@ -116,6 +121,218 @@ func ObserveEthConnectivity(
 	)
 }
 
+// ObserveRelayEntryAudit triggers an observation process of the
+// relay_entry_valid_count and relay_entry_invalid_count metrics.
+func ObserveRelayEntryAudit(
+	ctx context.Context,
+	registry *metrics.Registry,
+	auditor *audit.Auditor,
+	tick time.Duration,
+) {
+	validInput := func() float64 {
+		return float64(auditor.Status().ValidEntries)
+	}
+
+	invalidInput := func() float64 {
+		return float64(auditor.Status().InvalidEntries)
+	}
+
+	observe(
+		ctx,
+		"relay_entry_valid_count",
+		validInput,
+		registry,
+		validateTick(tick, DefaultEthereumMetricsTick),
+	)
+
+	observe(
+		ctx,
+		"relay_entry_invalid_count",
+		invalidInput,
+		registry,
+		validateTick(tick, DefaultEthereumMetricsTick),
+	)
+}
+
+// ObserveProtocolTransactions triggers an observation process of the
+// protocol_transactions_submitted_count, protocol_transactions_replaced_count,
+// protocol_transactions_mined_count,
+// protocol_transactions_deadline_exceeded_count and
+// protocol_transactions_pending_count metrics.
+func ObserveProtocolTransactions(
+	ctx context.Context,
+	registry *metrics.Registry,
+	chainHandles []chain.Handle,
+	tick time.Duration,
+) {
+	status := func() ethereum.TransactionStatus {
+		return ethereum.TransactionStatusOf(chainHandles)
+	}
+	tick = validateTick(tick, DefaultEthereumMetricsTick)
+
+	observe(
+		ctx,
+		"protocol_transactions_submitted_count",
+		func() float64 { return float64(status().Submitted) },
+		registry,
+		tick,
+	)
+
+	observe(
+		ctx,
+		"protocol_transactions_replaced_count",
+		func() float64 { return float64(status().Replaced) },
+		registry,
+		tick,
+	)
+
+	observe(
+		ctx,
+		"protocol_transactions_mined_count",
+		func() float64 { return float64(status().Mined) },
+		registry,
+		tick,
+	)
+
+	observe(
+		ctx,
+		"protocol_transactions_deadline_exceeded_count",
+		func() float64 { return float64(status().DeadlineExceeded) },
+		registry,
+		tick,
+	)
+
+	observe(
+		ctx,
+		"protocol_transactions_pending_count",
+		func() float64 { return float64(status().Pending) },
+		registry,
+		tick,
+	)
+}
+
+// ObserveOperatorLedger triggers an observation process of the
+// operator_transactions_count, operator_transaction_fees_wei,
+// operator_dkg_reimbursements_wei and operator_group_member_rewards_wei
+// metrics. The amounts are summed over all operators recorded in the ledger.
+func ObserveOperatorLedger(
+	ctx context.Context,
+	registry *metrics.Registry,
+	feeLedger *ledger.Ledger,
+	tick time.Duration,
+) {
+	tick = validateTick(tick, DefaultEthereumMetricsTick)
+
+	observe(
+		ctx,
+		"operator_transactions_count",
+		func() float64 { return float64(feeLedger.Totals().Transactions) },
+		registry,
+		tick,
+	)
+
+	observe(
+		ctx,
+		"operator_transaction_fees_wei",
+		func() float64 { return weiToFloat(feeLedger.Totals().Fees) },
+		registry,
+		tick,
+	)
+
+	observe(
+		ctx,
+		"operator_dkg_reimbursements_wei",
+		func() float64 { return weiToFloat(feeLedger.Totals().Reimbursements) },
+		registry,
+		tick,
+	)
+
+	observe(
+		ctx,
+		"operator_group_member_rewards_wei",
+		func() float64 { return weiToFloat(feeLedger.Totals().Rewards) },
+		registry,
+		tick,
+	)
+}
+
+func weiToFloat(amount *big.Int) float64 {
+	value, _ := new(big.Float).SetInt(amount).Float64()
+	return value
+}
+
+// ObserveEthereumEndpoints triggers an observation process of the
+// ethereum_endpoint_active_index, ethereum_endpoint_healthy_count and
+// ethereum_endpoint_switches_count metrics and exposes URLs of the configured
+// endpoints in the ethereum_endpoints_info metric. The active index refers to
+// the endpoint_<index> label of the info metric. Nothing is observed if the
+// given chain handle is not connected to Ethereum.
+func ObserveEthereumEndpoints(
+	ctx context.Context,
+	registry *metrics.Registry,
+	chainHandle chain.Handle,
+	tick time.Duration,
+) {
+	status := func() ethereum.EndpointsStatus {
+		return ethereum.EndpointsStatusOf(chainHandle)
+	}
+	tick = validateTick(tick, DefaultEthereumMetricsTick)
+
+	endpoints := status().Endpoints
+	if len(endpoints) == 0 {
+		return
+	}
+
+	labels := make([]metrics.Label, len(endpoints))
+	for i, endpoint := range endpoints {
+		labels[i] = metrics.NewLabel(fmt.Sprintf("endpoint_%v", i), endpoint.URL)
+	}
+
+	name := "ethereum_endpoints_info"
+	if _, err := registry.NewInfo(name, labels); err != nil {
+		logger.Warningf("could not create info metric [%v]", name)
+	}
+
+	observe(
+		ctx,
+		"ethereum_endpoint_active_index",
+		func() float64 {
+			for i, endpoint := range status().Endpoints {
+				if endpoint.Active {
+					return float64(i)
+				}
+			}
+			return -1
+		},
+		registry,
+		tick,
+	)
+
+	observe(
+		ctx,
+		"ethereum_endpoint_healthy_count",
+		func() float64 {
+			healthy := 0
+			for _, endpoint := range status().Endpoints {
+				if endpoint.Healthy {
+					healthy++
+				}
+			}
+			return float64(healthy)
+		},
+		registry,
+		tick,
+	)
+
+	observe(
+		ctx,
+		"ethereum_endpoint_switches_count",
+		func() float64 { return float64(status().Switches) },
+		registry,
+		tick,
+	)
+}
+
 func observe(
 	ctx context.Context,
 	name string,
@@ -37,6 +37,12 @@ type BroadcastNetworkMessage struct {
 	// Sequence number of the message. Retransmissions have the same sequence
 	// number as the original message.
 	SequenceNumber uint64 `protobuf:"varint,4,opt,name=sequenceNumber,proto3" json:"sequenceNumber,omitempty"`
+	// The identity of the operator on whose behalf the message is sent. Set
+	// only if the sender hosts multiple operators and the message is sent on
+	// behalf of an operator other than the sender itself.
+	Operator []byte `protobuf:"bytes,5,opt,name=operator,proto3" json:"operator,omitempty"`
+	// Signature of the operator over the message and the channel name.
+	OperatorSignature []byte `protobuf:"bytes,6,opt,name=operatorSignature,proto3" json:"operatorSignature,omitempty"`
 }
 
 func (m *BroadcastNetworkMessage) Reset()      { *m = BroadcastNetworkMessage{} }
@@ -99,6 +105,20 @@ func (m *BroadcastNetworkMessage) GetSequenceNumber() uint64 {
 	return 0
 }
 
+func (m *BroadcastNetworkMessage) GetOperator() []byte {
+	if m != nil {
+		return m.Operator
+	}
+	return nil
+}
+
+func (m *BroadcastNetworkMessage) GetOperatorSignature() []byte {
+	if m != nil {
+		return m.OperatorSignature
+	}
+	return nil
+}
+
 // UnicastNetworkMessage represents a network message used by unicast
 // channels.
 type UnicastNetworkMessage struct {
@@ -224,24 +244,26 @@ func init() {
 func init() { proto.RegisterFile("pb/message.proto", fileDescriptor_8447775385e7eb85) }
 
 var fileDescriptor_8447775385e7eb85 = []byte{
-	// 272 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x90, 0xb1, 0x4a, 0x03, 0x41,
-	0x10, 0x86, 0x77, 0x92, 0x90, 0xe8, 0x12, 0x44, 0x16, 0x34, 0x57, 0xc8, 0x10, 0x4e, 0x90, 0x54,
-	0x5a, 0xd8, 0x58, 0xa7, 0x13, 0x31, 0xc5, 0x81, 0x8d, 0x8d, 0xec, 0xe6, 0x86, 0x70, 0xc4, 0xec,
-	0xad, 0xbb, 0x7b, 0xc8, 0x61, 0x63, 0x67, 0xeb, 0x63, 0xf8, 0x28, 0x96, 0x57, 0xa6, 0xf4, 0xf6,
-	0x1a, 0xcb, 0x3c, 0x82, 0x70, 0x1a, 0x04, 0x7b, 0xbb, 0xf9, 0xbf, 0x19, 0x98, 0x8f, 0x9f, 0xef,
-	0x1b, 0x75, 0xb6, 0x22, 0xe7, 0xe4, 0x82, 0x4e, 0x8d, 0xcd, 0x7d, 0x2e, 0xba, 0x9a, 0x7c, 0xfc,
-	0x02, 0x7c, 0x34, 0xb5, 0xb9, 0x4c, 0xe7, 0xd2, 0xf9, 0x19, 0xf9, 0xc7, 0xdc, 0x2e, 0xaf, 0xbf,
-	0xcf, 0xc4, 0x21, 0xef, 0x3b, 0xd2, 0x29, 0xd9, 0x08, 0xc6, 0x30, 0x19, 0x26, 0x3f, 0x49, 0x44,
-	0x7c, 0x60, 0x64, 0x79, 0x9f, 0xcb, 0x34, 0xea, 0xb4, 0x8b, 0x6d, 0x14, 0x82, 0xf7, 0x7c, 0x69,
-	0x28, 0xea, 0xb6, 0xb8, 0x9d, 0xc5, 0x09, 0xdf, 0x73, 0xf4, 0x50, 0x90, 0x9e, 0xd3, 0xac, 0x58,
-	0x29, 0xb2, 0x51, 0x6f, 0x0c, 0x93, 0x5e, 0xf2, 0x87, 0xc6, 0x4f, 0xfc, 0xe0, 0x46, 0x67, 0xff,
-	0xa6, 0x71, 0xc4, 0x77, 0x5d, 0xb6, 0xd0, 0xd2, 0x17, 0x96, 0x5a, 0x83, 0x61, 0xf2, 0x0b, 0xe2,
-	0x63, 0xbe, 0x73, 0x99, 0x92, 0xf6, 0x99, 0x2f, 0xc5, 0x88, 0x0f, 0x4c, 0xa1, 0xee, 0x96, 0x54,
-	0x6e, 0x1f, 0x9a, 0x42, 0x5d, 0x51, 0x39, 0xbd, 0xa8, 0x6a, 0x64, 0xeb, 0x1a, 0xd9, 0xa6, 0x46,
-	0x78, 0x0e, 0x08, 0x6f, 0x01, 0xe1, 0x3d, 0x20, 0x54, 0x01, 0xe1, 0x23, 0x20, 0x7c, 0x06, 0x64,
-	0x9b, 0x80, 0xf0, 0xda, 0x20, 0xab, 0x1a, 0x64, 0xeb, 0x06, 0xd9, 0x6d, 0xc7, 0x28, 0xd5, 0x6f,
-	0x1b, 0x3f, 0xff, 0x0a, 0x00, 0x00, 0xff, 0xff, 0x67, 0x49, 0x47, 0x0c, 0x85, 0x01, 0x00, 0x00,
+	// 297 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x91, 0xb1, 0x4a, 0x03, 0x41,
+	0x10, 0x86, 0x6f, 0x93, 0x33, 0x89, 0x83, 0x88, 0x2e, 0x68, 0x16, 0x91, 0x21, 0x44, 0x90, 0x14,
+	0xa2, 0x85, 0x8d, 0x75, 0x3a, 0x11, 0x53, 0x44, 0x6c, 0x6c, 0x64, 0x2f, 0x37, 0x84, 0x10, 0x73,
+	0xbb, 0xee, 0xee, 0x21, 0x87, 0x8d, 0x8f, 0xe0, 0x63, 0xf8, 0x28, 0x96, 0xb1, 0x4b, 0x69, 0x36,
+	0x8d, 0x65, 0x1e, 0x41, 0x58, 0xbd, 0x08, 0x5a, 0xdb, 0xfd, 0xff, 0xff, 0xfd, 0xcb, 0xce, 0x30,
+	0xb0, 0xa5, 0x93, 0x93, 0x09, 0x59, 0x2b, 0x87, 0x74, 0xac, 0x8d, 0x72, 0x8a, 0x57, 0x33, 0x72,
+	0xed, 0x37, 0x06, 0xcd, 0xae, 0x51, 0x32, 0x1d, 0x48, 0xeb, 0x7a, 0xe4, 0x1e, 0x94, 0x19, 0x5f,
+	0x7e, 0xd5, 0xf8, 0x2e, 0xd4, 0x2c, 0x65, 0x29, 0x19, 0xc1, 0x5a, 0xac, 0xb3, 0xd1, 0xff, 0x76,
+	0x5c, 0x40, 0x5d, 0xcb, 0xe2, 0x4e, 0xc9, 0x54, 0x54, 0x02, 0x28, 0x2d, 0xe7, 0x10, 0xbb, 0x42,
+	0x93, 0xa8, 0x86, 0x38, 0x68, 0x7e, 0x08, 0x9b, 0x96, 0xee, 0x73, 0xca, 0x06, 0xd4, 0xcb, 0x27,
+	0x09, 0x19, 0x11, 0xb7, 0x58, 0x27, 0xee, 0xff, 0x4a, 0xf9, 0x1e, 0x34, 0x94, 0x26, 0x23, 0x9d,
+	0x32, 0x62, 0x2d, 0xbc, 0x5f, 0x79, 0x7e, 0x04, 0xdb, 0xa5, 0xbe, 0x1a, 0x0d, 0x33, 0xe9, 0x72,
+	0x43, 0xa2, 0x16, 0x4a, 0x7f, 0x41, 0xfb, 0x11, 0x76, 0xae, 0xb3, 0xd1, 0xbf, 0x2d, 0xb4, 0x0f,
+	0xeb, 0x76, 0x35, 0x44, 0x1c, 0xc0, 0x4f, 0xd0, 0x3e, 0x80, 0xc6, 0x79, 0x4a, 0x99, 0x1b, 0xb9,
+	0x82, 0x37, 0xa1, 0xae, 0xf3, 0xe4, 0x76, 0x4c, 0x45, 0xf9, 0xa1, 0xce, 0x93, 0x0b, 0x2a, 0xba,
+	0x67, 0xd3, 0x39, 0x46, 0xb3, 0x39, 0x46, 0xcb, 0x39, 0xb2, 0x27, 0x8f, 0xec, 0xc5, 0x23, 0x7b,
+	0xf5, 0xc8, 0xa6, 0x1e, 0xd9, 0xbb, 0x47, 0xf6, 0xe1, 0x31, 0x5a, 0x7a, 0x64, 0xcf, 0x0b, 0x8c,
+	0xa6, 0x0b, 0x8c, 0x66, 0x0b, 0x8c, 0x6e, 0x2a, 0x3a, 0x49, 0x6a, 0xe1, 0x76, 0xa7, 0x9f, 0x03,
+	0x00, 0x88, 0x48, 0xe1, 0xb2, 0xcf, 0x01, 0x00, 0x00,
 }
 
 func (this *BroadcastNetworkMessage) Equal(that interface{}) bool {
@@ -275,6 +297,12 @@ func (this *BroadcastNetworkMessage) Equal(that interface{}) bool {
 	if this.SequenceNumber != that1.SequenceNumber {
 		return false
 	}
+	if !bytes.Equal(this.Operator, that1.Operator) {
+		return false
+	}
+	if !bytes.Equal(this.OperatorSignature, that1.OperatorSignature) {
+		return false
+	}
 	return true
 }
 func (this *UnicastNetworkMessage) Equal(that interface{}) bool {
@@ -338,12 +366,14 @@ func (this *BroadcastNetworkMessage) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 8)
+	s := make([]string, 0, 10)
 	s = append(s, "&pb.BroadcastNetworkMessage{")
 	s = append(s, "Sender: "+fmt.Sprintf("%#v", this.Sender)+",\n")
 	s = append(s, "Payload: "+fmt.Sprintf("%#v", this.Payload)+",\n")
 	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
 	s = append(s, "SequenceNumber: "+fmt.Sprintf("%#v", this.SequenceNumber)+",\n")
+	s = append(s, "Operator: "+fmt.Sprintf("%#v", this.Operator)+",\n")
+	s = append(s, "OperatorSignature: "+fmt.Sprintf("%#v", this.OperatorSignature)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -398,6 +428,20 @@ func (m *BroadcastNetworkMessage) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	_ = i
 	var l int
 	_ = l
+	if len(m.OperatorSignature) > 0 {
+		i -= len(m.OperatorSignature)
+		copy(dAtA[i:], m.OperatorSignature)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.OperatorSignature)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Operator) > 0 {
+		i -= len(m.Operator)
+		copy(dAtA[i:], m.Operator)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.Operator)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if m.SequenceNumber != 0 {
 		i = encodeVarintMessage(dAtA, i, uint64(m.SequenceNumber))
 		i--
@@ -540,6 +584,14 @@ func (m *BroadcastNetworkMessage) Size() (n int) {
 	if m.SequenceNumber != 0 {
 		n += 1 + sovMessage(uint64(m.SequenceNumber))
 	}
+	l = len(m.Operator)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	l = len(m.OperatorSignature)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
 	return n
 }
 
@@ -596,6 +648,8 @@ func (this *BroadcastNetworkMessage) String() string {
 		`Payload:` + fmt.Sprintf("%v", this.Payload) + `,`,
 		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
 		`SequenceNumber:` + fmt.Sprintf("%v", this.SequenceNumber) + `,`,
+		`Operator:` + fmt.Sprintf("%v", this.Operator) + `,`,
+		`OperatorSignature:` + fmt.Sprintf("%v", this.OperatorSignature) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -781,6 +835,74 @@ func (m *BroadcastNetworkMessage) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Operator", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Operator = append(m.Operator[:0], dAtA[iNdEx:postIndex]...)
+			if m.Operator == nil {
+				m.Operator = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OperatorSignature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OperatorSignature = append(m.OperatorSignature[:0], dAtA[iNdEx:postIndex]...)
+			if m.OperatorSignature == nil {
+				m.OperatorSignature = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMessage(dAtA[iNdEx:])
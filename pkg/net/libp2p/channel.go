@@ -71,6 +71,16 @@ func (c *channel) Name() string {
 }
 
 func (c *channel) Send(ctx context.Context, message net.TaggedMarshaler) error {
+	return c.send(ctx, message, nil)
+}
+
+// send publishes the message to the channel. If the operator is provided,
+// the message is signed by and sent on behalf of that operator.
+func (c *channel) send(
+	ctx context.Context,
+	message net.TaggedMarshaler,
+	operator *identity,
+) error {
 	messageProto, err := c.messageProto(message)
 	if err != nil {
 		return err
@@ -78,6 +88,12 @@ func (c *channel) Send(ctx context.Context, message net.TaggedMarshaler) error {
 
 	messageProto.SequenceNumber = c.nextSeqno()
 
+	if operator != nil {
+		if err := signForOperator(c.name, operator, messageProto); err != nil {
+			return err
+		}
+	}
+
 	doSend := func() error {
 		return c.publishToPubSub(messageProto)
 	}
@@ -270,12 +286,23 @@ func (c *channel) processContainerMessage(
 		)
 	}
 
-	networkKey := key.Libp2pKeyToNetworkKey(senderIdentifier.pubKey)
+	// If the message has been sent on behalf of one of the operators hosted
+	// by the sender, the operator is the author of the message.
+	senderPublicKey := senderIdentifier.pubKey
+	operatorPublicKey, err := extractOperatorPublicKey(c.name, &message)
+	if err != nil {
+		return err
+	}
+	if operatorPublicKey != nil {
+		senderPublicKey = operatorPublicKey
+	}
+
+	networkKey := key.Libp2pKeyToNetworkKey(senderPublicKey)
 	if networkKey == nil {
 		return fmt.Errorf(
 			"sender [%v] with key [%v] is not of correct type",
 			senderIdentifier.id,
-			senderIdentifier.pubKey,
+			senderPublicKey,
 		)
 	}
 
@@ -328,10 +355,16 @@ func (c *channel) SetFilter(filter net.BroadcastChannelFilter) error {
 
 	c.pubsub.UnregisterTopicValidator(c.name)
 
-	return c.pubsub.RegisterTopicValidator(c.name, createTopicValidator(filter))
+	return c.pubsub.RegisterTopicValidator(
+		c.name,
+		createTopicValidator(c.name, filter),
+	)
 }
 
-func createTopicValidator(filter net.BroadcastChannelFilter) pubsub.Validator {
+func createTopicValidator(
+	topic string,
+	filter net.BroadcastChannelFilter,
+) pubsub.Validator {
 	return func(_ context.Context, _ peer.ID, message *pubsub.Message) bool {
 		authorPublicKey, err := extractPublicKey(message.GetFrom())
 		if err != nil {
@@ -341,6 +374,29 @@ func createTopicValidator(filter net.BroadcastChannelFilter) pubsub.Validator {
 			)
 			return false
 		}
+
+		var messageProto pb.BroadcastNetworkMessage
+		if err := proto.Unmarshal(message.Data, &messageProto); err != nil {
+			logger.Warningf("could not unmarshal message: [%v]", err)
+			return false
+		}
+
+		operatorPublicKey, err := extractOperatorPublicKey(topic, &messageProto)
+		if err != nil {
+			logger.Warningf(
+				"could not retrieve message operator public key: [%v]",
+				err,
+			)
+			return false
+		}
+		if operatorPublicKey != nil {
+			return filter(
+				key.NetworkKeyToECDSAKey(
+					key.Libp2pKeyToNetworkKey(operatorPublicKey),
+				),
+			)
+		}
+
 		return filter(authorPublicKey)
 	}
 }
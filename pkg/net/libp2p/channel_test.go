@@ -170,7 +170,7 @@ func TestCreateTopicValidator(t *testing.T) {
 		return isAuthorized
 	}
 
-	validator := createTopicValidator(filter)
+	validator := createTopicValidator("test", filter)
 
 	expectedResults := []bool{true, false, false, true, false}
 	for i, publicKey := range publicKeys {
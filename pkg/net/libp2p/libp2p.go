@@ -77,6 +77,13 @@ type Config struct {
 	Port               int
 	AnnouncedAddresses []string
 	DisseminationTime  int
+
+	// KeyFile is a path to an Ethereum key file with the key identifying the
+	// client in the network when operator keys are held by an external
+	// signer. It has to be the key of the operator since peers validate
+	// messages against the operator address. Otherwise, the key of the
+	// primary operator is used.
+	KeyFile string
 }
 
 type provider struct {
@@ -0,0 +1,165 @@
+package libp2p
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/gen/pb"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
+)
+
+// operatorProvider is a view of the provider for one of the operators hosted
+// by the client other than the one whose key identifies the client in the
+// network. Broadcast channel messages sent through this view are signed by
+// and sent on behalf of that operator so that group members can identify the
+// operator as the author of the message.
+type operatorProvider struct {
+	*provider
+
+	operator *identity
+}
+
+// ForOperator returns a view of the provider sending broadcast channel
+// messages on behalf of the operator with the given network private key.
+// If the key is the key of the provider itself, the provider is returned.
+func (p *provider) ForOperator(
+	operatorPrivateKey *key.NetworkPrivate,
+) (net.Provider, error) {
+	operator, err := createIdentity(operatorPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if operator.id == p.identity.id {
+		return p, nil
+	}
+
+	return &operatorProvider{
+		provider: p,
+		operator: operator,
+	}, nil
+}
+
+func (op *operatorProvider) BroadcastChannelFor(
+	name string,
+) (net.BroadcastChannel, error) {
+	op.channelManagerMutex.Lock()
+	defer op.channelManagerMutex.Unlock()
+
+	channel, err := op.broadcastChannelManager.getChannel(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &operatorChannel{
+		channel:  channel,
+		operator: op.operator,
+	}, nil
+}
+
+// operatorChannel is a view of the broadcast channel sending messages on
+// behalf of the operator. All the other channel functions are shared with
+// the underlying channel.
+type operatorChannel struct {
+	*channel
+
+	operator *identity
+}
+
+func (oc *operatorChannel) Send(
+	ctx context.Context,
+	message net.TaggedMarshaler,
+) error {
+	return oc.send(ctx, message, oc.operator)
+}
+
+// signForOperator sets the operator identity in the message and signs the
+// message along with the topic name with the operator's private key.
+func signForOperator(
+	topic string,
+	operator *identity,
+	message *pb.BroadcastNetworkMessage,
+) error {
+	operatorBytes, err := operator.Marshal()
+	if err != nil {
+		return fmt.Errorf("could not marshal operator identity: [%v]", err)
+	}
+	message.Operator = operatorBytes
+
+	digest, err := operatorSignatureDigest(topic, message)
+	if err != nil {
+		return err
+	}
+
+	signature, err := operator.privKey.Sign(digest)
+	if err != nil {
+		return fmt.Errorf("could not sign message for operator: [%v]", err)
+	}
+	message.OperatorSignature = signature
+
+	return nil
+}
+
+// extractOperatorPublicKey returns the public key of the operator on whose
+// behalf the message has been sent, after validating the operator signature.
+// If the message has not been sent on behalf of an operator, nil is returned.
+func extractOperatorPublicKey(
+	topic string,
+	message *pb.BroadcastNetworkMessage,
+) (libp2pcrypto.PubKey, error) {
+	if len(message.Operator) == 0 {
+		return nil, nil
+	}
+
+	operator := &identity{}
+	if err := operator.Unmarshal(message.Operator); err != nil {
+		return nil, fmt.Errorf("could not unmarshal operator identity: [%v]", err)
+	}
+
+	if key.Libp2pKeyToNetworkKey(operator.pubKey) == nil {
+		return nil, fmt.Errorf(
+			"operator [%v] key is not of correct type",
+			operator.id,
+		)
+	}
+
+	digest, err := operatorSignatureDigest(topic, message)
+	if err != nil {
+		return nil, err
+	}
+
+	isValid, err := operator.pubKey.Verify(digest, message.OperatorSignature)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not verify signature of operator [%v]: [%v]",
+			operator.id,
+			err,
+		)
+	}
+	if !isValid {
+		return nil, fmt.Errorf("invalid signature of operator [%v]", operator.id)
+	}
+
+	return operator.pubKey, nil
+}
+
+// operatorSignatureDigest returns the data signed by the operator. It covers
+// the whole message, including the sender and the operator identity, except
+// the operator signature itself. The topic name is included so that the
+// message can not be replayed in another channel.
+func operatorSignatureDigest(
+	topic string,
+	message *pb.BroadcastNetworkMessage,
+) ([]byte, error) {
+	unsigned := *message
+	unsigned.OperatorSignature = nil
+
+	messageBytes, err := unsigned.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal message: [%v]", err)
+	}
+
+	return append([]byte(topic), messageBytes...), nil
+}
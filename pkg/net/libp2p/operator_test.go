@@ -0,0 +1,172 @@
+package libp2p
+
+import (
+	"crypto/ecdsa"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/net/gen/pb"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+)
+
+func TestExtractOperatorPublicKey(t *testing.T) {
+	sender := generateIdentity(t)
+	operator := generateIdentity(t)
+
+	newMessage := func() *pb.BroadcastNetworkMessage {
+		senderBytes, err := sender.Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return &pb.BroadcastNetworkMessage{
+			Sender:         senderBytes,
+			Payload:        []byte{0x01, 0x02},
+			Type:           []byte("test"),
+			SequenceNumber: 10,
+		}
+	}
+
+	var tests = map[string]struct {
+		tamper        func(message *pb.BroadcastNetworkMessage) string
+		expectedError bool
+	}{
+		"valid operator signature": {
+			tamper: func(message *pb.BroadcastNetworkMessage) string {
+				return "topic"
+			},
+		},
+		"tampered payload": {
+			tamper: func(message *pb.BroadcastNetworkMessage) string {
+				message.Payload = []byte{0x03}
+				return "topic"
+			},
+			expectedError: true,
+		},
+		"tampered sender": {
+			tamper: func(message *pb.BroadcastNetworkMessage) string {
+				message.Sender, _ = generateIdentity(t).Marshal()
+				return "topic"
+			},
+			expectedError: true,
+		},
+		"replayed in another topic": {
+			tamper: func(message *pb.BroadcastNetworkMessage) string {
+				return "another-topic"
+			},
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			message := newMessage()
+			if err := signForOperator("topic", operator, message); err != nil {
+				t.Fatal(err)
+			}
+
+			topic := test.tamper(message)
+
+			operatorPublicKey, err := extractOperatorPublicKey(topic, message)
+
+			if test.expectedError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !operatorPublicKey.Equals(operator.pubKey) {
+				t.Errorf(
+					"unexpected operator public key\nexpected: [%v]\nactual:   [%v]",
+					operator.pubKey,
+					operatorPublicKey,
+				)
+			}
+		})
+	}
+}
+
+func TestExtractOperatorPublicKeyNoOperator(t *testing.T) {
+	operatorPublicKey, err := extractOperatorPublicKey(
+		"topic",
+		&pb.BroadcastNetworkMessage{Payload: []byte{0x01}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if operatorPublicKey != nil {
+		t.Errorf("expected no operator public key; has: [%v]", operatorPublicKey)
+	}
+}
+
+func TestCreateTopicValidatorForOperatorMessage(t *testing.T) {
+	sender := generateIdentity(t)
+	operator := generateIdentity(t)
+
+	var filteredKey *ecdsa.PublicKey
+	filter := func(publicKey *ecdsa.PublicKey) bool {
+		filteredKey = publicKey
+		return true
+	}
+
+	validator := createTopicValidator("topic", filter)
+
+	message := &pb.BroadcastNetworkMessage{Payload: []byte{0x01}}
+	if err := signForOperator("topic", operator, message); err != nil {
+		t.Fatal(err)
+	}
+	messageBytes, err := message.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authorIDBytes, _ := sender.id.Marshal()
+	pubsubMessage := &pubsub.Message{
+		Message: &pubsubpb.Message{From: authorIDBytes, Data: messageBytes},
+	}
+
+	if !validator(nil, peer.ID(""), pubsubMessage) {
+		t.Fatal("expected message to be accepted")
+	}
+
+	expectedKey := key.NetworkKeyToECDSAKey(
+		key.Libp2pKeyToNetworkKey(operator.pubKey),
+	)
+	if !reflect.DeepEqual(expectedKey, filteredKey) {
+		t.Errorf("message author should be the operator, not the sender")
+	}
+
+	// Invalid operator signature makes the validator reject the message.
+	message.OperatorSignature = []byte{0x01}
+	messageBytes, err = message.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubsubMessage.Data = messageBytes
+
+	if validator(nil, peer.ID(""), pubsubMessage) {
+		t.Error("expected message to be rejected")
+	}
+}
+
+func generateIdentity(t *testing.T) *identity {
+	privateKey, _, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	identity, err := createIdentity(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return identity
+}
@@ -230,6 +230,58 @@ loop:
 	}
 }
 
+func TestSendOnBehalfOfOperator(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	_, staticKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	operatorPrivateKey, operatorPublicKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operatorProvider, err := ConnectWithKey(staticKey).ForOperator(
+		operatorPrivateKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operatorChannel, err := operatorProvider.BroadcastChannelFor("operator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	operatorChannel.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
+		return &mockNetMessage{}
+	})
+
+	senderPublicKey := make(chan []byte)
+	operatorChannel.Recv(ctx, func(msg net.Message) {
+		senderPublicKey <- msg.SenderPublicKey()
+	})
+
+	if err := operatorChannel.Send(ctx, &mockNetMessage{}); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case actual := <-senderPublicKey:
+		expected := key.Marshal(operatorPublicKey)
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf(
+				"unexpected sender public key\nexpected: [%x]\nactual:   [%x]",
+				expected,
+				actual,
+			)
+		}
+	case <-ctx.Done():
+		t.Errorf("expected handler not called")
+	}
+}
+
 func initTestChannel(channelName string) (*key.NetworkPublic, net.BroadcastChannel, error) {
 	_, staticKey, err := key.GenerateStaticNetworkKey()
 	if err != nil {
@@ -6,6 +6,7 @@ package local
 import (
 	"context"
 	"crypto/ecdsa"
+	"fmt"
 	"sync"
 
 	"github.com/ipfs/go-log"
@@ -73,6 +74,22 @@ func (lp *localProvider) BroadcastChannelForwarderFor(name string) {
 	//no-op
 }
 
+func (lp *localProvider) ForOperator(
+	operatorPrivateKey *key.NetworkPrivate,
+) (net.Provider, error) {
+	operatorPublicKey, ok := operatorPrivateKey.GetPublic().(*key.NetworkPublic)
+	if !ok {
+		return nil, fmt.Errorf("operator key is of type other than Secp256k1")
+	}
+
+	return &localProvider{
+		id:                    lp.id,
+		staticKey:             operatorPublicKey,
+		connectionManager:     lp.connectionManager,
+		unicastChannelManager: lp.unicastChannelManager,
+	}, nil
+}
+
 // Connect returns a local instance of a net provider that does not go over the
 // network.
 func Connect() Provider {
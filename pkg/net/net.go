@@ -65,6 +65,12 @@ type Provider interface {
 
 	// BroadcastChannelForwarderFor creates a message relay for given channel name.
 	BroadcastChannelForwarderFor(name string)
+
+	// ForOperator returns a view of the provider for another operator hosted
+	// by the same client. Broadcast channel messages sent through the returned
+	// provider are authored by the operator with the given network private
+	// key instead of the operator identifying the provider in the network.
+	ForOperator(operatorPrivateKey *key.NetworkPrivate) (Provider, error)
 }
 
 // ConnectionManager is an interface which exposes peers a client is connected